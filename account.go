@@ -29,6 +29,9 @@ var AccountThree = types.AccountThree
 // AccountFour represents the 0x4 address
 var AccountFour = types.AccountFour
 
+// AccountTen represents the 0xa address, the metadata of the APT fungible asset
+var AccountTen = types.AccountTen
+
 // NewAccountFromSigner creates an account from a Signer, which is most commonly a private key
 func NewAccountFromSigner(signer crypto.Signer, authKey ...crypto.AuthenticationKey) (*Account, error) {
 	return types.NewAccountFromSigner(signer, authKey...)
@@ -39,6 +42,25 @@ func NewEd25519Account() (*Account, error) {
 	return types.NewEd25519Account()
 }
 
+// NewAccount creates an account with a new random Ed25519 private key, the same as [NewEd25519Account]
+//
+// The account is [Account.Address] with its [Account.Signer].  Transactions are signed with
+// [RawTransaction.SignedTransaction], and to track the sequence number locally when sending many transactions, pass a
+// [SequenceNumberManager] to [Client.BuildSignAndSubmitTransaction].
+//
+//	account, err := NewAccount()
+//	manager := client.NewSequenceNumberManager(account.Address)
+//	response, err := client.BuildSignAndSubmitTransaction(account, payload, manager)
+func NewAccount() (*Account, error) {
+	return types.NewEd25519Account()
+}
+
+// NewAccountFromPrivateKey creates a legacy Ed25519 account from the hex of a private key, with or without a 0x
+// prefix, or in the AIP-80 form e.g. ed25519-priv-0x1234...
+func NewAccountFromPrivateKey(privateKey string) (*Account, error) {
+	return types.NewAccountFromPrivateKey(privateKey)
+}
+
 // NewEd25519SingleSenderAccount creates a single signer Ed25519 account
 func NewEd25519SingleSenderAccount() (*Account, error) {
 	return types.NewEd25519SingleSignerAccount()
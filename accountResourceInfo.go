@@ -1,5 +1,7 @@
 package aptos
 
+import "github.com/aptos-labs/aptos-go-sdk/api"
+
 // AccountResourceInfo is returned by #AccountResource() and #AccountResources()
 type AccountResourceInfo struct {
 	// e.g. "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>"
@@ -8,3 +10,30 @@ type AccountResourceInfo struct {
 	// Decoded from Move contract data, could really be anything
 	Data map[string]any `json:"data"`
 }
+
+// moveResource is the resource as an [api.MoveResource], sharing the same data
+func (o *AccountResourceInfo) moveResource() *api.MoveResource {
+	return &api.MoveResource{Type: o.Type, Data: o.Data}
+}
+
+// GetU64 returns the u64 in the resource data at the dotted path, see [api.MoveResource.GetU64]
+//
+//	balance, err := resource.GetU64("coin.value")
+func (o *AccountResourceInfo) GetU64(path string) (uint64, error) {
+	return o.moveResource().GetU64(path)
+}
+
+// GetString returns the string in the resource data at the dotted path, see [api.MoveResource.GetString]
+func (o *AccountResourceInfo) GetString(path string) (string, error) {
+	return o.moveResource().GetString(path)
+}
+
+// GetAddress returns the address in the resource data at the dotted path, see [api.MoveResource.GetAddress]
+func (o *AccountResourceInfo) GetAddress(path string) (AccountAddress, error) {
+	return o.moveResource().GetAddress(path)
+}
+
+// GetBool returns the bool in the resource data at the dotted path, see [api.MoveResource.GetBool]
+func (o *AccountResourceInfo) GetBool(path string) (bool, error) {
+	return o.moveResource().GetBool(path)
+}
@@ -0,0 +1,230 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// DefaultAccountTransactionPageSize is the number of transactions requested from the node per page.  The node may cap
+// this lower.
+const DefaultAccountTransactionPageSize = 100
+
+// PageOption is an option to [NodeClient.AccountTransactionsIterator] and [NodeClient.EventsByCreationNumber], either a
+// [PageStart], a [PageSize], or a [PageConcurrency]
+type PageOption interface {
+	applyPageOption(opts *pageOptions)
+}
+
+// PageStart is the sequence number to start paging from, default 0
+type PageStart uint64
+
+// PageSize is the number of items to request per page, default [DefaultAccountTransactionPageSize] for transactions, and
+// [DefaultEventPageSize] for events
+type PageSize uint64
+
+// PageConcurrency is a hint for how many pages [AccountTransactionIterator.CollectAll] fetches at once, default 1
+type PageConcurrency int
+
+// pageOptions are the resolved options for paging
+type pageOptions struct {
+	start       uint64
+	pageSize    uint64
+	concurrency int
+}
+
+func (p PageStart) applyPageOption(opts *pageOptions) {
+	opts.start = uint64(p)
+}
+
+func (p PageSize) applyPageOption(opts *pageOptions) {
+	opts.pageSize = uint64(p)
+}
+
+func (p PageConcurrency) applyPageOption(opts *pageOptions) {
+	opts.concurrency = int(p)
+}
+
+// AccountTransactionIterator pages through the committed transactions sent by an account, in sequence number order.
+// An account that doesn't exist has no transactions.
+//
+// The iterator is not safe for concurrent use.
+//
+//	iter := client.AccountTransactionsIterator(address)
+//	for {
+//		txn, ok, err := iter.Next(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		if !ok {
+//			break // no more transactions
+//		}
+//		// handle txn
+//	}
+type AccountTransactionIterator struct {
+	*Paginator[*api.CommittedTransaction]
+
+	rc          *NodeClient
+	address     AccountAddress
+	pageSize    uint64
+	concurrency int
+
+	start uint64 // start is the sequence number of the next page to fetch
+}
+
+// AccountTransactionsIterator returns an [AccountTransactionIterator] over the transactions sent by the account
+//
+// Optional arguments:
+//   - PageStart: uint64, the sequence number to start from. Default 0.
+//   - PageSize: uint64, the number of transactions to request per page. Default 100.
+//   - PageConcurrency: int, the number of pages fetched at once by [AccountTransactionIterator.CollectAll]. Default 1.
+func (rc *NodeClient) AccountTransactionsIterator(address AccountAddress, opts ...PageOption) *AccountTransactionIterator {
+	options := pageOptions{pageSize: DefaultAccountTransactionPageSize, concurrency: 1}
+	for _, opt := range opts {
+		opt.applyPageOption(&options)
+	}
+	if options.pageSize == 0 {
+		options.pageSize = DefaultAccountTransactionPageSize
+	}
+	if options.concurrency < 1 {
+		options.concurrency = 1
+	}
+	it := &AccountTransactionIterator{
+		rc:          rc,
+		address:     address,
+		pageSize:    options.pageSize,
+		concurrency: options.concurrency,
+		start:       options.start,
+	}
+	it.Paginator = NewPaginator(it.nextPage)
+	return it
+}
+
+// CollectAll gathers all the remaining transactions into a slice.  With a [PageConcurrency] greater than 1, that many
+// pages are prefetched from the node at once.
+//
+// On an error, the transactions collected so far are returned with the error.
+func (it *AccountTransactionIterator) CollectAll(ctx context.Context) ([]*api.CommittedTransaction, error) {
+	if it.concurrency <= 1 {
+		return it.All(ctx)
+	}
+	txns := make([]*api.CommittedTransaction, 0, len(it.buffer))
+	txns = append(txns, it.buffer...)
+	it.buffer = nil
+
+	for !it.done {
+		if err := ctx.Err(); err != nil {
+			return txns, err
+		}
+		pages, err := it.fetchPages(ctx)
+		for _, page := range pages {
+			if page == nil {
+				// A page after an error
+				break
+			}
+			more, advanceErr := it.advance(page)
+			if advanceErr != nil {
+				return txns, advanceErr
+			}
+			txns = append(txns, page...)
+			if !more {
+				it.done = true
+				break
+			}
+			// A short page is either the end, or the node capped the limit, so the prefetched pages may have skipped
+			// transactions.  Resume from after the last transaction received instead.
+			if uint64(len(page)) < it.pageSize {
+				break
+			}
+		}
+		if err != nil {
+			return txns, err
+		}
+	}
+	return txns, nil
+}
+
+// nextPage fetches the page at the current start, implementing [PageFetcher]
+func (it *AccountTransactionIterator) nextPage(ctx context.Context) ([]*api.CommittedTransaction, bool, error) {
+	txns, err := it.fetchPage(ctx, it.start)
+	if err != nil {
+		return nil, false, err
+	}
+	more, err := it.advance(txns)
+	if err != nil {
+		return nil, false, err
+	}
+	return txns, more, nil
+}
+
+// fetchPages fetches the next pages concurrently, from the current start.  The pages are returned in order, up to the
+// first error; the pages after it are nil.
+func (it *AccountTransactionIterator) fetchPages(ctx context.Context) ([][]*api.CommittedTransaction, error) {
+	pages := make([][]*api.CommittedTransaction, it.concurrency)
+	errs := make([]error, it.concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < it.concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pages[i], errs[i] = it.fetchPage(ctx, it.start+uint64(i)*it.pageSize)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			for j := i; j < len(pages); j++ {
+				pages[j] = nil
+			}
+			return pages, err
+		}
+	}
+	return pages, nil
+}
+
+// advance moves the start past a page fetched at the current start, and returns whether there may be more pages
+//
+// The node may return fewer transactions than the limit, even if there are more available, so only an empty page is
+// considered the end of the transactions.  The next page is resumed from after the last sequence number received.
+func (it *AccountTransactionIterator) advance(txns []*api.CommittedTransaction) (bool, error) {
+	if len(txns) == 0 {
+		return false, nil
+	}
+	userTxn, err := txns[len(txns)-1].UserTransaction()
+	if err != nil {
+		return false, fmt.Errorf("unexpected account transaction: %w", err)
+	}
+	it.start = userTxn.SequenceNumber + 1
+	return true, nil
+}
+
+// fetchPage fetches a page of transactions starting at the sequence number.  An account that doesn't exist has no
+// transactions, so it returns an empty page.
+func (it *AccountTransactionIterator) fetchPage(ctx context.Context, start uint64) ([]*api.CommittedTransaction, error) {
+	au := it.rc.baseUrl.JoinPath("accounts", it.address.String(), "transactions")
+	params := url.Values{}
+	params.Set("start", strconv.FormatUint(start, 10))
+	params.Set("limit", strconv.FormatUint(it.pageSize, 10))
+	au.RawQuery = params.Encode()
+
+	txns, _, err := getWithResp[[]*api.CommittedTransaction](ctx, it.rc, au.String())
+	if err != nil {
+		var httpErr *HttpError
+		if errors.As(err, &httpErr) {
+			if apiErr, ok := httpErr.ApiError(); ok && apiErr.ErrorCode == api.ErrorCodeAccountNotFound {
+				return []*api.CommittedTransaction{}, nil
+			}
+		}
+		return nil, fmt.Errorf("get account transactions api err: %w", err)
+	}
+	if txns == nil {
+		txns = []*api.CommittedTransaction{}
+	}
+	return txns, nil
+}
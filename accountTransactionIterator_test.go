@@ -0,0 +1,194 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAccountTransactionJson is a committed user transaction sent by 0x1, with the sequence number to be filled in
+const testAccountTransactionJson = `{
+	"version": "%d",
+	"hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"state_change_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"event_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"state_checkpoint_hash": null,
+	"gas_used": "5",
+	"success": true,
+	"vm_status": "Executed successfully",
+	"accumulator_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"changes": [],
+	"sender": "0x1",
+	"sequence_number": "%d",
+	"max_gas_amount": "2000",
+	"gas_unit_price": "100",
+	"expiration_timestamp_secs": "1719968695",
+	"payload": {
+		"function": "0x1::aptos_account::transfer",
+		"type_arguments": [],
+		"arguments": ["0x2", "100"],
+		"type": "entry_function_payload"
+	},
+	"signature": {
+		"public_key": "0x5e10e3db4e3c700142b9a3e18c40038db5903f2dedfe41d09aca74a8c68565d6",
+		"signature": "0xa95686dab2c93cf1720e300b929e3656cc6cdc3a8389dc12bb9bd5a17ae3af975bee9d618f080266e3a60f1e2968220a83d773e2b3902edfe54127ed0a7b290b",
+		"type": "ed25519_signature"
+	},
+	"events": [],
+	"timestamp": "1719965096135309",
+	"type": "user_transaction"
+}`
+
+// newAccountTransactionServerClient creates a client against a mock server with a number of transactions sent by 0x1,
+// which caps the limit at maxLimit
+func newAccountTransactionServerClient(t *testing.T, numTxns uint64, maxLimit uint64) (*Client, *atomic.Int32) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "/v1/accounts/"+AccountOne.String()+"/transactions", r.URL.Path)
+		start, err := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		assert.NoError(t, err)
+		limit, err := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		assert.NoError(t, err)
+		limit = min(limit, maxLimit)
+
+		txns := make([]string, 0)
+		for i := start; i < numTxns && i < start+limit; i++ {
+			txns = append(txns, fmt.Sprintf(testAccountTransactionJson, 1000+i, i))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(txns, ",") + "]"))
+	})
+	return client, &calls
+}
+
+// assertAccountTransactionSequence checks the user transactions have the sequence numbers [start, end) in order
+func assertAccountTransactionSequence(t *testing.T, start uint64, end uint64, txns []*api.CommittedTransaction) {
+	assert.Len(t, txns, int(end-start))
+	for i, txn := range txns {
+		userTxn, err := txn.UserTransaction()
+		assert.NoError(t, err)
+		assert.Equal(t, start+uint64(i), userTxn.SequenceNumber)
+	}
+}
+
+func TestAccountTransactionIterator_MultiplePages(t *testing.T) {
+	// The node caps the limit, so it should resume from the last sequence number
+	client, calls := newAccountTransactionServerClient(t, 7, 3)
+	iter := client.AccountTransactionsIterator(AccountOne)
+	for i := uint64(0); i < 7; i++ {
+		txn, ok, err := iter.Next(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		userTxn, err := txn.UserTransaction()
+		assert.NoError(t, err)
+		assert.Equal(t, i, userTxn.SequenceNumber)
+	}
+	txn, ok, err := iter.Next(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, txn)
+	// 3 pages, and an empty page to find the end
+	assert.Equal(t, int32(4), calls.Load())
+
+	// It stays done without calling the node again
+	_, ok, err = iter.Next(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestAccountTransactionIterator_CollectAll(t *testing.T) {
+	client, calls := newAccountTransactionServerClient(t, 9, 100)
+	iter := client.AccountTransactionsIterator(AccountOne, PageSize(2), PageConcurrency(3))
+
+	// Collection continues after the transactions already read
+	txn, ok, err := iter.Next(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	userTxn, err := txn.UserTransaction()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), userTxn.SequenceNumber)
+
+	txns, err := iter.CollectAll(context.Background())
+	assert.NoError(t, err)
+	assertAccountTransactionSequence(t, 1, 9, txns)
+	// 1 page read, then prefetching 3 pages until the short page, and 3 more pages to find the end
+	assert.Equal(t, int32(10), calls.Load())
+
+	txns, err = iter.CollectAll(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, txns)
+	assert.Equal(t, int32(10), calls.Load())
+}
+
+func TestAccountTransactionIterator_CollectAllCapped(t *testing.T) {
+	// The node returns less than the page size, so the prefetched pages are discarded rather than skipping any
+	client, _ := newAccountTransactionServerClient(t, 10, 3)
+	txns, err := client.AccountTransactionsIterator(AccountOne, PageSize(5), PageConcurrency(2)).CollectAll(context.Background())
+	assert.NoError(t, err)
+	assertAccountTransactionSequence(t, 0, 10, txns)
+}
+
+func TestAccountTransactionIterator_Start(t *testing.T) {
+	client, _ := newAccountTransactionServerClient(t, 10, 100)
+	txns, err := client.AccountTransactionsIterator(AccountOne, PageStart(4), PageSize(4), PageConcurrency(2)).CollectAll(context.Background())
+	assert.NoError(t, err)
+	assertAccountTransactionSequence(t, 4, 10, txns)
+}
+
+func TestAccountTransactionIterator_AccountNotFound(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Account not found by Address(0x1)","error_code":"account_not_found","vm_error_code":null}`))
+	})
+	txns, err := client.AccountTransactionsIterator(AccountOne).CollectAll(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, txns)
+	_, ok, err := client.AccountTransactionsIterator(AccountOne).Next(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestAccountTransactionIterator_Error(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"invalid limit","error_code":"invalid_input","vm_error_code":null}`))
+	})
+	_, err := client.AccountTransactionsIterator(AccountOne, PageConcurrency(2)).CollectAll(context.Background())
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+}
+
+func TestAccountTransactionIterator_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) > 1 {
+			// Cancel while the second page is in flight
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		_, _ = fmt.Fprintf(w, "[%s,%s]", fmt.Sprintf(testAccountTransactionJson, 1000, 0), fmt.Sprintf(testAccountTransactionJson, 1001, 1))
+	})
+
+	txns, err := client.AccountTransactionsIterator(AccountOne, PageSize(2)).CollectAll(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	// The transactions before the cancellation are returned
+	assertAccountTransactionSequence(t, 0, 2, txns)
+
+	// An already cancelled context doesn't call the node
+	txns, err = client.AccountTransactionsIterator(AccountOne).CollectAll(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, txns)
+	assert.Equal(t, int32(2), calls.Load())
+}
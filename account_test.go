@@ -0,0 +1,110 @@
+package aptos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const testEd25519PrivateKeyHex = "0xc5338cd251c22daa8c9c9cc94f498cc8a5c7e1d2e75287a5dda91096fe64efa5"
+
+func TestNewAccount(t *testing.T) {
+	account, err := NewAccount()
+	assert.NoError(t, err)
+	assert.IsType(t, &crypto.Ed25519PrivateKey{}, account.Signer)
+	authKey := account.AuthKey()
+	assert.Equal(t, authKey[:], account.Address[:])
+
+	// Sign a transfer, and verify the authenticator
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     account.Address,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: transfer},
+		MaxGasAmount:               DefaultMaxGasAmount,
+		GasUnitPrice:               DefaultGasUnitPrice,
+		ExpirationTimestampSeconds: uint64(time.Now().Unix() + DefaultExpirationSeconds),
+		ChainId:                    4,
+	}
+	signedTxn, err := rawTxn.SignedTransaction(account)
+	assert.NoError(t, err)
+	assert.NoError(t, signedTxn.Verify())
+	assert.Equal(t, TransactionAuthenticatorEd25519, signedTxn.Authenticator.Variant)
+	assert.Equal(t, account.PubKey(), signedTxn.Authenticator.Auth.(*Ed25519TransactionAuthenticator).Sender.PubKey())
+
+	// The signature doesn't verify for a changed transaction
+	rawTxn.SequenceNumber = 2
+	assert.Error(t, signedTxn.Verify())
+}
+
+func TestNewAccountFromPrivateKey(t *testing.T) {
+	key := &crypto.Ed25519PrivateKey{}
+	err := key.FromHex(testEd25519PrivateKeyHex)
+	assert.NoError(t, err)
+	expected, err := NewAccountFromSigner(key)
+	assert.NoError(t, err)
+
+	for _, privateKey := range []string{
+		testEd25519PrivateKeyHex,
+		testEd25519PrivateKeyHex[2:],
+		"ed25519-priv-" + testEd25519PrivateKeyHex,
+	} {
+		account, err := NewAccountFromPrivateKey(privateKey)
+		assert.NoError(t, err, privateKey)
+		assert.Equal(t, expected.Address, account.Address, privateKey)
+		assert.Equal(t, expected.PubKey(), account.PubKey(), privateKey)
+	}
+
+	_, err = NewAccountFromPrivateKey("0x1234")
+	assert.Error(t, err)
+	_, err = NewAccountFromPrivateKey("not hex")
+	assert.Error(t, err)
+}
+
+func TestBuildSignAndSubmitTransaction_SequenceNumberManager(t *testing.T) {
+	account, err := NewAccountFromPrivateKey(testEd25519PrivateKeyHex)
+	assert.NoError(t, err)
+	address := account.Address
+	submitted := make([]uint64, 0)
+	accountCalls := 0
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1":
+			_, _ = fmt.Fprint(w, testNodeInfoJson)
+		case "/v1/accounts/" + address.String():
+			accountCalls++
+			_, _ = fmt.Fprintf(w, `{"sequence_number":"7","authentication_key":"%s"}`, address.StringLong())
+		case "/v1/transactions":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			signedTxn := &SignedTransaction{Transaction: &RawTransaction{}, Authenticator: &TransactionAuthenticator{}}
+			err = bcs.Deserialize(signedTxn, body)
+			assert.NoError(t, err)
+			assert.NoError(t, signedTxn.Verify())
+			submitted = append(submitted, signedTxn.Transaction.(*RawTransaction).SequenceNumber)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprintf(w, `{"hash":"%s","sender":"%s","sequence_number":"%d","max_gas_amount":"1","gas_unit_price":"100","expiration_timestamp_secs":"1","payload":{"type":"entry_function_payload","function":"0x1::aptos_account::transfer","type_arguments":[],"arguments":[]},"signature":null}`,
+				testTxnHash, address.StringLong(), signedTxn.Transaction.(*RawTransaction).SequenceNumber)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	manager := client.NewSequenceNumberManager(account.Address)
+	for i := 0; i < 3; i++ {
+		_, err = client.BuildSignAndSubmitTransaction(account, TransactionPayload{Payload: transfer}, manager, GasUnitPrice(100))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []uint64{7, 8, 9}, submitted)
+	assert.Equal(t, 1, accountCalls)
+}
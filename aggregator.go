@@ -0,0 +1,43 @@
+package aptos
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// TableItemClient is the ability to fetch table items as JSON, see [AggregatorValue].  It is implemented by [Client]
+// and [NodeClient].
+type TableItemClient interface {
+	GetTableItem(handle string, keyType string, valueType string, key any, out any, ledgerVersion ...uint64) error
+}
+
+// AggregatorValue returns the current value of an aggregator parsed with [api.ParseAggregator].  Aggregators v2 and
+// snapshots have their value inline, but a v1 aggregator's value is fetched from the table item it's stored in.
+//
+//	aggregator, err := resource.GetAggregator("current")
+//	supply, err := AggregatorValue(client, aggregator)
+//
+// Optionally, a ledgerVersion can be given to get the value of a v1 aggregator at a specific ledger version
+func AggregatorValue(client TableItemClient, aggregator *api.Aggregator, ledgerVersion ...uint64) (*big.Int, error) {
+	if aggregator == nil {
+		return nil, fmt.Errorf("aggregator is nil")
+	}
+	if aggregator.IsInline() {
+		return aggregator.Value, nil
+	}
+	key := AccountAddress{}
+	err := key.ParseStringRelaxed(aggregator.Key)
+	if err != nil {
+		return nil, fmt.Errorf("aggregator key %s is not an address: %w", aggregator.Key, err)
+	}
+
+	// v1 aggregator values are stored in a Table<address, u128>
+	var value api.U128
+	err = client.GetTableItem(aggregator.Handle, "address", "u128", key, &value, ledgerVersion...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aggregator value: %w", err)
+	}
+	return value.ToBigInt(), nil
+}
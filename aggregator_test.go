@@ -0,0 +1,35 @@
+package aptos
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregatorValue(t *testing.T) {
+	// Inline values don't need a request
+	value, err := AggregatorValue(nil, &api.Aggregator{Value: big.NewInt(1000)})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), value)
+
+	// v1 aggregators are looked up in their table
+	client := newTableServerClient(t, "item",
+		`{"key_type":"address","value_type":"u128","key":"0x0619dc29a0aac8fa146714058e8dd6d2d0f3bdf5f6331907bf91f3acd81e6935"}`,
+		"application/json",
+		`"183253012108402790"`,
+	)
+	value, err = AggregatorValue(client, &api.Aggregator{
+		Handle: testTableHandle,
+		Key:    "0x619dc29a0aac8fa146714058e8dd6d2d0f3bdf5f6331907bf91f3acd81e6935",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(183253012108402790), value)
+
+	// Errors
+	_, err = AggregatorValue(client, nil)
+	assert.Error(t, err)
+	_, err = AggregatorValue(client, &api.Aggregator{Handle: testTableHandle, Key: "not an address"})
+	assert.ErrorContains(t, err, "not an address")
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+const (
+	AggregatorV2Type       = "0x1::aggregator_v2::Aggregator"               // AggregatorV2Type is the type of aggregators, e.g. the current supply in 0x1::fungible_asset::ConcurrentSupply
+	AggregatorSnapshotType = "0x1::aggregator_v2::AggregatorSnapshot"       // AggregatorSnapshotType is the type of aggregator snapshots, a read only copy of an aggregator's value
+	AggregatorV1Type       = "0x1::aggregator::Aggregator"                  // AggregatorV1Type is the type of the older aggregators, with the value stored in a table
+	OptionalAggregatorType = "0x1::optional_aggregator::OptionalAggregator" // OptionalAggregatorType is the type of the coin supply, either an AggregatorV1Type or an integer
+	ConcurrentSupplyType   = "0x1::fungible_asset::ConcurrentSupply"        // ConcurrentSupplyType is the resource holding the supply of a fungible asset, as an aggregator
+)
+
+// Aggregator is the parsed form of an aggregator in resource JSON, see [ParseAggregator]
+//
+// Aggregators v2 and snapshots have their value inline.  The older v1 aggregators only have the handle and key of the
+// table item which holds the value, which can be fetched with the handle as a table of address to u128.
+type Aggregator struct {
+	Value    *big.Int // Value is the current value, nil if it's stored in a table
+	MaxValue *big.Int // MaxValue is the limit of the aggregator, nil for a snapshot, which has no limit
+	Handle   string   // Handle is the table handle holding the value, only if it isn't inline
+	Key      string   // Key is the address key of the value in the table, only if it isn't inline
+}
+
+// IsInline tells whether the value is in the [Aggregator], rather than stored in a table
+func (o *Aggregator) IsInline() bool {
+	return o.Value != nil
+}
+
+// ParseAggregator parses an aggregator from the JSON of its value in resource data, e.g. the "current" field of
+// 0x1::fungible_asset::ConcurrentSupply.  It accepts:
+//
+//	{"value": "1000", "max_value": "340282366920938463463374607431768211455"} -> 0x1::aggregator_v2::Aggregator
+//	{"value": "1000"} -> 0x1::aggregator_v2::AggregatorSnapshot
+//	{"handle": "0x...", "key": "0x...", "limit": "340282366920938463463374607431768211455"} -> 0x1::aggregator::Aggregator
+//
+// As well as 0x1::optional_aggregator::OptionalAggregator, as used for the supply of coins, which holds either an
+// integer with its value inline, or a v1 aggregator.
+func ParseAggregator(data any) (*Aggregator, error) {
+	fields, ok := data.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("aggregator is not an object, it is %T", data)
+	}
+
+	// OptionalAggregator is one of two options, each holding one of the other forms
+	if _, ok = fields["aggregator"]; ok {
+		if inner, err := aggregatorOption(fields, "aggregator"); err != nil || inner != nil {
+			return parseAggregatorOrError(inner, err)
+		}
+		inner, err := aggregatorOption(fields, "integer")
+		if err == nil && inner == nil {
+			err = fmt.Errorf("optional aggregator has neither an aggregator or integer")
+		}
+		return parseAggregatorOrError(inner, err)
+	}
+
+	if handle, ok := fields["handle"]; ok {
+		handleStr, ok := handle.(string)
+		if !ok {
+			return nil, fmt.Errorf("aggregator handle is not a string, it is %T", handle)
+		}
+		key, ok := fields["key"].(string)
+		if !ok {
+			return nil, fmt.Errorf("aggregator key is missing or not a string")
+		}
+		limit, err := aggregatorNumber(fields, "limit")
+		if err != nil {
+			return nil, err
+		}
+		return &Aggregator{MaxValue: limit, Handle: handleStr, Key: key}, nil
+	}
+
+	value, err := aggregatorNumber(fields, "value")
+	if err != nil {
+		return nil, err
+	}
+	out := &Aggregator{Value: value}
+	// The integer form of an OptionalAggregator has a limit rather than a max_value
+	for _, limitField := range []string{"max_value", "limit"} {
+		if _, ok = fields[limitField]; ok {
+			out.MaxValue, err = aggregatorNumber(fields, limitField)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return out, nil
+}
+
+// GetAggregator returns the aggregator in the resource data at the dotted path, see [MoveResource.Get] and
+// [ParseAggregator]
+//
+//	supply, err := resource.GetAggregator("current")
+func (o *MoveResource) GetAggregator(path string) (*Aggregator, error) {
+	value, err := o.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	aggregator, err := ParseAggregator(value)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s field %s: %w", o.Type, path, err)
+	}
+	return aggregator, nil
+}
+
+// parseAggregatorOrError parses the aggregator, unless there is already an error
+func parseAggregatorOrError(data any, err error) (*Aggregator, error) {
+	if err != nil {
+		return nil, err
+	}
+	return ParseAggregator(data)
+}
+
+// aggregatorOption returns the value of the Move option in the field, or nil if it's empty
+func aggregatorOption(fields map[string]any, field string) (any, error) {
+	option, ok := fields[field].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("optional aggregator %s is missing or not an option", field)
+	}
+	vec, ok := option["vec"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("optional aggregator %s is not an option", field)
+	}
+	switch len(vec) {
+	case 0:
+		return nil, nil
+	case 1:
+		return vec[0], nil
+	default:
+		return nil, fmt.Errorf("optional aggregator %s has %d values", field, len(vec))
+	}
+}
+
+// aggregatorNumber parses the unsigned integer in the field, which is a string for u64 and larger, or may be a number
+func aggregatorNumber(fields map[string]any, field string) (*big.Int, error) {
+	value, ok := fields[field]
+	if !ok {
+		return nil, fmt.Errorf("aggregator is missing %s", field)
+	}
+	var str string
+	switch inner := value.(type) {
+	case string:
+		str = inner
+	case json.Number:
+		str = inner.String()
+	case float64:
+		str = big.NewFloat(inner).Text('f', -1)
+	default:
+		return nil, fmt.Errorf("aggregator %s is not a number, it is %T", field, value)
+	}
+	out, ok := new(big.Int).SetString(str, 10)
+	if !ok || out.Sign() < 0 {
+		return nil, fmt.Errorf("aggregator %s is not an unsigned integer: %s", field, str)
+	}
+	return out, nil
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+// testConcurrentSupplyResourceJson is the supply of a fungible asset, captured from mainnet
+const testConcurrentSupplyResourceJson = `{
+	"type": "0x1::fungible_asset::ConcurrentSupply",
+	"data": {
+		"current": {
+			"max_value": "340282366920938463463374607431768211455",
+			"value": "10000000000000000"
+		}
+	}
+}`
+
+// testCoinInfoResourceJson is the supply of APT as a coin, which is held in a v1 aggregator
+const testCoinInfoResourceJson = `{
+	"type": "0x1::coin::CoinInfo<0x1::aptos_coin::AptosCoin>",
+	"data": {
+		"decimals": 8,
+		"name": "Aptos Coin",
+		"supply": {
+			"vec": [
+				{
+					"aggregator": {
+						"vec": [
+							{
+								"handle": "0x1b854694ae746cdbd8d44186ca4929b2b337df21d1c74633be19b2710552fdca",
+								"key": "0x619dc29a0aac8fa146714058e8dd6d2d0f3bdf5f6331907bf91f3acd81e6935",
+								"limit": "340282366920938463463374607431768211455"
+							}
+						]
+					},
+					"integer": {
+						"vec": []
+					}
+				}
+			]
+		},
+		"symbol": "APT"
+	}
+}`
+
+func maxU128(t *testing.T) *big.Int {
+	out, ok := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.True(t, ok)
+	return out
+}
+
+func TestMoveResource_GetAggregator_ConcurrentSupply(t *testing.T) {
+	resource := &MoveResource{}
+	err := json.Unmarshal([]byte(testConcurrentSupplyResourceJson), resource)
+	assert.NoError(t, err)
+	assert.Equal(t, ConcurrentSupplyType, resource.Type)
+
+	aggregator, err := resource.GetAggregator("current")
+	assert.NoError(t, err)
+	assert.True(t, aggregator.IsInline())
+	assert.Equal(t, big.NewInt(10000000000000000), aggregator.Value)
+	assert.Equal(t, maxU128(t), aggregator.MaxValue)
+	assert.Empty(t, aggregator.Handle)
+}
+
+func TestMoveResource_GetAggregator_OptionalAggregator(t *testing.T) {
+	resource := &MoveResource{}
+	err := json.Unmarshal([]byte(testCoinInfoResourceJson), resource)
+	assert.NoError(t, err)
+
+	aggregator, err := resource.GetAggregator("supply.vec.0")
+	assert.NoError(t, err)
+	assert.False(t, aggregator.IsInline())
+	assert.Nil(t, aggregator.Value)
+	assert.Equal(t, "0x1b854694ae746cdbd8d44186ca4929b2b337df21d1c74633be19b2710552fdca", aggregator.Handle)
+	assert.Equal(t, "0x619dc29a0aac8fa146714058e8dd6d2d0f3bdf5f6331907bf91f3acd81e6935", aggregator.Key)
+	assert.Equal(t, maxU128(t), aggregator.MaxValue)
+
+	// Not an aggregator
+	_, err = resource.GetAggregator("name")
+	assert.ErrorContains(t, err, "field name")
+	_, err = resource.GetAggregator("missing")
+	assert.Error(t, err)
+}
+
+func TestParseAggregator(t *testing.T) {
+	parse := func(t *testing.T, str string) (*Aggregator, error) {
+		var data any
+		err := json.Unmarshal([]byte(str), &data)
+		assert.NoError(t, err)
+		return ParseAggregator(data)
+	}
+
+	// Snapshot, with no limit
+	aggregator, err := parse(t, `{"value":"12345"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(12345), aggregator.Value)
+	assert.Nil(t, aggregator.MaxValue)
+
+	// Numbers are accepted as well as strings
+	aggregator, err = parse(t, `{"value":42,"max_value":100}`)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), aggregator.Value)
+	assert.Equal(t, big.NewInt(100), aggregator.MaxValue)
+
+	// OptionalAggregator integer form
+	aggregator, err = parse(t, `{"aggregator":{"vec":[]},"integer":{"vec":[{"value":"1000","limit":"18446744073709551615"}]}}`)
+	assert.NoError(t, err)
+	assert.True(t, aggregator.IsInline())
+	assert.Equal(t, big.NewInt(1000), aggregator.Value)
+	assert.Equal(t, new(big.Int).SetUint64(18446744073709551615), aggregator.MaxValue)
+
+	// Errors
+	for _, str := range []string{
+		`"1000"`,
+		`{}`,
+		`{"value":"-1"}`,
+		`{"value":"abc"}`,
+		`{"value":true}`,
+		`{"value":"1","max_value":"x"}`,
+		`{"handle":1,"key":"0x1","limit":"1"}`,
+		`{"handle":"0x1","limit":"1"}`,
+		`{"handle":"0x1","key":"0x1"}`,
+		`{"aggregator":{"vec":[]},"integer":{"vec":[]}}`,
+		`{"aggregator":{"vec":[]}}`,
+		`{"aggregator":{},"integer":{"vec":[]}}`,
+		`{"aggregator":{"vec":[{"value":"1"},{"value":"2"}]},"integer":{"vec":[]}}`,
+	} {
+		_, err = parse(t, str)
+		assert.Error(t, err, str)
+	}
+}
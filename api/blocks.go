@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"time"
 )
 
 //region Block
@@ -27,6 +28,11 @@ type Block struct {
 	Transactions   []*CommittedTransaction // Transactions in the block if requested, otherwise it is empty
 }
 
+// Time converts the BlockTimestamp to a [time.Time] in UTC.  It will be the zero [time.Time] if the timestamp is not set.
+func (o *Block) Time() time.Time {
+	return MicrosecondsToTime(o.BlockTimestamp)
+}
+
 //region Block JSON
 
 // UnmarshalJSON deserializes a JSON data blob into a [Block]
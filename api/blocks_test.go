@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestBlock(t *testing.T) {
@@ -19,8 +20,9 @@ func TestBlock(t *testing.T) {
 	err := json.Unmarshal([]byte(testJson), &data)
 	assert.NoError(t, err)
 
-	assert.Equal(t, "0x014e30aafd9f715ab6262322bf919abebd66d948f6822ffb8a2699a57722fb80", data.BlockHash)
+	assert.Equal(t, "0x014e30aafd9f715ab6262322bf919abebd66d948f6822ffb8a2699a57722fb80", data.BlockHash.String())
 	assert.Equal(t, uint64(1665609760857472), data.BlockTimestamp)
+	assert.Equal(t, time.Date(2022, time.October, 12, 21, 22, 40, 857472000, time.UTC), data.Time())
 	assert.Equal(t, uint64(1), data.BlockHeight)
 	assert.Equal(t, uint64(1), data.FirstVersion)
 	assert.Equal(t, uint64(1), data.LastVersion)
@@ -40,7 +42,7 @@ func TestBlockWithNoTransactions(t *testing.T) {
 	err := json.Unmarshal([]byte(testJson), &data)
 	assert.NoError(t, err)
 
-	assert.Equal(t, "0x014e30aafd9f715ab6262322bf919abebd66d948f6822ffb8a2699a57722fb80", data.BlockHash)
+	assert.Equal(t, "0x014e30aafd9f715ab6262322bf919abebd66d948f6822ffb8a2699a57722fb80", data.BlockHash.String())
 	assert.Equal(t, uint64(1665609760857472), data.BlockTimestamp)
 	assert.Equal(t, uint64(1), data.BlockHeight)
 	assert.Equal(t, uint64(1), data.FirstVersion)
@@ -155,7 +157,7 @@ func TestBlockWithTransactions(t *testing.T) {
 	err := json.Unmarshal([]byte(testJson), &data)
 	assert.NoError(t, err)
 
-	assert.Equal(t, "0x014e30aafd9f715ab6262322bf919abebd66d948f6822ffb8a2699a57722fb80", data.BlockHash)
+	assert.Equal(t, "0x014e30aafd9f715ab6262322bf919abebd66d948f6822ffb8a2699a57722fb80", data.BlockHash.String())
 	assert.Equal(t, uint64(1665609760857472), data.BlockTimestamp)
 	assert.Equal(t, uint64(1), data.BlockHeight)
 	assert.Equal(t, uint64(1), data.FirstVersion)
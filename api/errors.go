@@ -1,8 +1,72 @@
 package api
 
+import "fmt"
+
+// Error codes returned by the REST API in [Error.ErrorCode]
+const (
+	ErrorCodeAccountNotFound          = "account_not_found"          // ErrorCodeAccountNotFound is returned when the account doesn't exist
+	ErrorCodeResourceNotFound         = "resource_not_found"         // ErrorCodeResourceNotFound is returned when the account doesn't have the resource
+	ErrorCodeModuleNotFound           = "module_not_found"           // ErrorCodeModuleNotFound is returned when the account doesn't have the module
+	ErrorCodeStructFieldNotFound      = "struct_field_not_found"     // ErrorCodeStructFieldNotFound is returned when a struct field doesn't exist
+	ErrorCodeVersionNotFound          = "version_not_found"          // ErrorCodeVersionNotFound is returned when the ledger version doesn't exist yet
+	ErrorCodeTransactionNotFound      = "transaction_not_found"      // ErrorCodeTransactionNotFound is returned when the transaction doesn't exist
+	ErrorCodeTableItemNotFound        = "table_item_not_found"       // ErrorCodeTableItemNotFound is returned when the table item doesn't exist
+	ErrorCodeBlockNotFound            = "block_not_found"            // ErrorCodeBlockNotFound is returned when the block doesn't exist
+	ErrorCodeStateValueNotFound       = "state_value_not_found"      // ErrorCodeStateValueNotFound is returned when the state value doesn't exist
+	ErrorCodeVersionPruned            = "version_pruned"             // ErrorCodeVersionPruned is returned when the ledger version has been pruned from the node
+	ErrorCodeBlockPruned              = "block_pruned"               // ErrorCodeBlockPruned is returned when the block has been pruned from the node
+	ErrorCodeInvalidInput             = "invalid_input"              // ErrorCodeInvalidInput is returned when the request is invalid
+	ErrorCodeInvalidTransactionUpdate = "invalid_transaction_update" // ErrorCodeInvalidTransactionUpdate is returned when a transaction replaces one in mempool in an invalid way
+	ErrorCodeSequenceNumberTooOld     = "sequence_number_too_old"    // ErrorCodeSequenceNumberTooOld is returned when the transaction's sequence number is already used
+	ErrorCodeVmError                  = "vm_error"                   // ErrorCodeVmError is returned when the transaction fails validation, see [Error.VmErrorCode]
+	ErrorCodeHealthCheckFailed        = "health_check_failed"        // ErrorCodeHealthCheckFailed is returned when the node is unhealthy
+	ErrorCodeMempoolIsFull            = "mempool_is_full"            // ErrorCodeMempoolIsFull is returned when the node can't accept more transactions
+	ErrorCodeInternalError            = "internal_error"             // ErrorCodeInternalError is returned when the node fails internally
+	ErrorCodeWebFrameworkError        = "web_framework_error"        // ErrorCodeWebFrameworkError is returned when the request can't be handled by the web server
+	ErrorCodeBcsNotSupported          = "bcs_not_supported"          // ErrorCodeBcsNotSupported is returned when BCS isn't supported for the endpoint
+	ErrorCodeApiDisabled              = "api_disabled"               // ErrorCodeApiDisabled is returned when the endpoint is disabled on the node
+)
+
 // Error is an error from the REST API
+//
+// Example:
+//
+//	{
+//		"message": "Resource not found by Address(0x1), Struct tag(0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>) and Ledger version(123)",
+//		"error_code": "resource_not_found",
+//		"vm_error_code": null
+//	}
 type Error struct {
 	Message     string `json:"message"`       // Message is the error message
-	ErrorCode   string `json:"error_code"`    // ErrorCode is the string name of the error
+	ErrorCode   string `json:"error_code"`    // ErrorCode is the string name of the error e.g. [ErrorCodeResourceNotFound]
 	VmErrorCode uint64 `json:"vm_error_code"` // VmErrorCode is the number of the failure, optional 0 if not set
 }
+
+// Error returns the error code and message, and the VM error code if set
+//
+// Implements:
+//   - [error]
+func (e *Error) Error() string {
+	if e.VmErrorCode != 0 {
+		return fmt.Sprintf("api error %s (vm error code %d): %s", e.ErrorCode, e.VmErrorCode, e.Message)
+	}
+	return fmt.Sprintf("api error %s: %s", e.ErrorCode, e.Message)
+}
+
+// IsNotFound tells whether the error is for something that doesn't exist, e.g. an account, resource, or transaction
+func (e *Error) IsNotFound() bool {
+	switch e.ErrorCode {
+	case ErrorCodeAccountNotFound,
+		ErrorCodeResourceNotFound,
+		ErrorCodeModuleNotFound,
+		ErrorCodeStructFieldNotFound,
+		ErrorCodeVersionNotFound,
+		ErrorCodeTransactionNotFound,
+		ErrorCodeTableItemNotFound,
+		ErrorCodeBlockNotFound,
+		ErrorCodeStateValueNotFound:
+		return true
+	default:
+		return false
+	}
+}
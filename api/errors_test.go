@@ -41,3 +41,20 @@ func Test_ErrorWithVm(t *testing.T) {
 	assert.Equal(t, errorCode, data.ErrorCode)
 	assert.Equal(t, vmErrorCode, data.VmErrorCode)
 }
+
+func Test_ErrorString(t *testing.T) {
+	notFound := &Error{
+		Message:   "Resource not found by Address(0x1), Struct tag(0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>) and Ledger version(123)",
+		ErrorCode: ErrorCodeResourceNotFound,
+	}
+	assert.Equal(t, "api error resource_not_found: Resource not found by Address(0x1), Struct tag(0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>) and Ledger version(123)", notFound.Error())
+	assert.True(t, notFound.IsNotFound())
+
+	vmError := &Error{
+		Message:     "Invalid transaction: Type: Validation Code: INVALID_SIGNATURE",
+		ErrorCode:   ErrorCodeVmError,
+		VmErrorCode: 1,
+	}
+	assert.Equal(t, "api error vm_error (vm error code 1): Invalid transaction: Type: Validation Code: INVALID_SIGNATURE", vmError.Error())
+	assert.False(t, vmError.IsNotFound())
+}
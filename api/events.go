@@ -1,18 +1,26 @@
 package api
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+)
 
 //region Event
 
 // Event describes an on-chain event from Move. There are currently two types:
 //
-// Handle events will have a [GUID] and SequenceNumber associated
+// Handle events (V1) have a [GUID] and SequenceNumber from the event handle they were emitted to
 //
 //	{
 //	  "type": "0x1::coin::WithdrawEvent",
 //	  "guid": {
-//	    "addr": "0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b",
-//	    "creation_num": "3"
+//	    "account_address": "0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b",
+//	    "creation_number": "3"
 //	  },
 //	  "sequence_number": "0",
 //	  "data": {
@@ -20,13 +28,14 @@ import "encoding/json"
 //	  }
 //	}
 //
-// Module events will not have a [GUID] and SequenceNumber associated
+// Module events (V2) have no event handle, so the node returns a zero GUID and SequenceNumber, or may leave them out.
+// Either way, the Guid is nil, see [Event.IsModuleEvent].
 //
 //	{
 //	  "type": "0x1::fungible_asset::Withdraw",
 //	  "guid": {
-//	    "addr": "0x0",
-//	    "creation_num": "0"
+//	    "account_address": "0x0",
+//	    "creation_number": "0"
 //	  },
 //	  "sequence_number": "0",
 //	  "data": {
@@ -35,11 +44,16 @@ import "encoding/json"
 //	  }
 //	}
 type Event struct {
-	Type           string         // Type is the fully qualified name of the event e.g. 0x1::coin::WithdrawEvent
-	Guid           *GUID          // GUID is the unique identifier of the event, only present in V1 events
-	SequenceNumber uint64         // SequenceNumber is the sequence number of the event, only present in V1 events
-	Data           map[string]any // Data is the event data, a map of field name to value, this should match it's on-chain struct representation
-	RawData        json.RawMessage
+	Type           string          // Type is the fully qualified name of the event e.g. 0x1::coin::WithdrawEvent
+	Guid           *GUID           // GUID is the unique identifier of the event handle, nil for module events
+	SequenceNumber uint64          // SequenceNumber is the sequence number of the event in its handle, 0 for module events
+	Data           map[string]any  // Data is the event data, a map of field name to value, this should match it's on-chain struct representation.  Numbers are [json.Number], so they keep their precision.
+	RawData        json.RawMessage // RawData is the event data as returned by the node, to decode into your own types, see [DecodeEvents]
+}
+
+// IsModuleEvent tells whether the event is a module event (V2), rather than a handle event (V1) with a [GUID]
+func (o *Event) IsModuleEvent() bool {
+	return o.Guid == nil
 }
 
 //region Event JSON
@@ -59,13 +73,17 @@ func (o *Event) UnmarshalJSON(b []byte) error {
 	}
 	o.Type = data.Type
 	o.Guid = data.Guid
+	if isModuleEventGuid(o.Guid) {
+		o.Guid = nil
+	}
 	o.SequenceNumber = data.SequenceNumber.ToUint64()
 	o.RawData = data.RawData
 	// it's possible that the data is a map[string]any or array
-	_ = json.Unmarshal(data.RawData, &o.Data)
+	_ = unmarshalUseNumber(data.RawData, &o.Data)
 	return nil
 }
 
+// MarshalJSON serializes an Event the same as the node, with a zero GUID for module events
 func (o *Event) MarshalJSON() ([]byte, error) {
 	type inner struct {
 		Type           string          `json:"type"`
@@ -79,8 +97,137 @@ func (o *Event) MarshalJSON() ([]byte, error) {
 		SequenceNumber: U64(o.SequenceNumber),
 		RawData:        o.RawData,
 	}
+	if data.Guid == nil {
+		data.Guid = &GUID{AccountAddress: &types.AccountZero}
+	}
 	return json.Marshal(data)
 }
 
+// isModuleEventGuid tells whether the GUID is missing, or is the zero GUID the node returns for module events
+func isModuleEventGuid(guid *GUID) bool {
+	if guid == nil {
+		return true
+	}
+	return guid.CreationNumber == 0 && (guid.AccountAddress == nil || *guid.AccountAddress == types.AccountZero)
+}
+
+//endregion
 //endregion
+
+//region Event filtering
+
+// FilterEvents returns the events with the given Move struct type e.g. 0x1::coin::DepositEvent
+//
+// Addresses in the types are compared by value, so 0x1::coin::DepositEvent matches
+// 0x0000000000000000000000000000000000000000000000000000000000000001::coin::DepositEvent.  Type arguments must match
+// as well.
+func FilterEvents(events []*Event, typeTag string) []*Event {
+	normalized := normalizeTypeString(typeTag)
+	filtered := make([]*Event, 0)
+	for _, event := range events {
+		if event != nil && normalizeTypeString(event.Type) == normalized {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// DecodeEvents decodes the data of the events with the given Move struct type into T, see [FilterEvents] for how types
+// are matched.  Numbers decoded into an any are [json.Number], so they keep their precision.
+//
+//	type DepositEvent struct {
+//		Amount U64 `json:"amount"`
+//	}
+//	deposits, err := DecodeEvents[DepositEvent](txn.Events, "0x1::coin::DepositEvent")
+func DecodeEvents[T any](events []*Event, typeTag string) ([]T, error) {
+	filtered := FilterEvents(events, typeTag)
+	decoded := make([]T, len(filtered))
+	for i, event := range filtered {
+		err := unmarshalUseNumber(event.RawData, &decoded[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode event %d of type %s: %w", i, event.Type, err)
+		}
+	}
+	return decoded, nil
+}
+
+// EventsOfType returns the events emitted by the transaction with the given Move struct type, see [FilterEvents] for
+// how types are matched.  This saves querying the events endpoint for the events of a submitted transaction.
+//
+//	deposits := txn.EventsOfType("0x1::coin::DepositEvent")
+func (o *UserTransaction) EventsOfType(typeTag string) []*Event {
+	return FilterEvents(o.Events, typeTag)
+}
+
+// DecodeTransactionEvents decodes the data of the events emitted by the transaction with the given Move struct type
+// into T, see [DecodeEvents]
+//
+//	type DepositEvent struct {
+//		Amount U64 `json:"amount"`
+//	}
+//	deposits, err := DecodeTransactionEvents[DepositEvent](txn, "0x1::coin::DepositEvent")
+func DecodeTransactionEvents[T any](txn *UserTransaction, typeTag string) ([]T, error) {
+	if txn == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+	return DecodeEvents[T](txn.Events, typeTag)
+}
+
+// unmarshalUseNumber is [json.Unmarshal], but numbers decoded into an any are [json.Number] rather than float64, which
+// loses precision above 2^53 e.g. for u64 values given as numbers
+func unmarshalUseNumber(b []byte, out any) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(out); err != nil {
+		return err
+	}
+	// Only a single value is valid JSON, the same as json.Unmarshal
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("invalid data after top-level value")
+	}
+	return nil
+}
+
+// normalizeTypeString converts all addresses in a type string to their canonical form, and removes whitespace
+//
+// Example:
+//
+//	0x00001::coin::CoinStore<0x1::aptos_coin::AptosCoin> -> 0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>
+func normalizeTypeString(typeStr string) string {
+	var builder strings.Builder
+	for i := 0; i < len(typeStr); {
+		c := typeStr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '0' && i+1 < len(typeStr) && typeStr[i+1] == 'x' && (i == 0 || !isIdentifierChar(typeStr[i-1])):
+			end := i + 2
+			for end < len(typeStr) && isHexChar(typeStr[end]) {
+				end++
+			}
+			address := types.AccountAddress{}
+			if err := address.ParseStringRelaxed(typeStr[i:end]); err == nil {
+				builder.WriteString(address.String())
+			} else {
+				builder.WriteString(typeStr[i:end])
+			}
+			i = end
+		default:
+			builder.WriteByte(c)
+			i++
+		}
+	}
+	return builder.String()
+}
+
+// isIdentifierChar tells whether the character can be part of a Move identifier
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isHexChar tells whether the character is a hex digit
+func isHexChar(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
 //endregion
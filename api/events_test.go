@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/aptos-labs/aptos-go-sdk/internal/types"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -25,6 +26,7 @@ func TestEvent_V1(t *testing.T) {
 	assert.Equal(t, "0x1::coin::WithdrawEvent", data.Type)
 	assert.Equal(t, uint64(0), data.SequenceNumber)
 	assert.Equal(t, "1000", data.Data["amount"].(string))
+	assert.False(t, data.IsModuleEvent())
 	assert.Equal(t, uint64(3), data.Guid.CreationNumber)
 
 	addr := &types.AccountAddress{}
@@ -59,13 +61,73 @@ func TestEvent_V2(t *testing.T) {
 	assert.Equal(t, uint64(0), data.SequenceNumber)
 	assert.Equal(t, "1000", data.Data["amount"].(string))
 	assert.Equal(t, "0x1234123412341234123412341234123412341234123412341234123412341234", data.Data["store"].(string))
-	assert.Equal(t, uint64(0), data.Guid.CreationNumber)
-	assert.Equal(t, &types.AccountZero, data.Guid.AccountAddress)
+	// Module events have no GUID
+	assert.True(t, data.IsModuleEvent())
+	assert.Nil(t, data.Guid)
 
 	// test json marshal
 	b, err := json.Marshal(data)
 	assert.NoError(t, err)
-	assert.JSONEq(t, testJson, string(b))
+	// Addresses are always marshalled in the long form
+	assert.JSONEq(t, strings.Replace(testJson, `"account_address": "0x0"`, `"account_address": "`+types.AccountZero.StringLong()+`"`, 1), string(b))
+}
+
+func TestEvent_V2WithoutGuid(t *testing.T) {
+	testJson := `{
+		"type": "0x1::transaction_fee::FeeStatement",
+		"data": {
+			"total_charge_gas_units": "5"
+		}
+	}`
+	data := &Event{}
+	err := json.Unmarshal([]byte(testJson), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::transaction_fee::FeeStatement", data.Type)
+	assert.True(t, data.IsModuleEvent())
+	assert.Nil(t, data.Guid)
+	assert.Equal(t, uint64(0), data.SequenceNumber)
+	assert.Equal(t, "5", data.Data["total_charge_gas_units"].(string))
+
+	// A null GUID is the same
+	data = &Event{}
+	err = json.Unmarshal([]byte(`{"type":"0x1::transaction_fee::FeeStatement","guid":null,"data":{}}`), &data)
+	assert.NoError(t, err)
+	assert.True(t, data.IsModuleEvent())
+
+	// It's marshalled with the zero GUID, as the node returns it
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "0x1::transaction_fee::FeeStatement",
+		"guid": {"account_address": "`+types.AccountZero.StringLong()+`", "creation_number": "0"},
+		"sequence_number": "0",
+		"data": {}
+	}`, string(b))
+}
+
+func TestEvent_Mixed(t *testing.T) {
+	testJson := `[
+		{
+			"type": "0x1::coin::WithdrawEvent",
+			"guid": {"account_address": "0x1", "creation_number": "3"},
+			"sequence_number": "7",
+			"data": {"amount": "1000"}
+		},
+		{
+			"type": "0x1::fungible_asset::Withdraw",
+			"guid": {"account_address": "0x0", "creation_number": "0"},
+			"sequence_number": "0",
+			"data": {"store": "0x1", "amount": "1000"}
+		}
+	]`
+	var events []*Event
+	err := json.Unmarshal([]byte(testJson), &events)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.False(t, events[0].IsModuleEvent())
+	assert.Equal(t, "0x1/3", events[0].Guid.String())
+	assert.Equal(t, uint64(7), events[0].SequenceNumber)
+	assert.True(t, events[1].IsModuleEvent())
 }
 
 func TestUnMarshalU64(t *testing.T) {
@@ -101,3 +163,191 @@ func TestUnMarshalU64(t *testing.T) {
 	err = json.Unmarshal([]byte(testJson2), &data)
 	assert.NoError(t, err)
 }
+
+func TestFilterEvents(t *testing.T) {
+	events := []*Event{
+		{Type: "0x1::coin::WithdrawEvent", RawData: json.RawMessage(`{"amount":"100"}`)},
+		{Type: "0x1::coin::DepositEvent", RawData: json.RawMessage(`{"amount":"200"}`)},
+		{Type: "0x0000000000000000000000000000000000000000000000000000000000000001::coin::DepositEvent", RawData: json.RawMessage(`{"amount":"300"}`)},
+		{Type: "0x1::transaction_fee::FeeStatement", RawData: json.RawMessage(`{"total_charge_gas_units":"5"}`)},
+		nil,
+	}
+
+	deposits := FilterEvents(events, "0x1::coin::DepositEvent")
+	assert.Len(t, deposits, 2)
+	assert.Equal(t, events[1], deposits[0])
+	assert.Equal(t, events[2], deposits[1])
+
+	// The long form of the address matches the same
+	assert.Equal(t, deposits, FilterEvents(events, "0x0000000000000000000000000000000000000000000000000000000000000001::coin::DepositEvent"))
+	assert.Equal(t, deposits, FilterEvents(events, "0x01::coin::DepositEvent"))
+
+	assert.Empty(t, FilterEvents(events, "0x2::coin::DepositEvent"))
+	assert.Empty(t, FilterEvents(events, "0x1::coin::Deposit"))
+	assert.Empty(t, FilterEvents(nil, "0x1::coin::DepositEvent"))
+}
+
+func TestFilterEvents_TypeArguments(t *testing.T) {
+	events := []*Event{
+		{Type: "0x1::object::Event<0x1::aptos_coin::AptosCoin, u64>"},
+		{Type: "0x1::object::Event<0x2::coin::Coin, u64>"},
+		{Type: "0x1::object::Event<0x01::aptos_coin::AptosCoin,u64>"},
+		{Type: "0xabc::my_module::Event"},
+		{Type: "0x0000000000000000000000000000000000000000000000000000000000000abc::my_module::Event"},
+	}
+	assert.Equal(t, []*Event{events[0], events[2]}, FilterEvents(events, "0x1::object::Event<0x1::aptos_coin::AptosCoin,u64>"))
+	assert.Equal(t, []*Event{events[3], events[4]}, FilterEvents(events, "0xABC::my_module::Event"))
+}
+
+func TestDecodeEvents(t *testing.T) {
+	events := []*Event{
+		{Type: "0x1::coin::WithdrawEvent", RawData: json.RawMessage(`{"amount":"100"}`)},
+		{Type: "0x1::coin::DepositEvent", RawData: json.RawMessage(`{"amount":"200"}`)},
+		{Type: "0x1::coin::DepositEvent", RawData: json.RawMessage(`{"amount":"300"}`)},
+	}
+	type DepositEvent struct {
+		Amount U64 `json:"amount"`
+	}
+	deposits, err := DecodeEvents[DepositEvent](events, "0x1::coin::DepositEvent")
+	assert.NoError(t, err)
+	assert.Equal(t, []DepositEvent{{Amount: 200}, {Amount: 300}}, deposits)
+
+	// No matches is an empty list
+	deposits, err = DecodeEvents[DepositEvent](events, "0x1::coin::Other")
+	assert.NoError(t, err)
+	assert.Empty(t, deposits)
+
+	// Data that doesn't match the type is an error
+	events = append(events, &Event{Type: "0x1::coin::DepositEvent", RawData: json.RawMessage(`{"amount":"abc"}`)})
+	_, err = DecodeEvents[DepositEvent](events, "0x1::coin::DepositEvent")
+	assert.Error(t, err)
+}
+
+func TestNormalizeTypeString(t *testing.T) {
+	assert.Equal(t, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", normalizeTypeString("0x00001::coin::CoinStore< 0x1::aptos_coin::AptosCoin >"))
+	assert.Equal(t, "vector<u8>", normalizeTypeString("vector<u8>"))
+	// Identifiers containing 0x are left alone
+	assert.Equal(t, "0x1::a0x1::B", normalizeTypeString("0x1::a0x1::B"))
+}
+
+func TestUserTransaction_EventsOfType(t *testing.T) {
+	testJson := `{
+  "version": "1000",
+  "hash": "0x1a2b3c3b2a1a2b3c3b2a1a2b3c3b2a1a2b3c3b2a1a2b3c3b2a1a2b3c3b2a1a2b",
+  "state_change_hash": "0x0000000000000000000000000000000000000000000000000000000000000001",
+  "event_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000001",
+  "state_checkpoint_hash": null,
+  "gas_used": "9",
+  "success": true,
+  "vm_status": "Executed successfully",
+  "accumulator_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000001",
+  "changes": [],
+  "sender": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e",
+  "sequence_number": "5",
+  "max_gas_amount": "2000",
+  "gas_unit_price": "100",
+  "expiration_timestamp_secs": "1719968695",
+  "payload": {
+    "function": "0x1::coin::transfer",
+    "type_arguments": ["0x1::aptos_coin::AptosCoin"],
+    "arguments": ["0x8038df5e61a19a5f86ad01f4389736b08250dad1b4aa864afc4fc639a2581ca8", "1000"],
+    "type": "entry_function_payload"
+  },
+  "signature": {
+    "public_key": "0x5e10e3db4e3c700142b9a3e18c40038db5903f2dedfe41d09aca74a8c68565d6",
+    "signature": "0xa95686dab2c93cf1720e300b929e3656cc6cdc3a8389dc12bb9bd5a17ae3af975bee9d618f080266e3a60f1e2968220a83d773e2b3902edfe54127ed0a7b290b",
+    "type": "ed25519_signature"
+  },
+  "events": [
+  {
+    "guid": {"creation_number": "3", "account_address": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e"},
+    "sequence_number": "4",
+    "type": "0x1::coin::WithdrawEvent",
+    "data": {"amount": "1000"}
+  },
+  {
+    "guid": {"creation_number": "2", "account_address": "0x8038df5e61a19a5f86ad01f4389736b08250dad1b4aa864afc4fc639a2581ca8"},
+    "sequence_number": "0",
+    "type": "0x0000000000000000000000000000000000000000000000000000000000000001::coin::DepositEvent",
+    "data": {"amount": "1000"}
+  },
+  {
+    "guid": {"creation_number": "0", "account_address": "0x0"},
+    "sequence_number": "0",
+    "type": "0x1::transaction_fee::FeeStatement",
+    "data": {"total_charge_gas_units": "9"}
+  }
+  ],
+  "timestamp": "1719965096135309",
+  "type": "user_transaction"
+}`
+	txn := &UserTransaction{}
+	err := json.Unmarshal([]byte(testJson), txn)
+	assert.NoError(t, err)
+
+	// The short and long forms of the address match
+	deposits := txn.EventsOfType("0x1::coin::DepositEvent")
+	assert.Len(t, deposits, 1)
+	assert.Equal(t, txn.Events[1], deposits[0])
+	assert.Empty(t, txn.EventsOfType("0x1::fungible_asset::Deposit"))
+
+	type depositEvent struct {
+		Amount U64 `json:"amount"`
+	}
+	decoded, err := DecodeTransactionEvents[depositEvent](txn, "0x01::coin::DepositEvent")
+	assert.NoError(t, err)
+	assert.Equal(t, []depositEvent{{Amount: 1000}}, decoded)
+
+	_, err = DecodeTransactionEvents[depositEvent](nil, "0x1::coin::DepositEvent")
+	assert.Error(t, err)
+}
+
+func TestEvent_LargeNumbers(t *testing.T) {
+	// 2^60 + 1 can't be represented exactly as a float64
+	testJson := `{
+		"type": "0xcafe::counter::Incremented",
+		"guid": {"account_address": "0x0", "creation_number": "0"},
+		"sequence_number": "0",
+		"data": {
+			"count": 1152921504606846977,
+			"nested": {"values": [1152921504606846977, 7]},
+			"small": 7
+		}
+	}`
+	data := &Event{}
+	err := json.Unmarshal([]byte(testJson), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("1152921504606846977"), data.Data["count"])
+	assert.Equal(t, json.Number("7"), data.Data["small"])
+	nested := data.Data["nested"].(map[string]any)
+	assert.Equal(t, []any{json.Number("1152921504606846977"), json.Number("7")}, nested["values"])
+	count, err := data.Data["count"].(json.Number).Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1<<60+1), count)
+
+	// The raw data is kept to decode into other types
+	var raw struct {
+		Count uint64 `json:"count"`
+	}
+	err = json.Unmarshal(data.RawData, &raw)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1<<60+1), raw.Count)
+
+	// As well as when decoding into any
+	type counterEvent struct {
+		Count any `json:"count"`
+		Small any `json:"small"`
+	}
+	decoded, err := DecodeEvents[counterEvent]([]*Event{data}, "0xcafe::counter::Incremented")
+	assert.NoError(t, err)
+	assert.Equal(t, []counterEvent{{Count: json.Number("1152921504606846977"), Small: json.Number("7")}}, decoded)
+}
+
+func TestUnmarshalUseNumber(t *testing.T) {
+	var out any
+	assert.NoError(t, unmarshalUseNumber([]byte(` {"a": 1} `), &out))
+	assert.Equal(t, map[string]any{"a": json.Number("1")}, out)
+	assert.Error(t, unmarshalUseNumber([]byte(`{"a": 1} {"b": 2}`), &out))
+	assert.Error(t, unmarshalUseNumber([]byte(`{"a": 1}}`), &out))
+	assert.Error(t, unmarshalUseNumber([]byte(``), &out))
+}
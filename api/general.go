@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 // HealthCheckResponse is the response to a health check request
 //
 // Example:
@@ -10,3 +12,35 @@ package api
 type HealthCheckResponse struct {
 	Message string `json:"message"` // Message is the human-readable message, usually "aptos-node:ok"
 }
+
+// LedgerInfo is the current state of the blockchain on the node, as returned by the root of the API
+//
+// Example:
+//
+//	{
+//		"chain_id": 2,
+//		"epoch": "1234",
+//		"ledger_version": "5678901",
+//		"oldest_ledger_version": "0",
+//		"ledger_timestamp": "1719520421743738",
+//		"node_role": "full_node",
+//		"oldest_block_height": "0",
+//		"block_height": "123456",
+//		"git_hash": "1bd2d53e5bd77ca8b0f8ab7e0fc0d8a7b3c1a6c9"
+//	}
+type LedgerInfo struct {
+	ChainId             uint8  `json:"chain_id"`              // ChainId is the chain ID of the network e.g. 2 for Testnet
+	Epoch               U64    `json:"epoch"`                 // Epoch is the current epoch of the network
+	LedgerVersion       U64    `json:"ledger_version"`        // LedgerVersion is the newest transaction version available on the node
+	OldestLedgerVersion U64    `json:"oldest_ledger_version"` // OldestLedgerVersion is the oldest transaction version not pruned on the node
+	LedgerTimestamp     U64    `json:"ledger_timestamp"`      // LedgerTimestamp is the timestamp of the newest ledger version, in microseconds
+	NodeRole            string `json:"node_role"`             // NodeRole is the role of the node in the network e.g. full_node
+	BlockHeight         U64    `json:"block_height"`          // BlockHeight is the newest block available on the node
+	OldestBlockHeight   U64    `json:"oldest_block_height"`   // OldestBlockHeight is the oldest block not pruned on the node
+	GitHash             string `json:"git_hash"`              // GitHash is the git hash of the node build, may be empty
+}
+
+// LedgerTime converts the LedgerTimestamp to a [time.Time] in UTC
+func (o *LedgerInfo) LedgerTime() time.Time {
+	return MicrosecondsToTime(o.LedgerTimestamp.ToUint64())
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func Test_HealthCheckResponse(t *testing.T) {
@@ -15,3 +16,30 @@ func Test_HealthCheckResponse(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "aptos-node:ok", data.Message)
 }
+
+func Test_LedgerInfo(t *testing.T) {
+	testJson := `{
+		"chain_id": 2,
+		"epoch": "1234",
+		"ledger_version": "5678901",
+		"oldest_ledger_version": "10",
+		"ledger_timestamp": "1719520421743738",
+		"node_role": "full_node",
+		"oldest_block_height": "3",
+		"block_height": "123456",
+		"git_hash": "1bd2d53e5bd77ca8b0f8ab7e0fc0d8a7b3c1a6c9"
+	}`
+	data := &LedgerInfo{}
+	err := json.Unmarshal([]byte(testJson), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), data.ChainId)
+	assert.Equal(t, uint64(1234), data.Epoch.ToUint64())
+	assert.Equal(t, uint64(5678901), data.LedgerVersion.ToUint64())
+	assert.Equal(t, uint64(10), data.OldestLedgerVersion.ToUint64())
+	assert.Equal(t, uint64(1719520421743738), data.LedgerTimestamp.ToUint64())
+	assert.Equal(t, time.Date(2024, time.June, 27, 20, 33, 41, 743738000, time.UTC), data.LedgerTime())
+	assert.Equal(t, "full_node", data.NodeRole)
+	assert.Equal(t, uint64(3), data.OldestBlockHeight.ToUint64())
+	assert.Equal(t, uint64(123456), data.BlockHeight.ToUint64())
+	assert.Equal(t, "1bd2d53e5bd77ca8b0f8ab7e0fc0d8a7b3c1a6c9", data.GitHash)
+}
@@ -76,6 +76,26 @@ type MoveModule struct {
 	Structs          []*MoveStruct         `json:"structs"`           // Structs are the structs defined in the module.
 }
 
+// Function returns the exposed function with the name, or nil if the module has no such function
+func (m *MoveModule) Function(name string) *MoveFunction {
+	for _, function := range m.ExposedFunctions {
+		if function.Name == name {
+			return function
+		}
+	}
+	return nil
+}
+
+// Struct returns the struct with the name, or nil if the module has no such struct
+func (m *MoveModule) Struct(name string) *MoveStruct {
+	for _, moveStruct := range m.Structs {
+		if moveStruct.Name == name {
+			return moveStruct
+		}
+	}
+	return nil
+}
+
 // MoveScript is the representation of a compiled script.  The API may not fill in the ABI field.
 //
 // Example:
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MoveOption is a representation of a Move Option<T> in JSON
+//
+// The node represents an option as a vector of either 0 or 1 elements.  The zero value is none.
+//
+// Example:
+//
+//	{"vec": []} -> none
+//	{"vec": ["1000"]} -> some("1000")
+type MoveOption[T any] struct {
+	value T
+	some  bool
+}
+
+// NewMoveOptionSome creates a [MoveOption] containing the value
+func NewMoveOptionSome[T any](value T) MoveOption[T] {
+	return MoveOption[T]{value: value, some: true}
+}
+
+// NewMoveOptionNone creates an empty [MoveOption]
+func NewMoveOptionNone[T any]() MoveOption[T] {
+	return MoveOption[T]{}
+}
+
+// IsSome tells whether the [MoveOption] has a value
+func (o *MoveOption[T]) IsSome() bool {
+	return o.some
+}
+
+// IsNone tells whether the [MoveOption] is empty
+func (o *MoveOption[T]) IsNone() bool {
+	return !o.some
+}
+
+// Get returns the value, and whether it is set.  If it is not set, it returns the zero value of T.
+func (o *MoveOption[T]) Get() (T, bool) {
+	return o.value, o.some
+}
+
+// GetAny returns the value as an any, and whether it is set, for code handling options of any type e.g. serializing
+// them as entry function arguments
+func (o MoveOption[T]) GetAny() (any, bool) {
+	return o.value, o.some
+}
+
+// Unwrap returns the value, and panics if it is not set
+func (o *MoveOption[T]) Unwrap() T {
+	if !o.some {
+		panic("unwrap called on an empty MoveOption")
+	}
+	return o.value
+}
+
+// UnmarshalJSON deserializes a JSON data blob into a [MoveOption]
+//
+// A JSON null is none, as rendered by [EmptyMoveOptionsAsNull].  It will fail if the vec has more than 1 element.
+func (o *MoveOption[T]) UnmarshalJSON(b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		*o = NewMoveOptionNone[T]()
+		return nil
+	}
+	type inner struct {
+		Vec *[]T `json:"vec"`
+	}
+	data := &inner{}
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return err
+	}
+	if data.Vec == nil {
+		return fmt.Errorf("missing vec field for MoveOption")
+	}
+	switch len(*data.Vec) {
+	case 0:
+		*o = NewMoveOptionNone[T]()
+	case 1:
+		*o = NewMoveOptionSome((*data.Vec)[0])
+	default:
+		return fmt.Errorf("invalid MoveOption, expected at most 1 element and got %d", len(*data.Vec))
+	}
+	return nil
+}
+
+// MarshalJSON serializes a [MoveOption] into a JSON data blob
+func (o MoveOption[T]) MarshalJSON() ([]byte, error) {
+	vec := make([]T, 0, 1)
+	if o.some {
+		vec = append(vec, o.value)
+	}
+	return json.Marshal(&struct {
+		Vec []T `json:"vec"`
+	}{
+		Vec: vec,
+	})
+}
+
+// EmptyMoveOptionsAsNull wraps a value for JSON marshalling, so any empty Move Option in it renders as null rather than
+// {"vec":[]} e.g. for GraphQL schemas with nullable fields.  Options with a value are unchanged.
+//
+// It applies to both [MoveOption] fields, and options in raw resource data e.g. [MoveResource] Data, so the mode is
+// chosen per call, rather than per field.
+//
+//	b, err := json.Marshal(EmptyMoveOptionsAsNull{Value: resource})
+//
+// Any JSON object with only an empty "vec" field is taken to be an empty option.  The order of fields is kept.
+type EmptyMoveOptionsAsNull struct {
+	Value any // Value is the value to marshal
+}
+
+// MarshalJSON serializes the value as normal, then replaces empty options with null
+func (o EmptyMoveOptionsAsNull) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(o.Value)
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	err = writeEmptyMoveOptionsAsNull(out, b)
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// writeEmptyMoveOptionsAsNull writes a JSON value, with every empty option in it replaced by null
+func writeEmptyMoveOptionsAsNull(out *bytes.Buffer, b json.RawMessage) error {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || (b[0] != '{' && b[0] != '[') {
+		out.Write(b)
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	if b[0] == '[' {
+		out.WriteByte('[')
+		for i := 0; decoder.More(); i++ {
+			var element json.RawMessage
+			if err := decoder.Decode(&element); err != nil {
+				return err
+			}
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := writeEmptyMoveOptionsAsNull(out, element); err != nil {
+				return err
+			}
+		}
+		out.WriteByte(']')
+		return nil
+	}
+
+	keys := make([]string, 0)
+	values := make([]json.RawMessage, 0)
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return err
+		}
+		keys = append(keys, key.(string))
+		values = append(values, value)
+	}
+	if len(keys) == 1 && keys[0] == "vec" && isEmptyJsonArray(values[0]) {
+		out.WriteString("null")
+		return nil
+	}
+	out.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		keyJson, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		out.Write(keyJson)
+		out.WriteByte(':')
+		if err = writeEmptyMoveOptionsAsNull(out, values[i]); err != nil {
+			return err
+		}
+	}
+	out.WriteByte('}')
+	return nil
+}
+
+// isEmptyJsonArray tells whether the JSON value is an array with no elements
+func isEmptyJsonArray(b json.RawMessage) bool {
+	b = bytes.TrimSpace(b)
+	if len(b) < 2 || b[0] != '[' || b[len(b)-1] != ']' {
+		return false
+	}
+	return len(bytes.TrimSpace(b[1:len(b)-1])) == 0
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestMoveOption_None(t *testing.T) {
+	testJson := `{"vec":[]}`
+	data := &MoveOption[U64]{}
+	err := json.Unmarshal([]byte(testJson), data)
+	assert.NoError(t, err)
+	assert.False(t, data.IsSome())
+	assert.True(t, data.IsNone())
+	value, ok := data.Get()
+	assert.False(t, ok)
+	assert.Equal(t, U64(0), value)
+	assert.Panics(t, func() { data.Unwrap() })
+
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, testJson, string(b))
+
+	// The zero value is none
+	b, err = json.Marshal(MoveOption[U64]{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, testJson, string(b))
+}
+
+func TestMoveOption_Some(t *testing.T) {
+	testJson := `{"vec":["1000"]}`
+	data := &MoveOption[U64]{}
+	err := json.Unmarshal([]byte(testJson), data)
+	assert.NoError(t, err)
+	assert.True(t, data.IsSome())
+	value, ok := data.Get()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1000), value.ToUint64())
+	assert.Equal(t, U64(1000), data.Unwrap())
+
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, testJson, string(b))
+}
+
+func TestMoveOption_GetAny(t *testing.T) {
+	value, ok := NewMoveOptionSome(U64(5)).GetAny()
+	assert.True(t, ok)
+	assert.Equal(t, U64(5), value)
+
+	value, ok = NewMoveOptionNone[string]().GetAny()
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+}
+
+func TestMoveOption_Struct(t *testing.T) {
+	type metadata struct {
+		Inner *types.AccountAddress `json:"inner"`
+	}
+	type resource struct {
+		Metadata MoveOption[metadata] `json:"metadata"`
+		Nested   MoveOption[MoveOption[string]]
+	}
+	testJson := `{
+		"metadata": {"vec": [{"inner": "0xa"}]},
+		"Nested": {"vec": [{"vec": ["hello"]}]}
+	}`
+	data := &resource{}
+	err := json.Unmarshal([]byte(testJson), data)
+	assert.NoError(t, err)
+	assert.Equal(t, types.AccountAddress{31: 0xa}, *data.Metadata.Unwrap().Inner)
+	nested := data.Nested.Unwrap()
+	assert.Equal(t, "hello", nested.Unwrap())
+
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	// Addresses are always marshalled in the long form
+	assert.JSONEq(t, strings.Replace(testJson, `"inner": "0xa"`, `"inner": "0x000000000000000000000000000000000000000000000000000000000000000a"`, 1), string(b))
+
+	// Nested none
+	err = json.Unmarshal([]byte(`{"metadata": {"vec": []}, "Nested": {"vec": [{"vec": []}]}}`), data)
+	assert.NoError(t, err)
+	assert.True(t, data.Metadata.IsNone())
+	nested = data.Nested.Unwrap()
+	assert.True(t, nested.IsNone())
+}
+
+func TestMoveOption_Malformed(t *testing.T) {
+	data := &MoveOption[string]{}
+	err := json.Unmarshal([]byte(`{"vec":["a","b"]}`), data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`{}`), data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`{"vec":[1]}`), data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`["a"]`), data)
+	assert.Error(t, err)
+}
+
+func TestMoveOption_Constructors(t *testing.T) {
+	some := NewMoveOptionSome("hello")
+	assert.Equal(t, "hello", some.Unwrap())
+	none := NewMoveOptionNone[string]()
+	assert.True(t, none.IsNone())
+}
+
+func TestMoveOption_Null(t *testing.T) {
+	data := NewMoveOptionSome("hello")
+	err := json.Unmarshal([]byte(`null`), &data)
+	assert.NoError(t, err)
+	assert.True(t, data.IsNone())
+}
+
+func TestEmptyMoveOptionsAsNull(t *testing.T) {
+	type resource struct {
+		Name     string                         `json:"name"`
+		Metadata MoveOption[string]             `json:"metadata"`
+		Amount   MoveOption[U64]                `json:"amount"`
+		Nested   MoveOption[MoveOption[string]] `json:"nested"`
+		List     []MoveOption[string]           `json:"list"`
+	}
+	data := resource{
+		Name:     "token",
+		Metadata: NewMoveOptionNone[string](),
+		Amount:   NewMoveOptionSome(U64(1000)),
+		Nested:   NewMoveOptionSome(NewMoveOptionNone[string]()),
+		List:     []MoveOption[string]{NewMoveOptionNone[string](), NewMoveOptionSome("a")},
+	}
+
+	// By default, empty options are an empty vec
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "token",
+		"metadata": {"vec": []},
+		"amount": {"vec": ["1000"]},
+		"nested": {"vec": [{"vec": []}]},
+		"list": [{"vec": []}, {"vec": ["a"]}]
+	}`, string(b))
+
+	// With the wrapper, they're null, and the field order is kept
+	b, err = json.Marshal(EmptyMoveOptionsAsNull{Value: data})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"token","metadata":null,"amount":{"vec":["1000"]},"nested":{"vec":[null]},"list":[null,{"vec":["a"]}]}`, string(b))
+
+	// It can be read back in either mode
+	readBack := resource{}
+	err = json.Unmarshal(b, &readBack)
+	assert.NoError(t, err)
+	assert.Equal(t, data, readBack)
+
+	// Raw resource data is rewritten too, with large numbers kept as is
+	raw := map[string]any{
+		"coin":   map[string]any{"value": "5"},
+		"frozen": false,
+		"id":     json.Number("18446744073709551615"),
+		"owner":  map[string]any{"vec": []any{}},
+	}
+	b, err = json.Marshal(EmptyMoveOptionsAsNull{Value: raw})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"coin":{"value":"5"},"frozen":false,"id":18446744073709551615,"owner":null}`, string(b))
+
+	// Other values are unchanged
+	b, err = json.Marshal(EmptyMoveOptionsAsNull{Value: "hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, `"hello"`, string(b))
+	b, err = json.Marshal(EmptyMoveOptionsAsNull{Value: map[string]any{"vec": []int{1}, "other": 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"other":2,"vec":[1]}`, string(b))
+}
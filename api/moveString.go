@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+// MoveString is a representation of a Move 0x1::string::String in JSON
+//
+// Depending on the endpoint, the node represents a string either as a plain UTF-8 string, or as the underlying struct
+// with the bytes in hex.  Both are accepted, and invalid UTF-8 is an error.  Use [MoveStringBytes] to keep the raw
+// bytes instead.
+//
+// Example:
+//
+//	"hello" -> "hello"
+//	{"bytes": "0x68656c6c6f"} -> "hello"
+type MoveString string
+
+// UnmarshalJSON deserializes a JSON data blob into a [MoveString]
+func (o *MoveString) UnmarshalJSON(b []byte) error {
+	bytes, err := unmarshalMoveStringBytes(b)
+	if err != nil {
+		return err
+	}
+	if !utf8.Valid(bytes) {
+		return fmt.Errorf("move string is not valid UTF-8: %s", util.BytesToHex(bytes))
+	}
+	*o = MoveString(bytes)
+	return nil
+}
+
+// MarshalJSON serializes a [MoveString] into a JSON data blob as a plain string
+func (o MoveString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(o))
+}
+
+// String returns the [MoveString] as a Go string
+func (o MoveString) String() string {
+	return string(o)
+}
+
+// MoveStringBytes is a representation of a Move 0x1::string::String in JSON, which keeps the raw bytes
+//
+// It accepts the same shapes as [MoveString], but does not require the bytes to be valid UTF-8.  This is for values
+// that may not be valid UTF-8, such as other structs with a bytes field in the same shape as a string.
+type MoveStringBytes []byte
+
+// UnmarshalJSON deserializes a JSON data blob into a [MoveStringBytes]
+func (o *MoveStringBytes) UnmarshalJSON(b []byte) error {
+	bytes, err := unmarshalMoveStringBytes(b)
+	if err != nil {
+		return err
+	}
+	*o = bytes
+	return nil
+}
+
+// MarshalJSON serializes a [MoveStringBytes] into a JSON data blob as the struct representation, which keeps any
+// invalid UTF-8
+func (o MoveStringBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Bytes HexBytes `json:"bytes"`
+	}{Bytes: HexBytes(o)})
+}
+
+// IsValidUtf8 tells whether the bytes are a valid UTF-8 string
+func (o MoveStringBytes) IsValidUtf8() bool {
+	return utf8.Valid(o)
+}
+
+// String returns the bytes as a Go string, invalid UTF-8 is kept as is
+func (o MoveStringBytes) String() string {
+	return string(o)
+}
+
+// unmarshalMoveStringBytes parses either a plain string, or the `{"bytes": "0x..."}` struct representation
+func unmarshalMoveStringBytes(b []byte) ([]byte, error) {
+	if len(b) > 0 && b[0] == '"' {
+		var str string
+		err := json.Unmarshal(b, &str)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(str), nil
+	}
+
+	var data struct {
+		Bytes *string `json:"bytes"`
+	}
+	err := json.Unmarshal(b, &data)
+	if err != nil {
+		return nil, err
+	}
+	if data.Bytes == nil {
+		return nil, errors.New("move string must be a string or have a bytes field")
+	}
+	return util.ParseHex(*data.Bytes)
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMoveString(t *testing.T) {
+	var str MoveString
+	err := json.Unmarshal([]byte(`"hello ∑"`), &str)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello ∑", str.String())
+
+	err = json.Unmarshal([]byte(`{"bytes":"0x68656c6c6f"}`), &str)
+	assert.NoError(t, err)
+	assert.Equal(t, MoveString("hello"), str)
+
+	err = json.Unmarshal([]byte(`{"bytes":"0x"}`), &str)
+	assert.NoError(t, err)
+	assert.Equal(t, MoveString(""), str)
+
+	b, err := json.Marshal(MoveString("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"hello"`, string(b))
+}
+
+func TestMoveString_InResource(t *testing.T) {
+	testJson := `{"name":{"bytes":"0x546f6b656e"},"description":"A token"}`
+	data := &struct {
+		Name        MoveString `json:"name"`
+		Description MoveString `json:"description"`
+	}{}
+	err := json.Unmarshal([]byte(testJson), data)
+	assert.NoError(t, err)
+	assert.Equal(t, MoveString("Token"), data.Name)
+	assert.Equal(t, MoveString("A token"), data.Description)
+}
+
+func TestMoveString_Invalid(t *testing.T) {
+	var str MoveString
+	// Invalid UTF-8
+	err := json.Unmarshal([]byte(`{"bytes":"0xff00fe"}`), &str)
+	assert.Error(t, err)
+	// Invalid hex
+	err = json.Unmarshal([]byte(`{"bytes":"0xzz"}`), &str)
+	assert.Error(t, err)
+	// Missing bytes
+	err = json.Unmarshal([]byte(`{"other":"0x00"}`), &str)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`12`), &str)
+	assert.Error(t, err)
+}
+
+func TestMoveStringBytes(t *testing.T) {
+	var str MoveStringBytes
+	err := json.Unmarshal([]byte(`{"bytes":"0xff00fe"}`), &str)
+	assert.NoError(t, err)
+	assert.Equal(t, MoveStringBytes{0xff, 0x00, 0xfe}, str)
+	assert.False(t, str.IsValidUtf8())
+
+	// Round trips the raw bytes
+	b, err := json.Marshal(str)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"bytes":"0xff00fe"}`, string(b))
+
+	err = json.Unmarshal([]byte(`"hello"`), &str)
+	assert.NoError(t, err)
+	assert.True(t, str.IsValidUtf8())
+	assert.Equal(t, "hello", str.String())
+}
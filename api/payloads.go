@@ -129,6 +129,9 @@ type TransactionPayloadUnknown struct {
 }
 
 // TransactionPayloadEntryFunction describes an entry function call by a transaction.
+//
+// This is the JSON representation from the node, and has no BCS representation, as the arguments are not typed without
+// the function's ABI.  For offline signing, build an aptos.EntryFunction instead, which has its arguments BCS encoded.
 type TransactionPayloadEntryFunction struct {
 	Function      string   `json:"function"`       // Function is the name of the function called e.g. 0x1::coin::transfer
 	TypeArguments []string `json:"type_arguments"` // TypeArguments are the type arguments for the function as a string representation of the TypeTag.
@@ -139,6 +142,9 @@ type TransactionPayloadEntryFunction struct {
 //
 // See more information about scripts at the [MoveScript Documentation].
 //
+// This is the JSON representation from the node, and has no BCS representation.  For offline signing, build an
+// aptos.Script instead.
+//
 // [MoveScript Documentation]: https://aptos.dev/en/build/smart-contracts/scripts
 type TransactionPayloadScript struct {
 	Code          *MoveScript `json:"code"`           // Code is the Move bytecode for the script.
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+)
+
+// ResourceType is the type of the resource changed, if the [WriteSetChange] is a write_resource or delete_resource.
+// Otherwise, it returns an empty string.
+func (o *WriteSetChange) ResourceType() string {
+	switch inner := o.Inner.(type) {
+	case *WriteSetChangeWriteResource:
+		if inner.Data == nil {
+			return ""
+		}
+		return inner.Data.Type
+	case *WriteSetChangeDeleteResource:
+		return inner.Resource
+	default:
+		return ""
+	}
+}
+
+// Address is the account the change was applied to, if the [WriteSetChange] is a resource or module change.
+// Otherwise, it returns nil e.g. for table items.
+func (o *WriteSetChange) Address() *types.AccountAddress {
+	switch inner := o.Inner.(type) {
+	case *WriteSetChangeWriteResource:
+		return inner.Address
+	case *WriteSetChangeDeleteResource:
+		return inner.Address
+	case *WriteSetChangeWriteModule:
+		return inner.Address
+	case *WriteSetChangeDeleteModule:
+		return inner.Address
+	default:
+		return nil
+	}
+}
+
+// TableHandle is the handle of the table changed, if the [WriteSetChange] is a write_table_item or delete_table_item.
+// Otherwise, it returns an empty string.
+func (o *WriteSetChange) TableHandle() string {
+	switch inner := o.Inner.(type) {
+	case *WriteSetChangeWriteTableItem:
+		return inner.Handle
+	case *WriteSetChangeDeleteTableItem:
+		return inner.Handle
+	default:
+		return ""
+	}
+}
+
+// Changes is the write set changes of the transaction.  Pending and unknown transactions have no changes, and will
+// return nil.
+func (o *Transaction) Changes() []*WriteSetChange {
+	return transactionChanges(o.Inner)
+}
+
+// FindResourceChange finds the change to the resource of the type at the address.  The change is either a
+// [WriteSetChangeWriteResource] or [WriteSetChangeDeleteResource], the bool is false if the resource wasn't changed.
+//
+// Type strings are compared ignoring whitespace and the address format, so `0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>`
+// matches `0x0000000000000000000000000000000000000000000000000000000000000001::coin::CoinStore<0x1::aptos_coin::AptosCoin>`.
+//
+//	change, ok := txn.FindResourceChange(sender, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>")
+//	if ok && change.Type == WriteSetChangeVariantWriteResource {
+//		coinStore := change.Inner.(*WriteSetChangeWriteResource).Data.Data
+//	}
+func (o *Transaction) FindResourceChange(address types.AccountAddress, resourceType string) (*WriteSetChange, bool) {
+	return findResourceChange(o.Changes(), address, resourceType)
+}
+
+// FindResourceChanges finds all changes to resources of the type, at any address.  Each change is either a
+// [WriteSetChangeWriteResource] or [WriteSetChangeDeleteResource], in the order in the transaction.
+//
+// This is useful to compute the balance changes of a transfer, e.g. with `0x1::fungible_asset::FungibleStore`.
+func (o *Transaction) FindResourceChanges(resourceType string) []*WriteSetChange {
+	return findResourceChanges(o.Changes(), resourceType)
+}
+
+// FindTableItemChanges finds all changes to items of the table with the handle.  Each change is either a
+// [WriteSetChangeWriteTableItem] or [WriteSetChangeDeleteTableItem], in the order in the transaction.
+func (o *Transaction) FindTableItemChanges(handle string) []*WriteSetChange {
+	return findTableItemChanges(o.Changes(), handle)
+}
+
+// Changes is the write set changes of the transaction.  Unknown transactions have no changes, and will return nil.
+func (o *CommittedTransaction) Changes() []*WriteSetChange {
+	return transactionChanges(o.Inner)
+}
+
+// FindResourceChange finds the change to the resource of the type at the address, see [Transaction.FindResourceChange]
+func (o *CommittedTransaction) FindResourceChange(address types.AccountAddress, resourceType string) (*WriteSetChange, bool) {
+	return findResourceChange(o.Changes(), address, resourceType)
+}
+
+// FindResourceChanges finds all changes to resources of the type, see [Transaction.FindResourceChanges]
+func (o *CommittedTransaction) FindResourceChanges(resourceType string) []*WriteSetChange {
+	return findResourceChanges(o.Changes(), resourceType)
+}
+
+// FindTableItemChanges finds all changes to items of the table with the handle, see [Transaction.FindTableItemChanges]
+func (o *CommittedTransaction) FindTableItemChanges(handle string) []*WriteSetChange {
+	return findTableItemChanges(o.Changes(), handle)
+}
+
+// transactionChanges retrieves the changes from any committed transaction type
+func transactionChanges(inner TransactionImpl) []*WriteSetChange {
+	switch txn := inner.(type) {
+	case *UserTransaction:
+		return txn.Changes
+	case *GenesisTransaction:
+		return txn.Changes
+	case *BlockMetadataTransaction:
+		return txn.Changes
+	case *BlockEpilogueTransaction:
+		return txn.Changes
+	case *StateCheckpointTransaction:
+		return txn.Changes
+	case *ValidatorTransaction:
+		return txn.Changes
+	default:
+		return nil
+	}
+}
+
+func findResourceChange(changes []*WriteSetChange, address types.AccountAddress, resourceType string) (*WriteSetChange, bool) {
+	resourceType = normalizeTypeString(resourceType)
+	for _, change := range changes {
+		changeAddress := change.Address()
+		if changeAddress == nil || *changeAddress != address {
+			continue
+		}
+		changeType := change.ResourceType()
+		if changeType != "" && normalizeTypeString(changeType) == resourceType {
+			return change, true
+		}
+	}
+	return nil, false
+}
+
+func findResourceChanges(changes []*WriteSetChange, resourceType string) []*WriteSetChange {
+	resourceType = normalizeTypeString(resourceType)
+	out := make([]*WriteSetChange, 0)
+	for _, change := range changes {
+		changeType := change.ResourceType()
+		if changeType != "" && normalizeTypeString(changeType) == resourceType {
+			out = append(out, change)
+		}
+	}
+	return out
+}
+
+func findTableItemChanges(changes []*WriteSetChange, handle string) []*WriteSetChange {
+	handle = normalizeTypeString(handle)
+	out := make([]*WriteSetChange, 0)
+	for _, change := range changes {
+		changeHandle := change.TableHandle()
+		if changeHandle != "" && normalizeTypeString(changeHandle) == handle {
+			out = append(out, change)
+		}
+	}
+	return out
+}
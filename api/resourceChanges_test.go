@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testTransferTransactionJson is a transfer of APT between two accounts, with the legacy coin store of the sender and the
+// primary fungible store of the receiver, and the table item for the total supply
+const testTransferTransactionJson = `{
+  "version": "2069431296",
+  "hash": "0x5b9c2b2bb1d4a2b0e6aa0c5e3ef7de0e9ba07f6d8e52b2b1e1c21c0d46d7d761",
+  "state_change_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+  "event_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+  "state_checkpoint_hash": null,
+  "gas_used": "8",
+  "success": true,
+  "vm_status": "Executed successfully",
+  "accumulator_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+  "changes": [
+    {
+      "address": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e",
+      "state_key_hash": "0x1a3e0b8c38f1d5d2bbd7a6f8f5cfdeb6e8e1d8e2b1f1f9330b4e1f4b9e6c7a01",
+      "data": {
+        "type": "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+        "data": {
+          "coin": {"value": "99934200"},
+          "deposit_events": {"counter": "1", "guid": {"id": {"addr": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e", "creation_num": "2"}}},
+          "frozen": false,
+          "withdraw_events": {"counter": "1", "guid": {"id": {"addr": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e", "creation_num": "3"}}}
+        }
+      },
+      "type": "write_resource"
+    },
+    {
+      "address": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e",
+      "state_key_hash": "0x2b4f1c9d49f2e6e3ccd8b7f9f6d0efc7f9f2e9f3c2f2fa441c5f2f5cafd7db02",
+      "data": {
+        "type": "0x1::account::Account",
+        "data": {
+          "authentication_key": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e",
+          "coin_register_events": {"counter": "1", "guid": {"id": {"addr": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e", "creation_num": "0"}}},
+          "guid_creation_num": "4",
+          "key_rotation_events": {"counter": "0", "guid": {"id": {"addr": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e", "creation_num": "1"}}},
+          "rotation_capability_offer": {"for": {"vec": []}},
+          "sequence_number": "1",
+          "signer_capability_offer": {"for": {"vec": []}}
+        }
+      },
+      "type": "write_resource"
+    },
+    {
+      "address": "0x8d4d6b552b5dc21cd2dec5c6b5cba6f2ed7b1e4ae346e4cb20b4584495d38b55",
+      "state_key_hash": "0x3c5a2dae5a03f7f4dde9c80a07e1f0d8a0a3fa04d3a30b552d6a3a6dbfe8ec03",
+      "data": {
+        "type": "0x1::fungible_asset::FungibleStore",
+        "data": {
+          "balance": "100",
+          "frozen": false,
+          "metadata": {"inner": "0xa"}
+        }
+      },
+      "type": "write_resource"
+    },
+    {
+      "address": "0x8d4d6b552b5dc21cd2dec5c6b5cba6f2ed7b1e4ae346e4cb20b4584495d38b55",
+      "state_key_hash": "0x4d6b3ebf6b14080500000000000000000000000000000000000000000000aa04",
+      "resource": "0x1::fungible_asset::ConcurrentFungibleBalance",
+      "type": "delete_resource"
+    },
+    {
+      "state_key_hash": "0x6e2efe4e853ddb1e8e2c1b9b9c7d2d2c0d3b2a4c9b8e6f7a5c4d3e2f1a0b9c05",
+      "handle": "0x1b854694ae746cdbd8d44186ca4929b2b337df21d1c74633be19b2710552fdca",
+      "key": "0x0619dc29a0aac8fa146714058e8dd6d2d0f3bdf5f6331907bf91f3acd81e6935",
+      "value": "0xa4c1d1ff9c5c1e1b0100000000000000",
+      "data": null,
+      "type": "write_table_item"
+    }
+  ],
+  "sender": "0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e",
+  "sequence_number": "0",
+  "max_gas_amount": "200000",
+  "gas_unit_price": "100",
+  "expiration_timestamp_secs": "1719968695",
+  "payload": {
+    "function": "0x1::aptos_account::transfer",
+    "type_arguments": [],
+    "arguments": ["0x8038df5e61a19a5f86ad01f4389736b08250dad1b4aa864afc4fc639a2581ca8", "100"],
+    "type": "entry_function_payload"
+  },
+  "signature": {
+    "public_key": "0x5e10e3db4e3c700142b9a3e18c40038db5903f2dedfe41d09aca74a8c68565d6",
+    "signature": "0xa95686dab2c93cf1720e300b929e3656cc6cdc3a8389dc12bb9bd5a17ae3af975bee9d618f080266e3a60f1e2968220a83d773e2b3902edfe54127ed0a7b290b",
+    "type": "ed25519_signature"
+  },
+  "events": [],
+  "timestamp": "1719965096135309",
+  "type": "user_transaction"
+}`
+
+func TestTransaction_FindResourceChange(t *testing.T) {
+	txn := &Transaction{}
+	err := json.Unmarshal([]byte(testTransferTransactionJson), txn)
+	assert.NoError(t, err)
+	assert.Len(t, txn.Changes(), 5)
+
+	sender := types.AccountAddress{}
+	err = sender.ParseStringRelaxed("0xa46c6c7a65d605685e23055a6a906fb7284ba87849cbeb579d5c07424938241e")
+	assert.NoError(t, err)
+	store := types.AccountAddress{}
+	err = store.ParseStringRelaxed("0x8d4d6b552b5dc21cd2dec5c6b5cba6f2ed7b1e4ae346e4cb20b4584495d38b55")
+	assert.NoError(t, err)
+
+	// The address format and whitespace in the type doesn't matter
+	change, ok := txn.FindResourceChange(sender, "0x0000000000000000000000000000000000000000000000000000000000000001::coin::CoinStore< 0x1::aptos_coin::AptosCoin >")
+	assert.True(t, ok)
+	assert.Equal(t, WriteSetChangeVariantWriteResource, change.Type)
+	coinStore := change.Inner.(*WriteSetChangeWriteResource).Data.Data
+	assert.Equal(t, "99934200", coinStore["coin"].(map[string]any)["value"])
+
+	change, ok = txn.FindResourceChange(sender, "0x1::account::Account")
+	assert.True(t, ok)
+	assert.Equal(t, "1", change.Inner.(*WriteSetChangeWriteResource).Data.Data["sequence_number"])
+
+	// Deleted resources are found too
+	change, ok = txn.FindResourceChange(store, "0x1::fungible_asset::ConcurrentFungibleBalance")
+	assert.True(t, ok)
+	assert.Equal(t, WriteSetChangeVariantDeleteResource, change.Type)
+	assert.Equal(t, "0x1::fungible_asset::ConcurrentFungibleBalance", change.ResourceType())
+
+	// Not at that address, or not changed
+	_, ok = txn.FindResourceChange(store, "0x1::account::Account")
+	assert.False(t, ok)
+	_, ok = txn.FindResourceChange(sender, "0x1::coin::CoinStore<0x1::fake::Coin>")
+	assert.False(t, ok)
+
+	// The same on a committed transaction
+	committed := &CommittedTransaction{}
+	err = json.Unmarshal([]byte(testTransferTransactionJson), committed)
+	assert.NoError(t, err)
+	committedChange, ok := committed.FindResourceChange(store, "0x1::fungible_asset::FungibleStore")
+	assert.True(t, ok)
+	assert.Equal(t, "100", committedChange.Inner.(*WriteSetChangeWriteResource).Data.Data["balance"])
+}
+
+func TestTransaction_FindResourceChanges(t *testing.T) {
+	txn := &Transaction{}
+	err := json.Unmarshal([]byte(testTransferTransactionJson), txn)
+	assert.NoError(t, err)
+
+	changes := txn.FindResourceChanges("0x1::fungible_asset::FungibleStore")
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "0x8d4d6b552b5dc21cd2dec5c6b5cba6f2ed7b1e4ae346e4cb20b4584495d38b55", changes[0].Address().StringLong())
+	assert.Empty(t, changes[0].TableHandle())
+
+	changes = txn.FindResourceChanges("0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>")
+	assert.Len(t, changes, 1)
+
+	assert.Empty(t, txn.FindResourceChanges("0x1::object::ObjectCore"))
+}
+
+func TestTransaction_FindTableItemChanges(t *testing.T) {
+	txn := &Transaction{}
+	err := json.Unmarshal([]byte(testTransferTransactionJson), txn)
+	assert.NoError(t, err)
+
+	changes := txn.FindTableItemChanges("0x1b854694ae746cdbd8d44186ca4929b2b337df21d1c74633be19b2710552fdca")
+	assert.Len(t, changes, 1)
+	assert.Equal(t, WriteSetChangeVariantWriteTableItem, changes[0].Type)
+	item := changes[0].Inner.(*WriteSetChangeWriteTableItem)
+	assert.Equal(t, "0x0619dc29a0aac8fa146714058e8dd6d2d0f3bdf5f6331907bf91f3acd81e6935", item.Key)
+	assert.Nil(t, changes[0].Address())
+	assert.Empty(t, changes[0].ResourceType())
+
+	assert.Empty(t, txn.FindTableItemChanges("0x2"))
+}
+
+func TestTransaction_ChangesPending(t *testing.T) {
+	// Pending transactions have no changes
+	txn := &Transaction{Type: TransactionVariantPending, Inner: &PendingTransaction{}}
+	assert.Nil(t, txn.Changes())
+	assert.Empty(t, txn.FindResourceChanges("0x1::account::Account"))
+	_, ok := txn.FindResourceChange(types.AccountOne, "0x1::account::Account")
+	assert.False(t, ok)
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Get returns the raw value in the resource data at the dotted path, e.g. "coin.value" in a CoinStore.  A path segment
+// that is a number indexes into a vector, e.g. "metadata.vec.0" for the value of an option.
+//
+// It will fail if any part of the path is missing.
+func (o *MoveResource) Get(path string) (any, error) {
+	var value any = o.Data
+	traversed := ""
+	for _, segment := range strings.Split(path, ".") {
+		if traversed == "" {
+			traversed = segment
+		} else {
+			traversed += "." + segment
+		}
+		switch inner := value.(type) {
+		case map[string]any:
+			next, ok := inner[segment]
+			if !ok {
+				return nil, fmt.Errorf("resource %s has no field %s", o.Type, traversed)
+			}
+			value = next
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(inner) {
+				return nil, fmt.Errorf("resource %s has no element %s, vector has %d elements", o.Type, traversed, len(inner))
+			}
+			value = inner[index]
+		default:
+			return nil, fmt.Errorf("resource %s has no field %s, parent is %T", o.Type, traversed, value)
+		}
+	}
+	return value, nil
+}
+
+// GetU64 returns the u64 in the resource data at the dotted path, see [MoveResource.Get].  The node returns u64 values
+// as strings, which are converted.
+//
+//	balance, err := resource.GetU64("coin.value")
+func (o *MoveResource) GetU64(path string) (uint64, error) {
+	value, err := o.Get(path)
+	if err != nil {
+		return 0, err
+	}
+	switch inner := value.(type) {
+	case string:
+		out, err := strconv.ParseUint(inner, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("resource %s field %s is not a u64: %w", o.Type, path, err)
+		}
+		return out, nil
+	case json.Number:
+		out, err := strconv.ParseUint(inner.String(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("resource %s field %s is not a u64: %w", o.Type, path, err)
+		}
+		return out, nil
+	case float64:
+		// u8, u16, and u32 are numbers
+		if inner < 0 || inner > math.MaxUint32 || inner != math.Trunc(inner) {
+			return 0, fmt.Errorf("resource %s field %s is not a u64: %v", o.Type, path, inner)
+		}
+		return uint64(inner), nil
+	default:
+		return 0, fmt.Errorf("resource %s field %s is not a u64, it is %T", o.Type, path, value)
+	}
+}
+
+// GetString returns the string in the resource data at the dotted path, see [MoveResource.Get]
+//
+//	name, err := resource.GetString("name")
+func (o *MoveResource) GetString(path string) (string, error) {
+	value, err := o.Get(path)
+	if err != nil {
+		return "", err
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("resource %s field %s is not a string, it is %T", o.Type, path, value)
+	}
+	return str, nil
+}
+
+// GetAddress returns the address in the resource data at the dotted path, see [MoveResource.Get].  Both the short and
+// long forms of addresses are accepted.  For an object reference, the address is in the inner field.
+//
+//	metadata, err := resource.GetAddress("metadata.inner")
+func (o *MoveResource) GetAddress(path string) (types.AccountAddress, error) {
+	str, err := o.GetString(path)
+	if err != nil {
+		return types.AccountAddress{}, err
+	}
+	address := types.AccountAddress{}
+	err = address.ParseStringRelaxed(str)
+	if err != nil {
+		return types.AccountAddress{}, fmt.Errorf("resource %s field %s is not an address: %w", o.Type, path, err)
+	}
+	return address, nil
+}
+
+// GetBool returns the bool in the resource data at the dotted path, see [MoveResource.Get]
+//
+//	frozen, err := resource.GetBool("frozen")
+func (o *MoveResource) GetBool(path string) (bool, error) {
+	value, err := o.Get(path)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("resource %s field %s is not a bool, it is %T", o.Type, path, value)
+	}
+	return b, nil
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testCoinStoreResourceJson = `{
+	"type": "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+	"data": {
+		"coin": {
+			"value": "4236137720"
+		},
+		"deposit_events": {
+			"counter": "2",
+			"guid": {
+				"id": {
+					"addr": "0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b",
+					"creation_num": "2"
+				}
+			}
+		},
+		"frozen": false,
+		"metadata": {"vec": [{"inner": "0xa"}]},
+		"decimals": 8
+	}
+}`
+
+func TestMoveResource_Getters(t *testing.T) {
+	resource := &MoveResource{}
+	err := json.Unmarshal([]byte(testCoinStoreResourceJson), resource)
+	assert.NoError(t, err)
+
+	balance, err := resource.GetU64("coin.value")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4236137720), balance)
+	counter, err := resource.GetU64("deposit_events.counter")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), counter)
+	decimals, err := resource.GetU64("decimals")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), decimals)
+
+	frozen, err := resource.GetBool("frozen")
+	assert.NoError(t, err)
+	assert.False(t, frozen)
+
+	addr, err := resource.GetAddress("deposit_events.guid.id.addr")
+	assert.NoError(t, err)
+	expected := types.AccountAddress{}
+	assert.NoError(t, expected.ParseStringRelaxed("0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b"))
+	assert.Equal(t, expected, addr)
+
+	// Vectors are indexed by number, and short addresses are accepted
+	metadata, err := resource.GetAddress("metadata.vec.0.inner")
+	assert.NoError(t, err)
+	assert.Equal(t, types.AccountAddress{31: 0xa}, metadata)
+
+	str, err := resource.GetString("deposit_events.guid.id.creation_num")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", str)
+
+	raw, err := resource.Get("coin")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"value": "4236137720"}, raw)
+}
+
+func TestMoveResource_Getters_Errors(t *testing.T) {
+	resource := &MoveResource{}
+	err := json.Unmarshal([]byte(testCoinStoreResourceJson), resource)
+	assert.NoError(t, err)
+
+	// Missing fields
+	_, err = resource.GetU64("coin.amount")
+	assert.ErrorContains(t, err, "no field coin.amount")
+	_, err = resource.GetU64("coin.value.inner")
+	assert.ErrorContains(t, err, "no field coin.value.inner")
+	_, err = resource.GetAddress("metadata.vec.1.inner")
+	assert.ErrorContains(t, err, "no element metadata.vec.1")
+	_, err = resource.GetAddress("metadata.vec.first")
+	assert.Error(t, err)
+
+	// Wrong types
+	_, err = resource.GetU64("frozen")
+	assert.ErrorContains(t, err, "not a u64")
+	_, err = resource.GetU64("deposit_events.guid.id.addr")
+	assert.ErrorContains(t, err, "not a u64")
+	_, err = resource.GetBool("coin.value")
+	assert.ErrorContains(t, err, "not a bool")
+	_, err = resource.GetString("coin")
+	assert.ErrorContains(t, err, "not a string")
+	_, err = resource.GetAddress("frozen")
+	assert.ErrorContains(t, err, "not a string")
+	_, err = (&MoveResource{Data: map[string]any{"owner": "0xnothex"}}).GetAddress("owner")
+	assert.ErrorContains(t, err, "not an address")
+
+	// Empty data
+	_, err = (&MoveResource{Type: "0x1::account::Account"}).GetU64("sequence_number")
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"strings"
+)
+
+const (
+	CoinStoreTypePrefix = "0x1::coin::CoinStore<"              // CoinStoreTypePrefix is the start of all CoinStore resource types, followed by the coin type
+	FungibleStoreType   = "0x1::fungible_asset::FungibleStore" // FungibleStoreType is the type of the FungibleStore resource
+)
+
+// CoinStore is the parsed form of the 0x1::coin::CoinStore<T> resource
+//
+// Example:
+//
+//	{
+//		"type": "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+//		"data": {
+//			"coin": {"value": "1000"},
+//			"frozen": false,
+//			...
+//		}
+//	}
+type CoinStore struct {
+	CoinType string // CoinType is the type of the coin e.g. 0x1::aptos_coin::AptosCoin
+	Balance  U64    // Balance of the coin in the store, in the smallest unit of the coin
+	Frozen   bool   // Frozen is true if the store is frozen, and cannot be deposited to or withdrawn from
+}
+
+// ParseCoinStore parses a [MoveResource] into a [CoinStore]
+//
+// It will fail if the resource is not a 0x1::coin::CoinStore<T>.
+func ParseCoinStore(resource *MoveResource) (*CoinStore, error) {
+	if resource == nil {
+		return nil, fmt.Errorf("cannot parse nil resource as CoinStore")
+	}
+	if !strings.HasPrefix(resource.Type, CoinStoreTypePrefix) || !strings.HasSuffix(resource.Type, ">") {
+		return nil, fmt.Errorf("resource type %s is not a CoinStore", resource.Type)
+	}
+	type inner struct {
+		Coin *struct {
+			Value U64 `json:"value"`
+		} `json:"coin"`
+		Frozen bool `json:"frozen"`
+	}
+	data := &inner{}
+	err := remarshalResourceData(resource, data)
+	if err != nil {
+		return nil, err
+	}
+	if data.Coin == nil {
+		return nil, fmt.Errorf("resource %s is missing coin field", resource.Type)
+	}
+	return &CoinStore{
+		CoinType: resource.Type[len(CoinStoreTypePrefix) : len(resource.Type)-1],
+		Balance:  data.Coin.Value,
+		Frozen:   data.Frozen,
+	}, nil
+}
+
+// FungibleStore is the parsed form of the 0x1::fungible_asset::FungibleStore resource
+//
+// Example:
+//
+//	{
+//		"type": "0x1::fungible_asset::FungibleStore",
+//		"data": {
+//			"balance": "1000",
+//			"frozen": false,
+//			"metadata": {"inner": "0xa"}
+//		}
+//	}
+type FungibleStore struct {
+	Metadata *types.AccountAddress // Metadata is the address of the fungible asset's metadata object e.g. 0xa for APT
+	Balance  U64                   // Balance of the fungible asset in the store, in the smallest unit of the asset
+	Frozen   bool                  // Frozen is true if the store is frozen, and cannot be deposited to or withdrawn from
+}
+
+// ParseFungibleStore parses a [MoveResource] into a [FungibleStore]
+//
+// It will fail if the resource is not a 0x1::fungible_asset::FungibleStore.  Note that if the fungible asset supports
+// concurrent balances, the balance will be 0 and the balance is stored in the ConcurrentFungibleBalance resource.
+func ParseFungibleStore(resource *MoveResource) (*FungibleStore, error) {
+	if resource == nil {
+		return nil, fmt.Errorf("cannot parse nil resource as FungibleStore")
+	}
+	if resource.Type != FungibleStoreType {
+		return nil, fmt.Errorf("resource type %s is not a FungibleStore", resource.Type)
+	}
+	type inner struct {
+		Balance  *U64 `json:"balance"`
+		Frozen   bool `json:"frozen"`
+		Metadata *struct {
+			Inner *types.AccountAddress `json:"inner"`
+		} `json:"metadata"`
+	}
+	data := &inner{}
+	err := remarshalResourceData(resource, data)
+	if err != nil {
+		return nil, err
+	}
+	if data.Balance == nil {
+		return nil, fmt.Errorf("resource %s is missing balance field", resource.Type)
+	}
+	if data.Metadata == nil || data.Metadata.Inner == nil {
+		return nil, fmt.Errorf("resource %s is missing metadata field", resource.Type)
+	}
+	return &FungibleStore{
+		Metadata: data.Metadata.Inner,
+		Balance:  *data.Balance,
+		Frozen:   data.Frozen,
+	}, nil
+}
+
+// remarshalResourceData converts the JSON-like map data of a [MoveResource] into a typed struct
+func remarshalResourceData(resource *MoveResource, out any) error {
+	b, err := json.Marshal(resource.Data)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, out)
+	if err != nil {
+		return fmt.Errorf("failed to parse resource %s: %w", resource.Type, err)
+	}
+	return nil
+}
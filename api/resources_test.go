@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseCoinStore(t *testing.T) {
+	testJson := `{
+		"type": "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+		"data": {
+			"coin": {
+				"value": "4236137720"
+			},
+			"deposit_events": {
+				"counter": "2",
+				"guid": {
+					"id": {
+						"addr": "0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b",
+						"creation_num": "2"
+					}
+				}
+			},
+			"frozen": false,
+			"withdraw_events": {
+				"counter": "1",
+				"guid": {
+					"id": {
+						"addr": "0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b",
+						"creation_num": "3"
+					}
+				}
+			}
+		}
+	}`
+	resource := &MoveResource{}
+	err := json.Unmarshal([]byte(testJson), resource)
+	assert.NoError(t, err)
+
+	store, err := ParseCoinStore(resource)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::aptos_coin::AptosCoin", store.CoinType)
+	assert.Equal(t, uint64(4236137720), store.Balance.ToUint64())
+	assert.False(t, store.Frozen)
+}
+
+func TestParseCoinStore_Frozen(t *testing.T) {
+	testJson := `{
+		"type": "0x1::coin::CoinStore<0x5e156f1207d0ebfa19a9eeff00d62a282278fb8719f4fab3a586a0a2c0fffbea::coin::T>",
+		"data": {
+			"coin": {
+				"value": "0"
+			},
+			"deposit_events": {
+				"counter": "0",
+				"guid": {
+					"id": {
+						"addr": "0x1",
+						"creation_num": "4"
+					}
+				}
+			},
+			"frozen": true,
+			"withdraw_events": {
+				"counter": "0",
+				"guid": {
+					"id": {
+						"addr": "0x1",
+						"creation_num": "5"
+					}
+				}
+			}
+		}
+	}`
+	resource := &MoveResource{}
+	err := json.Unmarshal([]byte(testJson), resource)
+	assert.NoError(t, err)
+
+	store, err := ParseCoinStore(resource)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x5e156f1207d0ebfa19a9eeff00d62a282278fb8719f4fab3a586a0a2c0fffbea::coin::T", store.CoinType)
+	assert.Equal(t, uint64(0), store.Balance.ToUint64())
+	assert.True(t, store.Frozen)
+}
+
+func TestParseCoinStore_Invalid(t *testing.T) {
+	_, err := ParseCoinStore(nil)
+	assert.Error(t, err)
+
+	_, err = ParseCoinStore(&MoveResource{Type: "0x1::account::Account", Data: map[string]any{}})
+	assert.Error(t, err)
+
+	_, err = ParseCoinStore(&MoveResource{Type: FungibleStoreType, Data: map[string]any{"balance": "1"}})
+	assert.Error(t, err)
+
+	_, err = ParseCoinStore(&MoveResource{Type: "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", Data: map[string]any{}})
+	assert.Error(t, err)
+
+	_, err = ParseCoinStore(&MoveResource{Type: "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", Data: map[string]any{
+		"coin": map[string]any{"value": "abc"},
+	}})
+	assert.Error(t, err)
+}
+
+func TestParseFungibleStore(t *testing.T) {
+	testJson := `{
+		"type": "0x1::fungible_asset::FungibleStore",
+		"data": {
+			"balance": "6181075",
+			"frozen": false,
+			"metadata": {
+				"inner": "0x357b0b74bc833e95a115ad22604854d6b0fca151cecd94111770e5d6ffc9dc2b"
+			}
+		}
+	}`
+	resource := &MoveResource{}
+	err := json.Unmarshal([]byte(testJson), resource)
+	assert.NoError(t, err)
+
+	store, err := ParseFungibleStore(resource)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6181075), store.Balance.ToUint64())
+	assert.False(t, store.Frozen)
+	expectedMetadata := &types.AccountAddress{}
+	err = expectedMetadata.ParseStringRelaxed("0x357b0b74bc833e95a115ad22604854d6b0fca151cecd94111770e5d6ffc9dc2b")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMetadata, store.Metadata)
+}
+
+func TestParseFungibleStore_Frozen(t *testing.T) {
+	testJson := `{
+		"type": "0x1::fungible_asset::FungibleStore",
+		"data": {
+			"balance": "100",
+			"frozen": true,
+			"metadata": {
+				"inner": "0xa"
+			}
+		}
+	}`
+	resource := &MoveResource{}
+	err := json.Unmarshal([]byte(testJson), resource)
+	assert.NoError(t, err)
+
+	store, err := ParseFungibleStore(resource)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), store.Balance.ToUint64())
+	assert.True(t, store.Frozen)
+	assert.Equal(t, "0xa", store.Metadata.String())
+}
+
+func TestParseFungibleStore_Invalid(t *testing.T) {
+	_, err := ParseFungibleStore(nil)
+	assert.Error(t, err)
+
+	_, err = ParseFungibleStore(&MoveResource{Type: "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", Data: map[string]any{}})
+	assert.Error(t, err)
+
+	_, err = ParseFungibleStore(&MoveResource{Type: FungibleStoreType, Data: map[string]any{
+		"balance": "1",
+	}})
+	assert.Error(t, err)
+
+	_, err = ParseFungibleStore(&MoveResource{Type: FungibleStoreType, Data: map[string]any{
+		"metadata": map[string]any{"inner": "0xa"},
+	}})
+	assert.Error(t, err)
+}
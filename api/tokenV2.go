@@ -0,0 +1,134 @@
+package api
+
+import (
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+)
+
+const (
+	ObjectCoreType         = "0x1::object::ObjectCore"      // ObjectCoreType is the type of the ObjectCore resource, on every object
+	TokenV2Type            = "0x4::token::Token"            // TokenV2Type is the type of the Token resource, on every Digital Asset (Token v2) object
+	TokenIdentifiersV2Type = "0x4::token::TokenIdentifiers" // TokenIdentifiersV2Type is the type of the TokenIdentifiers resource, holding the name and index of newer tokens
+	CollectionV2Type       = "0x4::collection::Collection"  // CollectionV2Type is the type of the Collection resource, on every Digital Asset collection object
+	RoyaltyV2Type          = "0x4::royalty::Royalty"        // RoyaltyV2Type is the type of the Royalty resource, on a token or collection object
+)
+
+// TokenV2 is the parsed form of a Digital Asset (Token v2) object, assembled from the resources on the object address
+//
+// Only the 0x4::token::Token resource is required, the fields from the other resources are empty if they're missing.
+type TokenV2 struct {
+	Collection  *types.AccountAddress // Collection is the address of the token's collection object
+	Name        string                // Name of the token, from 0x4::token::TokenIdentifiers if present, otherwise 0x4::token::Token
+	Description string                // Description of the token
+	Uri         string                // Uri of the token's metadata e.g. an image or JSON file
+	Index       uint64                // Index of the token in the collection, or 0 if it's unknown
+
+	Owner   *types.AccountAddress // Owner of the object, from 0x1::object::ObjectCore, nil if missing
+	Royalty *RoyaltyV2            // Royalty of the token, from 0x4::royalty::Royalty, nil if missing
+
+	// CollectionName is the name of the collection, only if the 0x4::collection::Collection resource of the collection
+	// object is included in the resources
+	CollectionName string
+}
+
+// RoyaltyV2 is the parsed form of the 0x4::royalty::Royalty resource
+type RoyaltyV2 struct {
+	Numerator   U64                   // Numerator of the royalty fraction
+	Denominator U64                   // Denominator of the royalty fraction
+	Payee       *types.AccountAddress // Payee is the address the royalty is paid to
+}
+
+// ParseTokenV2 assembles a [TokenV2] from the resources of a Digital Asset object, e.g. the account resources of the
+// object address.  The resources of the collection object may be included too, to fill in the collection's name.
+//
+// Newer tokens store the name and index in 0x4::token::TokenIdentifiers, with them left empty in 0x4::token::Token, so
+// the identifiers are preferred when present.
+//
+// It will fail if there is no 0x4::token::Token resource.
+func ParseTokenV2(resources []MoveResource) (*TokenV2, error) {
+	type objectInner struct {
+		Inner *types.AccountAddress `json:"inner"`
+	}
+	type tokenInner struct {
+		Collection  *objectInner `json:"collection"`
+		Description string       `json:"description"`
+		Index       U64          `json:"index"`
+		Name        string       `json:"name"`
+		Uri         string       `json:"uri"`
+	}
+	type identifiersInner struct {
+		Index *struct {
+			Value U64 `json:"value"`
+		} `json:"index"`
+		Name *struct {
+			Value string `json:"value"`
+		} `json:"name"`
+	}
+	type objectCoreInner struct {
+		Owner *types.AccountAddress `json:"owner"`
+	}
+	type royaltyInner struct {
+		Numerator    U64                   `json:"numerator"`
+		Denominator  U64                   `json:"denominator"`
+		PayeeAddress *types.AccountAddress `json:"payee_address"`
+	}
+	type collectionInner struct {
+		Name string `json:"name"`
+	}
+
+	var token *tokenInner
+	var identifiers *identifiersInner
+	result := &TokenV2{}
+	for i := range resources {
+		resource := &resources[i]
+		var err error
+		switch resource.Type {
+		case TokenV2Type:
+			token = &tokenInner{}
+			err = remarshalResourceData(resource, token)
+		case TokenIdentifiersV2Type:
+			identifiers = &identifiersInner{}
+			err = remarshalResourceData(resource, identifiers)
+		case ObjectCoreType:
+			objectCore := &objectCoreInner{}
+			err = remarshalResourceData(resource, objectCore)
+			result.Owner = objectCore.Owner
+		case RoyaltyV2Type:
+			royalty := &royaltyInner{}
+			err = remarshalResourceData(resource, royalty)
+			result.Royalty = &RoyaltyV2{
+				Numerator:   royalty.Numerator,
+				Denominator: royalty.Denominator,
+				Payee:       royalty.PayeeAddress,
+			}
+		case CollectionV2Type:
+			collection := &collectionInner{}
+			err = remarshalResourceData(resource, collection)
+			result.CollectionName = collection.Name
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if token == nil {
+		return nil, fmt.Errorf("resources are missing %s, not a token", TokenV2Type)
+	}
+	if token.Collection == nil || token.Collection.Inner == nil {
+		return nil, fmt.Errorf("resource %s is missing collection field", TokenV2Type)
+	}
+
+	result.Collection = token.Collection.Inner
+	result.Name = token.Name
+	result.Description = token.Description
+	result.Uri = token.Uri
+	result.Index = token.Index.ToUint64()
+	if identifiers != nil {
+		if identifiers.Name != nil {
+			result.Name = identifiers.Name.Value
+		}
+		if identifiers.Index != nil {
+			result.Index = identifiers.Index.Value.ToUint64()
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// testTokenV2ResourcesJson is the resource set of the mainnet token object
+// 0x2932a152328163661f0ae591911270d0edfe0a765beb48a270b9b8a70e766572, with the TokenIdentifiers of a newer token added
+const testTokenV2ResourcesJson = `[
+  {
+    "type": "0x1::object::ObjectCore",
+    "data": {
+      "allow_ungated_transfer": true,
+      "guid_creation_num": "1125899906842626",
+      "owner": "0x8038df5e61a19a5f86ad01f4389736b08250dad1b4aa864afc4fc639a2581ca8",
+      "transfer_events": {
+        "counter": "1",
+        "guid": {
+          "id": {
+            "addr": "0x2932a152328163661f0ae591911270d0edfe0a765beb48a270b9b8a70e766572",
+            "creation_num": "1125899906842624"
+          }
+        }
+      }
+    }
+  },
+  {
+    "type": "0x4::aptos_token::AptosToken",
+    "data": {
+      "burn_ref": {
+        "vec": [
+          {
+            "inner": {
+              "vec": [
+                {
+                  "self": "0x2932a152328163661f0ae591911270d0edfe0a765beb48a270b9b8a70e766572"
+                }
+              ]
+            },
+            "self": {
+              "vec": []
+            }
+          }
+        ]
+      },
+      "mutator_ref": {
+        "vec": [
+          {
+            "self": "0x2932a152328163661f0ae591911270d0edfe0a765beb48a270b9b8a70e766572"
+          }
+        ]
+      },
+      "property_mutator_ref": {
+        "self": "0x2932a152328163661f0ae591911270d0edfe0a765beb48a270b9b8a70e766572"
+      },
+      "transfer_ref": {
+        "vec": [
+          {
+            "self": "0x2932a152328163661f0ae591911270d0edfe0a765beb48a270b9b8a70e766572"
+          }
+        ]
+      }
+    }
+  },
+  {
+    "type": "0x4::property_map::PropertyMap",
+    "data": {
+      "inner": {
+        "data": []
+      }
+    }
+  },
+  {
+    "type": "0x4::token::Token",
+    "data": {
+      "collection": {
+        "inner": "0x778adb39026a14009cf5aa93eb53d81299e40c7a8dbcdbf7b490cbc29749d259"
+      },
+      "description": "This is BLACK FLAG ARMY NFT",
+      "index": "0",
+      "mutation_events": {
+        "counter": "0",
+        "guid": {
+          "id": {
+            "addr": "0x2932a152328163661f0ae591911270d0edfe0a765beb48a270b9b8a70e766572",
+            "creation_num": "1125899906842625"
+          }
+        }
+      },
+      "name": "",
+      "uri": "https://bafybeierhssqdg7fv64xkkjuvsq4bikj2yfmuxm4dvb6jxb2un4yw37ohi.ipfs.w3s.link/68.webp"
+    }
+  },
+  {
+    "type": "0x4::token::TokenIdentifiers",
+    "data": {
+      "index": {
+        "value": "68"
+      },
+      "name": {
+        "padding": "0x",
+        "value": "BLACK FLAG ARMY #68"
+      }
+    }
+  }
+]`
+
+func parseTestAddress(t *testing.T, address string) *types.AccountAddress {
+	parsed := &types.AccountAddress{}
+	err := parsed.ParseStringRelaxed(address)
+	assert.NoError(t, err)
+	return parsed
+}
+
+func TestParseTokenV2(t *testing.T) {
+	resources := make([]MoveResource, 0)
+	err := json.Unmarshal([]byte(testTokenV2ResourcesJson), &resources)
+	assert.NoError(t, err)
+
+	token, err := ParseTokenV2(resources)
+	assert.NoError(t, err)
+	assert.Equal(t, parseTestAddress(t, "0x778adb39026a14009cf5aa93eb53d81299e40c7a8dbcdbf7b490cbc29749d259"), token.Collection)
+	assert.Equal(t, "BLACK FLAG ARMY #68", token.Name)
+	assert.Equal(t, "This is BLACK FLAG ARMY NFT", token.Description)
+	assert.Equal(t, "https://bafybeierhssqdg7fv64xkkjuvsq4bikj2yfmuxm4dvb6jxb2un4yw37ohi.ipfs.w3s.link/68.webp", token.Uri)
+	assert.Equal(t, uint64(68), token.Index)
+	assert.Equal(t, parseTestAddress(t, "0x8038df5e61a19a5f86ad01f4389736b08250dad1b4aa864afc4fc639a2581ca8"), token.Owner)
+	assert.Nil(t, token.Royalty)
+	assert.Empty(t, token.CollectionName)
+}
+
+func TestParseTokenV2_Optional(t *testing.T) {
+	// Only the token, with the older name and index
+	token, err := ParseTokenV2([]MoveResource{{
+		Type: TokenV2Type,
+		Data: map[string]any{
+			"collection":  map[string]any{"inner": "0x1234"},
+			"description": "",
+			"index":       "5",
+			"name":        "Token #5",
+			"uri":         "",
+		},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, parseTestAddress(t, "0x1234"), token.Collection)
+	assert.Equal(t, "Token #5", token.Name)
+	assert.Equal(t, uint64(5), token.Index)
+	assert.Nil(t, token.Owner)
+	assert.Nil(t, token.Royalty)
+
+	// With the royalty, and the collection's resources
+	token, err = ParseTokenV2([]MoveResource{
+		{
+			Type: RoyaltyV2Type,
+			Data: map[string]any{"numerator": "5", "denominator": "100", "payee_address": "0x5678"},
+		},
+		{
+			Type: TokenV2Type,
+			Data: map[string]any{"collection": map[string]any{"inner": "0x1234"}, "name": "Token #5"},
+		},
+		{
+			Type: CollectionV2Type,
+			Data: map[string]any{"creator": "0x5678", "description": "", "name": "My Collection", "uri": ""},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "My Collection", token.CollectionName)
+	assert.Equal(t, &RoyaltyV2{Numerator: 5, Denominator: 100, Payee: parseTestAddress(t, "0x5678")}, token.Royalty)
+}
+
+func TestParseTokenV2_Invalid(t *testing.T) {
+	_, err := ParseTokenV2(nil)
+	assert.Error(t, err)
+
+	_, err = ParseTokenV2([]MoveResource{{Type: ObjectCoreType, Data: map[string]any{"owner": "0x1"}}})
+	assert.ErrorContains(t, err, "not a token")
+
+	_, err = ParseTokenV2([]MoveResource{{Type: TokenV2Type, Data: map[string]any{"name": "Token"}}})
+	assert.ErrorContains(t, err, "missing collection")
+
+	_, err = ParseTokenV2([]MoveResource{{Type: TokenV2Type, Data: map[string]any{
+		"collection": map[string]any{"inner": "0x1234"},
+		"index":      "abc",
+	}}})
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/internal/types"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+	"time"
 )
 
 // TransactionVariant is the type of transaction, all transactions submitted by this SDK are [TransactionVariantUser]
@@ -44,6 +45,12 @@ func (o *CommittedTransaction) Version() uint64 {
 	return *o.Inner.TxnVersion()
 }
 
+// Time of the block the transaction was committed in, in UTC.  It will be the zero [time.Time] for genesis, which has no
+// timestamp.
+func (o *CommittedTransaction) Time() time.Time {
+	return transactionTime(o.Inner)
+}
+
 // UnmarshalJSON unmarshals the [Transaction] from JSON handling conversion between types
 func (o *CommittedTransaction) UnmarshalJSON(b []byte) error {
 	type inner struct {
@@ -160,6 +167,32 @@ func (o *Transaction) Version() *uint64 {
 	return o.Inner.TxnVersion()
 }
 
+// IsPending is true if the transaction is a [PendingTransaction], which hasn't been committed yet
+func (o *Transaction) IsPending() bool {
+	return o.Type == TransactionVariantPending
+}
+
+// IsSuccess is true if the transaction has been committed, and succeeded.  It's false for pending transactions, and
+// for failed transactions.
+func (o *Transaction) IsSuccess() bool {
+	success := o.Success()
+	return !o.IsPending() && success != nil && *success
+}
+
+// Time of the block the transaction was committed in, in UTC.  It will be the zero [time.Time] for genesis, and pending
+// transactions, which have no timestamp.
+func (o *Transaction) Time() time.Time {
+	return transactionTime(o.Inner)
+}
+
+// transactionTime is the time of the transaction if it has a timestamp, otherwise the zero [time.Time]
+func transactionTime(inner TransactionImpl) time.Time {
+	if txn, ok := inner.(interface{ Time() time.Time }); ok {
+		return txn.Time()
+	}
+	return time.Time{}
+}
+
 // UnmarshalJSON unmarshals the [Transaction] from JSON handling conversion between types
 func (o *Transaction) UnmarshalJSON(b []byte) error {
 	type inner struct {
@@ -290,9 +323,11 @@ func (u *UnknownTransaction) TxnSuccess() *bool {
 	return &successBool
 }
 
-// TxnHash gives us the hash of the transaction.
+// TxnHash gives us the hash of the transaction.  It will be the zero [Hash] if the payload has no valid hash.
 func (u *UnknownTransaction) TxnHash() Hash {
-	return u.Payload["hash"].(string)
+	str, _ := u.Payload["hash"].(string)
+	hash, _ := ParseHash(str)
+	return hash
 }
 
 // TxnVersion gives us the ledger version of the transaction. It will be nil if the transaction is not committed.
@@ -332,7 +367,12 @@ type UserTransaction struct {
 	Payload                 *TransactionPayload   // Payload of the transaction, this is the actual transaction data.
 	Signature               *Signature            // Signature is the AccountAuthenticator of the sender.
 	Timestamp               uint64                // Timestamp is the Unix timestamp in microseconds when the block of the transaction was committed.
-	StateCheckpointHash     Hash                  // StateCheckpointHash of the transaction. Optional, and will be "" if not set.
+	StateCheckpointHash     Hash                  // StateCheckpointHash of the transaction. Optional, and will be the zero Hash if not set.
+}
+
+// Time converts the Timestamp to a [time.Time] in UTC
+func (o *UserTransaction) Time() time.Time {
+	return MicrosecondsToTime(o.Timestamp)
 }
 
 // TxnHash gives us the hash of the transaction.
@@ -421,7 +461,7 @@ func (o *UserTransaction) MarshalJSON() ([]byte, error) {
 		Payload                 *TransactionPayload   `json:"payload"`
 		Signature               *Signature            `json:"signature"`
 		Timestamp               U64                   `json:"timestamp"`
-		StateCheckpointHash     *string               `json:"state_checkpoint_hash"`
+		StateCheckpointHash     *Hash                 `json:"state_checkpoint_hash"`
 	}{
 		Type:                    string(TransactionVariantUser),
 		Version:                 U64(o.Version),
@@ -443,7 +483,7 @@ func (o *UserTransaction) MarshalJSON() ([]byte, error) {
 		Signature:               o.Signature,
 		Timestamp:               U64(o.Timestamp),
 	}
-	if o.StateCheckpointHash != "" {
+	if o.StateCheckpointHash != (Hash{}) {
 		data.StateCheckpointHash = &o.StateCheckpointHash
 	}
 	return json.Marshal(data)
@@ -542,7 +582,7 @@ type GenesisTransaction struct {
 	Changes             []*WriteSetChange   // Changes to the ledger from the transaction, should never be empty.
 	Events              []*Event            // Events emitted by the transaction, may be empty.
 	Payload             *TransactionPayload // Payload of the transaction, this is the actual transaction data.
-	StateCheckpointHash Hash                // StateCheckpointHash of the transaction. Optional, and will be "" if not set.
+	StateCheckpointHash Hash                // StateCheckpointHash of the transaction. Optional, and will be the zero Hash if not set.
 }
 
 // TxnHash gives us the hash of the transaction.
@@ -609,7 +649,7 @@ func (o *GenesisTransaction) MarshalJSON() ([]byte, error) {
 		VmStatus            string            `json:"vm_status"`
 		Changes             []*WriteSetChange `json:"changes"`
 		Events              []*Event          `json:"events"`
-		StateCheckpointHash *string           `json:"state_checkpoint_hash"`
+		StateCheckpointHash *Hash             `json:"state_checkpoint_hash"`
 	}{
 		Type:                string(TransactionVariantGenesis),
 		Version:             U64(o.Version),
@@ -623,7 +663,7 @@ func (o *GenesisTransaction) MarshalJSON() ([]byte, error) {
 		Changes:             o.Changes,
 		Events:              o.Events,
 	}
-	if o.StateCheckpointHash != "" {
+	if o.StateCheckpointHash != (Hash{}) {
 		data.StateCheckpointHash = &o.StateCheckpointHash
 	}
 	return json.Marshal(data)
@@ -638,7 +678,7 @@ type BlockMetadataTransaction struct {
 	Proposer                 *types.AccountAddress // Proposer of the block, will never be nil.
 	FailedProposerIndices    []uint32              // FailedProposerIndices of the block, this is the indices of the proposers that failed to propose a block.
 	Version                  uint64                // Version of the transaction, starts at 0 and increments per transaction.
-	Hash                     string                // Hash of the transaction, it is a SHA3-256 hash in hexadecimal format with a leading 0x.
+	Hash                     Hash                  // Hash of the transaction, it is a SHA3-256 hash in hexadecimal format with a leading 0x.
 	AccumulatorRootHash      Hash                  // AccumulatorRootHash of the transaction.
 	StateChangeHash          Hash                  // StateChangeHash of the transaction.
 	EventRootHash            Hash                  // EventRootHash of the transaction.
@@ -648,7 +688,12 @@ type BlockMetadataTransaction struct {
 	Changes                  []*WriteSetChange     // Changes to the ledger from the transaction, should never be empty.
 	Events                   []*Event              // Events emitted by the transaction, may be empty.
 	Timestamp                uint64                // Timestamp is the Unix timestamp in microseconds when the block of the transaction was committed.
-	StateCheckpointHash      Hash                  // StateCheckpointHash of the transaction. Optional, and will be "" if not set.
+	StateCheckpointHash      Hash                  // StateCheckpointHash of the transaction. Optional, and will be the zero Hash if not set.
+}
+
+// Time converts the Timestamp to a [time.Time] in UTC
+func (o *BlockMetadataTransaction) Time() time.Time {
+	return MicrosecondsToTime(o.Timestamp)
 }
 
 // TxnHash gives us the hash of the transaction.
@@ -758,7 +803,7 @@ func (o *BlockMetadataTransaction) MarshalJSON() ([]byte, error) {
 		Timestamp:                U64(o.Timestamp),
 		Type:                     string(TransactionVariantBlockMetadata),
 	}
-	if o.StateCheckpointHash != "" {
+	if o.StateCheckpointHash != (Hash{}) {
 		data.StateCheckpointHash = &o.StateCheckpointHash
 	}
 	return json.Marshal(data)
@@ -778,7 +823,12 @@ type BlockEpilogueTransaction struct {
 	Events              []*Event          // Events emitted by the transaction, may be empty.
 	Timestamp           uint64            // Timestamp is the Unix timestamp in microseconds when the block of the transaction was committed.
 	BlockEndInfo        *BlockEndInfo     // BlockEndInfo of the transaction, this will contain information about block gas.
-	StateCheckpointHash Hash              // StateCheckpointHash of the transaction. Optional, and will be "" if not set.
+	StateCheckpointHash Hash              // StateCheckpointHash of the transaction. Optional, and will be the zero Hash if not set.
+}
+
+// Time converts the Timestamp to a [time.Time] in UTC
+func (o *BlockEpilogueTransaction) Time() time.Time {
+	return MicrosecondsToTime(o.Timestamp)
 }
 
 // TxnHash gives us the hash of the transaction.
@@ -847,7 +897,7 @@ func (o *BlockEpilogueTransaction) MarshalJSON() ([]byte, error) {
 		Changes             []*WriteSetChange `json:"changes"`
 		Timestamp           U64               `json:"timestamp"`
 		BlockEndInfo        *BlockEndInfo     `json:"block_end_info"`
-		StateCheckpointHash Hash              `json:"state_checkpoint_hash"`
+		StateCheckpointHash *Hash             `json:"state_checkpoint_hash"`
 		Type                string            `json:"type"`
 	}
 	data := &inner{
@@ -864,8 +914,8 @@ func (o *BlockEpilogueTransaction) MarshalJSON() ([]byte, error) {
 		BlockEndInfo:        o.BlockEndInfo,
 		Type:                string(TransactionVariantBlockEpilogue),
 	}
-	if o.StateCheckpointHash != "" {
-		data.StateCheckpointHash = o.StateCheckpointHash
+	if o.StateCheckpointHash != (Hash{}) {
+		data.StateCheckpointHash = &o.StateCheckpointHash
 	}
 	return json.Marshal(data)
 }
@@ -882,7 +932,12 @@ type StateCheckpointTransaction struct {
 	VmStatus            string            // VmStatus of the transaction, this will contain the error if any.
 	Changes             []*WriteSetChange // Changes to the ledger from the transaction, should never be empty.
 	Timestamp           uint64            // Timestamp is the Unix timestamp in microseconds when the block of the transaction was committed.
-	StateCheckpointHash Hash              // StateCheckpointHash of the transaction. Optional, and will be "" if not set.
+	StateCheckpointHash Hash              // StateCheckpointHash of the transaction. Optional, and will be the zero Hash if not set.
+}
+
+// Time converts the Timestamp to a [time.Time] in UTC
+func (o *StateCheckpointTransaction) Time() time.Time {
+	return MicrosecondsToTime(o.Timestamp)
 }
 
 // TxnHash gives us the hash of the transaction.
@@ -964,7 +1019,7 @@ func (o *StateCheckpointTransaction) MarshalJSON() ([]byte, error) {
 		Timestamp:           U64(o.Timestamp),
 		Type:                string(TransactionVariantStateCheckpoint),
 	}
-	if o.StateCheckpointHash != "" {
+	if o.StateCheckpointHash != (Hash{}) {
 		data.StateCheckpointHash = &o.StateCheckpointHash
 	}
 	return json.Marshal(data)
@@ -983,7 +1038,12 @@ type ValidatorTransaction struct {
 	Changes             []*WriteSetChange // Changes to the ledger from the transaction, should never be empty.
 	Events              []*Event          // Events emitted by the transaction, may be empty.
 	Timestamp           uint64            // Timestamp is the Unix timestamp in microseconds when the block of the transaction was committed.
-	StateCheckpointHash Hash              // StateCheckpointHash of the transaction. Optional, and will be "" if not set.
+	StateCheckpointHash Hash              // StateCheckpointHash of the transaction. Optional, and will be the zero Hash if not set.
+}
+
+// Time converts the Timestamp to a [time.Time] in UTC
+func (o *ValidatorTransaction) Time() time.Time {
+	return MicrosecondsToTime(o.Timestamp)
 }
 
 // TxnHash gives us the hash of the transaction.
@@ -1066,7 +1126,7 @@ func (o *ValidatorTransaction) MarshalJSON() ([]byte, error) {
 		Events:              o.Events,
 		Timestamp:           U64(o.Timestamp),
 	}
-	if o.StateCheckpointHash != "" {
+	if o.StateCheckpointHash != (Hash{}) {
 		data.StateCheckpointHash = &o.StateCheckpointHash
 	}
 	return json.Marshal(data)
@@ -1082,11 +1142,31 @@ type BatchSubmitTransactionResponse struct {
 	TransactionFailures []BatchSubmitTransactionFailure `json:"transaction_failures"`
 }
 
-// BatchSubmitTransactionFailure is a failure of a transaction in a batch submission,
+// Failures returns the failures by the index of the transaction in the submitted batch.  The transactions without a
+// failure were accepted.
+func (r *BatchSubmitTransactionResponse) Failures() map[uint32]*Error {
+	failures := make(map[uint32]*Error, len(r.TransactionFailures))
+	for i := range r.TransactionFailures {
+		failures[r.TransactionFailures[i].TransactionIndex] = &r.TransactionFailures[i].Error
+	}
+	return failures
+}
+
+// Failure returns the failure of the transaction at the index in the submitted batch, or nil if it was accepted
+func (r *BatchSubmitTransactionResponse) Failure(index uint32) *Error {
+	for i := range r.TransactionFailures {
+		if r.TransactionFailures[i].TransactionIndex == index {
+			return &r.TransactionFailures[i].Error
+		}
+	}
+	return nil
+}
+
+// BatchSubmitTransactionFailure is a failure of a transaction in a batch submission
 type BatchSubmitTransactionFailure struct {
 	// Error is the error that occurred when submitting the transaction
-	Error Error
-	//TransactionIndex is the index of submitted transactions that failed
+	Error Error `json:"error"`
+	// TransactionIndex is the index of submitted transactions that failed
 	TransactionIndex uint32 `json:"transaction_index"`
 }
 
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"github.com/aptos-labs/aptos-go-sdk/internal/types"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestTransaction_GenesisTransaction(t *testing.T) {
@@ -356,7 +358,7 @@ func TestTransaction_PendingTransaction(t *testing.T) {
 	txn, err := data.PendingTransaction()
 	assert.NoError(t, err)
 
-	assert.Equal(t, "0xae3f1f751c6cacd61f46054a5e9e39ca9f094802875befbc54ceecbcdf6eff69", txn.Hash)
+	assert.Equal(t, "0xae3f1f751c6cacd61f46054a5e9e39ca9f094802875befbc54ceecbcdf6eff69", txn.Hash.String())
 	assert.Equal(t, uint64(242217), txn.SequenceNumber)
 	assert.Equal(t, uint64(100), txn.GasUnitPrice)
 	assert.Equal(t, uint64(2018), txn.MaxGasAmount)
@@ -366,8 +368,12 @@ func TestTransaction_PendingTransaction(t *testing.T) {
 
 	// Check functions
 	assert.Nil(t, data.Version())
-	assert.Equal(t, "0xae3f1f751c6cacd61f46054a5e9e39ca9f094802875befbc54ceecbcdf6eff69", data.Hash())
+	assert.Equal(t, "0xae3f1f751c6cacd61f46054a5e9e39ca9f094802875befbc54ceecbcdf6eff69", data.Hash().String())
 	assert.Nil(t, data.Success())
+	assert.True(t, data.IsPending())
+	assert.False(t, data.IsSuccess())
+	// Pending transactions have no timestamp
+	assert.True(t, data.Time().IsZero())
 }
 
 func TestTransaction_UserTransaction(t *testing.T) {
@@ -571,11 +577,25 @@ func TestTransaction_UserTransaction(t *testing.T) {
 	assert.Equal(t, *data.Version(), data2.Version())
 	assert.Equal(t, data.Hash(), data2.Hash())
 	assert.Equal(t, *data.Success(), data2.Success())
+	assert.False(t, data.IsPending())
+	assert.True(t, data.IsSuccess())
+
+	// A failed transaction isn't a success
+	txn.Success = false
+	assert.False(t, data.IsSuccess())
+	txn.Success = true
+
+	expectedTime := time.Date(2024, time.July, 3, 0, 4, 56, 135309000, time.UTC)
+	assert.Equal(t, expectedTime, txn.Time())
+	assert.Equal(t, expectedTime, data.Time())
+	assert.Equal(t, expectedTime, data2.Time())
+	assert.Equal(t, time.UTC, txn.Time().Location())
 
 	// Check MarshalJSON
 	jsonData, err := json.Marshal(data)
 	assert.NoError(t, err)
-	assert.JSONEq(t, testJson, string(jsonData))
+	// Addresses are always marshalled in the long form
+	assert.JSONEq(t, strings.Replace(testJson, `"account_address": "0x0"`, `"account_address": "`+types.AccountZero.StringLong()+`"`, 1), string(jsonData))
 }
 
 func TestTransaction_BlockMetadataTransaction(t *testing.T) {
@@ -722,15 +742,15 @@ func TestTransaction_StateCheckpointTransaction(t *testing.T) {
 	assert.Equal(t, txn, txn2)
 
 	assert.Equal(t, uint64(3), txn.Version)
-	assert.Equal(t, "0x77da2c7a41ba6d46dc015c58f489c8d6ee030f98d95cca5b096578ca9e144aa6", txn.Hash)
-	assert.Equal(t, "0xafb6e14fe47d850fd0a7395bcfb997ffacf4715e0f895cc162c218e4a7564bc6", txn.StateChangeHash)
-	assert.Equal(t, "0x414343554d554c41544f525f504c414345484f4c4445525f4841534800000000", txn.EventRootHash)
-	assert.Equal(t, "0x56bf9bb8d9049d2f56541c19f48da847dd5c12419529f8db97255b08c2cf42b7", txn.StateCheckpointHash)
+	assert.Equal(t, "0x77da2c7a41ba6d46dc015c58f489c8d6ee030f98d95cca5b096578ca9e144aa6", txn.Hash.String())
+	assert.Equal(t, "0xafb6e14fe47d850fd0a7395bcfb997ffacf4715e0f895cc162c218e4a7564bc6", txn.StateChangeHash.String())
+	assert.Equal(t, "0x414343554d554c41544f525f504c414345484f4c4445525f4841534800000000", txn.EventRootHash.String())
+	assert.Equal(t, "0x56bf9bb8d9049d2f56541c19f48da847dd5c12419529f8db97255b08c2cf42b7", txn.StateCheckpointHash.String())
 	assert.Equal(t, uint64(1662686657332551), txn.Timestamp)
 	assert.Equal(t, uint64(0), txn.GasUsed)
 	assert.True(t, txn.Success)
 	assert.Equal(t, "Executed successfully", txn.VmStatus)
-	assert.Equal(t, "0x5e8e44711fba04cd509484a14b6071e50b06071e36d4b6ccf8edd724af0d6393", txn.AccumulatorRootHash)
+	assert.Equal(t, "0x5e8e44711fba04cd509484a14b6071e50b06071e36d4b6ccf8edd724af0d6393", txn.AccumulatorRootHash.String())
 	assert.Empty(t, txn.Changes)
 
 	// Check functions
@@ -781,15 +801,15 @@ func TestTransaction_BlockEpilogueTransaction(t *testing.T) {
 	assert.Equal(t, txn, txn2)
 
 	assert.Equal(t, uint64(2), txn.Version)
-	assert.Equal(t, "0x1f19608413baaa8f39b670fbf001d17443ba7b975e0c22733bf742cea99fbdaf", txn.Hash)
-	assert.Equal(t, "0xafb6e14fe47d850fd0a7395bcfb997ffacf4715e0f895cc162c218e4a7564bc6", txn.StateChangeHash)
-	assert.Equal(t, "0x414343554d554c41544f525f504c414345484f4c4445525f4841534800000000", txn.EventRootHash)
-	assert.Equal(t, "0x986343cd66e79d3f8b52fcd65df05da9801f0894ac4b5c27d079a8bdadbaa432", txn.StateCheckpointHash)
+	assert.Equal(t, "0x1f19608413baaa8f39b670fbf001d17443ba7b975e0c22733bf742cea99fbdaf", txn.Hash.String())
+	assert.Equal(t, "0xafb6e14fe47d850fd0a7395bcfb997ffacf4715e0f895cc162c218e4a7564bc6", txn.StateChangeHash.String())
+	assert.Equal(t, "0x414343554d554c41544f525f504c414345484f4c4445525f4841534800000000", txn.EventRootHash.String())
+	assert.Equal(t, "0x986343cd66e79d3f8b52fcd65df05da9801f0894ac4b5c27d079a8bdadbaa432", txn.StateCheckpointHash.String())
 	assert.Equal(t, uint64(1719520421743738), txn.Timestamp)
 	assert.Equal(t, uint64(0), txn.GasUsed)
 	assert.True(t, txn.Success)
 	assert.Equal(t, "Executed successfully", txn.VmStatus)
-	assert.Equal(t, "0x957c214e74b1aded27be7fd78b50c96fc0bfc25a70ad1555a08968a8fdc05cb1", txn.AccumulatorRootHash)
+	assert.Equal(t, "0x957c214e74b1aded27be7fd78b50c96fc0bfc25a70ad1555a08968a8fdc05cb1", txn.AccumulatorRootHash.String())
 	assert.Empty(t, txn.Changes)
 	assert.False(t, txn.BlockEndInfo.BlockGasLimitReached)
 	assert.False(t, txn.BlockEndInfo.BlockOutputLimitReached)
@@ -864,7 +884,7 @@ func TestTransaction_UnknownTransaction(t *testing.T) {
 
 	assert.Equal(t, "block_imaginary_transaction", txn.Type)
 	assert.Equal(t, uint64(2), *txn.TxnVersion())
-	assert.Equal(t, "0x957c214e74b1aded27be7fd78b50c96fc0bfc25a70ad1555a08968a8fdc05cb1", txn.TxnHash())
+	assert.Equal(t, "0x957c214e74b1aded27be7fd78b50c96fc0bfc25a70ad1555a08968a8fdc05cb1", txn.TxnHash().String())
 	assert.True(t, *txn.TxnSuccess())
 
 	// Check functions
@@ -6,9 +6,20 @@ import (
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/internal/types"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+	"math/big"
 	"strings"
+	"time"
 )
 
+// MicrosecondsToTime converts an on-chain Unix timestamp in microseconds to a [time.Time] in UTC.  A zero timestamp,
+// e.g. genesis which has no timestamp, converts to the zero [time.Time], so check [time.Time.IsZero].
+func MicrosecondsToTime(micros uint64) time.Time {
+	if micros == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(micros)).UTC()
+}
+
 // GUID describes a GUID associated with things like V1 events
 //
 // Note that this can only be used to deserialize events in the `events` field, and not the `GUID` resource in `changes`.
@@ -44,6 +55,34 @@ func (o *GUID) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// Equal tells whether two [GUID]s have the same creation number and account address
+func (o *GUID) Equal(other *GUID) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+	if o.CreationNumber != other.CreationNumber {
+		return false
+	}
+	if o.AccountAddress == nil || other.AccountAddress == nil {
+		return o.AccountAddress == other.AccountAddress
+	}
+	return *o.AccountAddress == *other.AccountAddress
+}
+
+// String returns the [GUID] in the form `<address>/<creation_number>`
+//
+// If the AccountAddress is nil, the address will be rendered as `<nil>`
+//
+// Example:
+//
+//	0x1/3
+func (o *GUID) String() string {
+	if o.AccountAddress == nil {
+		return fmt.Sprintf("<nil>/%d", o.CreationNumber)
+	}
+	return fmt.Sprintf("%s/%d", o.AccountAddress.String(), o.CreationNumber)
+}
+
 // U64 is a type for handling JSON string representations of the uint64
 type U64 uint64
 
@@ -80,56 +119,284 @@ func (u *U64) ToUint64() uint64 {
 	return uint64(*u)
 }
 
+// U64Numeric is a type for handling uint64 in JSON, but serialized as a bare number rather than a string
+//
+// Deserialization accepts both the string and number representations, the same as [U64].  This should only be used
+// for output consumed by tools other than the Aptos node, as the node always represents u64 as a string.
+//
+// Example:
+//
+//	"12345" -> 12345
+//	12345 -> 12345
+type U64Numeric uint64
+
+// UnmarshalJSON deserializes a JSON data blob into a [U64Numeric]
+func (u *U64Numeric) UnmarshalJSON(b []byte) error {
+	var inner U64
+	err := inner.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+	*u = U64Numeric(inner)
+	return nil
+}
+
+// MarshalJSON serializes a [U64Numeric] into a JSON data blob as a bare number
+func (u U64Numeric) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.ToUint64())
+}
+
+// ToUint64 converts a [U64Numeric] to an uint64
+func (u *U64Numeric) ToUint64() uint64 {
+	return uint64(*u)
+}
+
+// U128 is a type for handling JSON string representations of the u128
+type U128 big.Int
+
+// UnmarshalJSON deserializes a JSON data blob into a [U128]
+func (u *U128) UnmarshalJSON(b []byte) error {
+	num, err := unmarshalBigUint(b, 128)
+	if err != nil {
+		return err
+	}
+	*u = U128(*num)
+	return nil
+}
+
+// MarshalJSON serializes a [U128] into a JSON data blob
+func (u U128) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*big.Int)(&u).String())
+}
+
+// ToBigInt converts a [U128] to a [big.Int]
+//
+// A copy is returned, so modifying the result will not modify the [U128]
+func (u *U128) ToBigInt() *big.Int {
+	return new(big.Int).Set((*big.Int)(u))
+}
+
+// U256 is a type for handling JSON string representations of the u256
+type U256 big.Int
+
+// UnmarshalJSON deserializes a JSON data blob into a [U256]
+func (u *U256) UnmarshalJSON(b []byte) error {
+	num, err := unmarshalBigUint(b, 256)
+	if err != nil {
+		return err
+	}
+	*u = U256(*num)
+	return nil
+}
+
+// MarshalJSON serializes a [U256] into a JSON data blob
+func (u U256) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*big.Int)(&u).String())
+}
+
+// ToBigInt converts a [U256] to a [big.Int]
+//
+// A copy is returned, so modifying the result will not modify the [U256]
+func (u *U256) ToBigInt() *big.Int {
+	return new(big.Int).Set((*big.Int)(u))
+}
+
+// unmarshalBigUint parses a number or string JSON value as an unsigned integer of at most the given bits
+func unmarshalBigUint(b []byte, bits int) (*big.Int, error) {
+	var str string
+	// it's possible that the value is a number or a string
+	if len(b) > 1 && b[0] == '"' && b[len(b)-1] == '"' {
+		err := json.Unmarshal(b, &str)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		str = string(b)
+	}
+
+	num, err := util.StrToBigInt(str)
+	if err != nil {
+		return nil, err
+	}
+	if num.Sign() < 0 {
+		return nil, fmt.Errorf("num %s is negative, and cannot be a u%d", str, bits)
+	}
+	if num.BitLen() > bits {
+		return nil, fmt.Errorf("num %s is too large for a u%d", str, bits)
+	}
+	return num, nil
+}
+
 // HexBytes is a type for handling Bytes encoded as hex in JSON
 type HexBytes []byte
 
+// ParseHexBytes parses a string into [HexBytes], the same as [HexBytes.UnmarshalJSON] without the JSON quotes.  It
+// accepts 0x prefixed hex, unprefixed hex, or base64, and odd length hex is rejected rather than decoded as base64.
+//
+//	bytes, err := ParseHexBytes("0xdeadbeef")
+func ParseHexBytes(str string) (HexBytes, error) {
+	bytes, err := parseHexOrBase64(str, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hex bytes %q: %w", str, err)
+	}
+	return bytes, nil
+}
+
+// String returns the bytes as 0x prefixed hex, the same as in JSON.  Empty bytes are "0x".
+//
+// Implements:
+//   - [fmt.Stringer]
+func (u HexBytes) String() string {
+	return util.BytesToHex(u)
+}
+
 // UnmarshalJSON deserializes a JSON data blob into a [HexBytes]
 //
+// Hex is always preferred, and base64 is only attempted if the string contains characters outside the hex alphabet.
+// Use [Base64Bytes] if base64 is expected for ambiguous strings.
+//
 // Example:
 //
 //	"0x123456" -> []byte{0x12, 0x34, 0x56}
+//	"deadbeef" -> []byte{0xde, 0xad, 0xbe, 0xef}
+//	"AQIDBAU=" -> []byte{0x01, 0x02, 0x03, 0x04, 0x05}
 func (u *HexBytes) UnmarshalJSON(b []byte) error {
 	var str string
 	err := json.Unmarshal(b, &str)
 	if err != nil {
 		return err
 	}
-	var bytes []byte
-	if strings.HasPrefix(str, "0x") {
-		bytes, err = util.ParseHex(str)
-		if err != nil {
-			return err
-		}
-	} else if strings.HasSuffix(str, "=") {
-		bytes, err = base64.StdEncoding.DecodeString(str)
-		if err != nil {
-			return err
-		}
-	} else {
-		// try hex first
-		bytes, err = util.ParseHex(str)
-		if err != nil {
-			// then base64
-			bytes, err = base64.StdEncoding.DecodeString(str)
-			if err != nil {
-				return err
-			}
-		}
+	bytes, err := parseHexOrBase64(str, false)
+	if err != nil {
+		return err
 	}
-
 	*u = bytes
 	return nil
 }
 
+// MarshalJSON serializes a [HexBytes] into a JSON data blob as 0x prefixed hex
 func (u HexBytes) MarshalJSON() ([]byte, error) {
-	return json.Marshal(util.BytesToHex(u))
+	return json.Marshal(u.String())
+}
+
+// Base64Bytes is a type for handling Bytes encoded as base64 in JSON
+//
+// This is the opt-in for base64 on strings that are valid in both encodings, otherwise it behaves like [HexBytes].
+type Base64Bytes []byte
+
+// UnmarshalJSON deserializes a JSON data blob into a [Base64Bytes]
+//
+// Strings with a 0x prefix are still decoded as hex.
+//
+// Example:
+//
+//	"0x123456" -> []byte{0x12, 0x34, 0x56}
+//	"deadbeef" -> []byte{0x75, 0xe6, 0x9d, 0x6d, 0xe7, 0x9f}
+func (u *Base64Bytes) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+	bytes, err := parseHexOrBase64(str, true)
+	if err != nil {
+		return err
+	}
+	*u = bytes
+	return nil
+}
+
+// MarshalJSON serializes a [Base64Bytes] into a JSON data blob as base64
+func (u Base64Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.StdEncoding.EncodeToString(u))
 }
 
-// Hash is a representation of a hash as Hex in JSON
+// parseHexOrBase64 decodes a string as 0x prefixed hex, unprefixed hex, or base64.
+//
+// Unprefixed strings only made of hex characters are ambiguous, and are decoded as hex unless preferBase64 is set.
+func parseHexOrBase64(str string, preferBase64 bool) ([]byte, error) {
+	if strings.HasPrefix(str, "0x") {
+		return util.ParseHex(str)
+	}
+	if preferBase64 || !isHexString(str) {
+		return base64.StdEncoding.DecodeString(str)
+	}
+	return util.ParseHex(str)
+}
+
+// isHexString tells whether the string only contains hex characters
+func isHexString(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if !isHexChar(str[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashLength is the length of a [Hash] in bytes
+const HashLength = 32
+
+// Hash is a representation of a 32-byte hash, represented as 0x prefixed hex in JSON
+//
+// Example:
+//
+//	0xf4d07fdb8b5151971886a910e516d418a790dd5f6e068b0588066518a395a600
+type Hash [HashLength]byte
+
+// ParseHash parses a hex string into a [Hash]
+//
+// The 0x prefix is optional, and upper case hex is accepted.  It must be exactly 32 bytes, with no leading zeros
+// trimmed.
+func ParseHash(str string) (Hash, error) {
+	if !strings.HasPrefix(str, "0x") {
+		str = "0x" + str
+	}
+	if len(str) != 2+HashLength*2 {
+		return Hash{}, fmt.Errorf("invalid hash length %d, expected %d hex characters: %s", len(str)-2, HashLength*2, str)
+	}
+	bytes, err := util.ParseHex(str)
+	if err != nil {
+		return Hash{}, fmt.Errorf("invalid hash %s: %w", str, err)
+	}
+	return Hash(bytes), nil
+}
+
+// String returns the [Hash] as 0x prefixed lowercase hex
+func (h Hash) String() string {
+	return util.BytesToHex(h[:])
+}
+
+// UnmarshalJSON deserializes a JSON data blob into a [Hash]
+//
+// A JSON null leaves the [Hash] unchanged, as for optional hashes e.g. [UserTransaction.StateCheckpointHash].
+func (h *Hash) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+	*h, err = ParseHash(str)
+	return err
+}
+
+// MarshalJSON serializes a [Hash] into a JSON data blob
+func (h Hash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.String())
+}
+
+// HashString is a representation of a hash as Hex in JSON
 //
 // # This is always represented as a 32-byte hash in hexadecimal format
 //
 // Example:
 //
 //	0xf4d07fdb8b5151971886a910e516d418a790dd5f6e068b0588066518a395a600
-type Hash = string // TODO: do we make this a 32 byte array? or byte array?
+//
+// Hashes in the api types are all [Hash], this is only kept for callers' own variables.
+//
+// Deprecated: Use [Hash] instead, this will be removed in the next release.  [ParseHash] can convert it to a [Hash].
+type HashString = string
@@ -0,0 +1,372 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/internal/types"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestMicrosecondsToTime(t *testing.T) {
+	converted := MicrosecondsToTime(1719520421743738)
+	assert.Equal(t, time.Date(2024, time.June, 27, 20, 33, 41, 743738000, time.UTC), converted)
+	assert.Equal(t, time.UTC, converted.Location())
+	assert.Equal(t, int64(1719520421743738), converted.UnixMicro())
+
+	// Genesis has no timestamp
+	assert.True(t, MicrosecondsToTime(0).IsZero())
+}
+
+func TestU64_RoundTrip(t *testing.T) {
+	for _, testJson := range []string{`"0"`, `"12345"`, `"18446744073709551615"`} {
+		var data U64
+		err := json.Unmarshal([]byte(testJson), &data)
+		assert.NoError(t, err)
+
+		b, err := json.Marshal(data)
+		assert.NoError(t, err)
+		assert.Equal(t, testJson, string(b))
+	}
+
+	var data U64
+	err := json.Unmarshal([]byte(`18446744073709551615`), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), data.ToUint64())
+}
+
+func TestU64Numeric_RoundTrip(t *testing.T) {
+	for _, testJson := range []string{`0`, `12345`, `18446744073709551615`} {
+		var data U64Numeric
+		err := json.Unmarshal([]byte(testJson), &data)
+		assert.NoError(t, err)
+
+		b, err := json.Marshal(data)
+		assert.NoError(t, err)
+		assert.Equal(t, testJson, string(b))
+	}
+}
+
+func TestU64Numeric_AcceptsString(t *testing.T) {
+	var data U64Numeric
+	err := json.Unmarshal([]byte(`"18446744073709551615"`), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), data.ToUint64())
+
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, `18446744073709551615`, string(b))
+
+	err = json.Unmarshal([]byte(`"18446744073709551616"`), &data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`"abc"`), &data)
+	assert.Error(t, err)
+}
+
+func TestU64Numeric_InStruct(t *testing.T) {
+	type inner struct {
+		Amount U64Numeric `json:"amount"`
+	}
+	data := &inner{}
+	err := json.Unmarshal([]byte(`{"amount":"1000"}`), data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), data.Amount.ToUint64())
+
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":1000}`, string(b))
+}
+
+func TestU128_RoundTrip(t *testing.T) {
+	maxU128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	for _, testJson := range []string{`"0"`, `"12345"`, `"` + maxU128.String() + `"`} {
+		var data U128
+		err := json.Unmarshal([]byte(testJson), &data)
+		assert.NoError(t, err)
+
+		b, err := json.Marshal(data)
+		assert.NoError(t, err)
+		assert.Equal(t, testJson, string(b))
+	}
+
+	var data U128
+	err := json.Unmarshal([]byte(maxU128.String()), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, maxU128.Cmp(data.ToBigInt()))
+
+	// Leading zeros are allowed
+	err = json.Unmarshal([]byte(`"000123"`), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(123), data.ToBigInt())
+
+	// Modifying the output doesn't modify the value
+	data.ToBigInt().SetUint64(5)
+	assert.Equal(t, big.NewInt(123), data.ToBigInt())
+}
+
+func TestU128_Overflow(t *testing.T) {
+	overflow := new(big.Int).Lsh(big.NewInt(1), 128)
+	var data U128
+	err := json.Unmarshal([]byte(`"`+overflow.String()+`"`), &data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(overflow.String()), &data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`"-1"`), &data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`"0x1"`), &data)
+	assert.Error(t, err)
+}
+
+func TestU256_RoundTrip(t *testing.T) {
+	maxU256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	for _, testJson := range []string{`"0"`, `"12345"`, `"` + maxU256.String() + `"`} {
+		var data U256
+		err := json.Unmarshal([]byte(testJson), &data)
+		assert.NoError(t, err)
+
+		b, err := json.Marshal(data)
+		assert.NoError(t, err)
+		assert.Equal(t, testJson, string(b))
+	}
+
+	var data U256
+	err := json.Unmarshal([]byte(maxU256.String()), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, maxU256.Cmp(data.ToBigInt()))
+
+	err = json.Unmarshal([]byte(`"0000"`), &data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, data.ToBigInt().Sign())
+}
+
+func TestU256_Overflow(t *testing.T) {
+	overflow := new(big.Int).Lsh(big.NewInt(1), 256)
+	var data U256
+	err := json.Unmarshal([]byte(`"`+overflow.String()+`"`), &data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`"-5"`), &data)
+	assert.Error(t, err)
+}
+
+func TestU256_InStruct(t *testing.T) {
+	type inner struct {
+		Supply U256 `json:"supply"`
+	}
+	data := &inner{}
+	err := json.Unmarshal([]byte(`{"supply":"1000"}`), data)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), data.Supply.ToBigInt())
+
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"supply":"1000"}`, string(b))
+}
+
+func TestHexBytes_Unmarshal(t *testing.T) {
+	tests := map[string][]byte{
+		`"0x"`:         {},
+		`"0x123456"`:   {0x12, 0x34, 0x56},
+		`"deadbeef"`:   {0xde, 0xad, 0xbe, 0xef},
+		`"DEADBEEF"`:   {0xde, 0xad, 0xbe, 0xef},
+		`"abcdef"`:     {0xab, 0xcd, 0xef},
+		`"AQIDBAU="`:   {0x01, 0x02, 0x03, 0x04, 0x05},
+		`"AQID"`:       {0x01, 0x02, 0x03},
+		`"0xdeadbeef"`: {0xde, 0xad, 0xbe, 0xef},
+	}
+	for testJson, expected := range tests {
+		var data HexBytes
+		err := json.Unmarshal([]byte(testJson), &data)
+		assert.NoError(t, err, testJson)
+		assert.Equal(t, HexBytes(expected), data, testJson)
+	}
+
+	// Odd length hex is not base64 decoded
+	var data HexBytes
+	err := json.Unmarshal([]byte(`"abc"`), &data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`"0xzz"`), &data)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`"!!!!"`), &data)
+	assert.Error(t, err)
+}
+
+func TestHexBytes_RoundTrip(t *testing.T) {
+	var data HexBytes
+	err := json.Unmarshal([]byte(`"deadbeef"`), &data)
+	assert.NoError(t, err)
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, `"0xdeadbeef"`, string(b))
+}
+
+func TestParseHexBytes(t *testing.T) {
+	tests := map[string][]byte{
+		"":           {},
+		"0x":         {},
+		"0x123456":   {0x12, 0x34, 0x56},
+		"deadbeef":   {0xde, 0xad, 0xbe, 0xef},
+		"AQIDBAU=":   {0x01, 0x02, 0x03, 0x04, 0x05},
+		"0xDEADBEEF": {0xde, 0xad, 0xbe, 0xef},
+	}
+	for str, expected := range tests {
+		bytes, err := ParseHexBytes(str)
+		assert.NoError(t, err, str)
+		assert.Equal(t, HexBytes(expected), bytes, str)
+	}
+
+	// Odd length hex is rejected, rather than decoded as base64
+	_, err := ParseHexBytes("abc")
+	assert.Error(t, err)
+	_, err = ParseHexBytes("0xabc")
+	assert.Error(t, err)
+	_, err = ParseHexBytes("!!!!")
+	assert.Error(t, err)
+}
+
+func TestHexBytes_String(t *testing.T) {
+	tests := map[string]HexBytes{
+		"0x":         {},
+		"0xdeadbeef": {0xde, 0xad, 0xbe, 0xef},
+		"0x000102":   {0x00, 0x01, 0x02},
+	}
+	for expected, bytes := range tests {
+		assert.Equal(t, expected, bytes.String())
+		assert.Equal(t, expected, fmt.Sprint(bytes))
+
+		// String and JSON agree
+		b, err := json.Marshal(bytes)
+		assert.NoError(t, err)
+		assert.Equal(t, `"`+expected+`"`, string(b))
+
+		parsed, err := ParseHexBytes(bytes.String())
+		assert.NoError(t, err)
+		assert.Equal(t, bytes, parsed)
+	}
+	assert.Equal(t, "0x", HexBytes(nil).String())
+}
+
+func TestBase64Bytes_Unmarshal(t *testing.T) {
+	tests := map[string][]byte{
+		`"0x123456"`: {0x12, 0x34, 0x56},
+		`"deadbeef"`: {0x75, 0xe6, 0x9d, 0x6d, 0xe7, 0x9f},
+		`"AQIDBAU="`: {0x01, 0x02, 0x03, 0x04, 0x05},
+	}
+	for testJson, expected := range tests {
+		var data Base64Bytes
+		err := json.Unmarshal([]byte(testJson), &data)
+		assert.NoError(t, err, testJson)
+		assert.Equal(t, Base64Bytes(expected), data, testJson)
+	}
+
+	var data Base64Bytes
+	err := json.Unmarshal([]byte(`"abc"`), &data)
+	assert.Error(t, err)
+
+	// Round trip keeps base64
+	err = json.Unmarshal([]byte(`"deadbeef"`), &data)
+	assert.NoError(t, err)
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, `"deadbeef"`, string(b))
+}
+
+func TestParseHash(t *testing.T) {
+	const hashStr = "0xf4d07fdb8b5151971886a910e516d418a790dd5f6e068b0588066518a395a600"
+	hash, err := ParseHash(hashStr)
+	assert.NoError(t, err)
+	assert.Equal(t, hashStr, hash.String())
+	assert.Equal(t, byte(0xf4), hash[0])
+	assert.Equal(t, byte(0x00), hash[31])
+
+	// Missing prefix
+	hash2, err := ParseHash(hashStr[2:])
+	assert.NoError(t, err)
+	assert.Equal(t, hash, hash2)
+
+	// Uppercase
+	hash3, err := ParseHash("0xF4D07FDB8B5151971886A910E516D418A790DD5F6E068B0588066518A395A600")
+	assert.NoError(t, err)
+	assert.Equal(t, hash, hash3)
+
+	// Too short
+	_, err = ParseHash("0xf4d07fdb8b5151971886a910e516d418a790dd5f6e068b0588066518a395a6")
+	assert.Error(t, err)
+	_, err = ParseHash("0x1")
+	assert.Error(t, err)
+	_, err = ParseHash("")
+	assert.Error(t, err)
+
+	// Too long
+	_, err = ParseHash(hashStr + "00")
+	assert.Error(t, err)
+
+	// Not hex
+	_, err = ParseHash("0xz4d07fdb8b5151971886a910e516d418a790dd5f6e068b0588066518a395a600")
+	assert.Error(t, err)
+}
+
+func TestHash_JSON(t *testing.T) {
+	const testJson = `"0xf4d07fdb8b5151971886a910e516d418a790dd5f6e068b0588066518a395a600"`
+	var hash Hash
+	err := json.Unmarshal([]byte(testJson), &hash)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, testJson, string(b))
+
+	// Uppercase is emitted as lowercase
+	err = json.Unmarshal([]byte(`"0xF4D07FDB8B5151971886A910E516D418A790DD5F6E068B0588066518A395A600"`), &hash)
+	assert.NoError(t, err)
+	b, err = json.Marshal(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, testJson, string(b))
+
+	err = json.Unmarshal([]byte(`"0x1234"`), &hash)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`1234`), &hash)
+	assert.Error(t, err)
+
+	// Null is left unset, for optional hashes
+	var optional struct {
+		Hash Hash `json:"hash"`
+	}
+	err = json.Unmarshal([]byte(`{"hash":null}`), &optional)
+	assert.NoError(t, err)
+	assert.Equal(t, Hash{}, optional.Hash)
+}
+
+func TestGUID_Equal(t *testing.T) {
+	guid := &GUID{CreationNumber: 3, AccountAddress: &types.AccountOne}
+	same := &GUID{CreationNumber: 3, AccountAddress: &types.AccountAddress{}}
+	*same.AccountAddress = types.AccountOne
+	assert.True(t, guid.Equal(same))
+	assert.True(t, same.Equal(guid))
+	assert.True(t, guid.Equal(guid))
+
+	assert.False(t, guid.Equal(&GUID{CreationNumber: 4, AccountAddress: &types.AccountOne}))
+	assert.False(t, guid.Equal(&GUID{CreationNumber: 3, AccountAddress: &types.AccountTwo}))
+	assert.False(t, guid.Equal(&GUID{CreationNumber: 3}))
+	assert.False(t, guid.Equal(nil))
+
+	// Nil addresses
+	assert.True(t, (&GUID{CreationNumber: 3}).Equal(&GUID{CreationNumber: 3}))
+	assert.False(t, (&GUID{CreationNumber: 3}).Equal(guid))
+}
+
+func TestGUID_String(t *testing.T) {
+	guid := &GUID{CreationNumber: 3, AccountAddress: &types.AccountOne}
+	assert.Equal(t, "0x1/3", guid.String())
+
+	addr := &types.AccountAddress{}
+	err := addr.ParseStringRelaxed("0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b")
+	assert.NoError(t, err)
+	guid = &GUID{CreationNumber: 0, AccountAddress: addr}
+	assert.Equal(t, "0x810026ca8291dd88b5b30a1d3ca2edd683d33d06c4a7f7c451d96f6d47bc5e8b/0", guid.String())
+
+	guid = &GUID{CreationNumber: 12}
+	assert.Equal(t, "<nil>/12", guid.String())
+}
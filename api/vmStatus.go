@@ -0,0 +1,177 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VMStatusCategory is the kind of outcome in a vm_status string, see [ParseVMStatus]
+type VMStatusCategory string
+
+const (
+	VMStatusSuccess           VMStatusCategory = "success"            // VMStatusSuccess is a transaction that executed successfully
+	VMStatusAbort             VMStatusCategory = "abort"              // VMStatusAbort is a Move abort, with an abort code
+	VMStatusExecutionFailure  VMStatusCategory = "execution_failure"  // VMStatusExecutionFailure is a runtime failure e.g. arithmetic overflow, at a code offset
+	VMStatusOutOfGas          VMStatusCategory = "out_of_gas"         // VMStatusOutOfGas is a transaction that ran out of gas
+	VMStatusVerificationError VMStatusCategory = "verification_error" // VMStatusVerificationError is a failure to verify Move bytecode
+	VMStatusMiscellaneous     VMStatusCategory = "miscellaneous"      // VMStatusMiscellaneous is any other failure, often with the status code in [VMStatus.Name]
+)
+
+// VMStatus is the parsed form of the vm_status of a transaction, see [ParseVMStatus]
+type VMStatus struct {
+	Category    VMStatusCategory // Category is the kind of outcome
+	Location    string           // Location is the module where it failed e.g. "0x1::coin", or "script", only for aborts and execution failures
+	Function    string           // Function is the function where execution failed, only for execution failures
+	CodeOffset  uint64           // CodeOffset is the bytecode offset in Function where execution failed, only for execution failures
+	Code        uint64           // Code is the abort code e.g. 0x10006, only for aborts
+	Name        string           // Name is the abort reason e.g. "EINSUFFICIENT_BALANCE", or the status code of other failures e.g. "LINKER_ERROR", if known
+	Description string           // Description is the description of the abort reason, if known
+	Raw         string           // Raw is the unparsed vm_status
+}
+
+// IsSuccess tells whether the transaction executed successfully
+func (o *VMStatus) IsSuccess() bool {
+	return o.Category == VMStatusSuccess
+}
+
+// IsAbort tells whether the transaction failed with a Move abort
+func (o *VMStatus) IsAbort() bool {
+	return o.Category == VMStatusAbort
+}
+
+// AbortCode returns the abort code, and whether the transaction failed with a Move abort
+//
+// Framework abort codes are an error category in the upper bits and a reason in the lower 16 bits, e.g. 0x10006 is
+// reason 6 of category 1, invalid argument.
+func (o *VMStatus) AbortCode() (uint64, bool) {
+	return o.Code, o.IsAbort()
+}
+
+// String returns the unparsed vm_status
+//
+// Implements:
+//   - [fmt.Stringer]
+func (o *VMStatus) String() string {
+	return o.Raw
+}
+
+const (
+	vmStatusSuccessMessage      = "Executed successfully"
+	vmStatusOutOfGasMessage     = "Out of gas"
+	vmStatusAbortPrefix         = "Move abort in "
+	vmStatusExecutionPrefix     = "Execution failed in "
+	vmStatusCodeOffsetSeparator = " at code offset "
+	vmStatusVerificationPrefix  = "Move bytecode verification error"
+	vmStatusCommittedPrefix     = "Transaction Executed and Committed with Error "
+)
+
+// ParseVMStatus parses the vm_status of a transaction, as returned by the node e.g. in [UserTransaction.VmStatus].  It
+// accepts:
+//
+//	Executed successfully
+//	Out of gas
+//	Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction
+//	Move abort in 0x1::coin: 0x10006
+//	Execution failed in 0x1::coin::transfer at code offset 12
+//	Transaction Executed and Committed with Error LINKER_ERROR
+//
+// Other statuses are [VMStatusMiscellaneous], with the status in Raw.  It fails if the status is empty, or an abort
+// or execution failure is malformed.
+func ParseVMStatus(status string) (*VMStatus, error) {
+	out := &VMStatus{Raw: status}
+	switch {
+	case status == "":
+		return nil, fmt.Errorf("vm status is empty")
+	case status == vmStatusSuccessMessage:
+		out.Category = VMStatusSuccess
+	case status == vmStatusOutOfGasMessage:
+		out.Category = VMStatusOutOfGas
+	case strings.HasPrefix(status, vmStatusAbortPrefix):
+		out.Category = VMStatusAbort
+		if err := parseVMStatusAbort(out, strings.TrimPrefix(status, vmStatusAbortPrefix)); err != nil {
+			return nil, fmt.Errorf("vm status %q: %w", status, err)
+		}
+	case strings.HasPrefix(status, vmStatusExecutionPrefix):
+		out.Category = VMStatusExecutionFailure
+		if err := parseVMStatusExecutionFailure(out, strings.TrimPrefix(status, vmStatusExecutionPrefix)); err != nil {
+			return nil, fmt.Errorf("vm status %q: %w", status, err)
+		}
+	case strings.HasPrefix(status, vmStatusVerificationPrefix):
+		out.Category = VMStatusVerificationError
+	case strings.HasPrefix(status, vmStatusCommittedPrefix):
+		out.Category = VMStatusMiscellaneous
+		out.Name = strings.TrimPrefix(status, vmStatusCommittedPrefix)
+	default:
+		// e.g. "Miscellaneous error", and anything the node adds later
+		out.Category = VMStatusMiscellaneous
+	}
+	return out, nil
+}
+
+// parseVMStatusAbort parses the location, code, and reason of an abort e.g.
+// "0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction"
+func parseVMStatusAbort(out *VMStatus, abort string) error {
+	location, rest, ok := strings.Cut(abort, ": ")
+	if !ok || location == "" {
+		return fmt.Errorf("abort has no location")
+	}
+	out.Location = location
+
+	// Without a known reason, only the code is given
+	if strings.HasPrefix(rest, "0x") {
+		code, err := parseVMStatusCode(rest)
+		if err != nil {
+			return err
+		}
+		out.Code = code
+		return nil
+	}
+
+	name, rest, ok := strings.Cut(rest, "(")
+	if !ok || name == "" {
+		return fmt.Errorf("abort has no code")
+	}
+	codeStr, rest, ok := strings.Cut(rest, ")")
+	if !ok {
+		return fmt.Errorf("abort code is not closed")
+	}
+	code, err := parseVMStatusCode(codeStr)
+	if err != nil {
+		return err
+	}
+	out.Name = name
+	out.Code = code
+	out.Description = strings.TrimPrefix(rest, ": ")
+	return nil
+}
+
+// parseVMStatusExecutionFailure parses the location, function, and code offset of an execution failure e.g.
+// "0x1::coin::transfer at code offset 12"
+func parseVMStatusExecutionFailure(out *VMStatus, failure string) error {
+	function, offsetStr, ok := strings.Cut(failure, vmStatusCodeOffsetSeparator)
+	if !ok {
+		return fmt.Errorf("execution failure has no code offset")
+	}
+	separator := strings.LastIndex(function, "::")
+	if separator <= 0 {
+		return fmt.Errorf("execution failure has no function")
+	}
+	offset, err := strconv.ParseUint(offsetStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("execution failure code offset is not a number: %w", err)
+	}
+	out.Location = function[:separator]
+	out.Function = function[separator+2:]
+	out.CodeOffset = offset
+	return nil
+}
+
+// parseVMStatusCode parses a hex abort code e.g. "0x10006"
+func parseVMStatusCode(code string) (uint64, error) {
+	out, err := strconv.ParseUint(strings.TrimPrefix(code, "0x"), 16, 64)
+	if err != nil || !strings.HasPrefix(code, "0x") {
+		return 0, fmt.Errorf("abort code %q is not hex", code)
+	}
+	return out, nil
+}
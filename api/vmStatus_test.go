@@ -0,0 +1,132 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseVMStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		expected VMStatus
+	}{
+		{
+			name:     "success",
+			status:   "Executed successfully",
+			expected: VMStatus{Category: VMStatusSuccess},
+		},
+		{
+			name:     "out of gas",
+			status:   "Out of gas",
+			expected: VMStatus{Category: VMStatusOutOfGas},
+		},
+		{
+			name:   "abort with reason",
+			status: "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction",
+			expected: VMStatus{
+				Category:    VMStatusAbort,
+				Location:    "0x1::coin",
+				Code:        0x10006,
+				Name:        "EINSUFFICIENT_BALANCE",
+				Description: "Not enough coins to complete transaction",
+			},
+		},
+		{
+			name:   "abort with reason and punctuated description",
+			status: "Move abort in 0x1::coin: ECOIN_STORE_NOT_PUBLISHED(0x60005): Account hasn't registered `CoinStore` for `CoinType`",
+			expected: VMStatus{
+				Category:    VMStatusAbort,
+				Location:    "0x1::coin",
+				Code:        0x60005,
+				Name:        "ECOIN_STORE_NOT_PUBLISHED",
+				Description: "Account hasn't registered `CoinStore` for `CoinType`",
+			},
+		},
+		{
+			name:   "abort with only code",
+			status: "Move abort in 0xcafe::market: 0x3e9",
+			expected: VMStatus{
+				Category: VMStatusAbort,
+				Location: "0xcafe::market",
+				Code:     1001,
+			},
+		},
+		{
+			name:   "abort in script",
+			status: "Move abort in script: 0x1",
+			expected: VMStatus{
+				Category: VMStatusAbort,
+				Location: "script",
+				Code:     1,
+			},
+		},
+		{
+			name:   "execution failure",
+			status: "Execution failed in 0x1::fixed_point32::multiply_u64 at code offset 18",
+			expected: VMStatus{
+				Category:   VMStatusExecutionFailure,
+				Location:   "0x1::fixed_point32",
+				Function:   "multiply_u64",
+				CodeOffset: 18,
+			},
+		},
+		{
+			name:     "committed with error",
+			status:   "Transaction Executed and Committed with Error LINKER_ERROR",
+			expected: VMStatus{Category: VMStatusMiscellaneous, Name: "LINKER_ERROR"},
+		},
+		{
+			name:     "verification error",
+			status:   "Move bytecode verification error: CALL_TYPE_MISMATCH_ERROR",
+			expected: VMStatus{Category: VMStatusVerificationError},
+		},
+		{
+			name:     "miscellaneous",
+			status:   "Miscellaneous error",
+			expected: VMStatus{Category: VMStatusMiscellaneous},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := ParseVMStatus(tt.status)
+			assert.NoError(t, err)
+			tt.expected.Raw = tt.status
+			assert.Equal(t, &tt.expected, status)
+			assert.Equal(t, tt.status, status.String())
+		})
+	}
+}
+
+func TestVMStatus_AbortCode(t *testing.T) {
+	status, err := ParseVMStatus("Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction")
+	assert.NoError(t, err)
+	assert.True(t, status.IsAbort())
+	assert.False(t, status.IsSuccess())
+	code, ok := status.AbortCode()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0x10006), code)
+
+	status, err = ParseVMStatus("Executed successfully")
+	assert.NoError(t, err)
+	assert.False(t, status.IsAbort())
+	assert.True(t, status.IsSuccess())
+	_, ok = status.AbortCode()
+	assert.False(t, ok)
+}
+
+func TestParseVMStatus_Malformed(t *testing.T) {
+	for _, status := range []string{
+		"",
+		"Move abort in 0x1::coin",
+		"Move abort in 0x1::coin: 0xzz",
+		"Move abort in 0x1::coin: EINSUFFICIENT_BALANCE",
+		"Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(65542): Not enough coins",
+		"Execution failed in 0x1::coin::transfer",
+		"Execution failed in transfer at code offset 12",
+		"Execution failed in 0x1::coin::transfer at code offset twelve",
+	} {
+		_, err := ParseVMStatus(status)
+		assert.Error(t, err, status)
+	}
+}
@@ -91,12 +91,13 @@ func TestWriteSet_WriteModule(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, WriteSetChangeVariantWriteModule, data.Type)
-	inner := data.Inner.(*WriteSetChangeWriteModule) // TODO: probably make this a bit cleaner with a function
+	inner, err := data.WriteModule()
+	assert.NoError(t, err)
 	expectedAddress := &types.AccountAddress{}
 	err = expectedAddress.ParseStringRelaxed("0xe42895bdea9ffef448368a95f51b4c883a8e025be3f8e7d08df39f46861a0dc5")
 	assert.NoError(t, err)
 	assert.Equal(t, expectedAddress, inner.Address)
-	assert.Equal(t, "0xa9fd877ba16b362e10efda9410f3e718ae114e567858cbe120732935aceb1f0e", inner.StateKeyHash)
+	assert.Equal(t, "0xa9fd877ba16b362e10efda9410f3e718ae114e567858cbe120732935aceb1f0e", inner.StateKeyHash.String())
 	assert.NotNil(t, inner.Data) // TODO: more verification
 
 	// test json marshal
@@ -127,12 +128,13 @@ func TestWriteSet_WriteResource(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, WriteSetChangeVariantWriteResource, data.Type)
-	inner := data.Inner.(*WriteSetChangeWriteResource) // TODO: probably make this a bit cleaner with a function
+	inner, err := data.WriteResource()
+	assert.NoError(t, err)
 	expectedAddress := &types.AccountAddress{}
 	err = expectedAddress.ParseStringRelaxed("0xe42895bdea9ffef448368a95f51b4c883a8e025be3f8e7d08df39f46861a0dc5")
 	assert.NoError(t, err)
 	assert.Equal(t, expectedAddress, inner.Address)
-	assert.Equal(t, "0xa396667bfbfc6af66d8969edfbda02ef9c2f4e4468bf4c71f165a5427afdf6dc", inner.StateKeyHash)
+	assert.Equal(t, "0xa396667bfbfc6af66d8969edfbda02ef9c2f4e4468bf4c71f165a5427afdf6dc", inner.StateKeyHash.String())
 	assert.Equal(t, "0xe42895bdea9ffef448368a95f51b4c883a8e025be3f8e7d08df39f46861a0dc5::tablemania::Blah", inner.Data.Type)
 
 	// test json marshal
@@ -153,12 +155,13 @@ func TestWriteSet_DeleteResource(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, WriteSetChangeVariantDeleteResource, data.Type)
-	inner := data.Inner.(*WriteSetChangeDeleteResource) // TODO: probably make this a bit cleaner with a function
+	inner, err := data.DeleteResource()
+	assert.NoError(t, err)
 	expectedAddress := &types.AccountAddress{}
 	err = expectedAddress.ParseStringRelaxed("0x307401f7dd9ca5371ed820070dabaff6cf2196b500c0e359c0e388897987ca6a")
 	assert.NoError(t, err)
 	assert.Equal(t, expectedAddress, inner.Address)
-	assert.Equal(t, "0x3775f4dbd6900b26cf6c833b112fdfda084f84ef4e562678ca6b54a4791063fd", inner.StateKeyHash)
+	assert.Equal(t, "0x3775f4dbd6900b26cf6c833b112fdfda084f84ef4e562678ca6b54a4791063fd", inner.StateKeyHash.String())
 	assert.Equal(t, "0x1::object::ObjectGroup", inner.Resource)
 
 	// test json marshal
@@ -181,12 +184,13 @@ func TestWriteSet_WriteTableItem(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, WriteSetChangeVariantWriteTableItem, data.Type)
-	inner := data.Inner.(*WriteSetChangeWriteTableItem) // TODO: probably make this a bit cleaner with a function
+	inner, err := data.WriteTableItem()
+	assert.NoError(t, err)
 	expectedAddress := &types.AccountAddress{}
 	err = expectedAddress.ParseStringRelaxed("0x307401f7dd9ca5371ed820070dabaff6cf2196b500c0e359c0e388897987ca6a")
 	assert.NoError(t, err)
 	assert.Nil(t, inner.Data)
-	assert.Equal(t, "0x6e4b28d40f98a106a65163530924c0dcb40c1349d3aa915d108b4d6cfc1ddb19", inner.StateKeyHash)
+	assert.Equal(t, "0x6e4b28d40f98a106a65163530924c0dcb40c1349d3aa915d108b4d6cfc1ddb19", inner.StateKeyHash.String())
 	assert.Equal(t, "0x1b854694ae746cdbd8d44186ca4929b2b337df21d1c74633be19b2710552fdca", inner.Handle)
 	assert.Equal(t, "0x0619dc29a0aac8fa146714058e8dd6d2d0f3bdf5f6331907bf91f3acd81e6935", inner.Key)
 	assert.Equal(t, "0x465192b7fc2a88010000000000000000", inner.Value)
@@ -216,12 +220,13 @@ func TestWriteSet_DeleteTableItem(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, WriteSetChangeVariantDeleteTableItem, data.Type)
-	inner := data.Inner.(*WriteSetChangeDeleteTableItem) // TODO: probably make this a bit cleaner with a function
+	inner, err := data.DeleteTableItem()
+	assert.NoError(t, err)
 	expectedAddress := &types.AccountAddress{}
 	err = expectedAddress.ParseStringRelaxed("0x307401f7dd9ca5371ed820070dabaff6cf2196b500c0e359c0e388897987ca6a")
 	assert.NoError(t, err)
 	assert.Nil(t, inner.Data)
-	assert.Equal(t, "0x6b89622e7799dc7c46060ba5941b0d1655c1fc96311f7c6f70f0099f99d467cf", inner.StateKeyHash)
+	assert.Equal(t, "0x6b89622e7799dc7c46060ba5941b0d1655c1fc96311f7c6f70f0099f99d467cf", inner.StateKeyHash.String())
 	assert.Equal(t, "0x18cca5d121ebb854e2f16bd2892d0aad9ae0460e21250bc25daa2cdd6f93a070", inner.Handle)
 	assert.Equal(t, "0x0000000000000000", inner.Key)
 
@@ -235,3 +240,89 @@ func TestWriteSet_DeleteTableItem(t *testing.T) {
   "type": "delete_table_item"
 }`, string(b))
 }
+
+func TestWriteSet_DeleteModule(t *testing.T) {
+	testJson := `{
+      "address": "0x307401f7dd9ca5371ed820070dabaff6cf2196b500c0e359c0e388897987ca6a",
+      "state_key_hash": "0x3775f4dbd6900b26cf6c833b112fdfda084f84ef4e562678ca6b54a4791063fd",
+      "module": "0x307401f7dd9ca5371ed820070dabaff6cf2196b500c0e359c0e388897987ca6a::tablemania",
+      "type": "delete_module"
+    }`
+	data := &WriteSetChange{}
+	err := json.Unmarshal([]byte(testJson), &data)
+	assert.NoError(t, err)
+
+	assert.Equal(t, WriteSetChangeVariantDeleteModule, data.Type)
+	inner, err := data.DeleteModule()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3775f4dbd6900b26cf6c833b112fdfda084f84ef4e562678ca6b54a4791063fd", inner.StateKeyHash.String())
+	assert.Equal(t, "0x307401f7dd9ca5371ed820070dabaff6cf2196b500c0e359c0e388897987ca6a::tablemania", inner.Module)
+
+	// test json marshal
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, testJson, string(b))
+}
+
+func TestWriteSet_Unknown(t *testing.T) {
+	testJson := `{
+      "state_key_hash": "0x3775f4dbd6900b26cf6c833b112fdfda084f84ef4e562678ca6b54a4791063fd",
+      "something": {"new": true},
+      "type": "write_something_new"
+    }`
+	data := &WriteSetChange{}
+	err := json.Unmarshal([]byte(testJson), &data)
+	assert.NoError(t, err)
+
+	assert.Equal(t, WriteSetChangeVariantUnknown, data.Type)
+	inner, err := data.Unknown()
+	assert.NoError(t, err)
+	assert.Equal(t, "write_something_new", inner.Type)
+	assert.Equal(t, map[string]any{"new": true}, inner.Payload["something"])
+
+	// It's marshalled as it was received
+	b, err := json.Marshal(data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, testJson, string(b))
+}
+
+func TestWriteSetChange_Accessors(t *testing.T) {
+	changes := []string{
+		`{"type": "write_resource", "address": "0x1", "state_key_hash": "0x0000000000000000000000000000000000000000000000000000000000000001", "data": {"type": "0x1::account::Account", "data": {"sequence_number": "1"}}}`,
+		`{"type": "delete_resource", "address": "0x1", "state_key_hash": "0x0000000000000000000000000000000000000000000000000000000000000001", "resource": "0x1::object::ObjectGroup"}`,
+		`{"type": "write_module", "address": "0x1", "state_key_hash": "0x0000000000000000000000000000000000000000000000000000000000000001", "data": {"bytecode": "0xa11ceb0b"}}`,
+		`{"type": "delete_module", "address": "0x1", "state_key_hash": "0x0000000000000000000000000000000000000000000000000000000000000001", "module": "0x1::test"}`,
+		`{"type": "write_table_item", "state_key_hash": "0x0000000000000000000000000000000000000000000000000000000000000001", "handle": "0x2", "key": "0x00", "value": "0x01"}`,
+		`{"type": "delete_table_item", "state_key_hash": "0x0000000000000000000000000000000000000000000000000000000000000001", "handle": "0x2", "key": "0x00"}`,
+		`{"type": "write_something_new", "state_key_hash": "0x0000000000000000000000000000000000000000000000000000000000000001"}`,
+	}
+	for i, changeJson := range changes {
+		change := &WriteSetChange{}
+		err := json.Unmarshal([]byte(changeJson), change)
+		assert.NoError(t, err)
+
+		// Only the accessor for the variant succeeds
+		_, err = change.WriteResource()
+		assert.Equal(t, i == 0, err == nil, changeJson)
+		_, err = change.DeleteResource()
+		assert.Equal(t, i == 1, err == nil, changeJson)
+		_, err = change.WriteModule()
+		assert.Equal(t, i == 2, err == nil, changeJson)
+		_, err = change.DeleteModule()
+		assert.Equal(t, i == 3, err == nil, changeJson)
+		_, err = change.WriteTableItem()
+		assert.Equal(t, i == 4, err == nil, changeJson)
+		_, err = change.DeleteTableItem()
+		assert.Equal(t, i == 5, err == nil, changeJson)
+		_, err = change.Unknown()
+		assert.Equal(t, i == 6, err == nil, changeJson)
+
+		// Every variant round trips through JSON
+		b, err := json.Marshal(change)
+		assert.NoError(t, err)
+		roundTrip := &WriteSetChange{}
+		err = json.Unmarshal(b, roundTrip)
+		assert.NoError(t, err)
+		assert.Equal(t, change, roundTrip)
+	}
+}
@@ -0,0 +1,75 @@
+package aptos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OctasPerAPT is the number of octas in 1 APT, the smallest unit of APT, as APT has 8 decimals
+const OctasPerAPT = uint64(100_000_000)
+
+// APTDecimals is the number of decimal places of APT
+const APTDecimals = 8
+
+// OctasToAPT converts an amount of octas to a decimal string of APT, without any floating point rounding.  Trailing
+// zeros of the fraction are removed.
+//
+//	OctasToAPT(123456789) // "1.23456789"
+//	OctasToAPT(1)         // "0.00000001"
+//	OctasToAPT(150000000) // "1.5"
+func OctasToAPT(octas uint64) string {
+	whole := octas / OctasPerAPT
+	fraction := octas % OctasPerAPT
+	if fraction == 0 {
+		return strconv.FormatUint(whole, 10)
+	}
+	fractionStr := strings.TrimRight(fmt.Sprintf("%0*d", APTDecimals, fraction), "0")
+	return strconv.FormatUint(whole, 10) + "." + fractionStr
+}
+
+// APTToOctas parses a decimal string of APT into octas, without any floating point rounding
+//
+// Returns an error if the string isn't a non-negative decimal number, has more than 8 fractional digits, or is too
+// large for a uint64 of octas.
+//
+//	APTToOctas("1.23456789") // 123456789
+//	APTToOctas("0.00000001") // 1
+//	APTToOctas("2")          // 200000000
+func APTToOctas(apt string) (uint64, error) {
+	wholeStr, fractionStr, hasFraction := strings.Cut(apt, ".")
+	if wholeStr == "" || !isDigits(wholeStr) || (hasFraction && (fractionStr == "" || !isDigits(fractionStr))) {
+		return 0, fmt.Errorf("invalid APT amount %q", apt)
+	}
+	if len(fractionStr) > APTDecimals {
+		return 0, fmt.Errorf("invalid APT amount %q, more than %d fractional digits", apt, APTDecimals)
+	}
+
+	whole, err := strconv.ParseUint(wholeStr, 10, 64)
+	if err != nil || whole > (^uint64(0))/OctasPerAPT {
+		return 0, fmt.Errorf("invalid APT amount %q, too large", apt)
+	}
+	fraction := uint64(0)
+	if fractionStr != "" {
+		// Pad to 8 digits, so it's the number of octas e.g. "5" is 50000000 octas
+		fraction, err = strconv.ParseUint(fractionStr+strings.Repeat("0", APTDecimals-len(fractionStr)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid APT amount %q: %w", apt, err)
+		}
+	}
+	octas := whole * OctasPerAPT
+	if octas+fraction < octas {
+		return 0, fmt.Errorf("invalid APT amount %q, too large", apt)
+	}
+	return octas + fraction, nil
+}
+
+// isDigits tells whether the string is only ASCII digits
+func isDigits(str string) bool {
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package aptos
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOctasToAPT(t *testing.T) {
+	tests := map[uint64]string{
+		0:              "0",
+		1:              "0.00000001",
+		10:             "0.0000001",
+		123456789:      "1.23456789",
+		100_000_000:    "1",
+		150_000_000:    "1.5",
+		1_000_000_001:  "10.00000001",
+		math.MaxUint64: "184467440737.09551615",
+	}
+	for octas, expected := range tests {
+		assert.Equal(t, expected, OctasToAPT(octas))
+	}
+}
+
+func TestAPTToOctas(t *testing.T) {
+	tests := map[string]uint64{
+		"0":                     0,
+		"0.0":                   0,
+		"0.00000001":            1,
+		"1.23456789":            123456789,
+		"1":                     100_000_000,
+		"1.5":                   150_000_000,
+		"01.50":                 150_000_000,
+		"10.00000001":           1_000_000_001,
+		"184467440737.09551615": math.MaxUint64,
+	}
+	for apt, expected := range tests {
+		octas, err := APTToOctas(apt)
+		assert.NoError(t, err, apt)
+		assert.Equal(t, expected, octas, apt)
+		// It round trips, other than leading and trailing zeros
+		roundTrip, err := APTToOctas(OctasToAPT(octas))
+		assert.NoError(t, err)
+		assert.Equal(t, octas, roundTrip)
+	}
+}
+
+func TestAPTToOctas_Errors(t *testing.T) {
+	for _, apt := range []string{
+		"",
+		".",
+		".5",
+		"1.",
+		"-1",
+		"+1",
+		"1e8",
+		" 1",
+		"1.2.3",
+		"1,5",
+		"abc",
+		"0.000000001",           // More than 8 fractional digits
+		"1.234567890",           // Even if a trailing zero
+		"184467440737.09551616", // Overflow in the fraction
+		"184467440738",          // Overflow in the whole part
+		"99999999999999999999999",
+	} {
+		_, err := APTToOctas(apt)
+		assert.Error(t, err, apt)
+	}
+}
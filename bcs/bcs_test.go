@@ -128,6 +128,78 @@ func Test_Uleb128(t *testing.T) {
 	})
 }
 
+func Test_Uleb128Boundaries(t *testing.T) {
+	serialized := []string{"7f", "8001", "ff7f", "808001", "ffff7f", "80808001", "ffffff7f", "8080808001", "ffffffff0f"}
+	deserialized := []uint32{127, 128, 16383, 16384, 2097151, 2097152, 268435455, 268435456, 0xffffffff}
+
+	helper(t, serialized, deserialized, func(serializer *Serializer, input uint32) {
+		SerializeUleb128(serializer, input)
+	}, func(deserializer *Deserializer) uint32 {
+		return DeserializeUleb128(deserializer)
+	})
+
+	// Larger than a u32
+	des := NewDeserializer([]byte{0xff, 0xff, 0xff, 0xff, 0x1f})
+	DeserializeUleb128(des)
+	assert.Error(t, des.Error())
+
+	// Missing the last byte
+	des = NewDeserializer([]byte{0xff, 0xff})
+	DeserializeUleb128(des)
+	assert.Error(t, des.Error())
+}
+
+func Test_UintBoundaries(t *testing.T) {
+	maxU128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	maxU256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	ser := &Serializer{}
+	ser.U8(0xff)
+	ser.U16(0xffff)
+	ser.U32(0xffffffff)
+	ser.U64(0xffffffffffffffff)
+	ser.U128(*maxU128)
+	ser.U256(*maxU256)
+	assert.NoError(t, ser.Error())
+	serialized := ser.ToBytes()
+	assert.Len(t, serialized, 1+2+4+8+16+32)
+
+	des := NewDeserializer(serialized)
+	assert.Equal(t, uint8(0xff), des.U8())
+	assert.Equal(t, uint16(0xffff), des.U16())
+	assert.Equal(t, uint32(0xffffffff), des.U32())
+	assert.Equal(t, uint64(0xffffffffffffffff), des.U64())
+	u128 := des.U128()
+	assert.Equal(t, 0, maxU128.Cmp(&u128))
+	u256 := des.U256()
+	assert.Equal(t, 0, maxU256.Cmp(&u256))
+	assert.NoError(t, des.Error())
+	assert.Equal(t, 0, des.Remaining())
+
+	// Integers are little-endian
+	u32, err := SerializeU32(0x01020304)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x04, 0x03, 0x02, 0x01}, u32)
+}
+
+func Test_BigUintOutOfRange(t *testing.T) {
+	tests := map[string]struct {
+		serialize func(ser *Serializer)
+		message   string
+	}{
+		"negative u128": {func(ser *Serializer) { ser.U128(*big.NewInt(-1)) }, "value -1 out of range for u128"},
+		"u128 overflow": {func(ser *Serializer) { ser.U128(*new(big.Int).Lsh(big.NewInt(1), 128)) }, "out of range for u128"},
+		"negative u256": {func(ser *Serializer) { ser.U256(*big.NewInt(-5)) }, "value -5 out of range for u256"},
+		"u256 overflow": {func(ser *Serializer) { ser.U256(*new(big.Int).Lsh(big.NewInt(1), 256)) }, "out of range for u256"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := SerializeSingle(test.serialize)
+			assert.ErrorContains(t, err, test.message)
+		})
+	}
+}
+
 func Test_Bool(t *testing.T) {
 	serialized := []string{"00", "01"}
 	deserialized := []bool{false, true}
@@ -228,6 +300,48 @@ func Test_DeserializeSequence(t *testing.T) {
 	assert.Equal(t, deserialized, actualDeserialized)
 }
 
+func Test_DeserializeSequenceReferences(t *testing.T) {
+	deserialized := []*TestStruct{{0, false}, {5, true}, {255, true}}
+	serialized := []byte{0x03, 0x00, 0x00, 0x05, 0x01, 0xFF, 0x01}
+
+	actualSerialized, err := SerializeSequenceOnly(deserialized)
+	assert.NoError(t, err)
+	assert.Equal(t, serialized, actualSerialized)
+
+	// Each member is allocated
+	des := NewDeserializer(actualSerialized)
+	actualDeserialized := DeserializeSequence[*TestStruct](des)
+	assert.NoError(t, des.Error())
+	assert.Equal(t, deserialized, actualDeserialized)
+
+	// References to non Unmarshalers still fail
+	des = NewDeserializer([]byte{0x01, 0x00})
+	DeserializeSequence[*uint8](des)
+	assert.Error(t, des.Error())
+}
+
+func Test_SequenceEmpty(t *testing.T) {
+	serialized, err := SerializeSequenceOnly([]TestStruct{})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00}, serialized)
+
+	// A nil slice is the same as an empty slice
+	nilSerialized, err := SerializeSequenceOnly[[]TestStruct](nil)
+	assert.NoError(t, err)
+	assert.Equal(t, serialized, nilSerialized)
+
+	des := NewDeserializer(serialized)
+	deserialized := DeserializeSequence[TestStruct](des)
+	assert.NoError(t, des.Error())
+	assert.Equal(t, []TestStruct{}, deserialized)
+	assert.Equal(t, 0, des.Remaining())
+
+	des = NewDeserializer(serialized)
+	references := DeserializeSequence[*TestStruct](des)
+	assert.NoError(t, des.Error())
+	assert.Equal(t, []*TestStruct{}, references)
+}
+
 func Test_InvalidBool(t *testing.T) {
 	des := NewDeserializer([]byte{0x02})
 	des.Bool()
@@ -458,6 +572,127 @@ func Test_DeserializeNotEnoughBytes(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func Test_DeserializeOversizedLength(t *testing.T) {
+	// A u32 max length prefix, with only 2 bytes after it
+	oversized := []byte{0xff, 0xff, 0xff, 0xff, 0x0f, 0x01, 0x02}
+
+	des := NewDeserializer(oversized)
+	assert.Nil(t, des.ReadBytes())
+	assert.ErrorContains(t, des.Error(), "not enough bytes remaining")
+
+	des = NewDeserializer(oversized)
+	assert.Equal(t, "", des.ReadString())
+	assert.Error(t, des.Error())
+
+	des = NewDeserializer(oversized)
+	assert.Nil(t, DeserializeSequence[TestStruct](des))
+	assert.Error(t, des.Error())
+
+	des = NewDeserializer(oversized)
+	assert.Nil(t, DeserializeSequenceWithFunction(des, func(des *Deserializer, out *uint64) {
+		*out = des.U64()
+	}))
+	assert.Error(t, des.Error())
+
+	// Fixed lengths past the end, or negative
+	des = NewDeserializer(oversized)
+	assert.Nil(t, des.ReadFixedBytes(8))
+	assert.Error(t, des.Error())
+	des = NewDeserializer(oversized)
+	assert.Nil(t, des.ReadFixedBytes(-1))
+	assert.Error(t, des.Error())
+
+	// A uleb128 longer than 5 bytes
+	des = NewDeserializer([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x00})
+	assert.Equal(t, uint32(0), des.Uleb128())
+	assert.ErrorContains(t, des.Error(), "longer than 5 bytes")
+	des = NewDeserializer([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01})
+	assert.Equal(t, uint32(0), des.Uleb128())
+	assert.Error(t, des.Error())
+
+	// The first error is kept
+	des = NewDeserializer([]byte{0x02})
+	des.ReadBytes()
+	firstErr := des.Error()
+	des.U64()
+	assert.Equal(t, firstErr, des.Error())
+}
+
+// truncationTestStruct covers each kind of read, for checking that every truncated encoding fails gracefully
+type truncationTestStruct struct {
+	structs []TestStruct
+	bytes   []byte
+	str     string
+	big     big.Int
+	fixed   [4]byte
+	option  *uint32
+}
+
+func (st *truncationTestStruct) MarshalBCS(ser *Serializer) {
+	SerializeSequence(st.structs, ser)
+	ser.WriteBytes(st.bytes)
+	ser.WriteString(st.str)
+	ser.U128(st.big)
+	ser.FixedBytes(st.fixed[:])
+	SerializeOption(ser, st.option, func(ser *Serializer, item uint32) {
+		ser.U32(item)
+	})
+}
+
+func (st *truncationTestStruct) UnmarshalBCS(des *Deserializer) {
+	st.structs = DeserializeSequence[TestStruct](des)
+	st.bytes = des.ReadBytes()
+	st.str = des.ReadString()
+	st.big = des.U128()
+	des.ReadFixedBytesInto(st.fixed[:])
+	st.option = DeserializeOption(des, func(des *Deserializer, out *uint32) {
+		*out = des.U32()
+	})
+}
+
+func Test_DeserializeTruncated(t *testing.T) {
+	option := uint32(7)
+	input := &truncationTestStruct{
+		structs: []TestStruct{{num: 1, b: true}, {num: 2, b: false}},
+		bytes:   []byte{0x01, 0x02, 0x03},
+		str:     "hello",
+		big:     *big.NewInt(123456789),
+		fixed:   [4]byte{0xde, 0xad, 0xbe, 0xef},
+		option:  &option,
+	}
+	serialized, err := Serialize(input)
+	assert.NoError(t, err)
+
+	output := &truncationTestStruct{}
+	assert.NoError(t, Deserialize(output, serialized))
+	assert.Equal(t, input, output)
+
+	// Every prefix is short, so it must fail without panicking
+	for i := 0; i < len(serialized); i++ {
+		err = Deserialize(&truncationTestStruct{}, serialized[:i])
+		assert.Error(t, err, "truncated to %d bytes", i)
+	}
+}
+
+func FuzzDeserializer(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0x01, 0x00, 0x00})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0x0f, 0x01, 0x02})
+	f.Add([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01})
+	f.Add([]byte{0x02, 0x01, 0x01, 0x02, 0x00, 0x03, 0x01, 0x02, 0x03})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		output := &truncationTestStruct{}
+		err := Deserialize(output, data)
+		if err != nil {
+			return
+		}
+		// Anything that deserializes must serialize again, and never longer, as non-canonical uleb128s are shortened
+		serialized, err := Serialize(output)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(serialized), len(data))
+	})
+}
+
 func helper[TYPE uint8 | uint16 | uint32 | uint64 | bool | []byte | string](t *testing.T, serialized []string, deserialized []TYPE, serialize func(serializer *Serializer, val TYPE), deserialize func(deserializer *Deserializer) TYPE) {
 
 	// Serializer
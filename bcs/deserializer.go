@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/big"
+	"reflect"
 	"slices"
 )
 
@@ -86,7 +87,7 @@ func (des *Deserializer) Bool() bool {
 	case 1:
 		out = true
 	default:
-		des.setError("bad bool at [%des]: %x", des.pos-1, des.source[des.pos-1])
+		des.setError("bad bool at [%d]: %x", des.pos-1, des.source[des.pos-1])
 	}
 	return out
 }
@@ -117,48 +118,55 @@ func (des *Deserializer) deserializeUBigint(typeName string, size int) big.Int {
 	return out
 }
 
-// U8 deserializes a single unsigned 8-bit integer
+// U8 deserializes a single unsigned 8-bit integer from one byte
 func (des *Deserializer) U8() uint8 {
 	return deserializeUint(des, "u8", 1, func(slice []byte) uint8 {
 		return slice[0]
 	})
 }
 
-// U16 deserializes a single unsigned 16-bit integer
+// U16 deserializes a single unsigned 16-bit integer from 2 bytes in little-endian format
 func (des *Deserializer) U16() uint16 {
 	return deserializeUint(des, "u16", 2, binary.LittleEndian.Uint16)
 }
 
-// U32 deserializes a single unsigned 32-bit integer
+// U32 deserializes a single unsigned 32-bit integer from 4 bytes in little-endian format
 func (des *Deserializer) U32() uint32 {
 	return deserializeUint(des, "u32", 4, binary.LittleEndian.Uint32)
 }
 
-// U64 deserializes a single unsigned 64-bit integer
-
+// U64 deserializes a single unsigned 64-bit integer from 8 bytes in little-endian format
 func (des *Deserializer) U64() uint64 {
 	return deserializeUint(des, "u64", 8, binary.LittleEndian.Uint64)
 }
 
-// U128 deserializes a single unsigned 128-bit integer
+// U128 deserializes a single unsigned 128-bit integer from 16 bytes in little-endian format
 func (des *Deserializer) U128() big.Int {
 	return des.deserializeUBigint("u128", 16)
 }
 
-// U256 deserializes a single unsigned 256-bit integer
+// U256 deserializes a single unsigned 256-bit integer from 32 bytes in little-endian format
 func (des *Deserializer) U256() big.Int {
 	return des.deserializeUBigint("u256", 32)
 }
 
 // Uleb128 deserializes a 32-bit integer from a variable length [Unsigned LEB128]
 //
+// A u32 takes at most 5 bytes, so a longer encoding is an error.
+//
 // [Unsigned LEB128]: https://en.wikipedia.org/wiki/LEB128#Unsigned_LEB128
 func (des *Deserializer) Uleb128() uint32 {
 	const maxU32 = uint64(0xFFFFFFFF)
+	const maxBytes = 5
 	var out uint64 = 0
 	shift := 0
 
 	for out < maxU32 {
+		if shift >= 7*maxBytes {
+			des.setError("uleb128 is invalid as it is longer than %d bytes", maxBytes)
+			return 0
+		}
+
 		// Ensure we still have bytes to process
 		if des.pos >= len(des.source) {
 			des.setError("not enough bytes remaining to deserialize uleb128")
@@ -187,12 +195,33 @@ func (des *Deserializer) Uleb128() uint32 {
 	return uint32(out)
 }
 
+// DeserializeUleb128 deserializes an unsigned 32-bit integer from an [Unsigned LEB128] with the [Deserializer], the
+// same as [Deserializer.Uleb128].  It is the encoding of sequence lengths and enum variants, for reading custom
+// payloads:
+//
+//	length := DeserializeUleb128(des)
+//	items := make([]uint64, length)
+//	for i := range items {
+//		items[i] = des.U64()
+//	}
+//
+// [Unsigned LEB128]: https://en.wikipedia.org/wiki/LEB128#Unsigned_LEB128
+func DeserializeUleb128(des *Deserializer) uint32 {
+	return des.Uleb128()
+}
+
 // ReadBytes reads bytes prefixed with a length
+//
+// A length longer than the remaining bytes is an error, without allocating for it.
 func (des *Deserializer) ReadBytes() []byte {
 	length := des.Uleb128()
 	if des.err != nil {
 		return nil
 	}
+	if uint64(length) > uint64(des.Remaining()) {
+		des.setError("not enough bytes remaining to deserialize bytes of length %d, %d remaining", length, des.Remaining())
+		return nil
+	}
 
 	dest := make([]byte, length)
 	des.readBytes("bytes", int(length), dest)
@@ -206,6 +235,10 @@ func (des *Deserializer) ReadString() string {
 
 // ReadFixedBytes reads bytes not-prefixed with a length
 func (des *Deserializer) ReadFixedBytes(length int) []byte {
+	if length < 0 || length > des.Remaining() {
+		des.setError("not enough bytes remaining to deserialize fixedBytes of length %d, %d remaining", length, des.Remaining())
+		return nil
+	}
 	out := make([]byte, length)
 	des.ReadFixedBytesInto(out)
 	return out
@@ -242,15 +275,31 @@ func (des *Deserializer) Struct(v Unmarshaler) {
 //
 // This lets you deserialize a whole sequence of [Unmarshaler], and will fail if any member fails.
 // All sequences are prefixed with an Uleb128 length.
+//
+// It works with both arrays of values and arrays of references, where a new value is allocated for each member:
+//
+//	values := DeserializeSequence[MyStruct](des)
+//	references := DeserializeSequence[*MyStruct](des)
 func DeserializeSequence[T any](des *Deserializer) []T {
 	return DeserializeSequenceWithFunction(des, func(des *Deserializer, out *T) {
 		mv, ok := any(out).(Unmarshaler)
 		if ok {
 			mv.UnmarshalBCS(des)
-		} else {
-			// If it isn't of type Unmarshaler, we pass up an error
-			des.setError("type is not Unmarshaler")
+			return
 		}
+		// Check if it's a reference to an Unmarshaler, and allocate it
+		outType := reflect.TypeOf(out).Elem()
+		if outType.Kind() == reflect.Pointer {
+			value := reflect.New(outType.Elem())
+			mv, ok = value.Interface().(Unmarshaler)
+			if ok {
+				mv.UnmarshalBCS(des)
+				reflect.ValueOf(out).Elem().Set(value)
+				return
+			}
+		}
+		// If it isn't of type Unmarshaler, we pass up an error
+		des.setError("type is not Unmarshaler")
 	})
 }
 
@@ -258,13 +307,16 @@ func DeserializeSequence[T any](des *Deserializer) []T {
 //
 // This lets you deserialize a whole sequence of any type, and will fail if any member fails.
 // All sequences are prefixed with an Uleb128 length.
+//
+// The length prefix is untrusted, so no more members are allocated up front than there are bytes remaining.
 func DeserializeSequenceWithFunction[T any](des *Deserializer, deserialize func(des *Deserializer, out *T)) []T {
 	length := des.Uleb128()
 	if des.Error() != nil {
 		return nil
 	}
-	out := make([]T, length)
+	out := make([]T, 0, min(int(length), des.Remaining()))
 	for i := 0; i < int(length); i++ {
+		out = append(out, *new(T))
 		deserialize(des, &out[i])
 
 		if des.Error() != nil {
@@ -62,6 +62,11 @@ func serializeUInt[T uint16 | uint32 | uint64](ser *Serializer, size uint, v T,
 }
 
 func (ser *Serializer) serializeUBigInt(size uint, v *big.Int) {
+	// FillBytes would drop the sign of negative values, and panic on values too large
+	if v.Sign() < 0 || uint(v.BitLen()) > size*8 {
+		ser.SetError(fmt.Errorf("value %s out of range for u%d", v.String(), size*8))
+		return
+	}
 	ub := make([]byte, size)
 	v.FillBytes(ub[:])
 	// Reverse, since big.Int outputs bytes in BigEndian
@@ -69,7 +74,7 @@ func (ser *Serializer) serializeUBigInt(size uint, v *big.Int) {
 	ser.out.Write(ub[:])
 }
 
-// U8 serialize a byte
+// U8 serialize an unsigned 8-bit integer as a single byte
 func (ser *Serializer) U8(v uint8) {
 	ser.out.WriteByte(v)
 }
@@ -89,12 +94,14 @@ func (ser *Serializer) U64(v uint64) {
 	serializeUInt(ser, 8, v, binary.LittleEndian.PutUint64)
 }
 
-// U128 serialize an unsigned 128-bit integer in little-endian format
+// U128 serialize an unsigned 128-bit integer in little-endian format.  The value must fit in 128 bits, otherwise the
+// error is set.
 func (ser *Serializer) U128(v big.Int) {
 	ser.serializeUBigInt(16, &v)
 }
 
-// U256 serialize an unsigned 256-bit integer in little-endian format
+// U256 serialize an unsigned 256-bit integer in little-endian format.  The value must fit in 256 bits, otherwise the
+// error is set.
 func (ser *Serializer) U256(v big.Int) {
 	ser.serializeUBigInt(32, &v)
 }
@@ -108,6 +115,20 @@ func (ser *Serializer) Uleb128(val uint32) {
 	ser.out.WriteByte(uint8(val))
 }
 
+// SerializeUleb128 serializes an unsigned 32-bit integer as an [Unsigned LEB128] with the [Serializer], the same as
+// [Serializer.Uleb128].  It is the encoding of sequence lengths and enum variants, for building custom payloads:
+//
+//	ser := &Serializer{}
+//	SerializeUleb128(ser, uint32(len(items)))
+//	for _, item := range items {
+//		ser.U64(item)
+//	}
+//
+// [Unsigned LEB128]: https://en.wikipedia.org/wiki/LEB128#Unsigned_LEB128
+func SerializeUleb128(ser *Serializer, val uint32) {
+	ser.Uleb128(val)
+}
+
 // WriteBytes serialize an array of bytes with its length first as an Uleb128.
 func (ser *Serializer) WriteBytes(v []byte) {
 	ser.Uleb128(uint32(len(v)))
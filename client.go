@@ -1,11 +1,14 @@
 package aptos
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"github.com/hasura/go-graphql-client"
 )
 
@@ -77,6 +80,40 @@ func init() {
 	setNN(MainnetConfig)
 }
 
+// Network is the name of a preconfigured network in [NamedNetworks], for use with [NewClientForNetwork]
+type Network string
+
+const (
+	Mainnet  Network = "mainnet"  // Mainnet is the network for [MainnetConfig]
+	Testnet  Network = "testnet"  // Testnet is the network for [TestnetConfig]
+	Devnet   Network = "devnet"   // Devnet is the network for [DevnetConfig]
+	Localnet Network = "localnet" // Localnet is the network for [LocalnetConfig]
+)
+
+// Config returns the preconfigured [NetworkConfig] for the network
+func (network Network) Config() (NetworkConfig, error) {
+	config, ok := NamedNetworks[string(network)]
+	if !ok {
+		return NetworkConfig{}, fmt.Errorf("unknown network %s", network)
+	}
+	return config, nil
+}
+
+// NodeUrlOverride is an option to [NewClientForNetwork] to use a different fullnode URL than the preset
+type NodeUrlOverride string
+
+// IndexerUrlOverride is an option to [NewClientForNetwork] to use a different indexer URL than the preset, an empty
+// string disables the indexer
+type IndexerUrlOverride string
+
+// FaucetUrlOverride is an option to [NewClientForNetwork] to use a different faucet URL than the preset, an empty
+// string disables the faucet
+type FaucetUrlOverride string
+
+// ChainIdOverride is an option to [NewClientForNetwork] to use a different chain ID than the preset, 0 fetches it
+// on-chain
+type ChainIdOverride uint8
+
 // AptosClient is an interface for all functionality on the Client.
 // It is a combination of [AptosRpcClient], [AptosIndexerClient], and [AptosFaucetClient] for the purposes
 // of mocking and convenince.
@@ -84,16 +121,48 @@ type AptosClient interface {
 	AptosRpcClient
 	AptosIndexerClient
 	AptosFaucetClient
+
+	// FungibleStores returns all the fungible asset stores owned by the owner, found with the indexer, with their
+	// balances read from the node
+	//
+	//	stores, err := client.FungibleStores(owner)
+	FungibleStores(owner AccountAddress) ([]FungibleStore, error)
 }
 
 // AptosRpcClient is an interface for all functionality on the Client that is Node RPC related.  Its main implementation
-// is [NodeClient]
+// is [NodeClient].  For unit tests without a node, see [FakeRpcClient].
+//
+// Methods are added to it as they're added to [NodeClient], so other implementations should embed a [*NodeClient] or
+// [*FakeRpcClient], and override only the methods they change.
 type AptosRpcClient interface {
 	// SetTimeout adjusts the HTTP client timeout
 	//
 	//	client.SetTimeout(5 * time.Millisecond)
 	SetTimeout(timeout time.Duration)
 
+	// SetRequestTimeout sets a timeout on each request, 0 or less for no timeout.  With retries, it's for each attempt.
+	//
+	//	client.SetRequestTimeout(5 * time.Second)
+	SetRequestTimeout(timeout time.Duration)
+
+	// SetGzipRequests gzip compresses request bodies of at least minSize bytes, 0 or less to not compress.  The node
+	// must accept compressed requests.
+	//
+	//	client.SetGzipRequests(DefaultGzipRequestMinSize)
+	SetGzipRequests(minSize int64)
+
+	// SetLedgerExpiration sets transactions built to expire d after the ledger's timestamp, by default
+	// [DefaultLedgerExpiration], 0 to expire after the local clock
+	//
+	//	client.SetLedgerExpiration(time.Minute)
+	SetLedgerExpiration(d time.Duration)
+
+	// DefaultExpiration returns the expiration timestamp in seconds for a transaction to expire d after the ledger's
+	// timestamp
+	//
+	//	expiration, err := client.DefaultExpiration(DefaultLedgerExpiration)
+	DefaultExpiration(d time.Duration) (uint64, error)
+
 	// SetHeader sets the header for all future requests
 	//
 	//	client.SetHeader("Authorization", "Bearer abcde")
@@ -107,9 +176,21 @@ type AptosRpcClient interface {
 	// Info Retrieves the node info about the network and it's current state
 	Info() (info NodeInfo, err error)
 
+	// LedgerInfo Retrieves the current state of the blockchain, with numeric fields parsed
+	//
+	//	info, _ := client.LedgerInfo()
+	//	latestVersion := info.LedgerVersion.ToUint64()
+	LedgerInfo() (info *api.LedgerInfo, err error)
+
 	// Account Retrieves information about the account such as [SequenceNumber] and [crypto.AuthenticationKey]
 	Account(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error)
 
+	// AccountCtx is Account, but the request is bound to the context
+	AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error)
+
+	// AccountExists Checks whether the account exists on chain, returning false with no error if it doesn't
+	AccountExists(address AccountAddress) (bool, error)
+
 	// AccountResource Retrieves a single resource given its struct name.
 	//
 	//	address := AccountOne
@@ -121,6 +202,14 @@ type AptosRpcClient interface {
 	//	dataMap, _ := client.AccountResource(address, "0x1::coin::CoinStore", 1)
 	AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error)
 
+	// AccountResourceCtx is AccountResource, but the request is bound to the context
+	AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error)
+
+	// AccountResourceBCS Retrieves a single resource given its struct name, as the BCS bytes of the Move struct.
+	//
+	//	data, _ := client.AccountResourceBCS(address, "0x1::account::Account")
+	AccountResourceBCS(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data []byte, err error)
+
 	// AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 	// For fetching raw Move structs as BCS, See #AccountResourcesBCS
 	//
@@ -131,11 +220,65 @@ type AptosRpcClient interface {
 	//
 	//	address := AccountOne
 	//	dataMap, _ := client.AccountResource(address, 1)
+	//
+	// Every page of resources is fetched and merged, to cap the number fetched, see AccountResourcesUpTo
 	AccountResources(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error)
 
+	// AccountResourcesCtx is AccountResources, but the requests are bound to the context
+	AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error)
+
+	// AccountResourcesUpTo fetches resources for an account the same as AccountResources, but stops once maxResources
+	// have been fetched.  If maxResources is 0 or less, all resources are fetched.
+	//
+	//	resources, _ := client.AccountResourcesUpTo(address, 100)
+	AccountResourcesUpTo(address AccountAddress, maxResources int, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error)
+
 	// AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
 	AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error)
 
+	// AccountModule fetches a single module for an account by name, with its bytecode and ABI
+	//
+	//	module, _ := client.AccountModule(AccountOne, "coin")
+	//	balance := module.Abi.Function("balance")
+	//
+	// Can also fetch at a specific ledger version
+	//
+	//	module, _ := client.AccountModule(AccountOne, "coin", 1)
+	AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (module *api.MoveBytecode, err error)
+
+	// AccountModules fetches all modules for an account, with their bytecode and ABIs
+	//
+	//	modules, _ := client.AccountModules(AccountOne)
+	AccountModules(address AccountAddress, ledgerVersion ...uint64) (modules []*api.MoveBytecode, err error)
+
+	// FrameworkModules fetches the ABIs of all the framework modules at 0x1 by module name, cached for the life of the
+	// client
+	//
+	//	modules, _ := client.FrameworkModules()
+	//	transfer := modules["aptos_account"].Function("transfer")
+	FrameworkModules() (modules map[string]*api.MoveModule, err error)
+
+	// AccountResourcesBatch fetches multiple resources for an account concurrently, in the same order as resourceTypes
+	//
+	//	address := AccountOne
+	//	resources, _ := client.AccountResourcesBatch(address, []string{"0x1::account::Account", "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>"})
+	//
+	// Can also limit the number of concurrent requests, and return successful resources on partial failure
+	//
+	//	resources, err := client.AccountResourcesBatch(address, resourceTypes, BatchWorkers(4), PartialResults(true))
+	AccountResourcesBatch(address AccountAddress, resourceTypes []string, options ...any) (resources []AccountResourceInfo, err error)
+
+	// GetTableItem fetches a table item by its key, and decodes the JSON value into out
+	//
+	//	var balance api.U64
+	//	err := client.GetTableItem(handle, "address", "u64", AccountOne, &balance)
+	GetTableItem(handle string, keyType string, valueType string, key any, out any, ledgerVersion ...uint64) error
+
+	// GetTableItemRaw fetches the BCS bytes of a table item by the BCS bytes of its key
+	//
+	//	valueBytes, err := client.GetTableItemRaw(handle, keyBytes)
+	GetTableItemRaw(handle string, key []byte, ledgerVersion ...uint64) ([]byte, error)
+
 	// BlockByHeight fetches a block by height
 	//
 	//	block, _ := client.BlockByHeight(1, false)
@@ -159,18 +302,19 @@ type AptosRpcClient interface {
 	//
 	//	data, err := client.TransactionByHash("0xabcd")
 	//	if err != nil {
-	//		if httpErr, ok := err.(aptos.HttpError) {
-	//			if httpErr.StatusCode == 404 {
-	//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-	//			}
-	//		}
-	//	} else {
-	//		if data["type"] == "pending_transaction" {
-	//			// known to local mempool, but not committed yet
+	//		if IsNotFound(err) {
+	//			// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
 	//		}
+	//	} else if data.IsPending() {
+	//		// known to local mempool, but not committed yet
+	//	} else if !data.IsSuccess() {
+	//		// committed, but failed
 	//	}
 	TransactionByHash(txnHash string) (data *api.Transaction, err error)
 
+	// TransactionByHashCtx is TransactionByHash, but the request is bound to the context
+	TransactionByHashCtx(ctx context.Context, txnHash string) (data *api.Transaction, err error)
+
 	// TransactionByVersion gets info on a transaction from its LedgerVersion.  It must have been
 	// committed to have a ledger version
 	//
@@ -184,6 +328,9 @@ type AptosRpcClient interface {
 	//	}
 	TransactionByVersion(version uint64) (data *api.CommittedTransaction, err error)
 
+	// TransactionByVersionCtx is TransactionByVersion, but the request is bound to the context
+	TransactionByVersionCtx(ctx context.Context, version uint64) (data *api.CommittedTransaction, err error)
+
 	// PollForTransactions Waits up to 10 seconds for transactions to be done, polling at 10Hz
 	// Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
 	//
@@ -201,6 +348,30 @@ type AptosRpcClient interface {
 	//	data, err := client.WaitForTransaction("0x1234")
 	WaitForTransaction(txnHash string, options ...any) (data *api.UserTransaction, err error)
 
+	// WaitForTransactionByHash polls for a transaction until it is committed, the timeout expires, or the context is
+	// cancelled.  If the transaction was committed but failed, it is returned with a [TransactionFailedError].
+	//
+	//	txn, err := client.WaitForTransactionByHash(ctx, "0x1234", PollPeriod(500 * time.Millisecond), PollTimeout(30 * time.Second))
+	WaitForTransactionByHash(ctx context.Context, hash string, opts ...WaitOption) (*api.Transaction, error)
+
+	// EventsByCreationNumber returns an [EventIterator] over the V1 events of an event handle, which pages through
+	// the node as needed
+	//
+	//	events, err := client.EventsByCreationNumber(address, 2).Collect(ctx, 1000)
+	EventsByCreationNumber(address AccountAddress, creationNumber uint64, opts ...PageOption) *EventIterator
+
+	// TransactionsByVersionRange returns a [TransactionRangeIterator] over the committed transactions from the start
+	// version, up to but not including the end version, chunked by the node's page size
+	//
+	//	txns, err := client.TransactionsByVersionRange(1000, 2000).All(ctx)
+	TransactionsByVersionRange(start uint64, end uint64) *TransactionRangeIterator
+
+	// AccountTransactionsIterator returns an [AccountTransactionIterator] over the transactions sent by the account,
+	// which pages through the node as needed
+	//
+	//	txns, err := client.AccountTransactionsIterator(address, PageConcurrency(4)).CollectAll(ctx)
+	AccountTransactionsIterator(address AccountAddress, opts ...PageOption) *AccountTransactionIterator
+
 	// Transactions Get recent transactions.
 	// Start is a version number. Nil for most recent transactions.
 	// Limit is a number of transactions to return. 'about a hundred' by default.
@@ -209,6 +380,9 @@ type AptosRpcClient interface {
 	//	client.Transactions(1, 100) // Returns 100 transactions
 	Transactions(start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error)
 
+	// TransactionsCtx is Transactions, but the requests are bound to the context
+	TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error)
+
 	// AccountTransactions Get transactions associated with an account.
 	// Start is a version number. Nil for most recent transactions.
 	// Limit is a number of transactions to return. 'about a hundred' by default.
@@ -239,6 +413,25 @@ type AptosRpcClient interface {
 	//	submitResponse, err := client.SubmitTransaction(signedTxn)
 	SubmitTransaction(signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error)
 
+	// SubmitTransactionCtx is SubmitTransaction, but the request is bound to the context
+	SubmitTransactionCtx(ctx context.Context, signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error)
+
+	// SubmitTransactionBCS Submits an already signed and BCS serialized transaction to the blockchain
+	//
+	//	signedTxnBytes, _ := bcs.Serialize(signedTxn)
+	//	submitResponse, err := client.SubmitTransactionBCS(signedTxnBytes)
+	SubmitTransactionBCS(signedTxn []byte) (data *api.SubmitTransactionResponse, err error)
+
+	// SubmitTransactionBCSCtx is SubmitTransactionBCS, but the request is bound to the context
+	SubmitTransactionBCSCtx(ctx context.Context, signedTxn []byte) (data *api.SubmitTransactionResponse, err error)
+
+	// SubmitTransactionBCSResponse Submits an already signed and BCS serialized transaction to the blockchain, and
+	// returns the raw BCS response rather than parsing a JSON response
+	//
+	//	signedTxnBytes, _ := bcs.Serialize(signedTxn)
+	//	responseBytes, err := client.SubmitTransactionBCSResponse(signedTxnBytes)
+	SubmitTransactionBCSResponse(signedTxn []byte) (response []byte, err error)
+
 	// BatchSubmitTransaction submits a collection of signed transactions to the network in a single request
 	//
 	// It will return the responses in the same order as the input transactions that failed.  If the response is empty, then
@@ -285,10 +478,54 @@ type AptosRpcClient interface {
 	//	simResponse, err := client.SimulateTransaction(rawTxn, sender)
 	SimulateTransaction(rawTxn *RawTransaction, sender TransactionSigner, options ...any) (data []*api.UserTransaction, err error)
 
+	// SimulateTransactionBCS Simulates an already BCS serialized transaction, signed with a simulation authenticator,
+	// without sending it to the blockchain
+	//
+	//	signedTxn, _ := rawTxn.SignedTransactionWithAuthenticator(sender.SimulationAuthenticator())
+	//	signedTxnBytes, _ := bcs.Serialize(signedTxn)
+	//	simResponse, err := client.SimulateTransactionBCS(signedTxnBytes)
+	SimulateTransactionBCS(signedTxn []byte, options ...any) (data []*api.UserTransaction, err error)
+
+	// SimulateTransactionBCSResponse Simulates an already BCS serialized transaction, signed with a simulation
+	// authenticator, and returns the raw BCS response rather than parsing a JSON response
+	//
+	//	signedTxnBytes, _ := bcs.Serialize(signedTxn)
+	//	responseBytes, err := client.SimulateTransactionBCSResponse(signedTxnBytes)
+	SimulateTransactionBCSResponse(signedTxn []byte, options ...any) (response []byte, err error)
+
+	// SimulateFeePayerTransaction Simulates a fee payer transaction signed only by the sender, before the fee payer
+	// signs, to find the gas the fee payer would pay
+	//
+	//	sim, err := client.SimulateFeePayerTransaction(rawTxn, sender, EstimateGasUnitPrice(true), EstimateMaxGasAmount(true))
+	//	fmt.Println("sponsor pays up to", sim.FeePayerOctas())
+	SimulateFeePayerTransaction(rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) (*FeePayerSimulation, error)
+
+	// SimulatePayload Builds a transaction for the payload, and simulates it without sending it to the blockchain.  The
+	// gas unit price and max gas amount are estimated by the node unless provided.
+	//
+	//	simTxn, err := client.SimulatePayload(sender, txnPayload)
+	//	if err == nil && !simTxn.Success {
+	//		fmt.Println("simulation failed:", simTxn.VmStatus)
+	//	}
+	//	simTxn, err = client.SimulatePayload(sender, txnPayload, MaxGasAmount(2000), GasUnitPrice(100))
+	SimulatePayload(sender TransactionSigner, payload TransactionPayload, options ...any) (data *api.UserTransaction, err error)
+
+	// BuildAndSimulate Builds a transaction for the payload, simulates it, and sets the max gas amount to the simulated
+	// gas used times a [GasBuffer], default 1.5.  A failed simulation returns a [SimulationFailedError].
+	//
+	//	rawTxn, err := client.BuildAndSimulate(sender, txnPayload)
+	//	signedTxn, err := rawTxn.SignedTransaction(sender)
+	BuildAndSimulate(sender TransactionSigner, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error)
+
 	// GetChainId Retrieves the ChainId of the network
-	// Note this will be cached forever, or taken directly from the config
+	// Note this will be cached forever for the node URL, or taken directly from the config
 	GetChainId() (chainId uint8, err error)
 
+	// SetChainId overrides the ChainId used to build transactions, 0 to use the ChainId of the node
+	//
+	//	client.SetChainId(4)
+	SetChainId(chainId uint8)
+
 	// BuildTransaction Builds a raw transaction from the payload and fetches any necessary information from on-chain
 	//
 	//	sender := NewEd25519Account()
@@ -329,6 +566,41 @@ type AptosRpcClient interface {
 	//	rawTxn, err := client.BuildTransactionMultiAgent(sender.AccountAddress(), txnPayload, FeePayer(AccountZero))
 	BuildTransactionMultiAgent(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransactionWithData, err error)
 
+	// BuildFeePayerTransaction Builds a raw transaction for signing by the sender and a fee payer.  The fee payer can
+	// be [AccountZero] if it isn't known when the sender signs.  Accepts the same options as
+	// [Client.BuildTransactionMultiAgent], other than [FeePayer].
+	//
+	//	rawTxn, err := client.BuildFeePayerTransaction(sender.Address, payload, sponsor.Address)
+	//	signedTxn, err := rawTxn.FeePayerSignedTransaction(sender, sponsor)
+	BuildFeePayerTransaction(sender AccountAddress, payload TransactionPayload, feePayer AccountAddress, options ...any) (rawTxn *RawTransactionWithData, err error)
+
+	// BuildMultiAgentTransaction Builds a raw transaction for signing by the sender and the secondary signers.  Accepts
+	// the same options as [Client.BuildTransactionMultiAgent], other than [FeePayer] and [AdditionalSigners].
+	//
+	//	rawTxn, err := client.BuildMultiAgentTransaction(sender.Address, []AccountAddress{receiver.Address}, payload)
+	//	signedTxn, err := rawTxn.MultiAgentSignedTransaction(sender, receiver)
+	BuildMultiAgentTransaction(sender AccountAddress, secondarySigners []AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransactionWithData, err error)
+
+	// BuildKeyRotation Builds a transaction rotating the account's authentication key to the new key, with the rotation
+	// proof challenge signed by both keys.  Accepts the same options as [BuildTransaction].
+	//
+	//	rawTxn, err := client.BuildKeyRotation(account, newKey)
+	//	signedTxn, err := rawTxn.SignedTransaction(account)
+	BuildKeyRotation(account *Account, newKey crypto.Signer, options ...any) (rawTxn *RawTransaction, err error)
+
+	// AccountAuthKeyMatches tells whether the account's on-chain authentication key is derived from the public key, false
+	// if it has been rotated to another key
+	//
+	//	matches, err := client.AccountAuthKeyMatches(account.Address, account.PubKey())
+	AccountAuthKeyMatches(address AccountAddress, publicKey crypto.PublicKey) (bool, error)
+
+	// NewSequenceNumberManager Creates a [SequenceNumberManager] for the account, to hand out sequence numbers for
+	// concurrent transactions without fetching them each time.  It can be passed as an option to BuildTransaction.
+	//
+	//	manager := client.NewSequenceNumberManager(sender.Address)
+	//	rawTxn, err := client.BuildTransaction(sender.Address, payload, manager)
+	NewSequenceNumberManager(address AccountAddress) *SequenceNumberManager
+
 	// BuildSignAndSubmitTransaction Convenience function to do all three in one
 	// for more configuration, please use them separately
 	//
@@ -366,7 +638,20 @@ type AptosRpcClient interface {
 	//		balance := StrToU64(vals.(any[])[0].(string))
 	View(payload *ViewPayload, ledgerVersion ...uint64) (vals []any, err error)
 
-	// EstimateGasPrice Retrieves the gas estimate from the network.
+	// ViewCtx is View, but the request is bound to the context
+	ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) (vals []any, err error)
+
+	// ViewJson Runs a view function on chain with JSON arguments, see [NodeClient.ViewJson]
+	//
+	//	vals, err := client.ViewJson("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne})
+	ViewJson(function string, typeArgs []string, args []any, ledgerVersion ...uint64) (vals []any, err error)
+
+	// ViewBatch Runs many view functions concurrently, with a result or error for each in order, see [NodeClient.ViewBatch]
+	//
+	//	results, err := client.ViewBatch(calls, BatchWorkers(16))
+	ViewBatch(calls []ViewRequest, options ...any) (results []ViewResult, err error)
+
+	// EstimateGasPrice Retrieves the gas estimate from the network, cached for [DefaultGasEstimateCacheTTL] by default.
 	EstimateGasPrice() (info EstimateGasInfo, err error)
 
 	// AccountAPTBalance retrieves the APT balance in the account
@@ -381,6 +666,23 @@ type AptosRpcClient interface {
 type AptosFaucetClient interface {
 	// Fund Uses the faucet to fund an address, only applies to non-production networks
 	Fund(address AccountAddress, amount uint64) error
+
+	// FundTransactions Uses the faucet to fund an address, and returns the hashes of the funding transactions.  By
+	// default, it waits for them to be committed.
+	//
+	//	txnHashes, err := client.FundTransactions(address, 100_000_000, FaucetWait(false))
+	FundTransactions(address AccountAddress, amount uint64, options ...any) (txnHashes []string, err error)
+
+	// CreateAndFund Generates a new Ed25519 account, and uses the faucet to fund it
+	//
+	//	account, err := client.CreateAndFund(100_000_000)
+	CreateAndFund(amount uint64, options ...any) (*Account, error)
+
+	// CreateAndFundMany Generates many new Ed25519 accounts, and uses the faucet to fund them concurrently.  The funded
+	// accounts are returned even if some fail.
+	//
+	//	accounts, err := client.CreateAndFundMany(ctx, 100, 100_000_000, BatchWorkers(4))
+	CreateAndFundMany(ctx context.Context, count int, amount uint64, options ...any) ([]*Account, error)
 }
 
 // AptosIndexerClient is an interface for all functionality on the Client that is Indexer related.  Its main implementation
@@ -415,11 +717,28 @@ type AptosIndexerClient interface {
 	//	return out, nil
 	QueryIndexer(query any, variables map[string]any, options ...graphql.Option) error
 
+	// ExecIndexerQuery runs a GraphQL query string against the indexer, and unmarshals the data into out.  GraphQL errors
+	// are returned as [GraphQLErrors], with any partial data still unmarshalled into out.
+	//
+	//	var out struct {
+	//		ProcessorStatus []struct {
+	//			LastSuccessVersion uint64 `json:"last_success_version"`
+	//		} `json:"processor_status"`
+	//	}
+	//	err := client.ExecIndexerQuery(ctx, `query { processor_status { last_success_version } }`, nil, &out)
+	ExecIndexerQuery(ctx context.Context, query string, variables map[string]any, out any) error
+
 	// GetProcessorStatus returns the ledger version up to which the processor has processed
 	GetProcessorStatus(processorName string) (uint64, error)
 
 	// GetCoinBalances gets the balances of all coins associated with a given address
 	GetCoinBalances(address AccountAddress) ([]CoinBalance, error)
+
+	// GetFungibleAssetBalances gets the current fungible asset balances of an address, optionally only for the asset types
+	//
+	//	balances, err := client.GetFungibleAssetBalances(address, []string{"0xa"})
+	//	balances, err = client.GetFungibleAssetBalances(address, nil, IndexerOffset(100), IndexerLimit(100))
+	GetFungibleAssetBalances(owner AccountAddress, assetTypes []string, options ...any) ([]FABalance, error)
 }
 
 // Client is a facade over the multiple types of underlying clients, as the user doesn't actually care where the data
@@ -437,16 +756,51 @@ type Client struct {
 }
 
 // NewClient Creates a new client with a specific network config that can be extended in the future
+//
+//	client, err := NewClient(MainnetConfig, DefaultRetryPolicy())
+//
+// Accepts options:
+//   - [http.Client] pointer, or [WithHTTPClient], to use a custom HTTP client
+//   - [WithTransport] to use a custom [http.RoundTripper], rather than [NewDefaultTransport]
+//   - [RetryPolicy] to retry failed requests to the node
+//   - [WithRequestTimeout] to time out each request to the node, see [NodeClient.SetRequestTimeout]
+//   - [WithGzipRequests] to compress large requests to the node, see [NodeClient.SetGzipRequests]
+//   - [GasEstimateCacheTTL] to change how long gas estimates are cached
+//   - [slog.Logger] pointer, to log requests to the node at debug level, see [NodeClient.WithLogger]
 func NewClient(config NetworkConfig, options ...any) (client *Client, err error) {
 	var httpClient *http.Client = nil
+	var transport http.RoundTripper = nil
+	var retryPolicy *RetryPolicy = nil
+	var requestTimeout *RequestTimeoutOption = nil
+	var gzipRequests *GzipRequestsOption = nil
+	var gasEstimateCacheTTL *GasEstimateCacheTTL = nil
+	var logger *slog.Logger = nil
 	for i, arg := range options {
 		switch value := arg.(type) {
+		case *slog.Logger:
+			logger = value
 		case *http.Client:
 			if httpClient != nil {
 				err = fmt.Errorf("NewClient only accepts one http.Client")
 				return
 			}
 			httpClient = value
+		case HttpClientOption:
+			if httpClient != nil {
+				err = fmt.Errorf("NewClient only accepts one http.Client")
+				return
+			}
+			httpClient = value.Client
+		case TransportOption:
+			transport = value.Transport
+		case RetryPolicy:
+			retryPolicy = &value
+		case RequestTimeoutOption:
+			requestTimeout = &value
+		case GzipRequestsOption:
+			gzipRequests = &value
+		case GasEstimateCacheTTL:
+			gasEstimateCacheTTL = &value
 		default:
 			err = fmt.Errorf("NewClient arg %d bad type %T", i+1, arg)
 			return
@@ -461,6 +815,27 @@ func NewClient(config NetworkConfig, options ...any) (client *Client, err error)
 	if err != nil {
 		return nil, err
 	}
+	if transport != nil {
+		// Copy the client, so a client given by the caller isn't changed
+		withTransport := *nodeClient.client
+		withTransport.Transport = transport
+		nodeClient.client = &withTransport
+	}
+	if retryPolicy != nil {
+		nodeClient.SetRetryPolicy(*retryPolicy)
+	}
+	if requestTimeout != nil {
+		nodeClient.SetRequestTimeout(requestTimeout.Timeout)
+	}
+	if gzipRequests != nil {
+		nodeClient.SetGzipRequests(gzipRequests.MinSize)
+	}
+	if gasEstimateCacheTTL != nil {
+		nodeClient.SetGasEstimateCacheTTL(time.Duration(*gasEstimateCacheTTL))
+	}
+	if logger != nil {
+		nodeClient = nodeClient.WithLogger(logger)
+	}
 	// Indexer may not be present
 	var indexerClient *IndexerClient = nil
 	if config.IndexerUrl != "" {
@@ -489,6 +864,73 @@ func NewClient(config NetworkConfig, options ...any) (client *Client, err error)
 	return
 }
 
+// WithContext returns a copy of the client with every request bound to the context, so they can be cancelled, or have
+// a deadline.  It includes any waiting between requests, e.g. when polling for a transaction or retrying.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	info, err := client.WithContext(ctx).Account(address)
+//
+// The copy shares its configuration with the original client, see [NodeClient.WithContext].
+func (client *Client) WithContext(ctx context.Context) *Client {
+	bound := &Client{nodeClient: client.nodeClient.WithContext(ctx)}
+	if client.faucetClient != nil {
+		bound.faucetClient = client.faucetClient.WithContext(ctx)
+	}
+	if client.indexerClient != nil {
+		bound.indexerClient = client.indexerClient.WithContext(ctx)
+	}
+	return bound
+}
+
+// WithLogger returns a copy of the client which logs every request to the node, including for the faucet, at debug
+// level, see [NodeClient.WithLogger].  A nil logger turns off logging.
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+//	client = client.WithLogger(logger)
+func (client *Client) WithLogger(logger *slog.Logger) *Client {
+	bound := &Client{nodeClient: client.nodeClient.WithLogger(logger), indexerClient: client.indexerClient}
+	if client.faucetClient != nil {
+		bound.faucetClient = &FaucetClient{
+			nodeClient: client.faucetClient.nodeClient.WithLogger(logger),
+			url:        client.faucetClient.url,
+		}
+	}
+	return bound
+}
+
+// NewClientForNetwork Creates a new client for a preconfigured network, with the fullnode, indexer, and faucet URLs
+// and chain ID of the network
+//
+//	client, err := NewClientForNetwork(Testnet)
+//	client, err := NewClientForNetwork(Devnet, NodeUrlOverride("https://my-devnet-node.example.com/v1"))
+//
+// Accepts options:
+//   - [NodeUrlOverride], [IndexerUrlOverride], [FaucetUrlOverride], and [ChainIdOverride] to override the preset
+//   - any option accepted by [NewClient]
+func NewClientForNetwork(network Network, options ...any) (client *Client, err error) {
+	config, err := network.Config()
+	if err != nil {
+		return nil, err
+	}
+	clientOptions := make([]any, 0, len(options))
+	for _, arg := range options {
+		switch value := arg.(type) {
+		case NodeUrlOverride:
+			config.NodeUrl = string(value)
+		case IndexerUrlOverride:
+			config.IndexerUrl = string(value)
+		case FaucetUrlOverride:
+			config.FaucetUrl = string(value)
+		case ChainIdOverride:
+			config.ChainId = uint8(value)
+		default:
+			clientOptions = append(clientOptions, arg)
+		}
+	}
+	return NewClient(config, clientOptions...)
+}
+
 // SetTimeout adjusts the HTTP client timeout
 //
 //	client.SetTimeout(5 * time.Millisecond)
@@ -496,6 +938,38 @@ func (client *Client) SetTimeout(timeout time.Duration) {
 	client.nodeClient.SetTimeout(timeout)
 }
 
+// SetRequestTimeout sets a timeout on each request to the node, 0 or less for no timeout, see
+// [NodeClient.SetRequestTimeout]
+//
+//	client.SetRequestTimeout(5 * time.Second)
+func (client *Client) SetRequestTimeout(timeout time.Duration) {
+	client.nodeClient.SetRequestTimeout(timeout)
+}
+
+// SetGzipRequests gzip compresses request bodies of at least minSize bytes, 0 or less to not compress, see
+// [NodeClient.SetGzipRequests]
+//
+//	client.SetGzipRequests(DefaultGzipRequestMinSize)
+func (client *Client) SetGzipRequests(minSize int64) {
+	client.nodeClient.SetGzipRequests(minSize)
+}
+
+// SetLedgerExpiration sets transactions built to expire d after the ledger's timestamp, by default
+// [DefaultLedgerExpiration], 0 to expire after the local clock, see [NodeClient.SetLedgerExpiration]
+//
+//	client.SetLedgerExpiration(time.Minute)
+func (client *Client) SetLedgerExpiration(d time.Duration) {
+	client.nodeClient.SetLedgerExpiration(d)
+}
+
+// DefaultExpiration returns the expiration timestamp in seconds for a transaction to expire d after the ledger's
+// timestamp, see [NodeClient.DefaultExpiration]
+//
+//	expiration, err := client.DefaultExpiration(DefaultLedgerExpiration)
+func (client *Client) DefaultExpiration(d time.Duration) (uint64, error) {
+	return client.nodeClient.DefaultExpiration(d)
+}
+
 // SetHeader sets the header for all future requests
 //
 //	client.SetHeader("Authorization", "Bearer abcde")
@@ -515,11 +989,31 @@ func (client *Client) Info() (info NodeInfo, err error) {
 	return client.nodeClient.Info()
 }
 
+// LedgerInfo Retrieves the current state of the blockchain, with numeric fields parsed
+//
+//	info, _ := client.LedgerInfo()
+//	latestVersion := info.LedgerVersion.ToUint64()
+func (client *Client) LedgerInfo() (info *api.LedgerInfo, err error) {
+	return client.nodeClient.LedgerInfo()
+}
+
 // Account Retrieves information about the account such as [SequenceNumber] and [crypto.AuthenticationKey]
 func (client *Client) Account(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
 	return client.nodeClient.Account(address, ledgerVersion...)
 }
 
+// AccountCtx is [Client.Account], but the request is bound to the context
+func (client *Client) AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
+	return client.nodeClient.AccountCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountExists Checks whether the account exists on chain, returning false with no error if it doesn't
+//
+//	exists, err := client.AccountExists(address)
+func (client *Client) AccountExists(address AccountAddress) (bool, error) {
+	return client.nodeClient.AccountExists(address)
+}
+
 // AccountResource Retrieves a single resource given its struct name.
 //
 //	address := AccountOne
@@ -533,6 +1027,22 @@ func (client *Client) AccountResource(address AccountAddress, resourceType strin
 	return client.nodeClient.AccountResource(address, resourceType, ledgerVersion...)
 }
 
+// AccountResourceCtx is [Client.AccountResource], but the request is bound to the context
+func (client *Client) AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
+	return client.nodeClient.AccountResourceCtx(ctx, address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceBCS Retrieves a single resource given its struct name, as the BCS bytes of the Move struct.
+//
+//	data, _ := client.AccountResourceBCS(address, "0x1::account::Account")
+//
+// Can also be called with a specific ledger version
+//
+//	data, _ := client.AccountResourceBCS(address, "0x1::account::Account", 1)
+func (client *Client) AccountResourceBCS(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data []byte, err error) {
+	return client.nodeClient.AccountResourceBCS(address, resourceType, ledgerVersion...)
+}
+
 // AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 // For fetching raw Move structs as BCS, See #AccountResourcesBCS
 //
@@ -543,15 +1053,87 @@ func (client *Client) AccountResource(address AccountAddress, resourceType strin
 //
 //	address := AccountOne
 //	dataMap, _ := client.AccountResource(address, 1)
+//
+// Every page of resources is fetched and merged, to cap the number fetched, see [Client.AccountResourcesUpTo]
 func (client *Client) AccountResources(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
 	return client.nodeClient.AccountResources(address, ledgerVersion...)
 }
 
+// AccountResourcesCtx is [Client.AccountResources], but the requests are bound to the context
+func (client *Client) AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	return client.nodeClient.AccountResourcesCtx(ctx, address, ledgerVersion...)
+}
+
+// AccountResourcesUpTo fetches resources for an account the same as [Client.AccountResources], but stops once
+// maxResources have been fetched.  If maxResources is 0 or less, all resources are fetched.
+//
+//	resources, _ := client.AccountResourcesUpTo(address, 100)
+func (client *Client) AccountResourcesUpTo(address AccountAddress, maxResources int, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	return client.nodeClient.AccountResourcesUpTo(address, maxResources, ledgerVersion...)
+}
+
 // AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
 func (client *Client) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
 	return client.nodeClient.AccountResourcesBCS(address, ledgerVersion...)
 }
 
+// AccountModule fetches a single module for an account by name, with its bytecode and ABI
+//
+//	module, _ := client.AccountModule(AccountOne, "coin")
+//	balance := module.Abi.Function("balance")
+//
+// Can also fetch at a specific ledger version
+//
+//	module, _ := client.AccountModule(AccountOne, "coin", 1)
+func (client *Client) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (module *api.MoveBytecode, err error) {
+	return client.nodeClient.AccountModule(address, moduleName, ledgerVersion...)
+}
+
+// AccountModules fetches all modules for an account, with their bytecode and ABIs
+//
+//	modules, _ := client.AccountModules(AccountOne)
+func (client *Client) AccountModules(address AccountAddress, ledgerVersion ...uint64) (modules []*api.MoveBytecode, err error) {
+	return client.nodeClient.AccountModules(address, ledgerVersion...)
+}
+
+// FrameworkModules fetches the ABIs of all the framework modules at 0x1 by module name, cached for the life of the
+// client, see [NodeClient.FrameworkModules]
+//
+//	modules, _ := client.FrameworkModules()
+//	transfer := modules["aptos_account"].Function("transfer")
+func (client *Client) FrameworkModules() (modules map[string]*api.MoveModule, err error) {
+	return client.nodeClient.FrameworkModules()
+}
+
+// AccountResourcesBatch fetches multiple resources for an account concurrently, in the same order as resourceTypes
+//
+// Failures for individual resources are returned as a single joined error.
+//
+//	address := AccountOne
+//	resources, _ := client.AccountResourcesBatch(address, []string{"0x1::account::Account", "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>"})
+//
+// Can also limit the number of concurrent requests, and return successful resources on partial failure
+//
+//	resources, err := client.AccountResourcesBatch(address, resourceTypes, BatchWorkers(4), PartialResults(true))
+func (client *Client) AccountResourcesBatch(address AccountAddress, resourceTypes []string, options ...any) (resources []AccountResourceInfo, err error) {
+	return client.nodeClient.AccountResourcesBatch(address, resourceTypes, options...)
+}
+
+// GetTableItem fetches a table item by its key, and decodes the JSON value into out
+//
+//	var balance api.U64
+//	err := client.GetTableItem(handle, "address", "u64", AccountOne, &balance)
+func (client *Client) GetTableItem(handle string, keyType string, valueType string, key any, out any, ledgerVersion ...uint64) error {
+	return client.nodeClient.GetTableItem(handle, keyType, valueType, key, out, ledgerVersion...)
+}
+
+// GetTableItemRaw fetches the BCS bytes of a table item by the BCS bytes of its key
+//
+//	valueBytes, err := client.GetTableItemRaw(handle, keyBytes)
+func (client *Client) GetTableItemRaw(handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	return client.nodeClient.GetTableItemRaw(handle, key, ledgerVersion...)
+}
+
 // BlockByHeight fetches a block by height
 //
 //	block, _ := client.BlockByHeight(1, false)
@@ -579,20 +1161,23 @@ func (client *Client) BlockByVersion(ledgerVersion uint64, withTransactions bool
 //
 //	data, err := client.TransactionByHash("0xabcd")
 //	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-//			}
-//		}
-//	} else {
-//		if data["type"] == "pending_transaction" {
-//			// known to local mempool, but not committed yet
+//		if IsNotFound(err) {
+//			// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
 //		}
+//	} else if data.IsPending() {
+//		// known to local mempool, but not committed yet
+//	} else if !data.IsSuccess() {
+//		// committed, but failed
 //	}
 func (client *Client) TransactionByHash(txnHash string) (data *api.Transaction, err error) {
 	return client.nodeClient.TransactionByHash(txnHash)
 }
 
+// TransactionByHashCtx is [Client.TransactionByHash], but the request is bound to the context
+func (client *Client) TransactionByHashCtx(ctx context.Context, txnHash string) (data *api.Transaction, err error) {
+	return client.nodeClient.TransactionByHashCtx(ctx, txnHash)
+}
+
 // TransactionByVersion gets info on a transaction from its LedgerVersion.  It must have been
 // committed to have a ledger version
 //
@@ -608,6 +1193,11 @@ func (client *Client) TransactionByVersion(version uint64) (data *api.CommittedT
 	return client.nodeClient.TransactionByVersion(version)
 }
 
+// TransactionByVersionCtx is [Client.TransactionByVersion], but the request is bound to the context
+func (client *Client) TransactionByVersionCtx(ctx context.Context, version uint64) (data *api.CommittedTransaction, err error) {
+	return client.nodeClient.TransactionByVersionCtx(ctx, version)
+}
+
 // PollForTransactions Waits up to 10 seconds for transactions to be done, polling at 10Hz
 // Accepts options PollPeriod and PollTimeout which should wrap time.Duration values.
 //
@@ -629,6 +1219,38 @@ func (client *Client) WaitForTransaction(txnHash string, options ...any) (data *
 	return client.nodeClient.WaitForTransaction(txnHash, options...)
 }
 
+// WaitForTransactionByHash polls for a transaction until it is committed, the timeout expires, or the context is
+// cancelled.  If the transaction was committed but failed, it is returned with a [TransactionFailedError].
+//
+//	txn, err := client.WaitForTransactionByHash(ctx, "0x1234", PollPeriod(500 * time.Millisecond), PollTimeout(30 * time.Second))
+func (client *Client) WaitForTransactionByHash(ctx context.Context, hash string, opts ...WaitOption) (*api.Transaction, error) {
+	return client.nodeClient.WaitForTransactionByHash(ctx, hash, opts...)
+}
+
+// EventsByCreationNumber returns an [EventIterator] over the V1 events of an event handle, which pages through the
+// node as needed
+//
+//	events, err := client.EventsByCreationNumber(address, 2).Collect(ctx, 1000)
+func (client *Client) EventsByCreationNumber(address AccountAddress, creationNumber uint64, opts ...PageOption) *EventIterator {
+	return client.nodeClient.EventsByCreationNumber(address, creationNumber, opts...)
+}
+
+// TransactionsByVersionRange returns a [TransactionRangeIterator] over the committed transactions from the start
+// version, up to but not including the end version, chunked by the node's page size
+//
+//	txns, err := client.TransactionsByVersionRange(1000, 2000).All(ctx)
+func (client *Client) TransactionsByVersionRange(start uint64, end uint64) *TransactionRangeIterator {
+	return client.nodeClient.TransactionsByVersionRange(start, end)
+}
+
+// AccountTransactionsIterator returns an [AccountTransactionIterator] over the transactions sent by the account, which
+// pages through the node as needed
+//
+//	txns, err := client.AccountTransactionsIterator(address, PageConcurrency(4)).CollectAll(ctx)
+func (client *Client) AccountTransactionsIterator(address AccountAddress, opts ...PageOption) *AccountTransactionIterator {
+	return client.nodeClient.AccountTransactionsIterator(address, opts...)
+}
+
 // Transactions Get recent transactions.
 // Start is a version number. Nil for most recent transactions.
 // Limit is a number of transactions to return. 'about a hundred' by default.
@@ -639,6 +1261,11 @@ func (client *Client) Transactions(start *uint64, limit *uint64) (data []*api.Co
 	return client.nodeClient.Transactions(start, limit)
 }
 
+// TransactionsCtx is [Client.Transactions], but the requests are bound to the context
+func (client *Client) TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error) {
+	return client.nodeClient.TransactionsCtx(ctx, start, limit)
+}
+
 // AccountTransactions Get transactions associated with an account.
 // Start is a version number. Nil for most recent transactions.
 // Limit is a number of transactions to return. 'about a hundred' by default.
@@ -673,6 +1300,35 @@ func (client *Client) SubmitTransaction(signedTransaction *SignedTransaction) (d
 	return client.nodeClient.SubmitTransaction(signedTransaction)
 }
 
+// SubmitTransactionCtx is [Client.SubmitTransaction], but the request is bound to the context
+func (client *Client) SubmitTransactionCtx(ctx context.Context, signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.SubmitTransactionCtx(ctx, signedTransaction)
+}
+
+// SubmitTransactionBCS Submits an already signed and BCS serialized transaction to the blockchain
+//
+// This is useful for transactions signed offline, or outside the SDK
+//
+//	signedTxnBytes, _ := bcs.Serialize(signedTxn)
+//	submitResponse, err := client.SubmitTransactionBCS(signedTxnBytes)
+func (client *Client) SubmitTransactionBCS(signedTxn []byte) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.SubmitTransactionBCS(signedTxn)
+}
+
+// SubmitTransactionBCSCtx is [Client.SubmitTransactionBCS], but the request is bound to the context
+func (client *Client) SubmitTransactionBCSCtx(ctx context.Context, signedTxn []byte) (data *api.SubmitTransactionResponse, err error) {
+	return client.nodeClient.SubmitTransactionBCSCtx(ctx, signedTxn)
+}
+
+// SubmitTransactionBCSResponse Submits an already signed and BCS serialized transaction to the blockchain, and
+// returns the raw BCS response rather than parsing a JSON response
+//
+//	signedTxnBytes, _ := bcs.Serialize(signedTxn)
+//	responseBytes, err := client.SubmitTransactionBCSResponse(signedTxnBytes)
+func (client *Client) SubmitTransactionBCSResponse(signedTxn []byte) (response []byte, err error) {
+	return client.nodeClient.SubmitTransactionBCSResponse(signedTxn)
+}
+
 // BatchSubmitTransaction submits a collection of signed transactions to the network in a single request
 //
 // It will return the responses in the same order as the input transactions that failed.  If the response is empty, then
@@ -723,17 +1379,97 @@ func (client *Client) SimulateTransaction(rawTxn *RawTransaction, sender Transac
 	return client.nodeClient.SimulateTransaction(rawTxn, sender, options...)
 }
 
+// SimulateTransactionBCS Simulates an already BCS serialized transaction, signed with a simulation authenticator,
+// without sending it to the blockchain
+//
+//	signedTxn, _ := rawTxn.SignedTransactionWithAuthenticator(sender.SimulationAuthenticator())
+//	signedTxnBytes, _ := bcs.Serialize(signedTxn)
+//	simResponse, err := client.SimulateTransactionBCS(signedTxnBytes)
+func (client *Client) SimulateTransactionBCS(signedTxn []byte, options ...any) (data []*api.UserTransaction, err error) {
+	return client.nodeClient.SimulateTransactionBCS(signedTxn, options...)
+}
+
+// SimulateTransactionBCSResponse Simulates an already BCS serialized transaction, signed with a simulation
+// authenticator, and returns the raw BCS response rather than parsing a JSON response
+//
+//	signedTxnBytes, _ := bcs.Serialize(signedTxn)
+//	responseBytes, err := client.SimulateTransactionBCSResponse(signedTxnBytes)
+func (client *Client) SimulateTransactionBCSResponse(signedTxn []byte, options ...any) (response []byte, err error) {
+	return client.nodeClient.SimulateTransactionBCSResponse(signedTxn, options...)
+}
+
+// SimulateFeePayerTransaction Simulates a fee payer transaction signed only by the sender, before the fee payer signs,
+// to find the gas the fee payer would pay
+//
+//	sim, err := client.SimulateFeePayerTransaction(rawTxn, sender, EstimateGasUnitPrice(true), EstimateMaxGasAmount(true))
+//	fmt.Println("sponsor pays up to", sim.FeePayerOctas())
+func (client *Client) SimulateFeePayerTransaction(rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) (*FeePayerSimulation, error) {
+	return client.nodeClient.SimulateFeePayerTransaction(rawTxn, sender, options...)
+}
+
+// SimulatePayload Builds a transaction for the payload, and simulates it without sending it to the blockchain.  The gas
+// unit price and max gas amount are estimated by the node unless provided.
+//
+//	simTxn, err := client.SimulatePayload(sender, txnPayload)
+//	if err == nil && !simTxn.Success {
+//		fmt.Println("simulation failed:", simTxn.VmStatus)
+//	}
+//	simTxn, err = client.SimulatePayload(sender, txnPayload, MaxGasAmount(2000), GasUnitPrice(100))
+func (client *Client) SimulatePayload(sender TransactionSigner, payload TransactionPayload, options ...any) (data *api.UserTransaction, err error) {
+	return client.nodeClient.SimulatePayload(sender, payload, options...)
+}
+
+// BuildAndSimulate Builds a transaction for the payload, simulates it, and sets the max gas amount to the simulated gas
+// used times a [GasBuffer], default 1.5.  A failed simulation returns a [SimulationFailedError].
+//
+//	rawTxn, err := client.BuildAndSimulate(sender, txnPayload)
+//	signedTxn, err := rawTxn.SignedTransaction(sender)
+func (client *Client) BuildAndSimulate(sender TransactionSigner, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error) {
+	return client.nodeClient.BuildAndSimulate(sender, payload, options...)
+}
+
 // GetChainId Retrieves the ChainId of the network
-// Note this will be cached forever, or taken directly from the config
+// Note this will be cached forever for the node URL, or taken directly from the config
 func (client *Client) GetChainId() (chainId uint8, err error) {
 	return client.nodeClient.GetChainId()
 }
 
+// SetChainId overrides the ChainId used to build transactions, 0 to use the ChainId of the node, see
+// [NodeClient.SetChainId]
+//
+//	client.SetChainId(4)
+func (client *Client) SetChainId(chainId uint8) {
+	client.nodeClient.SetChainId(chainId)
+}
+
 // Fund Uses the faucet to fund an address, only applies to non-production networks
 func (client *Client) Fund(address AccountAddress, amount uint64) error {
 	return client.faucetClient.Fund(address, amount)
 }
 
+// FundTransactions Uses the faucet to fund an address, and returns the hashes of the funding transactions.  By default,
+// it waits for them to be committed.
+//
+//	txnHashes, err := client.FundTransactions(address, 100_000_000, FaucetWait(false))
+func (client *Client) FundTransactions(address AccountAddress, amount uint64, options ...any) (txnHashes []string, err error) {
+	return client.faucetClient.FundTransactions(address, amount, options...)
+}
+
+// CreateAndFund Generates a new Ed25519 account, and uses the faucet to fund it
+//
+//	account, err := client.CreateAndFund(100_000_000)
+func (client *Client) CreateAndFund(amount uint64, options ...any) (*Account, error) {
+	return client.faucetClient.CreateAndFund(amount, options...)
+}
+
+// CreateAndFundMany Generates many new Ed25519 accounts, and uses the faucet to fund them concurrently.  The funded
+// accounts are returned even if some fail, see [FaucetClient.CreateAndFundMany].
+//
+//	accounts, err := client.CreateAndFundMany(ctx, 100, 100_000_000, BatchWorkers(4))
+func (client *Client) CreateAndFundMany(ctx context.Context, count int, amount uint64, options ...any) ([]*Account, error) {
+	return client.faucetClient.CreateAndFundMany(ctx, count, amount, options...)
+}
+
 // BuildTransaction Builds a raw transaction from the payload and fetches any necessary information from on-chain
 //
 //	sender := NewEd25519Account()
@@ -778,6 +1514,51 @@ func (client *Client) BuildTransactionMultiAgent(sender AccountAddress, payload
 	return client.nodeClient.BuildTransactionMultiAgent(sender, payload, options...)
 }
 
+// BuildFeePayerTransaction Builds a raw transaction for signing by the sender and a fee payer.  The fee payer can be
+// [AccountZero] if it isn't known when the sender signs.  Accepts the same options as
+// [Client.BuildTransactionMultiAgent], other than [FeePayer].
+//
+//	rawTxn, err := client.BuildFeePayerTransaction(sender.Address, payload, sponsor.Address)
+//	signedTxn, err := rawTxn.FeePayerSignedTransaction(sender, sponsor)
+func (client *Client) BuildFeePayerTransaction(sender AccountAddress, payload TransactionPayload, feePayer AccountAddress, options ...any) (rawTxn *RawTransactionWithData, err error) {
+	return client.nodeClient.BuildFeePayerTransaction(sender, payload, feePayer, options...)
+}
+
+// BuildMultiAgentTransaction Builds a raw transaction for signing by the sender and the secondary signers.  Accepts the
+// same options as [Client.BuildTransactionMultiAgent], other than [FeePayer] and [AdditionalSigners].
+//
+//	rawTxn, err := client.BuildMultiAgentTransaction(sender.Address, []AccountAddress{receiver.Address}, payload)
+//	signedTxn, err := rawTxn.MultiAgentSignedTransaction(sender, receiver)
+func (client *Client) BuildMultiAgentTransaction(sender AccountAddress, secondarySigners []AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransactionWithData, err error) {
+	return client.nodeClient.BuildMultiAgentTransaction(sender, secondarySigners, payload, options...)
+}
+
+// BuildKeyRotation Builds a transaction rotating the account's authentication key to the new key, with the rotation
+// proof challenge signed by both keys.  Accepts the same options as [BuildTransaction].
+//
+//	rawTxn, err := client.BuildKeyRotation(account, newKey)
+//	signedTxn, err := rawTxn.SignedTransaction(account)
+func (client *Client) BuildKeyRotation(account *Account, newKey crypto.Signer, options ...any) (rawTxn *RawTransaction, err error) {
+	return client.nodeClient.BuildKeyRotation(account, newKey, options...)
+}
+
+// AccountAuthKeyMatches tells whether the account's on-chain authentication key is derived from the public key, false
+// if it has been rotated to another key, see [NodeClient.AccountAuthKeyMatches]
+//
+//	matches, err := client.AccountAuthKeyMatches(account.Address, account.PubKey())
+func (client *Client) AccountAuthKeyMatches(address AccountAddress, publicKey crypto.PublicKey) (bool, error) {
+	return client.nodeClient.AccountAuthKeyMatches(address, publicKey)
+}
+
+// NewSequenceNumberManager Creates a [SequenceNumberManager] for the account, to hand out sequence numbers for
+// concurrent transactions without fetching them each time.  It can be passed as an option to BuildTransaction.
+//
+//	manager := client.NewSequenceNumberManager(sender.Address)
+//	rawTxn, err := client.BuildTransaction(sender.Address, payload, manager)
+func (client *Client) NewSequenceNumberManager(address AccountAddress) *SequenceNumberManager {
+	return client.nodeClient.NewSequenceNumberManager(address)
+}
+
 // BuildSignAndSubmitTransaction Convenience function to do all three in one
 // for more configuration, please use them separately
 //
@@ -819,7 +1600,26 @@ func (client *Client) View(payload *ViewPayload, ledgerVersion ...uint64) (vals
 	return client.nodeClient.View(payload, ledgerVersion...)
 }
 
-// EstimateGasPrice Retrieves the gas estimate from the network.
+// ViewCtx is [Client.View], but the request is bound to the context
+func (client *Client) ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) (vals []any, err error) {
+	return client.nodeClient.ViewCtx(ctx, payload, ledgerVersion...)
+}
+
+// ViewJson Runs a view function on chain with JSON arguments, see [NodeClient.ViewJson]
+//
+//	vals, err := client.ViewJson("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne})
+func (client *Client) ViewJson(function string, typeArgs []string, args []any, ledgerVersion ...uint64) (vals []any, err error) {
+	return client.nodeClient.ViewJson(function, typeArgs, args, ledgerVersion...)
+}
+
+// ViewBatch Runs many view functions concurrently, with a result or error for each in order, see [NodeClient.ViewBatch]
+//
+//	results, err := client.ViewBatch(calls, BatchWorkers(16))
+func (client *Client) ViewBatch(calls []ViewRequest, options ...any) (results []ViewResult, err error) {
+	return client.nodeClient.ViewBatch(calls, options...)
+}
+
+// EstimateGasPrice Retrieves the gas estimate from the network, cached for [DefaultGasEstimateCacheTTL] by default.
 func (client *Client) EstimateGasPrice() (info EstimateGasInfo, err error) {
 	return client.nodeClient.EstimateGasPrice()
 }
@@ -859,6 +1659,19 @@ func (client *Client) QueryIndexer(query any, variables map[string]any, options
 	return client.indexerClient.Query(query, variables, options...)
 }
 
+// ExecIndexerQuery runs a GraphQL query string against the indexer, and unmarshals the data into out.  GraphQL errors are
+// returned as [GraphQLErrors], with any partial data still unmarshalled into out.
+//
+//	var out struct {
+//		ProcessorStatus []struct {
+//			LastSuccessVersion uint64 `json:"last_success_version"`
+//		} `json:"processor_status"`
+//	}
+//	err := client.ExecIndexerQuery(ctx, `query { processor_status { last_success_version } }`, nil, &out)
+func (client *Client) ExecIndexerQuery(ctx context.Context, query string, variables map[string]any, out any) error {
+	return client.indexerClient.ExecQuery(ctx, query, variables, out)
+}
+
 // GetProcessorStatus returns the ledger version up to which the processor has processed
 func (client *Client) GetProcessorStatus(processorName string) (uint64, error) {
 	return client.indexerClient.GetProcessorStatus(processorName)
@@ -869,6 +1682,14 @@ func (client *Client) GetCoinBalances(address AccountAddress) ([]CoinBalance, er
 	return client.indexerClient.GetCoinBalances(address)
 }
 
+// GetFungibleAssetBalances gets the current fungible asset balances of an address, optionally only for the asset types
+//
+//	balances, err := client.GetFungibleAssetBalances(address, []string{"0xa"})
+//	balances, err = client.GetFungibleAssetBalances(address, nil, IndexerOffset(100), IndexerLimit(100))
+func (client *Client) GetFungibleAssetBalances(owner AccountAddress, assetTypes []string, options ...any) ([]FABalance, error) {
+	return client.indexerClient.GetFungibleAssetBalances(owner, assetTypes, options...)
+}
+
 // NodeAPIHealthCheck checks if the node is within durationSecs of the current time, if not provided the node default is used
 func (client *Client) NodeAPIHealthCheck(durationSecs ...uint64) (api.HealthCheckResponse, error) {
 	return client.nodeClient.NodeHealthCheck(durationSecs...)
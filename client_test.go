@@ -51,12 +51,10 @@ func initSigners() {
 		signer, err := NewMultiKeyTestSigner(32, 5)
 		return any(signer).(TransactionSigner), err
 	}
-	/* TODO: MultiEd25519 is not supported ATM
-	TestSigners["MultiEd25519"] = func() (TransactionSigner, error) {
+	TestSigners["2-of-3 MultiEd25519"] = func() (TransactionSigner, error) {
 		signer, err := NewMultiEd25519Signer(3, 2)
 		return any(signer).(TransactionSigner), err
 	}
-	*/
 }
 
 func initSingleSignerPayloads() {
@@ -72,6 +70,67 @@ func TestNamedConfig(t *testing.T) {
 	}
 }
 
+func TestNetworkConfig(t *testing.T) {
+	tests := map[Network]struct {
+		chainId    uint8
+		nodeUrl    string
+		indexerUrl string
+		faucetUrl  string
+	}{
+		Mainnet:  {1, "https://api.mainnet.aptoslabs.com/v1", "https://api.mainnet.aptoslabs.com/v1/graphql", ""},
+		Testnet:  {2, "https://api.testnet.aptoslabs.com/v1", "https://api.testnet.aptoslabs.com/v1/graphql", "https://faucet.testnet.aptoslabs.com/"},
+		Devnet:   {0, "https://api.devnet.aptoslabs.com/v1", "https://api.devnet.aptoslabs.com/v1/graphql", "https://faucet.devnet.aptoslabs.com/"},
+		Localnet: {4, "http://127.0.0.1:8080/v1", "http://127.0.0.1:8090/v1/graphql", "http://127.0.0.1:8081"},
+	}
+	for network, expected := range tests {
+		t.Run(string(network), func(t *testing.T) {
+			config, err := network.Config()
+			assert.NoError(t, err)
+			assert.Equal(t, string(network), config.Name)
+			assert.Equal(t, expected.chainId, config.ChainId)
+			assert.Equal(t, expected.nodeUrl, config.NodeUrl)
+			assert.Equal(t, expected.indexerUrl, config.IndexerUrl)
+			assert.Equal(t, expected.faucetUrl, config.FaucetUrl)
+		})
+	}
+
+	_, err := Network("betanet").Config()
+	assert.ErrorContains(t, err, "unknown network betanet")
+}
+
+func TestNewClientForNetwork(t *testing.T) {
+	client, err := NewClientForNetwork(Mainnet)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.mainnet.aptoslabs.com/v1", client.nodeClient.baseUrl.String())
+	assert.Equal(t, "https://api.mainnet.aptoslabs.com/v1/graphql", client.indexerClient.url)
+	assert.Nil(t, client.faucetClient)
+	chainId, err := client.GetChainId()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), chainId)
+
+	// The URLs can be overridden, keeping the rest of the preset
+	client, err = NewClientForNetwork(Testnet, NodeUrlOverride("http://127.0.0.1:1234/v1"), FaucetUrlOverride("http://127.0.0.1:1235"), DefaultRetryPolicy())
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:1234/v1", client.nodeClient.baseUrl.String())
+	assert.Equal(t, "https://api.testnet.aptoslabs.com/v1/graphql", client.indexerClient.url)
+	assert.Equal(t, "http://127.0.0.1:1235", client.faucetClient.url.String())
+	chainId, err = client.GetChainId()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), chainId)
+
+	client, err = NewClientForNetwork(Localnet, IndexerUrlOverride(""), ChainIdOverride(9))
+	assert.NoError(t, err)
+	assert.Nil(t, client.indexerClient)
+	chainId, err = client.GetChainId()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(9), chainId)
+
+	_, err = NewClientForNetwork(Network("betanet"))
+	assert.Error(t, err)
+	_, err = NewClientForNetwork(Testnet, "bad")
+	assert.ErrorContains(t, err, "bad type string")
+}
+
 func TestAptosClientHeaderValue(t *testing.T) {
 	assert.Greater(t, len(ClientHeaderValue), 0)
 	assert.NotEqual(t, "aptos-go-sdk/unk", ClientHeaderValue)
@@ -138,7 +197,7 @@ func testTransaction(t *testing.T, createAccount CreateSigner, buildTransaction
 	result, err := client.SubmitTransaction(signedTxn)
 	assert.NoError(t, err)
 
-	hash := result.Hash
+	hash := result.Hash.String()
 
 	// Wait for the transaction
 	_, err = client.WaitForTransaction(hash)
@@ -369,7 +428,7 @@ func submitAccountTransaction(t *testing.T, client *Client, account *Account, se
 	assert.NoError(t, err)
 	txn, err := client.SubmitTransaction(signedTxn)
 	assert.NoError(t, err)
-	_, err = client.WaitForTransaction(txn.Hash)
+	_, err = client.WaitForTransaction(txn.Hash.String())
 	assert.NoError(t, err)
 }
 
@@ -516,7 +575,7 @@ func concurrentTxnWaiter(
 		responseCount++
 		assert.NoError(t, response.Err)
 
-		waitResponse, err := client.WaitForTransaction(response.Response.Hash, PollTimeout(21*time.Second))
+		waitResponse, err := client.WaitForTransaction(response.Response.Hash.String(), PollTimeout(21*time.Second))
 		if err != nil {
 			t.Logf("%s err %s", response.Response.Hash, err)
 		} else if waitResponse == nil {
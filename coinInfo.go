@@ -0,0 +1,89 @@
+package aptos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// commonCoinTypeTags are the coin types of common coins, by symbol, see [CoinTypeTagBySymbol]
+var commonCoinTypeTags = map[string]string{
+	"APT":  "0x1::aptos_coin::AptosCoin",
+	"USDC": "0xf22bede237a07e121b56d91a491eb7bcdfd1f5907926a9e58338f964a01b17fa::asset::USDC", // LayerZero bridged USDC on mainnet
+	"USDT": "0xf22bede237a07e121b56d91a491eb7bcdfd1f5907926a9e58338f964a01b17fa::asset::USDT", // LayerZero bridged USDT on mainnet
+	"WETH": "0xf22bede237a07e121b56d91a491eb7bcdfd1f5907926a9e58338f964a01b17fa::asset::WETH", // LayerZero bridged WETH on mainnet
+}
+
+// CoinTypeTagBySymbol returns the [TypeTag] of a common coin by its symbol, ignoring case, e.g. APT for
+// [AptosCoinTypeTag].  Other than APT, the coins are the mainnet deployments.
+//
+//	usdc, ok := CoinTypeTagBySymbol("USDC")
+func CoinTypeTagBySymbol(symbol string) (TypeTag, bool) {
+	typeStr, ok := commonCoinTypeTags[strings.ToUpper(symbol)]
+	if !ok {
+		return TypeTag{}, false
+	}
+	tag, err := ParseTypeTag(typeStr)
+	if err != nil {
+		// The registry is constant, so this can't happen
+		return TypeTag{}, false
+	}
+	return tag, true
+}
+
+// CoinInfoData is the metadata of a coin type, from the 0x1::coin::CoinInfo<T> resource, see [CoinInfo]
+type CoinInfoData struct {
+	CoinType string // CoinType is the type of the coin e.g. 0x1::aptos_coin::AptosCoin
+	Name     string // Name of the coin e.g. Aptos Coin
+	Symbol   string // Symbol of the coin e.g. APT
+	Decimals uint8  // Decimals is the number of decimal places of the coin e.g. 8 for APT, where 1 APT is 100000000
+}
+
+// FormatAmount formats an amount of the coin in its smallest unit as a decimal string, without trailing zeros e.g.
+// 150000000 of APT is "1.5"
+func (info *CoinInfoData) FormatAmount(amount uint64) string {
+	str := strconv.FormatUint(amount, 10)
+	if info.Decimals == 0 {
+		return str
+	}
+	decimals := int(info.Decimals)
+	if len(str) <= decimals {
+		str = strings.Repeat("0", decimals-len(str)+1) + str
+	}
+	whole := str[:len(str)-decimals]
+	fraction := strings.TrimRight(str[len(str)-decimals:], "0")
+	if fraction == "" {
+		return whole
+	}
+	return whole + "." + fraction
+}
+
+// CoinInfo fetches the name, symbol, and decimals of a coin type from its 0x1::coin::CoinInfo<T> resource, which is
+// stored at the address of the coin type
+//
+//	info, err := CoinInfo(client, AptosCoinTypeTag)
+//	fmt.Println(info.FormatAmount(balance), info.Symbol)
+//
+// Returns a [ResourceNotFoundError] if the coin type isn't initialized.
+func CoinInfo(client AccountResourceClient, coinType TypeTag) (*CoinInfoData, error) {
+	structTag, ok := coinType.Value.(*StructTag)
+	if !ok {
+		return nil, fmt.Errorf("coin type %s is not a struct", coinType.String())
+	}
+	type coinInfo struct {
+		Name     string `json:"name"`
+		Symbol   string `json:"symbol"`
+		Decimals uint8  `json:"decimals"`
+	}
+	coinTypeStr := structTag.String()
+	info, err := GetAccountResource[coinInfo](client, structTag.Address, "0x1::coin::CoinInfo<"+coinTypeStr+">")
+	if err != nil {
+		return nil, err
+	}
+	return &CoinInfoData{
+		CoinType: coinTypeStr,
+		Name:     info.Name,
+		Symbol:   info.Symbol,
+		Decimals: info.Decimals,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinTypeTagBySymbol(t *testing.T) {
+	tag, ok := CoinTypeTagBySymbol("APT")
+	assert.True(t, ok)
+	assert.Equal(t, AptosCoinTypeTag.String(), tag.String())
+
+	tag, ok = CoinTypeTagBySymbol("usdc")
+	assert.True(t, ok)
+	assert.Equal(t, "0xf22bede237a07e121b56d91a491eb7bcdfd1f5907926a9e58338f964a01b17fa::asset::USDC", tag.String())
+
+	_, ok = CoinTypeTagBySymbol("NOTACOIN")
+	assert.False(t, ok)
+}
+
+func TestCoinInfo(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/0x1/resource/0x1::coin::CoinInfo<0x1::aptos_coin::AptosCoin>":
+			// AptosCoin's CoinInfo on mainnet
+			_, _ = fmt.Fprint(w, `{
+				"type": "0x1::coin::CoinInfo<0x1::aptos_coin::AptosCoin>",
+				"data": {
+					"decimals": 8,
+					"name": "Aptos Coin",
+					"supply": {
+						"vec": [{
+							"aggregator": {"vec": [{"handle": "0x1b854694ae746cdbd8d44186ca4929b2b337df21d1c74633be19b2710552fdca", "key": "0x619dc29a0aac8fa146714058e8dd6d2d0f3bdf5f6331907bf91f3acd81e6935", "limit": "340282366920938463463374607431768211455"}]},
+							"integer": {"vec": []}
+						}]
+					},
+					"symbol": "APT"
+				}
+			}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"message":"Resource not found","error_code":"resource_not_found","vm_error_code":null}`)
+		}
+	})
+
+	info, err := CoinInfo(client, AptosCoinTypeTag)
+	assert.NoError(t, err)
+	assert.Equal(t, &CoinInfoData{
+		CoinType: "0x1::aptos_coin::AptosCoin",
+		Name:     "Aptos Coin",
+		Symbol:   "APT",
+		Decimals: 8,
+	}, info)
+
+	// An uninitialized coin
+	coinType, err := ParseTypeTag("0x1234::my_coin::MyCoin")
+	assert.NoError(t, err)
+	_, err = CoinInfo(client, coinType)
+	var notFound *ResourceNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	// Not a struct
+	_, err = CoinInfo(client, NewTypeTag(&U64Tag{}))
+	assert.Error(t, err)
+}
+
+func TestCoinInfoData_FormatAmount(t *testing.T) {
+	apt := &CoinInfoData{Decimals: 8}
+	assert.Equal(t, "1.5", apt.FormatAmount(150000000))
+	assert.Equal(t, "1", apt.FormatAmount(100000000))
+	assert.Equal(t, "0.00000001", apt.FormatAmount(1))
+	assert.Equal(t, "0", apt.FormatAmount(0))
+	assert.Equal(t, "184467440737.09551615", apt.FormatAmount(18446744073709551615))
+
+	noDecimals := &CoinInfoData{Decimals: 0}
+	assert.Equal(t, "12345", noDecimals.FormatAmount(12345))
+}
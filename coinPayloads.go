@@ -1,6 +1,10 @@
 package aptos
 
-import "github.com/aptos-labs/aptos-go-sdk/bcs"
+import (
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
 
 // CoinTransferPayload builds an EntryFunction payload for transferring coins
 //
@@ -91,3 +95,68 @@ func CoinBatchTransferPayload(coinType *TypeTag, dests []AccountAddress, amounts
 		}, nil
 	}
 }
+
+// BuildTransferCoins builds an [EntryFunction] payload for 0x1::coin::transfer<CoinType>, transferring coins of the
+// coin type to the recipient.  Unlike [CoinTransferPayload], this won't create the recipient account if it doesn't
+// exist, and the recipient must already be registered for the coin.
+//
+// Args:
+//   - sender is the [AccountAddress] that will sign the transaction, it's not part of the payload
+//   - recipient is the destination [AccountAddress]
+//   - amount is the amount of coins to transfer
+//   - coinType is the Move type of the coin e.g. 0x1::aptos_coin::AptosCoin
+//
+// Example:
+//
+//	payload, err := BuildTransferCoins(sender.Address, AccountTwo, 100, "0x1::aptos_coin::AptosCoin")
+func BuildTransferCoins(sender AccountAddress, recipient AccountAddress, amount uint64, coinType string) (payload *EntryFunction, err error) {
+	typeTag, err := ParseTypeTag(coinType)
+	if err != nil {
+		return nil, fmt.Errorf("coin type %s: %w", coinType, err)
+	}
+	return coinModuleTransferPayload(typeTag, recipient, amount)
+}
+
+// coinModuleTransferPayload builds the 0x1::coin::transfer payload for [BuildTransferCoins] and [BuildTransferAPT]
+func coinModuleTransferPayload(coinType TypeTag, recipient AccountAddress, amount uint64) (payload *EntryFunction, err error) {
+	amountBytes, err := bcs.SerializeU64(amount)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryFunction{
+		Module: ModuleId{
+			Address: AccountOne,
+			Name:    "coin",
+		},
+		Function: "transfer",
+		ArgTypes: []TypeTag{coinType},
+		Args: [][]byte{
+			recipient[:],
+			amountBytes,
+		},
+	}, nil
+}
+
+// BuildTransferAPT builds an [EntryFunction] payload for 0x1::coin::transfer<0x1::aptos_coin::AptosCoin>, see
+// [BuildTransferCoins]
+//
+// Args:
+//   - sender is the [AccountAddress] that will sign the transaction, it's not part of the payload
+//   - recipient is the destination [AccountAddress]
+//   - amount is the amount of octas to transfer
+func BuildTransferAPT(sender AccountAddress, recipient AccountAddress, amount uint64) (payload *EntryFunction, err error) {
+	return coinModuleTransferPayload(AptosCoinTypeTag, recipient, amount)
+}
+
+// BuildTransferFungibleAsset builds an [EntryFunction] payload for 0x1::primary_fungible_store::transfer, transferring
+// the fungible asset between the primary stores of the sender and recipient.  The recipient's primary store is created
+// if it doesn't exist.
+//
+// Args:
+//   - sender is the [AccountAddress] that will sign the transaction, it's not part of the payload
+//   - recipient is the destination [AccountAddress]
+//   - faMetadataAddress is the [AccountAddress] of the metadata for the fungible asset
+//   - amount is the amount of the fungible asset to transfer
+func BuildTransferFungibleAsset(sender AccountAddress, recipient AccountAddress, faMetadataAddress AccountAddress, amount uint64) (payload *EntryFunction, err error) {
+	return FungibleAssetPrimaryStoreTransferPayload(&faMetadataAddress, recipient, amount)
+}
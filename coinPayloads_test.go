@@ -0,0 +1,49 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTransferCoins(t *testing.T) {
+	payload, err := BuildTransferCoins(AccountOne, AccountTwo, 100, "0x1::aptos_coin::AptosCoin")
+	assert.NoError(t, err)
+	assert.Equal(t, ModuleId{Address: AccountOne, Name: "coin"}, payload.Module)
+	assert.Equal(t, "transfer", payload.Function)
+	assert.Len(t, payload.ArgTypes, 1)
+	assert.Equal(t, "0x1::aptos_coin::AptosCoin", payload.ArgTypes[0].String())
+	amountBytes, err := bcs.SerializeU64(100)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{AccountTwo[:], amountBytes}, payload.Args)
+
+	// Any coin type
+	payload, err = BuildTransferCoins(AccountOne, AccountTwo, 5, "0x1234::my_coin::MyCoin")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000001234::my_coin::MyCoin", payload.ArgTypes[0].String())
+
+	// A bad coin type
+	_, err = BuildTransferCoins(AccountOne, AccountTwo, 5, "not a type")
+	assert.Error(t, err)
+}
+
+func TestBuildTransferAPT(t *testing.T) {
+	payload, err := BuildTransferAPT(AccountOne, AccountTwo, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, ModuleId{Address: AccountOne, Name: "coin"}, payload.Module)
+	assert.Equal(t, []TypeTag{AptosCoinTypeTag}, payload.ArgTypes)
+	assert.Equal(t, "0x1::coin::transfer<0x1::aptos_coin::AptosCoin>(0x2, 100)", PayloadString(payload))
+}
+
+func TestBuildTransferFungibleAsset(t *testing.T) {
+	metadata := AccountTen
+	payload, err := BuildTransferFungibleAsset(AccountOne, AccountTwo, metadata, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, ModuleId{Address: AccountOne, Name: "primary_fungible_store"}, payload.Module)
+	assert.Equal(t, "transfer", payload.Function)
+	assert.Equal(t, []TypeTag{{Value: &StructTag{Address: AccountOne, Module: "fungible_asset", Name: "Metadata"}}}, payload.ArgTypes)
+	amountBytes, err := bcs.SerializeU64(100)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{metadata[:], AccountTwo[:], amountBytes}, payload.Args)
+}
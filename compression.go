@@ -0,0 +1,113 @@
+package aptos
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultGzipRequestMinSize is a reasonable minimum size of request bodies to compress with [WithGzipRequests], below
+// which compression isn't worth it
+const DefaultGzipRequestMinSize = 1024
+
+// GzipRequestsOption is an option to [NewClient] to gzip compress large request bodies, see [WithGzipRequests]
+type GzipRequestsOption struct {
+	MinSize int64
+}
+
+// WithGzipRequests is an option to [NewClient] to gzip compress request bodies of at least minSize bytes, e.g. large
+// transaction submissions and view requests, see [NodeClient.SetGzipRequests]
+//
+//	client, err := NewClient(config, WithGzipRequests(DefaultGzipRequestMinSize))
+func WithGzipRequests(minSize int64) GzipRequestsOption {
+	return GzipRequestsOption{MinSize: minSize}
+}
+
+// SetGzipRequests gzip compresses request bodies of at least minSize bytes for all future requests, 0 or less to not
+// compress.  Only use it if the node accepts Content-Encoding gzip, which isn't the default for fullnodes, e.g. behind
+// a proxy which decompresses requests.
+//
+// Responses are always accepted gzip compressed, and are decompressed transparently, even with a custom transport.
+func (rc *NodeClient) SetGzipRequests(minSize int64) {
+	rc.gzipRequestMinSize = minSize
+}
+
+// gzipRequest returns a copy of the request with the body gzip compressed, if it's at least minSize bytes.  The body
+// must be replayable, so it can be read here and again on a retry.
+func gzipRequest(req *http.Request, minSize int64) (*http.Request, error) {
+	if req.GetBody == nil || req.ContentLength < minSize || req.Header.Get("Content-Encoding") != "" {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	compressed := bytes.Buffer{}
+	writer := gzip.NewWriter(&compressed)
+	_, err = io.Copy(writer, body)
+	_ = body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	gzipped := req.Clone(req.Context())
+	compressedBytes := compressed.Bytes()
+	gzipped.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressedBytes)), nil
+	}
+	gzipped.Body, _ = gzipped.GetBody()
+	gzipped.ContentLength = int64(len(compressedBytes))
+	gzipped.Header.Set("Content-Encoding", "gzip")
+	gzipped.Header.Set("Content-Length", strconv.Itoa(len(compressedBytes)))
+	return gzipped, nil
+}
+
+// withGzipResponses wraps the send, so responses are accepted gzip compressed, and are decompressed as they're read.
+// Go's [http.Transport] does this itself, but other transports may not, and it stops once Accept-Encoding is set.
+func withGzipResponses(send sendFunc) sendFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("Accept-Encoding") == "" {
+			req = req.Clone(req.Context())
+			req.Header.Set("Accept-Encoding", "gzip")
+		}
+		response, err := send(req)
+		if err != nil || !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+			return response, err
+		}
+		response.Body = &gzipResponseBody{body: response.Body}
+		response.Header.Del("Content-Encoding")
+		response.Header.Del("Content-Length")
+		response.ContentLength = -1
+		response.Uncompressed = true
+		return response, nil
+	}
+}
+
+// gzipResponseBody decompresses a gzip response body as it's read
+type gzipResponseBody struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+	err    error
+}
+
+// Read reads decompressed bytes, the gzip header is read on the first read
+func (body *gzipResponseBody) Read(p []byte) (int, error) {
+	if body.reader == nil && body.err == nil {
+		body.reader, body.err = gzip.NewReader(body.body)
+	}
+	if body.err != nil {
+		return 0, body.err
+	}
+	return body.reader.Read(p)
+}
+
+// Close closes the underlying body
+func (body *gzipResponseBody) Close() error {
+	return body.body.Close()
+}
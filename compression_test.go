@@ -0,0 +1,158 @@
+package aptos
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeGzip writes the body gzip compressed, if the request accepts it
+func writeGzip(t *testing.T, w http.ResponseWriter, r *http.Request, status int, body string) {
+	if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		w.WriteHeader(status)
+		_, _ = fmt.Fprint(w, body)
+		return
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	w.WriteHeader(status)
+	writer := gzip.NewWriter(w)
+	_, err := writer.Write([]byte(body))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+}
+
+// newGzipServerClient creates a client against a mock server which gzip compresses its responses
+func newGzipServerClient(t *testing.T, options ...any) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		switch r.URL.Path {
+		case "/v1":
+			writeGzip(t, w, r, http.StatusOK, testNodeInfoJson)
+		case "/v1/accounts/" + AccountOne.String():
+			writeGzip(t, w, r, http.StatusOK, `{"sequence_number":"5","authentication_key":"`+AccountOne.StringLong()+`"}`)
+		default:
+			writeGzip(t, w, r, http.StatusNotFound, `{"message":"Account not found","error_code":"account_not_found","vm_error_code":null}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(NetworkConfig{Name: "mock", ChainId: 4, NodeUrl: server.URL + "/v1"}, options...)
+	assert.NoError(t, err)
+	return client
+}
+
+func TestGzipResponses(t *testing.T) {
+	// A transport that doesn't decompress responses itself
+	transport := NewDefaultTransport()
+	transport.DisableCompression = true
+	for name, client := range map[string]*Client{
+		"Default":   newGzipServerClient(t),
+		"Transport": newGzipServerClient(t, WithTransport(transport)),
+		"Timeout":   newGzipServerClient(t, WithRequestTimeout(5*time.Second)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			info, err := client.Info()
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(100), info.LedgerVersion())
+
+			account, err := client.Account(AccountOne)
+			assert.NoError(t, err)
+			sequenceNumber, err := account.SequenceNumber()
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(5), sequenceNumber)
+
+			// Errors are decompressed too
+			_, err = client.Account(AccountTwo)
+			assert.True(t, IsNotFound(err))
+			var httpErr *HttpError
+			assert.ErrorAs(t, err, &httpErr)
+			assert.Contains(t, string(httpErr.Body), "account_not_found")
+		})
+	}
+}
+
+func TestGzipResponses_Corrupt(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = fmt.Fprint(w, testNodeInfoJson)
+	})
+	_, err := client.Info()
+	assert.ErrorIs(t, err, gzip.ErrHeader)
+}
+
+// newGzipRequestServerClient creates a client against a mock server which responds to view requests, recording the
+// Content-Encoding and decompressed body of each request.  The first failures requests fail.
+func newGzipRequestServerClient(t *testing.T, minSize int64, failures int32) (*Client, *[]string, *[]string) {
+	var encodings, bodies []string
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/view", r.URL.Path)
+		encoding := r.Header.Get("Content-Encoding")
+		var reader io.Reader = r.Body
+		if encoding == "gzip" {
+			gzipReader, err := gzip.NewReader(r.Body)
+			assert.NoError(t, err)
+			reader = gzipReader
+		}
+		body, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.True(t, json.Valid(body))
+		encodings = append(encodings, encoding)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprint(w, `{"message":"unavailable","error_code":"internal_error","vm_error_code":null}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `["100"]`)
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(NetworkConfig{Name: "mock", ChainId: 4, NodeUrl: server.URL + "/v1"}, WithGzipRequests(minSize),
+		RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	assert.NoError(t, err)
+	return client, &encodings, &bodies
+}
+
+func TestGzipRequests(t *testing.T) {
+	client, encodings, bodies := newGzipRequestServerClient(t, 200, 0)
+
+	// Small requests aren't compressed
+	vals, err := client.ViewJson("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"100"}, vals)
+
+	// Large requests are
+	large := bytes.Repeat([]byte{0xab}, 200)
+	vals, err = client.ViewJson("0x1::hash::sha3_256", nil, []any{large})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"100"}, vals)
+
+	assert.Equal(t, []string{"", "gzip"}, *encodings)
+	assert.Contains(t, (*bodies)[1], "abababab")
+	assert.Equal(t, (*bodies)[0][:len(`{"function":"0x1::coin::balance"`)], `{"function":"0x1::coin::balance"`)
+
+	// It can be turned off
+	client.SetGzipRequests(0)
+	_, err = client.ViewJson("0x1::hash::sha3_256", nil, []any{large})
+	assert.NoError(t, err)
+	assert.Equal(t, "", (*encodings)[2])
+}
+
+func TestGzipRequests_Retried(t *testing.T) {
+	// The compressed body is sent again on a retry
+	client, encodings, bodies := newGzipRequestServerClient(t, 10, 1)
+	vals, err := client.ViewJson("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"100"}, vals)
+	assert.Equal(t, []string{"gzip", "gzip"}, *encodings)
+	assert.Equal(t, (*bodies)[0], (*bodies)[1])
+}
@@ -43,6 +43,39 @@ const AuthenticationKeyLength = 32
 //   - [bcs.Struct]
 type AuthenticationKey [AuthenticationKeyLength]byte
 
+// AuthKey derives the [AuthenticationKey] for any supported public key, appending the scheme byte for the key type to
+// the key's bytes before hashing with SHA3-256:
+//
+//   - [Ed25519PublicKey] uses [Ed25519Scheme], the legacy single Ed25519 account
+//   - [MultiEd25519PublicKey] uses [MultiEd25519Scheme]
+//   - [AnyPublicKey] uses [SingleKeyScheme]
+//   - [Secp256k1PublicKey] is wrapped in an [AnyPublicKey], and uses [SingleKeyScheme]
+//   - [MultiKey] uses [MultiKeyScheme]
+//
+// To derive the single key authentication key for an Ed25519 key, wrap it first with [ToAnyPublicKey].
+func AuthKey(publicKey VerifyingKey) (*AuthenticationKey, error) {
+	out := &AuthenticationKey{}
+	switch key := publicKey.(type) {
+	case *Ed25519PublicKey:
+		out.FromBytesAndScheme(key.Bytes(), Ed25519Scheme)
+	case *MultiEd25519PublicKey:
+		out.FromBytesAndScheme(key.Bytes(), MultiEd25519Scheme)
+	case *AnyPublicKey:
+		out.FromBytesAndScheme(key.Bytes(), SingleKeyScheme)
+	case *Secp256k1PublicKey:
+		anyKey, err := ToAnyPublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		out.FromBytesAndScheme(anyKey.Bytes(), SingleKeyScheme)
+	case *MultiKey:
+		out.FromBytesAndScheme(key.Bytes(), MultiKeyScheme)
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T for authentication key", publicKey)
+	}
+	return out, nil
+}
+
 // FromPublicKey for private / public key pairs, the [AuthenticationKey] is derived from the [PublicKey] directly
 func (ak *AuthenticationKey) FromPublicKey(publicKey PublicKey) {
 	ak.FromBytesAndScheme(publicKey.Bytes(), publicKey.Scheme())
@@ -57,6 +90,15 @@ func (ak *AuthenticationKey) FromBytesAndScheme(bytes []byte, scheme DeriveSchem
 	copy((*ak)[:], authBytes)
 }
 
+// AccountAddress returns the account address for the [AuthenticationKey].  An account's address is the authentication
+// key it was created with, and is assignable to an AccountAddress:
+//
+//	authKey, err := crypto.AuthKey(publicKey)
+//	address := aptos.AccountAddress(authKey.AccountAddress())
+func (ak *AuthenticationKey) AccountAddress() [AuthenticationKeyLength]byte {
+	return *ak
+}
+
 //region AuthenticationKey CryptoMaterial
 
 // Bytes returns the raw bytes of the [AuthenticationKey]
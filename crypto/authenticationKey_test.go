@@ -36,3 +36,64 @@ func TestAuthenticationKey_CryptoMaterialError(t *testing.T) {
 	err = authKey.FromHex("abcde")
 	assert.Error(t, err) // Not a string
 }
+
+func TestAuthKey_Schemes(t *testing.T) {
+	// Ed25519 and Secp256k1 vectors match the other SDKs
+	ed25519Key := &Ed25519PublicKey{}
+	err := ed25519Key.FromHex(testEd25519PublicKey)
+	assert.NoError(t, err)
+	secp256k1Key := &Secp256k1PublicKey{}
+	err = secp256k1Key.FromHex(testSecp256k1PublicKey)
+	assert.NoError(t, err)
+
+	ed25519Keys := make([]*Ed25519PublicKey, 3)
+	anyKeys := make([]*AnyPublicKey, 3)
+	for i, privateKeyHex := range []string{
+		"0x1111111111111111111111111111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333333333333333333333333333",
+	} {
+		privateKey := &Ed25519PrivateKey{}
+		err = privateKey.FromHex(privateKeyHex)
+		assert.NoError(t, err)
+		ed25519Keys[i] = privateKey.PubKey().(*Ed25519PublicKey)
+		anyKeys[i], err = ToAnyPublicKey(ed25519Keys[i])
+		assert.NoError(t, err)
+	}
+	anySecp256k1Key, err := ToAnyPublicKey(secp256k1Key)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		publicKey VerifyingKey
+		expected  string
+	}{
+		"ed25519":              {ed25519Key, testEd25519Address},
+		"ed25519 2":            {ed25519Keys[0], "0x147e4d3a5b10eaed2a93536e284c23096dfcea9ac61f0a8420e5d01fbd8f0ea8"},
+		"multi ed25519 2-of-3": {&MultiEd25519PublicKey{PubKeys: ed25519Keys, SignaturesRequired: 2}, "0x42d3a067f871adf376e26e4fad39f65b6710e79a47ea756856635d6529b52fe1"},
+		"single key ed25519":   {anyKeys[0], "0xc4c6cf75ac3530d31e455f8662df984159f5c71d9903a7202efc68f83775073a"},
+		"secp256k1":            {secp256k1Key, testSecp256k1Address},
+		"single key secp256k1": {anySecp256k1Key, testSecp256k1Address},
+		"multi key 2-of-3":     {&MultiKey{PubKeys: anyKeys, SignaturesRequired: 2}, "0x2b180ede366f8445d5ebe0ba5ba7e05a93bebc5289a00b57c2e56471f9045844"},
+		"multi key mixed":      {&MultiKey{PubKeys: []*AnyPublicKey{anyKeys[0], anySecp256k1Key, anyKeys[1]}, SignaturesRequired: 2}, "0x80467486a102a4149cf0d162a4b74d401738e6baecb81e82d588b3c506397281"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			authKey, err := AuthKey(test.publicKey)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, authKey.ToHex())
+
+			address := authKey.AccountAddress()
+			assert.Equal(t, test.expected, util.BytesToHex(address[:]))
+
+			// It agrees with the key's own derivation
+			if publicKey, ok := test.publicKey.(PublicKey); ok {
+				assert.Equal(t, publicKey.AuthKey(), authKey)
+			}
+		})
+	}
+}
+
+func TestAuthKey_Unsupported(t *testing.T) {
+	_, err := AuthKey(nil)
+	assert.ErrorContains(t, err, "unsupported public key type")
+}
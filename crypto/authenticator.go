@@ -13,6 +13,7 @@ import (
 //   - [MultiEd25519Authenticator]
 //   - [SingleKeyAuthenticator]
 //   - [MultiKeyAuthenticator]
+//   - [NoAccountAuthenticator]
 type AccountAuthenticatorImpl interface {
 	bcs.Struct
 
@@ -38,6 +39,7 @@ const (
 	AccountAuthenticatorMultiEd25519 AccountAuthenticatorType = 1 // AccountAuthenticatorMultiEd25519 is the authenticator type for multi-ed25519 accounts
 	AccountAuthenticatorSingleSender AccountAuthenticatorType = 2 // AccountAuthenticatorSingleSender is the authenticator type for single-key accounts
 	AccountAuthenticatorMultiKey     AccountAuthenticatorType = 3 // AccountAuthenticatorMultiKey is the authenticator type for multi-key accounts
+	AccountAuthenticatorNoAccount    AccountAuthenticatorType = 4 // AccountAuthenticatorNoAccount is the authenticator type for an unsigned account, only for simulation
 )
 
 // AccountAuthenticator a generic authenticator type for a transaction
@@ -101,6 +103,8 @@ func (ea *AccountAuthenticator) UnmarshalBCS(des *bcs.Deserializer) {
 		ea.Auth = &SingleKeyAuthenticator{}
 	case AccountAuthenticatorMultiKey:
 		ea.Auth = &MultiKeyAuthenticator{}
+	case AccountAuthenticatorNoAccount:
+		ea.Auth = &NoAccountAuthenticator{}
 	default:
 		des.SetError(fmt.Errorf("unknown AccountAuthenticator kind: %d", kindNum))
 		return
@@ -162,3 +166,68 @@ func (ea *AccountAuthenticator) FromKeyAndSignature(key PublicKey, sig Signature
 
 //endregion
 //endregion
+
+//region NoAccountAuthenticator
+
+// NoAccountAuthenticator is an authenticator with no public key or signature, for an account that hasn't signed.  It's
+// only accepted by the node for simulation, e.g. for a fee payer that hasn't committed to paying yet.
+//
+// Implements:
+//   - [AccountAuthenticatorImpl]
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type NoAccountAuthenticator struct{}
+
+// NewNoAccountAuthenticator creates an [AccountAuthenticator] for an account that hasn't signed, for simulation
+func NewNoAccountAuthenticator() *AccountAuthenticator {
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorNoAccount,
+		Auth:    &NoAccountAuthenticator{},
+	}
+}
+
+//region NoAccountAuthenticator AccountAuthenticatorImpl implementation
+
+// PublicKey returns nil, as there is no public key
+//
+// Implements:
+//   - [AccountAuthenticatorImpl]
+func (ea *NoAccountAuthenticator) PublicKey() PublicKey {
+	return nil
+}
+
+// Signature returns nil, as there is no signature
+//
+// Implements:
+//   - [AccountAuthenticatorImpl]
+func (ea *NoAccountAuthenticator) Signature() Signature {
+	return nil
+}
+
+// Verify always returns false, as there is nothing to verify
+//
+// Implements:
+//   - [AccountAuthenticatorImpl]
+func (ea *NoAccountAuthenticator) Verify([]byte) bool {
+	return false
+}
+
+//endregion
+
+//region NoAccountAuthenticator bcs.Struct implementation
+
+// MarshalBCS serializes nothing, only the variant of the [AccountAuthenticator] is serialized
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (ea *NoAccountAuthenticator) MarshalBCS(*bcs.Serializer) {}
+
+// UnmarshalBCS deserializes nothing, only the variant of the [AccountAuthenticator] is serialized
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (ea *NoAccountAuthenticator) UnmarshalBCS(*bcs.Deserializer) {}
+
+//endregion
+//endregion
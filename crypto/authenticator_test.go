@@ -81,6 +81,23 @@ func Test_AuthenticatorVerification(t *testing.T) {
 	assert.True(t, authenticator.Verify(msg))
 }
 
+func Test_NoAccountAuthenticator(t *testing.T) {
+	authenticator := NewNoAccountAuthenticator()
+	assert.Nil(t, authenticator.PubKey())
+	assert.Nil(t, authenticator.Signature())
+	assert.False(t, authenticator.Verify([]byte{0x01, 0x02}))
+
+	// Only the variant is serialized
+	serialized, err := bcs.Serialize(authenticator)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{uint8(AccountAuthenticatorNoAccount)}, serialized)
+
+	newAuthenticator := &AccountAuthenticator{}
+	err = bcs.Deserialize(newAuthenticator, serialized)
+	assert.NoError(t, err)
+	assert.Equal(t, authenticator, newAuthenticator)
+}
+
 func Test_InvalidAuthenticatorDeserialization(t *testing.T) {
 	serialized := []byte{0xFF}
 	newAuthenticator := &AccountAuthenticator{}
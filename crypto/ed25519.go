@@ -203,14 +203,14 @@ type Ed25519PublicKey struct {
 
 // Verify verifies a message with the public key and [Signature]
 //
-// Returns false if the signature is not [Ed25519Signature], or if the verification fails.
+// Returns false if the signature is not a non-nil [Ed25519Signature], or if the verification fails.
 //
 // Implements:
 //   - [VerifyingKey]
 func (key *Ed25519PublicKey) Verify(msg []byte, sig Signature) bool {
 	switch sig := sig.(type) {
 	case *Ed25519Signature:
-		return ed25519consensus.Verify(key.Inner, msg, sig.Bytes())
+		return sig != nil && ed25519consensus.Verify(key.Inner, msg, sig.Bytes())
 	default:
 		return false
 	}
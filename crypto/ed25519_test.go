@@ -93,6 +93,32 @@ func TestEd25519Keys(t *testing.T) {
 	assert.Equal(t, authenticator, authenticator2)
 }
 
+func TestEd25519PublicKey_VerifyTampered(t *testing.T) {
+	privateKey, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	publicKey := privateKey.PubKey()
+	message := []byte("hello world")
+	signature, err := privateKey.SignMessage(message)
+	assert.NoError(t, err)
+	assert.True(t, publicKey.Verify(message, signature))
+
+	// A different message, or a changed signature must not verify
+	assert.False(t, publicKey.Verify([]byte("hello world!"), signature))
+	tampered := &Ed25519Signature{Inner: signature.(*Ed25519Signature).Inner}
+	tampered.Inner[0] ^= 0x01
+	assert.False(t, publicKey.Verify(message, tampered))
+
+	// Another key's signature must not verify
+	otherKey, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	assert.False(t, otherKey.PubKey().Verify(message, signature))
+
+	// Nor other types, or nil
+	assert.False(t, publicKey.Verify(message, &Secp256k1Signature{}))
+	assert.False(t, publicKey.Verify(message, (*Ed25519Signature)(nil)))
+	assert.False(t, publicKey.Verify(message, nil))
+}
+
 func TestEd25519PrivateKeyWrongLength(t *testing.T) {
 	privateKey := &Ed25519PrivateKey{}
 	err := privateKey.FromBytes([]byte{0x01})
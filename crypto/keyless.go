@@ -0,0 +1,612 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+// Keyless accounts are authenticated by an OpenID Connect JWT, and a short-lived ephemeral key pair.  These are only
+// supported for parsing e.g. transactions sent by keyless accounts, not for signing, and verification always fails as
+// it needs the on-chain JWKs and verifying the zero knowledge proof.
+
+const (
+	Groth16G1Length = 32 // Groth16G1Length is the length of a compressed G1 point in a [Groth16Proof]
+	Groth16G2Length = 64 // Groth16G2Length is the length of a compressed G2 point in a [Groth16Proof]
+	PepperLength    = 31 // PepperLength is the length of an [OpenIdSig] pepper
+)
+
+//region KeylessPublicKey
+
+// KeylessPublicKey is the public key of a keyless account, a commitment to the identity of the user with the OpenID
+// provider.
+//
+// Implements:
+//   - [VerifyingKey]
+//   - [CryptoMaterial]
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type KeylessPublicKey struct {
+	Iss          string // Iss is the OpenID provider issuer e.g. https://accounts.google.com
+	IdCommitment []byte // IdCommitment is the hiding commitment to the user id, audience and pepper
+}
+
+//region KeylessPublicKey VerifyingKey implementation
+
+// Verify always returns false, as keyless signatures can't be verified offline
+//
+// Implements:
+//   - [VerifyingKey]
+func (key *KeylessPublicKey) Verify(_ []byte, _ Signature) bool {
+	return false
+}
+
+//endregion
+
+//region KeylessPublicKey CryptoMaterial implementation
+
+// Bytes returns the BCS bytes of the [KeylessPublicKey]
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *KeylessPublicKey) Bytes() []byte {
+	val, _ := bcs.Serialize(key)
+	return val
+}
+
+// FromBytes sets the [KeylessPublicKey] to the given BCS bytes
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *KeylessPublicKey) FromBytes(bytes []byte) (err error) {
+	return bcs.Deserialize(key, bytes)
+}
+
+// ToHex returns the hex string representation of the [KeylessPublicKey], with a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *KeylessPublicKey) ToHex() string {
+	return util.BytesToHex(key.Bytes())
+}
+
+// FromHex sets the [KeylessPublicKey] to the bytes represented by the hex string, with or without a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *KeylessPublicKey) FromHex(hexStr string) (err error) {
+	bytes, err := util.ParseHex(hexStr)
+	if err != nil {
+		return err
+	}
+	return key.FromBytes(bytes)
+}
+
+//endregion
+
+//region KeylessPublicKey bcs.Struct implementation
+
+// MarshalBCS serializes the [KeylessPublicKey] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (key *KeylessPublicKey) MarshalBCS(ser *bcs.Serializer) {
+	ser.WriteString(key.Iss)
+	ser.WriteBytes(key.IdCommitment)
+}
+
+// UnmarshalBCS deserializes the [KeylessPublicKey] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (key *KeylessPublicKey) UnmarshalBCS(des *bcs.Deserializer) {
+	key.Iss = des.ReadString()
+	key.IdCommitment = des.ReadBytes()
+}
+
+//endregion
+//endregion
+
+//region FederatedKeylessPublicKey
+
+// FederatedKeylessPublicKey is the public key of a keyless account, where the JWKs of the OpenID provider are
+// installed at an account rather than by the framework.
+//
+// Implements:
+//   - [VerifyingKey]
+//   - [CryptoMaterial]
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type FederatedKeylessPublicKey struct {
+	JwkAddress [32]byte         // JwkAddress is the address of the account with the JWKs
+	PubKey     KeylessPublicKey // PubKey is the keyless public key
+}
+
+//region FederatedKeylessPublicKey VerifyingKey implementation
+
+// Verify always returns false, as keyless signatures can't be verified offline
+//
+// Implements:
+//   - [VerifyingKey]
+func (key *FederatedKeylessPublicKey) Verify(_ []byte, _ Signature) bool {
+	return false
+}
+
+//endregion
+
+//region FederatedKeylessPublicKey CryptoMaterial implementation
+
+// Bytes returns the BCS bytes of the [FederatedKeylessPublicKey]
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *FederatedKeylessPublicKey) Bytes() []byte {
+	val, _ := bcs.Serialize(key)
+	return val
+}
+
+// FromBytes sets the [FederatedKeylessPublicKey] to the given BCS bytes
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *FederatedKeylessPublicKey) FromBytes(bytes []byte) (err error) {
+	return bcs.Deserialize(key, bytes)
+}
+
+// ToHex returns the hex string representation of the [FederatedKeylessPublicKey], with a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *FederatedKeylessPublicKey) ToHex() string {
+	return util.BytesToHex(key.Bytes())
+}
+
+// FromHex sets the [FederatedKeylessPublicKey] to the bytes represented by the hex string, with or without a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *FederatedKeylessPublicKey) FromHex(hexStr string) (err error) {
+	bytes, err := util.ParseHex(hexStr)
+	if err != nil {
+		return err
+	}
+	return key.FromBytes(bytes)
+}
+
+//endregion
+
+//region FederatedKeylessPublicKey bcs.Struct implementation
+
+// MarshalBCS serializes the [FederatedKeylessPublicKey] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (key *FederatedKeylessPublicKey) MarshalBCS(ser *bcs.Serializer) {
+	ser.FixedBytes(key.JwkAddress[:])
+	ser.Struct(&key.PubKey)
+}
+
+// UnmarshalBCS deserializes the [FederatedKeylessPublicKey] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (key *FederatedKeylessPublicKey) UnmarshalBCS(des *bcs.Deserializer) {
+	des.ReadFixedBytesInto(key.JwkAddress[:])
+	des.Struct(&key.PubKey)
+}
+
+//endregion
+//endregion
+
+//region KeylessSignature
+
+// KeylessSignature is the signature of a keyless account.  The transaction is signed by the ephemeral key, which is
+// certified by the [EphemeralCertificate] proving the user has a JWT from the OpenID provider committing to it.
+//
+// Implements:
+//   - [Signature]
+//   - [CryptoMaterial]
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type KeylessSignature struct {
+	Certificate        EphemeralCertificate // Certificate is the proof of the JWT, either a ZK proof or the JWT signature
+	JwtHeader          string               // JwtHeader is the JSON of the JWT header, see [KeylessSignature.ParseJwtHeader]
+	ExpiryDateSecs     uint64               // ExpiryDateSecs is when the ephemeral key expires, in Unix seconds
+	EphemeralPublicKey EphemeralPublicKey   // EphemeralPublicKey is the public key that signed the transaction
+	EphemeralSignature EphemeralSignature   // EphemeralSignature is the signature of the transaction by the ephemeral key
+}
+
+// JwtHeader is the header of the JWT used by a keyless account
+type JwtHeader struct {
+	Alg string `json:"alg"`           // Alg is the signing algorithm of the JWT e.g. RS256
+	Kid string `json:"kid"`           // Kid is the ID of the JWK of the OpenID provider that signed the JWT
+	Typ string `json:"typ,omitempty"` // Typ is the type of the token, usually JWT
+}
+
+// ParseJwtHeader parses the JSON [KeylessSignature.JwtHeader]
+func (e *KeylessSignature) ParseJwtHeader() (*JwtHeader, error) {
+	header := &JwtHeader{}
+	err := json.Unmarshal([]byte(e.JwtHeader), header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse keyless JWT header: %w", err)
+	}
+	return header, nil
+}
+
+//region KeylessSignature CryptoMaterial implementation
+
+// Bytes returns the BCS bytes of the [KeylessSignature]
+//
+// Implements:
+//   - [CryptoMaterial]
+func (e *KeylessSignature) Bytes() []byte {
+	val, _ := bcs.Serialize(e)
+	return val
+}
+
+// FromBytes sets the [KeylessSignature] to the given BCS bytes
+//
+// Implements:
+//   - [CryptoMaterial]
+func (e *KeylessSignature) FromBytes(bytes []byte) (err error) {
+	return bcs.Deserialize(e, bytes)
+}
+
+// ToHex returns the hex string representation of the [KeylessSignature], with a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (e *KeylessSignature) ToHex() string {
+	return util.BytesToHex(e.Bytes())
+}
+
+// FromHex sets the [KeylessSignature] to the bytes represented by the hex string, with or without a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (e *KeylessSignature) FromHex(hexStr string) (err error) {
+	bytes, err := util.ParseHex(hexStr)
+	if err != nil {
+		return err
+	}
+	return e.FromBytes(bytes)
+}
+
+//endregion
+
+//region KeylessSignature bcs.Struct implementation
+
+// MarshalBCS serializes the [KeylessSignature] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (e *KeylessSignature) MarshalBCS(ser *bcs.Serializer) {
+	ser.Struct(&e.Certificate)
+	ser.WriteString(e.JwtHeader)
+	ser.U64(e.ExpiryDateSecs)
+	ser.Struct(&e.EphemeralPublicKey)
+	ser.Struct(&e.EphemeralSignature)
+}
+
+// UnmarshalBCS deserializes the [KeylessSignature] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (e *KeylessSignature) UnmarshalBCS(des *bcs.Deserializer) {
+	des.Struct(&e.Certificate)
+	if des.Error() != nil {
+		return
+	}
+	e.JwtHeader = des.ReadString()
+	e.ExpiryDateSecs = des.U64()
+	des.Struct(&e.EphemeralPublicKey)
+	des.Struct(&e.EphemeralSignature)
+}
+
+//endregion
+//endregion
+
+//region EphemeralCertificate
+
+// EphemeralCertificateVariant is the type of certificate of the ephemeral key in a [KeylessSignature]
+type EphemeralCertificateVariant uint32
+
+const (
+	EphemeralCertificateVariantZeroKnowledge EphemeralCertificateVariant = 0 // EphemeralCertificateVariantZeroKnowledge is the variant for [ZeroKnowledgeSig]
+	EphemeralCertificateVariantOpenId        EphemeralCertificateVariant = 1 // EphemeralCertificateVariantOpenId is the variant for [OpenIdSig]
+)
+
+// EphemeralCertificate certifies the ephemeral key of a [KeylessSignature], either a [ZeroKnowledgeSig] or an
+// [OpenIdSig]
+type EphemeralCertificate struct {
+	Variant     EphemeralCertificateVariant
+	Certificate bcs.Struct // Certificate is a *[ZeroKnowledgeSig] or *[OpenIdSig]
+}
+
+// MarshalBCS serializes the [EphemeralCertificate] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (ec *EphemeralCertificate) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(ec.Variant))
+	ser.Struct(ec.Certificate)
+}
+
+// UnmarshalBCS deserializes the [EphemeralCertificate] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (ec *EphemeralCertificate) UnmarshalBCS(des *bcs.Deserializer) {
+	ec.Variant = EphemeralCertificateVariant(des.Uleb128())
+	switch ec.Variant {
+	case EphemeralCertificateVariantZeroKnowledge:
+		ec.Certificate = &ZeroKnowledgeSig{}
+	case EphemeralCertificateVariantOpenId:
+		ec.Certificate = &OpenIdSig{}
+	default:
+		des.SetError(fmt.Errorf("unknown ephemeral certificate variant: %d", ec.Variant))
+		return
+	}
+	des.Struct(ec.Certificate)
+}
+
+//endregion
+
+//region ZeroKnowledgeSig
+
+// ZeroKnowledgeSig is a zero knowledge proof that the user has a JWT committing to the ephemeral key, without
+// revealing the JWT
+type ZeroKnowledgeSig struct {
+	Proof                   ZkProof             // Proof is the zero knowledge proof
+	ExpHorizonSecs          uint64              // ExpHorizonSecs is the maximum lifetime of the ephemeral key, in seconds
+	ExtraField              *string             // ExtraField is an optional JWT field revealed by the proof
+	OverrideAudVal          *string             // OverrideAudVal is an optional audience, used for account recovery
+	TrainingWheelsSignature *EphemeralSignature // TrainingWheelsSignature is an optional signature of the proof by the prover service
+}
+
+// MarshalBCS serializes the [ZeroKnowledgeSig] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (zk *ZeroKnowledgeSig) MarshalBCS(ser *bcs.Serializer) {
+	ser.Struct(&zk.Proof)
+	ser.U64(zk.ExpHorizonSecs)
+	bcs.SerializeOption(ser, zk.ExtraField, func(ser *bcs.Serializer, item string) {
+		ser.WriteString(item)
+	})
+	bcs.SerializeOption(ser, zk.OverrideAudVal, func(ser *bcs.Serializer, item string) {
+		ser.WriteString(item)
+	})
+	bcs.SerializeOption(ser, zk.TrainingWheelsSignature, func(ser *bcs.Serializer, item EphemeralSignature) {
+		ser.Struct(&item)
+	})
+}
+
+// UnmarshalBCS deserializes the [ZeroKnowledgeSig] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (zk *ZeroKnowledgeSig) UnmarshalBCS(des *bcs.Deserializer) {
+	des.Struct(&zk.Proof)
+	zk.ExpHorizonSecs = des.U64()
+	zk.ExtraField = bcs.DeserializeOption(des, func(des *bcs.Deserializer, out *string) {
+		*out = des.ReadString()
+	})
+	zk.OverrideAudVal = bcs.DeserializeOption(des, func(des *bcs.Deserializer, out *string) {
+		*out = des.ReadString()
+	})
+	zk.TrainingWheelsSignature = bcs.DeserializeOption(des, func(des *bcs.Deserializer, out *EphemeralSignature) {
+		des.Struct(out)
+	})
+}
+
+//endregion
+
+//region ZkProof
+
+// ZkProofVariant is the type of zero knowledge proof in a [ZeroKnowledgeSig]
+type ZkProofVariant uint32
+
+const (
+	ZkProofVariantGroth16 ZkProofVariant = 0 // ZkProofVariantGroth16 is the variant for [Groth16Proof]
+)
+
+// ZkProof is a zero knowledge proof, currently only a [Groth16Proof]
+type ZkProof struct {
+	Variant ZkProofVariant
+	Proof   *Groth16Proof
+}
+
+// MarshalBCS serializes the [ZkProof] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (p *ZkProof) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(p.Variant))
+	ser.Struct(p.Proof)
+}
+
+// UnmarshalBCS deserializes the [ZkProof] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (p *ZkProof) UnmarshalBCS(des *bcs.Deserializer) {
+	p.Variant = ZkProofVariant(des.Uleb128())
+	switch p.Variant {
+	case ZkProofVariantGroth16:
+		p.Proof = &Groth16Proof{}
+	default:
+		des.SetError(fmt.Errorf("unknown zk proof variant: %d", p.Variant))
+		return
+	}
+	des.Struct(p.Proof)
+}
+
+// Groth16Proof is a Groth16 proof over BN254, with compressed points
+type Groth16Proof struct {
+	A [Groth16G1Length]byte
+	B [Groth16G2Length]byte
+	C [Groth16G1Length]byte
+}
+
+// MarshalBCS serializes the [Groth16Proof] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (p *Groth16Proof) MarshalBCS(ser *bcs.Serializer) {
+	ser.FixedBytes(p.A[:])
+	ser.FixedBytes(p.B[:])
+	ser.FixedBytes(p.C[:])
+}
+
+// UnmarshalBCS deserializes the [Groth16Proof] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (p *Groth16Proof) UnmarshalBCS(des *bcs.Deserializer) {
+	des.ReadFixedBytesInto(p.A[:])
+	des.ReadFixedBytesInto(p.B[:])
+	des.ReadFixedBytesInto(p.C[:])
+}
+
+//endregion
+
+//region OpenIdSig
+
+// OpenIdSig certifies the ephemeral key by revealing the JWT and its signature by the OpenID provider
+type OpenIdSig struct {
+	JwtSignature   []byte             // JwtSignature is the signature of the JWT by the OpenID provider
+	JwtPayloadJson string             // JwtPayloadJson is the JSON of the JWT payload
+	UidKey         string             // UidKey is the JWT field of the user id e.g. sub
+	EpkBlinder     []byte             // EpkBlinder is the blinding factor of the ephemeral key in the JWT nonce
+	Pepper         [PepperLength]byte // Pepper is the secret pepper of the IdCommitment
+	IdcAudVal      *string            // IdcAudVal is an optional audience, used for account recovery
+}
+
+// MarshalBCS serializes the [OpenIdSig] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (o *OpenIdSig) MarshalBCS(ser *bcs.Serializer) {
+	ser.WriteBytes(o.JwtSignature)
+	ser.WriteString(o.JwtPayloadJson)
+	ser.WriteString(o.UidKey)
+	ser.WriteBytes(o.EpkBlinder)
+	ser.FixedBytes(o.Pepper[:])
+	bcs.SerializeOption(ser, o.IdcAudVal, func(ser *bcs.Serializer, item string) {
+		ser.WriteString(item)
+	})
+}
+
+// UnmarshalBCS deserializes the [OpenIdSig] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (o *OpenIdSig) UnmarshalBCS(des *bcs.Deserializer) {
+	o.JwtSignature = des.ReadBytes()
+	o.JwtPayloadJson = des.ReadString()
+	o.UidKey = des.ReadString()
+	o.EpkBlinder = des.ReadBytes()
+	des.ReadFixedBytesInto(o.Pepper[:])
+	o.IdcAudVal = bcs.DeserializeOption(des, func(des *bcs.Deserializer, out *string) {
+		*out = des.ReadString()
+	})
+}
+
+//endregion
+
+//region EphemeralPublicKey
+
+// EphemeralPublicKeyVariant is the type of the ephemeral key in a [KeylessSignature]
+type EphemeralPublicKeyVariant uint32
+
+const (
+	EphemeralPublicKeyVariantEd25519   EphemeralPublicKeyVariant = 0 // EphemeralPublicKeyVariantEd25519 is the variant for [Ed25519PublicKey]
+	EphemeralPublicKeyVariantSecp256r1 EphemeralPublicKeyVariant = 1 // EphemeralPublicKeyVariantSecp256r1 is the variant for [Secp256r1PublicKey] e.g. a passkey
+)
+
+// EphemeralPublicKey is the short-lived key of a keyless account, which signs the transaction, either an Ed25519 key,
+// or a secp256r1 passkey.  Passkey keys are only parsed, and always fail to verify.
+type EphemeralPublicKey struct {
+	Variant EphemeralPublicKeyVariant
+	PubKey  VerifyingKey // PubKey is the actual public key
+}
+
+// MarshalBCS serializes the [EphemeralPublicKey] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (key *EphemeralPublicKey) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(key.Variant))
+	ser.Struct(key.PubKey)
+}
+
+// UnmarshalBCS deserializes the [EphemeralPublicKey] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (key *EphemeralPublicKey) UnmarshalBCS(des *bcs.Deserializer) {
+	key.Variant = EphemeralPublicKeyVariant(des.Uleb128())
+	switch key.Variant {
+	case EphemeralPublicKeyVariantEd25519:
+		key.PubKey = &Ed25519PublicKey{}
+	case EphemeralPublicKeyVariantSecp256r1:
+		key.PubKey = &Secp256r1PublicKey{}
+	default:
+		des.SetError(fmt.Errorf("unknown ephemeral public key variant: %d", key.Variant))
+		return
+	}
+	des.Struct(key.PubKey)
+}
+
+//endregion
+
+//region EphemeralSignature
+
+// EphemeralSignatureVariant is the type of the signature by the ephemeral key in a [KeylessSignature]
+type EphemeralSignatureVariant uint32
+
+const (
+	EphemeralSignatureVariantEd25519  EphemeralSignatureVariant = 0 // EphemeralSignatureVariantEd25519 is the variant for [Ed25519Signature]
+	EphemeralSignatureVariantWebAuthn EphemeralSignatureVariant = 1 // EphemeralSignatureVariantWebAuthn is the variant for [WebAuthnSignature], by a passkey
+)
+
+// EphemeralSignature is a signature by the ephemeral key of a keyless account, either an Ed25519 signature, or a
+// WebAuthn signature by a passkey.
+type EphemeralSignature struct {
+	Variant   EphemeralSignatureVariant
+	Signature Signature // Signature is the actual signature
+}
+
+// MarshalBCS serializes the [EphemeralSignature] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (e *EphemeralSignature) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(uint32(e.Variant))
+	ser.Struct(e.Signature)
+}
+
+// UnmarshalBCS deserializes the [EphemeralSignature] from bytes
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (e *EphemeralSignature) UnmarshalBCS(des *bcs.Deserializer) {
+	e.Variant = EphemeralSignatureVariant(des.Uleb128())
+	switch e.Variant {
+	case EphemeralSignatureVariantEd25519:
+		e.Signature = &Ed25519Signature{}
+	case EphemeralSignatureVariantWebAuthn:
+		e.Signature = &WebAuthnSignature{}
+	default:
+		des.SetError(fmt.Errorf("unknown ephemeral signature variant: %d", e.Variant))
+		return
+	}
+	des.Struct(e.Signature)
+}
+
+//endregion
@@ -0,0 +1,328 @@
+package crypto
+
+import (
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const (
+	testKeylessIss       = "https://accounts.google.com"
+	testKeylessJwtHeader = `{"alg":"RS256","kid":"test-kid","typ":"JWT"}`
+	testKeylessExpiry    = uint64(1735689600)
+	testKeylessHorizon   = uint64(10000000)
+)
+
+// testKeylessIdCommitment is a 32 byte commitment, only the layout matters
+var testKeylessIdCommitment = []byte{
+	0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+	0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+}
+
+// testKeylessAuthenticatorBytes builds the bytes of a single key account authenticator
+// for a keyless account, field by field in the on-chain layout, so they don't depend on the marshalers
+// under test.  The ephemeral key and signature are a real Ed25519 key and signature of the message.
+func testKeylessAuthenticatorBytes(t *testing.T, ephemeralKey *Ed25519PrivateKey, message []byte, certificate func(ser *bcs.Serializer)) []byte {
+	ephemeralSig, err := ephemeralKey.SignMessage(message)
+	assert.NoError(t, err)
+
+	ser := &bcs.Serializer{}
+	ser.Uleb128(uint32(AccountAuthenticatorSingleSender))
+	// AnyPublicKey::Keyless
+	ser.Uleb128(3)
+	ser.WriteString(testKeylessIss)
+	ser.WriteBytes(testKeylessIdCommitment)
+	// AnySignature::Keyless
+	ser.Uleb128(3)
+	certificate(ser)
+	ser.WriteString(testKeylessJwtHeader)
+	ser.U64(testKeylessExpiry)
+	// EphemeralPublicKey::Ed25519
+	ser.Uleb128(0)
+	ser.WriteBytes(ephemeralKey.PubKey().Bytes())
+	// EphemeralSignature::Ed25519
+	ser.Uleb128(0)
+	ser.WriteBytes(ephemeralSig.Bytes())
+	assert.NoError(t, ser.Error())
+	return ser.ToBytes()
+}
+
+// testZeroKnowledgeCertificate writes a ZeroKnowledgeSig certificate with a Groth16 proof, and a training wheels
+// signature
+func testZeroKnowledgeCertificate(trainingWheels *Ed25519Signature) func(ser *bcs.Serializer) {
+	return func(ser *bcs.Serializer) {
+		// EphemeralCertificate::ZeroKnowledgeSig
+		ser.Uleb128(0)
+		// ZKP::Groth16
+		ser.Uleb128(0)
+		for i := 0; i < Groth16G1Length+Groth16G2Length+Groth16G1Length; i++ {
+			ser.U8(uint8(i))
+		}
+		ser.U64(testKeylessHorizon)
+		// No extra field
+		ser.Uleb128(0)
+		// No override aud val
+		ser.Uleb128(0)
+		// Some(EphemeralSignature::Ed25519)
+		ser.Uleb128(1)
+		ser.Uleb128(0)
+		ser.WriteBytes(trainingWheels.Bytes())
+	}
+}
+
+func TestKeylessSignature_ZeroKnowledge(t *testing.T) {
+	ephemeralKey, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	trainingWheelsKey, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	trainingWheels, err := trainingWheelsKey.SignMessage([]byte("proof"))
+	assert.NoError(t, err)
+	message := []byte("hello keyless")
+	authBytes := testKeylessAuthenticatorBytes(t, ephemeralKey, message, testZeroKnowledgeCertificate(trainingWheels.(*Ed25519Signature)))
+
+	auth := &AccountAuthenticator{}
+	err = bcs.Deserialize(auth, authBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAuthenticatorSingleSender, auth.Variant)
+	singleKeyAuth, ok := auth.Auth.(*SingleKeyAuthenticator)
+	assert.True(t, ok)
+
+	// The public key
+	assert.Equal(t, AnyPublicKeyVariantKeyless, singleKeyAuth.PubKey.Variant)
+	pubKey, ok := singleKeyAuth.PubKey.PubKey.(*KeylessPublicKey)
+	assert.True(t, ok)
+	assert.Equal(t, testKeylessIss, pubKey.Iss)
+	assert.Equal(t, testKeylessIdCommitment, pubKey.IdCommitment)
+
+	// The signature
+	assert.Equal(t, AnySignatureVariantKeyless, singleKeyAuth.Sig.Variant)
+	sig, ok := singleKeyAuth.Sig.Signature.(*KeylessSignature)
+	assert.True(t, ok)
+	assert.Equal(t, testKeylessJwtHeader, sig.JwtHeader)
+	assert.Equal(t, testKeylessExpiry, sig.ExpiryDateSecs)
+	header, err := sig.ParseJwtHeader()
+	assert.NoError(t, err)
+	assert.Equal(t, &JwtHeader{Alg: "RS256", Kid: "test-kid", Typ: "JWT"}, header)
+
+	assert.Equal(t, EphemeralPublicKeyVariantEd25519, sig.EphemeralPublicKey.Variant)
+	assert.Equal(t, ephemeralKey.PubKey(), sig.EphemeralPublicKey.PubKey)
+	assert.Equal(t, EphemeralSignatureVariantEd25519, sig.EphemeralSignature.Variant)
+	assert.True(t, sig.EphemeralPublicKey.PubKey.Verify(message, sig.EphemeralSignature.Signature))
+
+	// The certificate
+	assert.Equal(t, EphemeralCertificateVariantZeroKnowledge, sig.Certificate.Variant)
+	zkSig, ok := sig.Certificate.Certificate.(*ZeroKnowledgeSig)
+	assert.True(t, ok)
+	assert.Equal(t, ZkProofVariantGroth16, zkSig.Proof.Variant)
+	assert.Equal(t, uint8(0), zkSig.Proof.Proof.A[0])
+	assert.Equal(t, uint8(Groth16G1Length), zkSig.Proof.Proof.B[0])
+	assert.Equal(t, uint8(Groth16G1Length+Groth16G2Length), zkSig.Proof.Proof.C[0])
+	assert.Equal(t, testKeylessHorizon, zkSig.ExpHorizonSecs)
+	assert.Nil(t, zkSig.ExtraField)
+	assert.Nil(t, zkSig.OverrideAudVal)
+	assert.NotNil(t, zkSig.TrainingWheelsSignature)
+	assert.Equal(t, trainingWheels, zkSig.TrainingWheelsSignature.Signature)
+
+	// Keyless signatures can't be verified offline
+	assert.False(t, auth.Verify(message))
+
+	// It serializes back to the same bytes
+	reserialized, err := bcs.Serialize(auth)
+	assert.NoError(t, err)
+	assert.Equal(t, authBytes, reserialized)
+}
+
+func TestKeylessSignature_OpenId(t *testing.T) {
+	ephemeralKey, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	message := []byte("hello keyless")
+	authBytes := testKeylessAuthenticatorBytes(t, ephemeralKey, message, func(ser *bcs.Serializer) {
+		// EphemeralCertificate::OpenIdSig
+		ser.Uleb128(1)
+		ser.WriteBytes([]byte{0xaa, 0xbb})
+		ser.WriteString(`{"sub":"1234"}`)
+		ser.WriteString("sub")
+		ser.WriteBytes([]byte{0x01})
+		ser.FixedBytes(make([]byte, PepperLength))
+		// Some("aud")
+		ser.Uleb128(1)
+		ser.WriteString("aud")
+	})
+
+	auth := &AccountAuthenticator{}
+	err = bcs.Deserialize(auth, authBytes)
+	assert.NoError(t, err)
+	sig := auth.Auth.(*SingleKeyAuthenticator).Sig.Signature.(*KeylessSignature)
+	assert.Equal(t, EphemeralCertificateVariantOpenId, sig.Certificate.Variant)
+	openIdSig, ok := sig.Certificate.Certificate.(*OpenIdSig)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0xaa, 0xbb}, openIdSig.JwtSignature)
+	assert.Equal(t, `{"sub":"1234"}`, openIdSig.JwtPayloadJson)
+	assert.Equal(t, "sub", openIdSig.UidKey)
+	assert.Equal(t, []byte{0x01}, openIdSig.EpkBlinder)
+	assert.Equal(t, [PepperLength]byte{}, openIdSig.Pepper)
+	assert.NotNil(t, openIdSig.IdcAudVal)
+	assert.Equal(t, "aud", *openIdSig.IdcAudVal)
+
+	reserialized, err := bcs.Serialize(auth)
+	assert.NoError(t, err)
+	assert.Equal(t, authBytes, reserialized)
+}
+
+func TestFederatedKeylessPublicKey(t *testing.T) {
+	ser := &bcs.Serializer{}
+	// AnyPublicKey::FederatedKeyless
+	ser.Uleb128(4)
+	jwkAddress := [32]byte{31: 0x01}
+	ser.FixedBytes(jwkAddress[:])
+	ser.WriteString(testKeylessIss)
+	ser.WriteBytes(testKeylessIdCommitment)
+	keyBytes := ser.ToBytes()
+
+	key := &AnyPublicKey{}
+	err := key.FromBytes(keyBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, AnyPublicKeyVariantFederatedKeyless, key.Variant)
+	federatedKey, ok := key.PubKey.(*FederatedKeylessPublicKey)
+	assert.True(t, ok)
+	assert.Equal(t, jwkAddress, federatedKey.JwkAddress)
+	assert.Equal(t, testKeylessIss, federatedKey.PubKey.Iss)
+	assert.Equal(t, testKeylessIdCommitment, federatedKey.PubKey.IdCommitment)
+	assert.Equal(t, keyBytes, key.Bytes())
+
+	// The auth key is of the whole any public key, the same as other single keys
+	authKey := key.AuthKey()
+	expected := &AuthenticationKey{}
+	expected.FromBytesAndScheme(keyBytes, SingleKeyScheme)
+	assert.Equal(t, expected, authKey)
+}
+
+func TestKeylessSignature_Unsupported(t *testing.T) {
+	ephemeralKey, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	sig, err := ephemeralKey.SignMessage([]byte("hello"))
+	assert.NoError(t, err)
+
+	// An unknown certificate
+	authBytes := testKeylessAuthenticatorBytes(t, ephemeralKey, []byte("hello"), func(ser *bcs.Serializer) {
+		ser.Uleb128(2)
+	})
+	err = bcs.Deserialize(&AccountAuthenticator{}, authBytes)
+	assert.ErrorContains(t, err, "unknown ephemeral certificate variant: 2")
+
+	// An unknown ephemeral key
+	ser := &bcs.Serializer{}
+	ser.Uleb128(2)
+	ser.WriteBytes(make([]byte, 65))
+	err = bcs.Deserialize(&EphemeralPublicKey{}, ser.ToBytes())
+	assert.ErrorContains(t, err, "unknown ephemeral public key variant: 2")
+
+	// Or ephemeral signature
+	ser = &bcs.Serializer{}
+	ser.Uleb128(2)
+	ser.WriteBytes(sig.Bytes())
+	err = bcs.Deserialize(&EphemeralSignature{}, ser.ToBytes())
+	assert.ErrorContains(t, err, "unknown ephemeral signature variant: 2")
+
+	// A secp256r1 key of the wrong length
+	ser = &bcs.Serializer{}
+	ser.Uleb128(uint32(EphemeralPublicKeyVariantSecp256r1))
+	ser.WriteBytes(make([]byte, 33))
+	err = bcs.Deserialize(&EphemeralPublicKey{}, ser.ToBytes())
+	assert.ErrorContains(t, err, "invalid secp256r1 public key size")
+
+	// A WebAuthn signature that isn't secp256r1, or of the wrong length
+	ser = &bcs.Serializer{}
+	ser.Uleb128(uint32(EphemeralSignatureVariantWebAuthn))
+	ser.Uleb128(1)
+	err = bcs.Deserialize(&EphemeralSignature{}, ser.ToBytes())
+	assert.ErrorContains(t, err, "unknown webauthn assertion signature variant: 1")
+	ser = &bcs.Serializer{}
+	ser.Uleb128(uint32(EphemeralSignatureVariantWebAuthn))
+	ser.Uleb128(0)
+	ser.WriteBytes(make([]byte, 70))
+	err = bcs.Deserialize(&EphemeralSignature{}, ser.ToBytes())
+	assert.ErrorContains(t, err, "invalid secp256r1 signature size")
+}
+
+func TestKeylessSignature_Passkey(t *testing.T) {
+	passkey := append([]byte{0x04}, make([]byte, 64)...)
+	passkey[1] = 0xaa
+	passkeySig := make([]byte, Secp256r1SignatureLength)
+	passkeySig[0] = 0xbb
+	authenticatorData := []byte{0x49, 0x96, 0x0d, 0xe5}
+	clientDataJson := []byte(`{"type":"webauthn.get","challenge":"abc","origin":"https://example.com"}`)
+
+	// The signature with a passkey as the ephemeral key, field by field in the on-chain layout
+	ser := &bcs.Serializer{}
+	testZeroKnowledgeCertificate(&Ed25519Signature{})(ser)
+	ser.WriteString(testKeylessJwtHeader)
+	ser.U64(testKeylessExpiry)
+	// EphemeralPublicKey::Secp256r1Ecdsa
+	ser.Uleb128(1)
+	ser.WriteBytes(passkey)
+	// EphemeralSignature::WebAuthn, with AssertionSignature::Secp256r1Ecdsa
+	ser.Uleb128(1)
+	ser.Uleb128(0)
+	ser.WriteBytes(passkeySig)
+	ser.WriteBytes(authenticatorData)
+	ser.WriteBytes(clientDataJson)
+	assert.NoError(t, ser.Error())
+	sigBytes := ser.ToBytes()
+
+	sig := &KeylessSignature{}
+	err := sig.FromBytes(sigBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, testKeylessJwtHeader, sig.JwtHeader)
+	assert.Equal(t, EphemeralPublicKeyVariantSecp256r1, sig.EphemeralPublicKey.Variant)
+	assert.Equal(t, &Secp256r1PublicKey{Inner: passkey}, sig.EphemeralPublicKey.PubKey)
+	assert.Equal(t, EphemeralSignatureVariantWebAuthn, sig.EphemeralSignature.Variant)
+	assert.Equal(t, &WebAuthnSignature{
+		Signature:         passkeySig,
+		AuthenticatorData: authenticatorData,
+		ClientDataJson:    clientDataJson,
+	}, sig.EphemeralSignature.Signature)
+
+	// Passkey signatures aren't verified by the SDK
+	assert.False(t, sig.EphemeralPublicKey.PubKey.Verify([]byte("hello"), sig.EphemeralSignature.Signature))
+
+	// It serializes back to the same bytes
+	assert.Equal(t, sigBytes, sig.Bytes())
+}
+
+// Captured keyless signature, for checking the layout against real on-chain data rather than this SDK's own
+// marshalers.  These were not captured, as the environment these changes were written in has no network access.  To
+// fill them in, take any transaction sent by a keyless account on mainnet or testnet, and copy signature.public_key.value
+// and signature.signature.value from its JSON on the node:
+//
+//	curl https://api.mainnet.aptoslabs.com/v1/transactions/by_hash/<hash>
+//
+// then set the iss, the JWT kid, and the ephemeral public key, decoded independently e.g. by the TypeScript SDK.
+const (
+	testCapturedKeylessPublicKeyHex = ""
+	testCapturedKeylessSignatureHex = ""
+	testCapturedKeylessIss          = ""
+	testCapturedKeylessKid          = ""
+	testCapturedKeylessEphemeralKey = ""
+)
+
+func TestKeylessSignature_Captured(t *testing.T) {
+	if testCapturedKeylessSignatureHex == "" {
+		t.Skip("no captured keyless signature, see testCapturedKeylessSignatureHex")
+	}
+	pubKey := &KeylessPublicKey{}
+	err := pubKey.FromHex(testCapturedKeylessPublicKeyHex)
+	assert.NoError(t, err)
+	assert.Equal(t, testCapturedKeylessIss, pubKey.Iss)
+
+	sig := &KeylessSignature{}
+	err = sig.FromHex(testCapturedKeylessSignatureHex)
+	assert.NoError(t, err)
+	header, err := sig.ParseJwtHeader()
+	assert.NoError(t, err)
+	assert.Equal(t, testCapturedKeylessKid, header.Kid)
+	assert.Equal(t, testCapturedKeylessEphemeralKey, sig.EphemeralPublicKey.PubKey.ToHex())
+
+	// It serializes back to the same bytes
+	assert.Equal(t, testCapturedKeylessSignatureHex, sig.ToHex())
+}
@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// AptosDerivationPathPrefix is the BIP-44 prefix of derivation paths for Aptos, with the coin type 637
+const AptosDerivationPathPrefix = "m/44'/637'"
+
+// AptosDefaultDerivationPath is the derivation path of the first account, as used by wallets e.g. Petra
+const AptosDefaultDerivationPath = "m/44'/637'/0'/0'/0'"
+
+// slip10Ed25519Curve is the HMAC key for the master key of SLIP-0010 ed25519 derivation
+const slip10Ed25519Curve = "ed25519 seed"
+
+// hardenedOffset is added to an index for hardened derivation, the only derivation ed25519 supports
+const hardenedOffset = 0x80000000
+
+// bip39EnglishWords is the BIP-39 English wordlist, one word per line
+//
+//go:embed bip39_english.txt
+var bip39EnglishWords string
+
+// bip39WordIndex is the index of each word in the BIP-39 English wordlist
+var bip39WordIndex = func() map[string]uint16 {
+	words := strings.Fields(bip39EnglishWords)
+	index := make(map[string]uint16, len(words))
+	for i, word := range words {
+		index[word] = uint16(i)
+	}
+	return index
+}()
+
+// DeriveEd25519FromMnemonic derives an [Ed25519PrivateKey] from a BIP-39 mnemonic, with SLIP-0010 ed25519 derivation
+// on the path.  The path must be of the form m/44'/637'/account'/change'/index' with every level hardened e.g.
+// [AptosDefaultDerivationPath], this matches the keys derived by other Aptos wallets and SDKs.
+//
+// Returns an error if the mnemonic isn't a valid English BIP-39 mnemonic, or the path is invalid.
+func DeriveEd25519FromMnemonic(mnemonic string, path string) (*Ed25519PrivateKey, error) {
+	indices, err := parseAptosDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	words, err := validateMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := mnemonicToSeed(words, "")
+	key, chainCode := slip10Ed25519MasterKey(seed)
+	for _, index := range indices {
+		key, chainCode = slip10Ed25519ChildKey(key, chainCode, index)
+	}
+	return &Ed25519PrivateKey{Inner: ed25519.NewKeyFromSeed(key)}, nil
+}
+
+// parseAptosDerivationPath parses the path into the hardened indices for each level
+func parseAptosDerivationPath(path string) ([]uint32, error) {
+	if !strings.HasPrefix(path, AptosDerivationPathPrefix+"/") {
+		return nil, fmt.Errorf("invalid derivation path %s, must start with %s", path, AptosDerivationPathPrefix)
+	}
+	levels := strings.Split(path, "/")[1:]
+	if len(levels) != 5 {
+		return nil, fmt.Errorf("invalid derivation path %s, must be of the form m/44'/637'/account'/change'/index'", path)
+	}
+	indices := make([]uint32, len(levels))
+	for i, level := range levels {
+		if !strings.HasSuffix(level, "'") {
+			return nil, fmt.Errorf("invalid derivation path %s, level %d must be hardened for ed25519", path, i+1)
+		}
+		index, err := strconv.ParseUint(strings.TrimSuffix(level, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %s, level %d: %w", path, i+1, err)
+		}
+		indices[i] = uint32(index) + hardenedOffset
+	}
+	return indices, nil
+}
+
+// validateMnemonic checks the words are in the wordlist and the checksum matches, and returns the words
+func validateMnemonic(mnemonic string) ([]string, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, fmt.Errorf("invalid mnemonic, must have 12, 15, 18, 21, or 24 words, got %d", len(words))
+	}
+
+	// Pack the 11 bits of each word, this is the entropy followed by the checksum
+	totalBits := len(words) * 11
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+	packed := make([]byte, (totalBits+7)/8)
+	for i, word := range words {
+		index, ok := bip39WordIndex[word]
+		if !ok {
+			return nil, fmt.Errorf("invalid mnemonic, word %d is not in the BIP-39 English wordlist", i+1)
+		}
+		for bit := 0; bit < 11; bit++ {
+			if index&(1<<(10-bit)) != 0 {
+				position := i*11 + bit
+				packed[position/8] |= 0x80 >> (position % 8)
+			}
+		}
+	}
+
+	entropy := packed[:entropyBits/8]
+	hash := sha256.Sum256(entropy)
+	for bit := 0; bit < checksumBits; bit++ {
+		position := entropyBits + bit
+		expected := hash[bit/8] & (0x80 >> (bit % 8))
+		actual := packed[position/8] & (0x80 >> (position % 8))
+		if (expected == 0) != (actual == 0) {
+			return nil, fmt.Errorf("invalid mnemonic, checksum doesn't match")
+		}
+	}
+	return words, nil
+}
+
+// mnemonicToSeed converts the mnemonic words into the BIP-39 seed
+func mnemonicToSeed(words []string, passphrase string) []byte {
+	return pbkdf2.Key([]byte(strings.Join(words, " ")), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+}
+
+// slip10Ed25519MasterKey derives the master key and chain code from the seed
+func slip10Ed25519MasterKey(seed []byte) (key []byte, chainCode []byte) {
+	mac := hmac.New(sha512.New, []byte(slip10Ed25519Curve))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// slip10Ed25519ChildKey derives the hardened child key and chain code at the index, which must include the hardened offset
+func slip10Ed25519ChildKey(key []byte, chainCode []byte, index uint32) ([]byte, []byte) {
+	data := make([]byte, 0, 1+len(key)+4)
+	data = append(data, 0)
+	data = append(data, key...)
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
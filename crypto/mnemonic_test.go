@@ -0,0 +1,98 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeriveEd25519FromMnemonic(t *testing.T) {
+	// Matches the TypeScript SDK and Petra
+	const mnemonic = "shoot island position soft burden budget tooth cruel issue economy destroy above"
+	key, err := DeriveEd25519FromMnemonic(mnemonic, AptosDefaultDerivationPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x5d996aa76b3212142792d9130796cd2e11e3c445a93118c08414df4f66bc60ec", key.ToHex())
+	assert.Equal(t, "0x07968dab936c1bad187c60ce4082f307d030d780e91e694ae03aef16aba73f30", key.AuthKey().ToHex())
+
+	// Whitespace and case don't matter
+	other, err := DeriveEd25519FromMnemonic("  SHOOT island position soft burden budget tooth cruel issue economy destroy\tabove ", AptosDefaultDerivationPath)
+	assert.NoError(t, err)
+	assert.Equal(t, key.ToHex(), other.ToHex())
+
+	// Other accounts are different keys
+	other, err = DeriveEd25519FromMnemonic(mnemonic, "m/44'/637'/1'/0'/0'")
+	assert.NoError(t, err)
+	assert.NotEqual(t, key.ToHex(), other.ToHex())
+}
+
+func TestDeriveEd25519FromMnemonic_Errors(t *testing.T) {
+	const mnemonic = "shoot island position soft burden budget tooth cruel issue economy destroy above"
+	badPaths := []string{
+		"",
+		"m/44'/637'/0'/0'",
+		"m/44'/637'/0'/0'/0'/0'",
+		"m/44'/637'/0'/0/0",
+		"m/44'/60'/0'/0'/0'",
+		"m/44'/637'/a'/0'/0'",
+		"m/44'/637'/2147483648'/0'/0'",
+	}
+	for _, path := range badPaths {
+		_, err := DeriveEd25519FromMnemonic(mnemonic, path)
+		assert.Error(t, err, path)
+	}
+
+	badMnemonics := map[string]string{
+		"too short":    "shoot island position soft burden budget tooth cruel issue economy destroy",
+		"unknown word": "shoot island position soft burden budget tooth cruel issue economy destroy aptos",
+		"checksum":     "shoot island position soft burden budget tooth cruel issue economy destroy about",
+	}
+	for name, badMnemonic := range badMnemonics {
+		_, err := DeriveEd25519FromMnemonic(badMnemonic, AptosDefaultDerivationPath)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	// BIP-39 test vectors
+	valid := []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+	}
+	for _, mnemonic := range valid {
+		_, err := validateMnemonic(mnemonic)
+		assert.NoError(t, err, mnemonic)
+	}
+
+	_, err := validateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	assert.Error(t, err)
+	_, err = validateMnemonic("zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo")
+	assert.Error(t, err)
+}
+
+func TestMnemonicToSeed(t *testing.T) {
+	// BIP-39 test vector
+	words, err := validateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	assert.NoError(t, err)
+	seed := mnemonicToSeed(words, "TREZOR")
+	assert.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+}
+
+func TestSlip10Ed25519(t *testing.T) {
+	// SLIP-0010 ed25519 test vector 1
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	assert.NoError(t, err)
+	key, chainCode := slip10Ed25519MasterKey(seed)
+	assert.Equal(t, "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", hex.EncodeToString(key))
+	assert.Equal(t, "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb", hex.EncodeToString(chainCode))
+
+	// m/0H
+	key, chainCode = slip10Ed25519ChildKey(key, chainCode, hardenedOffset)
+	assert.Equal(t, "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3", hex.EncodeToString(key))
+	assert.Equal(t, "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69", hex.EncodeToString(chainCode))
+}
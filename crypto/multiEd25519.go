@@ -5,8 +5,143 @@ import (
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+	"sort"
 )
 
+//region MultiEd25519PrivateKey
+
+// MaxMultiEd25519Keys is the maximum number of public keys in a [MultiEd25519PublicKey]
+const MaxMultiEd25519Keys = MultiEd25519BitmapLen * 8
+
+// MultiEd25519PrivateKey is a [Signer] for a k-of-n [MultiEd25519PublicKey] account.  It holds the private keys for
+// some, at least k, of the public keys.
+//
+// Implements:
+//   - [Signer]
+type MultiEd25519PrivateKey struct {
+	publicKey   *MultiEd25519PublicKey
+	privateKeys map[uint8]*Ed25519PrivateKey
+}
+
+// NewMultiEd25519PrivateKey creates a [MultiEd25519PrivateKey] for the public key, from the private keys by the index
+// of their public key in publicKey.PubKeys
+//
+// Returns an error if there are fewer private keys than signatures required, or a private key doesn't match the
+// public key at its index.
+//
+//	publicKey, err := NewMultiEd25519PublicKey([]*Ed25519PublicKey{pubKey0, pubKey1, pubKey2}, 2)
+//	signer, err := NewMultiEd25519PrivateKey(publicKey, map[uint8]*Ed25519PrivateKey{0: key0, 2: key2})
+func NewMultiEd25519PrivateKey(publicKey *MultiEd25519PublicKey, privateKeys map[uint8]*Ed25519PrivateKey) (*MultiEd25519PrivateKey, error) {
+	if err := publicKey.validate(); err != nil {
+		return nil, err
+	}
+	if len(privateKeys) < int(publicKey.SignaturesRequired) {
+		return nil, fmt.Errorf("not enough private keys %d, %d required", len(privateKeys), publicKey.SignaturesRequired)
+	}
+	keys := make(map[uint8]*Ed25519PrivateKey, len(privateKeys))
+	for index, privateKey := range privateKeys {
+		if int(index) >= len(publicKey.PubKeys) {
+			return nil, fmt.Errorf("private key index %d out of range for %d public keys", index, len(publicKey.PubKeys))
+		}
+		if privateKey == nil {
+			return nil, fmt.Errorf("private key index %d is nil", index)
+		}
+		if privateKey.PubKey().ToHex() != publicKey.PubKeys[index].ToHex() {
+			return nil, fmt.Errorf("private key index %d doesn't match the public key", index)
+		}
+		keys[index] = privateKey
+	}
+	return &MultiEd25519PrivateKey{publicKey: publicKey, privateKeys: keys}, nil
+}
+
+// signingIndices are the indices of the private keys that sign, the lowest SignaturesRequired held
+func (key *MultiEd25519PrivateKey) signingIndices() []uint8 {
+	indices := make([]uint8, 0, len(key.privateKeys))
+	for index := range key.privateKeys {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices[:key.publicKey.SignaturesRequired]
+}
+
+//region MultiEd25519PrivateKey Signer implementation
+
+// Sign signs a message and returns an [AccountAuthenticator] with the [MultiEd25519Signature] and
+// [MultiEd25519PublicKey]
+//
+// Implements:
+//   - [Signer]
+func (key *MultiEd25519PrivateKey) Sign(msg []byte) (authenticator *AccountAuthenticator, err error) {
+	signature, err := key.SignMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorMultiEd25519,
+		Auth: &MultiEd25519Authenticator{
+			PubKey: key.publicKey,
+			Sig:    signature.(*MultiEd25519Signature),
+		},
+	}, nil
+}
+
+// SignMessage signs a message with the lowest indexed SignaturesRequired private keys, and returns the
+// [MultiEd25519Signature] with their bitmap
+//
+// Implements:
+//   - [Signer]
+func (key *MultiEd25519PrivateKey) SignMessage(msg []byte) (signature Signature, err error) {
+	signatures := make(map[uint8]*Ed25519Signature, key.publicKey.SignaturesRequired)
+	for _, index := range key.signingIndices() {
+		sig, err := key.privateKeys[index].SignMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		signatures[index] = sig.(*Ed25519Signature)
+	}
+	return NewMultiEd25519Signature(signatures)
+}
+
+// SimulationAuthenticator creates a new [AccountAuthenticator] for simulation purposes, with empty signatures for the
+// keys that would sign
+//
+// Implements:
+//   - [Signer]
+func (key *MultiEd25519PrivateKey) SimulationAuthenticator() *AccountAuthenticator {
+	signatures := make(map[uint8]*Ed25519Signature, key.publicKey.SignaturesRequired)
+	for _, index := range key.signingIndices() {
+		signatures[index] = &Ed25519Signature{}
+	}
+	// Can't error, the indices are validated on creation
+	signature, _ := NewMultiEd25519Signature(signatures)
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorMultiEd25519,
+		Auth: &MultiEd25519Authenticator{
+			PubKey: key.publicKey,
+			Sig:    signature,
+		},
+	}
+}
+
+// AuthKey returns the [AuthenticationKey] of the [MultiEd25519PublicKey] for a [MultiEd25519Scheme]
+//
+// Implements:
+//   - [Signer]
+func (key *MultiEd25519PrivateKey) AuthKey() *AuthenticationKey {
+	return key.publicKey.AuthKey()
+}
+
+// PubKey returns the [MultiEd25519PublicKey]
+//
+// Implements:
+//   - [Signer]
+func (key *MultiEd25519PrivateKey) PubKey() PublicKey {
+	return key.publicKey
+}
+
+//endregion
+//endregion
+
 //region MultiEd25519PublicKey
 
 // MultiEd25519PublicKey is the public key for off-chain multi-sig on Aptos with Ed25519 keys
@@ -25,30 +160,57 @@ type MultiEd25519PublicKey struct {
 	SignaturesRequired uint8
 }
 
+// NewMultiEd25519PublicKey creates a k-of-n [MultiEd25519PublicKey], where k is signaturesRequired
+//
+// The public keys must be in the same order as the on-chain account.  Returns an error if there are more than
+// [MaxMultiEd25519Keys] keys, or signaturesRequired isn't between 1 and the number of keys.
+func NewMultiEd25519PublicKey(pubKeys []*Ed25519PublicKey, signaturesRequired uint8) (*MultiEd25519PublicKey, error) {
+	key := &MultiEd25519PublicKey{PubKeys: pubKeys, SignaturesRequired: signaturesRequired}
+	if err := key.validate(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// validate checks the number of keys and the threshold are valid on-chain
+func (key *MultiEd25519PublicKey) validate() error {
+	if len(key.PubKeys) == 0 || len(key.PubKeys) > MaxMultiEd25519Keys {
+		return fmt.Errorf("number of public keys %d must be between 1 and %d", len(key.PubKeys), MaxMultiEd25519Keys)
+	}
+	if key.SignaturesRequired == 0 || int(key.SignaturesRequired) > len(key.PubKeys) {
+		return fmt.Errorf("signatures required %d must be between 1 and the number of public keys %d", key.SignaturesRequired, len(key.PubKeys))
+	}
+	return nil
+}
+
 //region MultiEd25519PublicKey VerifyingKey implementation
 
 // Verify verifies the signature against the message
 //
-// # This function will return true if the number of verified signatures is greater than or equal to the number of required signatures
+// This function will return true if there are at least the number of required signatures, and every signature
+// verifies against the public key at its index in the bitmap, the same as on-chain.
 //
 // Implements:
 //   - [VerifyingKey]
 func (key *MultiEd25519PublicKey) Verify(msg []byte, signature Signature) bool {
 	switch sig := signature.(type) {
 	case *MultiEd25519Signature:
-		verified := uint8(0)
-		// TODO: Verify with bitmap, and check that this works properly
-		for i, pubKey := range key.PubKeys {
-			if pubKey.Verify(msg, sig.Signatures[i]) {
-				verified++
+		if sig == nil {
+			return false
+		}
+		indices := sig.Indices()
+		if key.SignaturesRequired == 0 || len(indices) != len(sig.Signatures) || len(indices) < int(key.SignaturesRequired) {
+			return false
+		}
+		for i, index := range indices {
+			if int(index) >= len(key.PubKeys) || !key.PubKeys[index].Verify(msg, sig.Signatures[i]) {
+				return false
 			}
 		}
-
-		return verified >= key.SignaturesRequired
+		return true
 	default:
 		return false
 	}
-
 }
 
 //endregion
@@ -101,6 +263,9 @@ func (key *MultiEd25519PublicKey) Bytes() []byte {
 //   - [CryptoMaterial]
 func (key *MultiEd25519PublicKey) FromBytes(bytes []byte) (err error) {
 	keyBytesLength := len(bytes)
+	if keyBytesLength%ed25519.PublicKeySize != 1 {
+		return fmt.Errorf("invalid multi ed25519 public key length %d", keyBytesLength)
+	}
 	numKeys := keyBytesLength / ed25519.PublicKeySize
 	signaturesRequired := bytes[keyBytesLength-1]
 
@@ -261,8 +426,46 @@ const MultiEd25519BitmapLen = 4
 //   - [bcs.Unmarshaler]
 //   - [bcs.Struct]
 type MultiEd25519Signature struct {
-	Signatures []*Ed25519Signature
-	Bitmap     [MultiEd25519BitmapLen]byte
+	Signatures []*Ed25519Signature         // Signatures are the signatures, in the order of the bits set in the Bitmap
+	Bitmap     [MultiEd25519BitmapLen]byte // Bitmap has a bit set for the index of each public key that signed, from the leftmost bit
+}
+
+// NewMultiEd25519Signature assembles a [MultiEd25519Signature] from the signatures by the index of their public key,
+// setting the bitmap and ordering the signatures to match
+//
+//	signature, err := NewMultiEd25519Signature(map[uint8]*Ed25519Signature{0: sig0, 2: sig2})
+func NewMultiEd25519Signature(signatures map[uint8]*Ed25519Signature) (*MultiEd25519Signature, error) {
+	indices := make([]uint8, 0, len(signatures))
+	for index, signature := range signatures {
+		if int(index) >= MaxMultiEd25519Keys {
+			return nil, fmt.Errorf("signature index %d is greater than the maximum number of keys %d", index, MaxMultiEd25519Keys)
+		}
+		if signature == nil {
+			return nil, fmt.Errorf("signature index %d is nil", index)
+		}
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	sig := &MultiEd25519Signature{Signatures: make([]*Ed25519Signature, len(indices))}
+	for i, index := range indices {
+		numByte, numBit := KeyIndices(index)
+		sig.Bitmap[numByte] |= 128 >> numBit
+		sig.Signatures[i] = signatures[index]
+	}
+	return sig, nil
+}
+
+// Indices returns the indices of the public keys that signed, from the bitmap, in increasing order
+func (e *MultiEd25519Signature) Indices() []uint8 {
+	indices := make([]uint8, 0, len(e.Signatures))
+	for i := uint8(0); i < MaxMultiEd25519Keys; i++ {
+		numByte, numBit := KeyIndices(i)
+		if e.Bitmap[numByte]&(128>>numBit) != 0 {
+			indices = append(indices, i)
+		}
+	}
+	return indices
 }
 
 //region MultiEd25519Signature CryptoMaterial implementation
@@ -290,8 +493,11 @@ func (e *MultiEd25519Signature) Bytes() []byte {
 // Implements:
 //   - [CryptoMaterial]
 func (e *MultiEd25519Signature) FromBytes(bytes []byte) (err error) {
+	if len(bytes) < MultiEd25519BitmapLen || (len(bytes)-MultiEd25519BitmapLen)%ed25519.SignatureSize != 0 {
+		return fmt.Errorf("invalid multi ed25519 signature length %d", len(bytes))
+	}
 	signatures := make([]*Ed25519Signature, len(bytes)/ed25519.SignatureSize)
-	for i := 0; (i+1)*ed25519.SignatureSize < len(bytes); i++ {
+	for i := range signatures {
 		start := i * ed25519.SignatureSize
 		end := start + ed25519.SignatureSize
 		signatures[i] = &Ed25519Signature{}
@@ -1,8 +1,10 @@
 package crypto
 
 import (
+	"encoding/hex"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -61,6 +63,182 @@ func TestMultiEd25519KeySerialization(t *testing.T) {
 
 }
 
+const (
+	testMultiEd25519PublicKey0  = "0xd04ab232742bb4ab3a1368bd4615e4e6d0224ab71a016baf8520a332c9778737"
+	testMultiEd25519PublicKey1  = "0xa09aa5f47a6759802ff955f8dc2d2a14a5c99d23be97f864127ff9383455a4f0"
+	testMultiEd25519PublicKey2  = "0x17cb79fb2b4120f2b1ec65e4198d6e08b28e813feb01e4a400839b85e18080ce"
+	testMultiEd25519Signature0  = "31cb0314a653e7f5e7a5bb118dff1931ccc345b008ec27b1f9fc2063e79ce3f3df66e8a2f903c3fbe7c2e1618d831b29654289409d5823338c4357ab6efd9402"
+	testMultiEd25519Signature2  = "678afe1b6b5a8dd931658748ff05dce5e68099596b3d70ff96d7097a12a7375d07a54d151aeb392b80e3b25ff2e5529142b0ac7ebac01375bc7d118b3e063500"
+	testMultiEd25519AuthKey     = "0x42d3a067f871adf376e26e4fad39f65b6710e79a47ea756856635d6529b52fe1"
+	testMultiEd25519SignMessage = "hello aptos"
+)
+
+// createMultiEd25519Signer creates a 2-of-3 signer from the private keys 0x1111..., 0x2222..., 0x3333..., holding the
+// keys at the indices
+func createMultiEd25519Signer(t *testing.T, indices ...uint8) (*MultiEd25519PrivateKey, []*Ed25519PrivateKey) {
+	privateKeys := make([]*Ed25519PrivateKey, 3)
+	pubKeys := make([]*Ed25519PublicKey, 3)
+	for i, b := range []string{"11", "22", "33"} {
+		privateKeys[i] = &Ed25519PrivateKey{}
+		err := privateKeys[i].FromHex("0x" + strings.Repeat(b, 32))
+		assert.NoError(t, err)
+		pubKeys[i] = privateKeys[i].PubKey().(*Ed25519PublicKey)
+	}
+	publicKey, err := NewMultiEd25519PublicKey(pubKeys, 2)
+	assert.NoError(t, err)
+
+	held := make(map[uint8]*Ed25519PrivateKey, len(indices))
+	for _, index := range indices {
+		held[index] = privateKeys[index]
+	}
+	signer, err := NewMultiEd25519PrivateKey(publicKey, held)
+	assert.NoError(t, err)
+	return signer, privateKeys
+}
+
+func TestMultiEd25519PrivateKey_Sign(t *testing.T) {
+	signer, _ := createMultiEd25519Signer(t, 2, 0)
+	publicKey := signer.PubKey().(*MultiEd25519PublicKey)
+	assert.Equal(t, testMultiEd25519PublicKey0, publicKey.PubKeys[0].ToHex())
+	assert.Equal(t, testMultiEd25519PublicKey1, publicKey.PubKeys[1].ToHex())
+	assert.Equal(t, testMultiEd25519PublicKey2, publicKey.PubKeys[2].ToHex())
+
+	// The public key is the keys, then the threshold, and the auth key adds the MultiEd25519 scheme
+	assert.Equal(t, testMultiEd25519PublicKey0+testMultiEd25519PublicKey1[2:]+testMultiEd25519PublicKey2[2:]+"02", publicKey.ToHex())
+	assert.Equal(t, testMultiEd25519AuthKey, signer.AuthKey().ToHex())
+	assert.Equal(t, MultiEd25519Scheme, publicKey.Scheme())
+
+	message := []byte(testMultiEd25519SignMessage)
+	signature, err := signer.SignMessage(message)
+	assert.NoError(t, err)
+	multiSig := signature.(*MultiEd25519Signature)
+	// Keys 0 and 2 signed, from the leftmost bit
+	assert.Equal(t, [4]byte{0xa0, 0, 0, 0}, multiSig.Bitmap)
+	assert.Equal(t, []uint8{0, 2}, multiSig.Indices())
+	// The signatures are in bitmap order, followed by the bitmap
+	assert.Equal(t, "0x"+testMultiEd25519Signature0+testMultiEd25519Signature2+"a0000000", multiSig.ToHex())
+	assert.True(t, publicKey.Verify(message, multiSig))
+	assert.False(t, publicKey.Verify([]byte("other message"), multiSig))
+
+	// The authenticator is the MultiEd25519 variant, with the length-prefixed public key and signature
+	auth, err := signer.Sign(message)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAuthenticatorMultiEd25519, auth.Variant)
+	assert.True(t, auth.Verify(message))
+	authBytes, err := bcs.Serialize(auth)
+	assert.NoError(t, err)
+	expectedAuth := "01" + "61" + testMultiEd25519PublicKey0[2:] + testMultiEd25519PublicKey1[2:] + testMultiEd25519PublicKey2[2:] + "02" +
+		"84" + "01" + testMultiEd25519Signature0 + testMultiEd25519Signature2 + "a0000000"
+	assert.Equal(t, expectedAuth, hex.EncodeToString(authBytes))
+
+	authDeserialized := &AccountAuthenticator{}
+	err = bcs.Deserialize(authDeserialized, authBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, auth, authDeserialized)
+	assert.True(t, authDeserialized.Verify(message))
+}
+
+func TestMultiEd25519PrivateKey_SignWithAllKeys(t *testing.T) {
+	// Only the threshold signs, from the lowest indices
+	signer, _ := createMultiEd25519Signer(t, 0, 1, 2)
+	signature, err := signer.SignMessage([]byte(testMultiEd25519SignMessage))
+	assert.NoError(t, err)
+	assert.Equal(t, [4]byte{0xc0, 0, 0, 0}, signature.(*MultiEd25519Signature).Bitmap)
+	assert.Len(t, signature.(*MultiEd25519Signature).Signatures, 2)
+	assert.True(t, signer.PubKey().Verify([]byte(testMultiEd25519SignMessage), signature))
+
+	// Simulation has empty signatures for the same keys
+	simulation := signer.SimulationAuthenticator()
+	assert.Equal(t, AccountAuthenticatorMultiEd25519, simulation.Variant)
+	simulationSig := simulation.Signature().(*MultiEd25519Signature)
+	assert.Equal(t, [4]byte{0xc0, 0, 0, 0}, simulationSig.Bitmap)
+	assert.Equal(t, []*Ed25519Signature{{}, {}}, simulationSig.Signatures)
+}
+
+func TestMultiEd25519PublicKey_VerifyBitmap(t *testing.T) {
+	signer, privateKeys := createMultiEd25519Signer(t, 0, 2)
+	publicKey := signer.PubKey().(*MultiEd25519PublicKey)
+	message := []byte(testMultiEd25519SignMessage)
+	sig0, err := privateKeys[0].SignMessage(message)
+	assert.NoError(t, err)
+	sig2, err := privateKeys[2].SignMessage(message)
+	assert.NoError(t, err)
+
+	// The signatures must match the keys in the bitmap
+	swapped, err := NewMultiEd25519Signature(map[uint8]*Ed25519Signature{0: sig2.(*Ed25519Signature), 2: sig0.(*Ed25519Signature)})
+	assert.NoError(t, err)
+	assert.False(t, publicKey.Verify(message, swapped))
+
+	// Not enough signatures
+	single, err := NewMultiEd25519Signature(map[uint8]*Ed25519Signature{0: sig0.(*Ed25519Signature)})
+	assert.NoError(t, err)
+	assert.False(t, publicKey.Verify(message, single))
+
+	// The bitmap must match the number of signatures
+	mismatched, err := NewMultiEd25519Signature(map[uint8]*Ed25519Signature{0: sig0.(*Ed25519Signature), 2: sig2.(*Ed25519Signature)})
+	assert.NoError(t, err)
+	assert.True(t, publicKey.Verify(message, mismatched))
+	mismatched.Bitmap[0] |= 0x40
+	assert.False(t, publicKey.Verify(message, mismatched))
+
+	// A bit past the number of keys
+	outOfRange, err := NewMultiEd25519Signature(map[uint8]*Ed25519Signature{0: sig0.(*Ed25519Signature), 5: sig2.(*Ed25519Signature)})
+	assert.NoError(t, err)
+	assert.False(t, publicKey.Verify(message, outOfRange))
+}
+
+func TestMultiEd25519PublicKey_VerifyTampered(t *testing.T) {
+	signer, _ := createMultiEd25519Signer(t, 0, 1)
+	publicKey := signer.PubKey()
+	message := []byte(testMultiEd25519SignMessage)
+	signature, err := signer.SignMessage(message)
+	assert.NoError(t, err)
+	assert.True(t, publicKey.Verify(message, signature))
+
+	// A different message, or a changed signature must not verify
+	assert.False(t, publicKey.Verify([]byte("other message"), signature))
+	multiSig := signature.(*MultiEd25519Signature)
+	tamperedSig := &Ed25519Signature{Inner: multiSig.Signatures[1].Inner}
+	tamperedSig.Inner[0] ^= 0x01
+	tampered := &MultiEd25519Signature{Signatures: []*Ed25519Signature{multiSig.Signatures[0], tamperedSig}, Bitmap: multiSig.Bitmap}
+	assert.False(t, publicKey.Verify(message, tampered))
+
+	// Missing signatures, other types, or nil
+	assert.False(t, publicKey.Verify(message, &MultiEd25519Signature{Signatures: []*Ed25519Signature{multiSig.Signatures[0], nil}, Bitmap: multiSig.Bitmap}))
+	assert.False(t, publicKey.Verify(message, &MultiEd25519Signature{}))
+	assert.False(t, publicKey.Verify(message, multiSig.Signatures[0]))
+	assert.False(t, publicKey.Verify(message, (*MultiEd25519Signature)(nil)))
+}
+
+func TestMultiEd25519_Errors(t *testing.T) {
+	signer, privateKeys := createMultiEd25519Signer(t, 0, 1)
+	publicKey := signer.PubKey().(*MultiEd25519PublicKey)
+
+	_, err := NewMultiEd25519PublicKey(publicKey.PubKeys, 0)
+	assert.Error(t, err)
+	_, err = NewMultiEd25519PublicKey(publicKey.PubKeys, 4)
+	assert.Error(t, err)
+	_, err = NewMultiEd25519PublicKey([]*Ed25519PublicKey{}, 1)
+	assert.Error(t, err)
+
+	// Not enough keys, out of range, or the wrong key
+	_, err = NewMultiEd25519PrivateKey(publicKey, map[uint8]*Ed25519PrivateKey{0: privateKeys[0]})
+	assert.ErrorContains(t, err, "not enough private keys")
+	_, err = NewMultiEd25519PrivateKey(publicKey, map[uint8]*Ed25519PrivateKey{0: privateKeys[0], 3: privateKeys[1]})
+	assert.ErrorContains(t, err, "out of range")
+	_, err = NewMultiEd25519PrivateKey(publicKey, map[uint8]*Ed25519PrivateKey{0: privateKeys[0], 1: privateKeys[2]})
+	assert.ErrorContains(t, err, "doesn't match")
+
+	_, err = NewMultiEd25519Signature(map[uint8]*Ed25519Signature{32: {}})
+	assert.Error(t, err)
+
+	// Bad lengths
+	assert.Error(t, (&MultiEd25519PublicKey{}).FromBytes([]byte{}))
+	assert.Error(t, (&MultiEd25519PublicKey{}).FromBytes(make([]byte, 32+2)))
+	assert.Error(t, (&MultiEd25519Signature{}).FromBytes([]byte{0xc0}))
+	assert.Error(t, (&MultiEd25519Signature{}).FromBytes(make([]byte, 64+5)))
+}
+
 func createMultiEd25519Key(t *testing.T) (
 	*Ed25519PrivateKey,
 	*Ed25519PrivateKey,
@@ -90,12 +268,11 @@ func createMultiEd25519Signature(t *testing.T, key1 *Ed25519PrivateKey, key2 *Ed
 	sig2, err := key2.SignMessage(message)
 	assert.NoError(t, err)
 
-	// TODO: This signature should be built easier, ergonomics to fix this late
-	return &MultiEd25519Signature{
-		Signatures: []*Ed25519Signature{
-			sig1.(*Ed25519Signature),
-			sig2.(*Ed25519Signature),
-		},
-		Bitmap: [4]byte([]byte("c0000000")),
-	}
+	signature, err := NewMultiEd25519Signature(map[uint8]*Ed25519Signature{
+		0: sig1.(*Ed25519Signature),
+		1: sig2.(*Ed25519Signature),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [4]byte{0xc0, 0, 0, 0}, signature.Bitmap)
+	return signature
 }
@@ -26,19 +26,31 @@ type MultiKey struct {
 //region MultiKey VerifyingKey implementation
 
 // Verify verifies the signature against the message
-// This function will return true if the number of verified signatures is greater than or equal to the number of required signatures
+//
+// This function will return true if there are at least the number of required signatures, and at least one, and every
+// signature verifies against the public key at its index in the bitmap.  A single invalid signature fails, even if
+// enough others are valid.
 //
 // Implements:
 //   - [VerifyingKey]
 func (key *MultiKey) Verify(msg []byte, signature Signature) bool {
 	switch sig := signature.(type) {
 	case *MultiKeySignature:
-		if key.SignaturesRequired > uint8(len(sig.Signatures)) {
+		if sig == nil || len(sig.Signatures) == 0 || int(key.SignaturesRequired) > len(sig.Signatures) {
+			return false
+		}
+
+		// Each signature must have exactly one key in the bitmap
+		indices := sig.Bitmap.Indices()
+		if len(indices) != len(sig.Signatures) {
 			return false
 		}
 
 		// Convert to individual authenticators, and verify
-		for sigIndex, keyIndex := range sig.Bitmap.Indices() {
+		for sigIndex, keyIndex := range indices {
+			if int(keyIndex) >= len(key.PubKeys) {
+				return false
+			}
 			authenticator := AccountAuthenticator{}
 			err := authenticator.FromKeyAndSignature(key.PubKeys[keyIndex], sig.Signatures[sigIndex])
 			if err != nil {
@@ -136,13 +148,7 @@ func (key *MultiKey) MarshalBCS(ser *bcs.Serializer) {
 // Implements:
 //   - [bcs.Unmarshaler]
 func (key *MultiKey) UnmarshalBCS(des *bcs.Deserializer) {
-	length := des.Uleb128()
-	key.PubKeys = make([]*AnyPublicKey, length)
-
-	for i := uint32(0); i < length; i++ {
-		key.PubKeys[i] = &AnyPublicKey{}
-		des.Struct(key.PubKeys[i])
-	}
+	key.PubKeys = bcs.DeserializeSequence[*AnyPublicKey](des)
 	key.SignaturesRequired = des.U8()
 }
 
@@ -261,14 +267,7 @@ func (e *MultiKeySignature) MarshalBCS(ser *bcs.Serializer) {
 // Implements:
 //   - [bcs.Unmarshaler]
 func (e *MultiKeySignature) UnmarshalBCS(des *bcs.Deserializer) {
-	length := des.Uleb128()
-	e.Signatures = make([]*AnySignature, length)
-
-	for i := uint32(0); i < length; i++ {
-		e.Signatures[i] = &AnySignature{}
-		des.Struct(e.Signatures[i])
-	}
-
+	e.Signatures = bcs.DeserializeSequence[*AnySignature](des)
 	e.Bitmap.UnmarshalBCS(des)
 }
 
@@ -290,6 +289,48 @@ type MultiKeyAuthenticator struct {
 	Sig    *MultiKeySignature // The signature of the authenticator
 }
 
+// NewMultiKeyAuthenticator assembles an [AccountAuthenticator] for a k-of-n [MultiKey], from the signatures by the
+// index of their public key
+//
+// The public keys must be in the same order as the on-chain account.  There must be at least signaturesRequired
+// signatures, and every index must be a key in pubKeys.
+//
+//	auth, err := NewMultiKeyAuthenticator(pubKeys, 2, map[uint8]*AnySignature{0: sig0, 2: sig2})
+func NewMultiKeyAuthenticator(pubKeys []*AnyPublicKey, signaturesRequired uint8, signatures map[uint8]*AnySignature) (*AccountAuthenticator, error) {
+	if len(pubKeys) > int(MaxMultiKeySignatures) {
+		return nil, fmt.Errorf("too many public keys %d, maximum is %d", len(pubKeys), MaxMultiKeySignatures)
+	}
+	if signaturesRequired == 0 || int(signaturesRequired) > len(pubKeys) {
+		return nil, fmt.Errorf("signatures required %d must be between 1 and the number of public keys %d", signaturesRequired, len(pubKeys))
+	}
+	if len(signatures) < int(signaturesRequired) {
+		return nil, fmt.Errorf("not enough signatures %d, %d required", len(signatures), signaturesRequired)
+	}
+
+	indexedSignatures := make([]IndexedAnySignature, 0, len(signatures))
+	for index, signature := range signatures {
+		if int(index) >= len(pubKeys) {
+			return nil, fmt.Errorf("signature index %d out of range for %d public keys", index, len(pubKeys))
+		}
+		if signature == nil {
+			return nil, fmt.Errorf("signature index %d is nil", index)
+		}
+		indexedSignatures = append(indexedSignatures, IndexedAnySignature{Index: index, Signature: signature})
+	}
+	sig, err := NewMultiKeySignature(indexedSignatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountAuthenticator{
+		Variant: AccountAuthenticatorMultiKey,
+		Auth: &MultiKeyAuthenticator{
+			PubKey: &MultiKey{PubKeys: pubKeys, SignaturesRequired: signaturesRequired},
+			Sig:    sig,
+		},
+	}, nil
+}
+
 //region MultiKeyAuthenticator AccountAuthenticatorImpl implementation
 
 // PublicKey returns the public key of the authenticator
@@ -371,7 +412,7 @@ func (bm *MultiKeyBitmap) ContainsKey(index uint8) bool {
 	if int(numByte) >= len(bm.inner) {
 		return false
 	}
-	return (bm.inner[numByte] & (128 >> numBit)) == 1
+	return (bm.inner[numByte] & (128 >> numBit)) != 0
 }
 
 // AddKey adds the value to the map, returning an error if it is already added
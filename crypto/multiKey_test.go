@@ -148,3 +148,147 @@ func createMultiKeySignature(t *testing.T, index1 uint8, key1 *SingleSigner, ind
 	assert.NoError(t, err)
 	return sig
 }
+
+func TestNewMultiKeyAuthenticator(t *testing.T) {
+	key1, key2, key3, pubkey1, pubkey2, pubkey3, _ := createMultiKey(t)
+	pubKeys := []*AnyPublicKey{pubkey1, pubkey2, pubkey3}
+	signers := []*SingleSigner{key1, key2, key3}
+	message := []byte("hello world")
+
+	tests := []struct {
+		indices []uint8
+		bitmap  byte
+	}{
+		{[]uint8{0, 1}, 0b11000000},
+		{[]uint8{0, 2}, 0b10100000},
+		{[]uint8{1, 2}, 0b01100000},
+		{[]uint8{0, 1, 2}, 0b11100000},
+	}
+	for _, test := range tests {
+		signatures := make(map[uint8]*AnySignature)
+		for _, index := range test.indices {
+			sig, err := signers[index].SignMessage(message)
+			assert.NoError(t, err)
+			signatures[index] = sig.(*AnySignature)
+		}
+
+		auth, err := NewMultiKeyAuthenticator(pubKeys, 2, signatures)
+		assert.NoError(t, err)
+		assert.Equal(t, AccountAuthenticatorMultiKey, auth.Variant)
+		assert.True(t, auth.Verify(message))
+		assert.False(t, auth.Verify([]byte("other message")))
+
+		multiKeySig := auth.Signature().(*MultiKeySignature)
+		assert.Equal(t, test.indices, multiKeySig.Bitmap.Indices())
+		for _, index := range test.indices {
+			assert.True(t, multiKeySig.Bitmap.ContainsKey(index))
+		}
+
+		// The bitmap is the last part of the authenticator, as a single length prefixed byte
+		authBytes, err := bcs.Serialize(auth)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1, test.bitmap}, authBytes[len(authBytes)-2:])
+
+		// Round trip
+		authDeserialized := &AccountAuthenticator{}
+		err = bcs.Deserialize(authDeserialized, authBytes)
+		assert.NoError(t, err)
+		assert.True(t, authDeserialized.Verify(message))
+	}
+}
+
+func TestNewMultiKeyAuthenticator_Errors(t *testing.T) {
+	key1, key2, _, pubkey1, pubkey2, pubkey3, _ := createMultiKey(t)
+	pubKeys := []*AnyPublicKey{pubkey1, pubkey2, pubkey3}
+	message := []byte("hello world")
+	sig1, err := key1.SignMessage(message)
+	assert.NoError(t, err)
+	sig2, err := key2.SignMessage(message)
+	assert.NoError(t, err)
+
+	// Fewer than the threshold
+	_, err = NewMultiKeyAuthenticator(pubKeys, 2, map[uint8]*AnySignature{0: sig1.(*AnySignature)})
+	assert.Error(t, err)
+
+	// Out of range index
+	_, err = NewMultiKeyAuthenticator(pubKeys, 2, map[uint8]*AnySignature{0: sig1.(*AnySignature), 3: sig2.(*AnySignature)})
+	assert.Error(t, err)
+
+	// Bad thresholds
+	_, err = NewMultiKeyAuthenticator(pubKeys, 0, map[uint8]*AnySignature{0: sig1.(*AnySignature)})
+	assert.Error(t, err)
+	_, err = NewMultiKeyAuthenticator(pubKeys, 4, map[uint8]*AnySignature{0: sig1.(*AnySignature), 1: sig2.(*AnySignature)})
+	assert.Error(t, err)
+}
+
+func TestMultiKey_VerifyWrongKey(t *testing.T) {
+	key1, key2, _, _, _, _, publicKey := createMultiKey(t)
+	message := []byte("hello world")
+
+	// Signatures assigned to the wrong keys must not verify
+	signature := createMultiKeySignature(t, 0, key2, 1, key1, message)
+	assert.False(t, publicKey.Verify(message, signature))
+
+	// A bitmap that doesn't match the number of signatures must not verify
+	signature = createMultiKeySignature(t, 0, key1, 1, key2, message)
+	assert.NoError(t, signature.Bitmap.AddKey(2))
+	assert.False(t, publicKey.Verify(message, signature))
+}
+
+func TestMultiKey_VerifyTampered(t *testing.T) {
+	key1, _, key3, _, _, _, publicKey := createMultiKey(t)
+	message := []byte("hello world")
+	signature := createMultiKeySignature(t, 0, key1, 2, key3, message)
+	assert.True(t, publicKey.Verify(message, signature))
+
+	// A different message, or a changed signature of either key type must not verify
+	assert.False(t, publicKey.Verify([]byte("hello world!"), signature))
+	for i, sig := range signature.Signatures {
+		tamperedSignatures := append([]*AnySignature{}, signature.Signatures...)
+		switch inner := sig.Signature.(type) {
+		case *Ed25519Signature:
+			tamperedInner := &Ed25519Signature{Inner: inner.Inner}
+			tamperedInner.Inner[0] ^= 0x01
+			tamperedSignatures[i] = &AnySignature{Variant: sig.Variant, Signature: tamperedInner}
+		case *Secp256k1Signature:
+			tamperedInner := &Secp256k1Signature{Inner: inner.Inner}
+			tamperedInner.Inner[0] ^= 0x01
+			tamperedSignatures[i] = &AnySignature{Variant: sig.Variant, Signature: tamperedInner}
+		}
+		tampered := &MultiKeySignature{Signatures: tamperedSignatures, Bitmap: signature.Bitmap}
+		assert.False(t, publicKey.Verify(message, tampered))
+	}
+
+	// Fewer signatures than the threshold
+	sig1, err := key1.SignMessage(message)
+	assert.NoError(t, err)
+	single, err := NewMultiKeySignature([]IndexedAnySignature{{Index: 0, Signature: sig1.(*AnySignature)}})
+	assert.NoError(t, err)
+	assert.False(t, publicKey.Verify(message, single))
+	assert.True(t, (&MultiKey{PubKeys: publicKey.PubKeys, SignaturesRequired: 1}).Verify(message, single))
+
+	// Missing signatures, other types, or nil
+	assert.False(t, (&MultiKey{PubKeys: publicKey.PubKeys}).Verify(message, &MultiKeySignature{}))
+	assert.False(t, publicKey.Verify(message, &MultiKeySignature{Signatures: []*AnySignature{signature.Signatures[0], nil}, Bitmap: signature.Bitmap}))
+	assert.False(t, publicKey.Verify(message, sig1))
+	assert.False(t, publicKey.Verify(message, (*MultiKeySignature)(nil)))
+}
+
+func TestMultiKeyBitmap(t *testing.T) {
+	bitmap := MultiKeyBitmap{}
+	assert.NoError(t, bitmap.AddKey(0))
+	assert.NoError(t, bitmap.AddKey(7))
+	assert.NoError(t, bitmap.AddKey(9))
+	assert.Error(t, bitmap.AddKey(7))
+	assert.Error(t, bitmap.AddKey(MaxMultiKeySignatures))
+	assert.True(t, bitmap.ContainsKey(0))
+	assert.False(t, bitmap.ContainsKey(1))
+	assert.True(t, bitmap.ContainsKey(7))
+	assert.True(t, bitmap.ContainsKey(9))
+	assert.False(t, bitmap.ContainsKey(31))
+	assert.Equal(t, []uint8{0, 7, 9}, bitmap.Indices())
+
+	bitmapBytes, err := bcs.Serialize(&bitmap)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2, 0b10000001, 0b01000000}, bitmapBytes)
+}
@@ -0,0 +1,64 @@
+package crypto
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PersonalMessagePrefix is the prefix of every wallet signing message, for domain separation from transactions
+const PersonalMessagePrefix = "APTOS"
+
+// PersonalMessage is an off-chain message signed by a wallet, in the format used by the wallet adapter's signMessage
+//
+// The optional fields are only included in the full message when set.  The full message is:
+//
+//	APTOS
+//	address: 0x1
+//	application: https://example.com
+//	chainId: 1
+//	message: hello world
+//	nonce: 1234
+type PersonalMessage struct {
+	Address     string // Address of the signer, optional
+	Application string // Application is the origin requesting the signature e.g. https://example.com, optional
+	ChainId     uint8  // ChainId of the network, optional and omitted if 0
+	Message     string // Message to sign
+	Nonce       string // Nonce to prevent replay, chosen by the application
+}
+
+// FullMessage is the message that is signed, with the [PersonalMessagePrefix] and the fields each on a new line
+func (m *PersonalMessage) FullMessage() string {
+	builder := strings.Builder{}
+	builder.WriteString(PersonalMessagePrefix)
+	if m.Address != "" {
+		builder.WriteString("\naddress: ")
+		builder.WriteString(m.Address)
+	}
+	if m.Application != "" {
+		builder.WriteString("\napplication: ")
+		builder.WriteString(m.Application)
+	}
+	if m.ChainId != 0 {
+		builder.WriteString("\nchainId: ")
+		builder.WriteString(strconv.FormatUint(uint64(m.ChainId), 10))
+	}
+	builder.WriteString("\nmessage: ")
+	builder.WriteString(m.Message)
+	builder.WriteString("\nnonce: ")
+	builder.WriteString(m.Nonce)
+	return builder.String()
+}
+
+// SignMessage signs the full message of a [PersonalMessage], the same as a wallet's signMessage.  The signature can be
+// verified with [VerifyMessage].
+//
+//	signature, err := SignMessage(signer, &PersonalMessage{Message: "hello world", Nonce: "1234"})
+func SignMessage(signer Signer, message *PersonalMessage) (Signature, error) {
+	return signer.SignMessage([]byte(message.FullMessage()))
+}
+
+// VerifyMessage verifies a signature of the full message of a [PersonalMessage], such as one returned by a wallet's
+// signMessage
+func VerifyMessage(publicKey VerifyingKey, message *PersonalMessage, signature Signature) bool {
+	return publicKey.Verify([]byte(message.FullMessage()), signature)
+}
@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPersonalMessage_FullMessage(t *testing.T) {
+	// Only the message and nonce
+	message := &PersonalMessage{Message: "hello world", Nonce: "1234"}
+	assert.Equal(t, "APTOS\nmessage: hello world\nnonce: 1234", message.FullMessage())
+
+	// All the optional fields, in the same order as the wallet adapter
+	message = &PersonalMessage{
+		Address:     "0x978c213990c4833df71548df7ce49d54c759d6b6d932de22b24d56060b7af2aa",
+		Application: "https://aptos.dev",
+		ChainId:     1,
+		Message:     "Welcome to Aptos!",
+		Nonce:       "random-nonce",
+	}
+	assert.Equal(t, "APTOS\n"+
+		"address: 0x978c213990c4833df71548df7ce49d54c759d6b6d932de22b24d56060b7af2aa\n"+
+		"application: https://aptos.dev\n"+
+		"chainId: 1\n"+
+		"message: Welcome to Aptos!\n"+
+		"nonce: random-nonce", message.FullMessage())
+
+	// Some of the optional fields, and a multi-line message
+	message = &PersonalMessage{Application: "https://aptos.dev", ChainId: 2, Message: "line 1\nline 2", Nonce: "5"}
+	assert.Equal(t, "APTOS\napplication: https://aptos.dev\nchainId: 2\nmessage: line 1\nline 2\nnonce: 5", message.FullMessage())
+}
+
+func TestSignMessage(t *testing.T) {
+	privateKey := &Ed25519PrivateKey{}
+	err := privateKey.FromHex(testEd25519PrivateKey)
+	assert.NoError(t, err)
+	message := &PersonalMessage{
+		Address: testEd25519Address,
+		Message: "hello world",
+		Nonce:   "1234",
+	}
+
+	signature, err := SignMessage(privateKey, message)
+	assert.NoError(t, err)
+	assert.True(t, VerifyMessage(privateKey.PubKey(), message, signature))
+
+	// It's a signature of the full message, not the message alone
+	expected, err := privateKey.SignMessage([]byte(message.FullMessage()))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, signature)
+	assert.False(t, privateKey.PubKey().Verify([]byte(message.Message), signature))
+
+	// A change to any of the fields must not verify
+	assert.False(t, VerifyMessage(privateKey.PubKey(), &PersonalMessage{Address: testEd25519Address, Message: "hello world", Nonce: "1235"}, signature))
+	assert.False(t, VerifyMessage(privateKey.PubKey(), &PersonalMessage{Message: "hello world", Nonce: "1234"}, signature))
+	assert.False(t, VerifyMessage(privateKey.PubKey(), &PersonalMessage{Address: testEd25519Address, ChainId: 1, Message: "hello world", Nonce: "1234"}, signature))
+
+	// Nor with another key
+	otherKey, err := GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	assert.False(t, VerifyMessage(otherKey.PubKey(), message, signature))
+}
+
+func TestSignMessage_SingleKey(t *testing.T) {
+	privateKey, err := GenerateSecp256k1Key()
+	assert.NoError(t, err)
+	signer := NewSingleSigner(privateKey)
+	message := &PersonalMessage{Application: "https://aptos.dev", Message: "hello world", Nonce: "1234"}
+
+	signature, err := SignMessage(signer, message)
+	assert.NoError(t, err)
+	assert.True(t, VerifyMessage(signer.PubKey(), message, signature))
+	assert.False(t, VerifyMessage(signer.PubKey(), &PersonalMessage{Message: "hello world", Nonce: "1234"}, signature))
+}
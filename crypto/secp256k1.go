@@ -67,7 +67,7 @@ func (key *Secp256k1PrivateKey) EmptySignature() Signature {
 //   - [MessageSigner]
 func (key *Secp256k1PrivateKey) SignMessage(msg []byte) (sig Signature, err error) {
 	hash := util.Sha3256Hash([][]byte{msg})
-	// TODO: The eth library doesn't protect against malleability issues, so we need to handle those.
+	// The signer always produces the low-S form, which is the only form accepted on-chain for malleability
 	signature, err := ethCrypto.Sign(hash, key.Inner)
 	if err != nil {
 		return nil, err
@@ -155,13 +155,16 @@ type Secp256k1PublicKey struct {
 
 // Verify verifies the signature of a message
 //
-// Returns true if the signature is valid and a [Secp256k1Signature], false otherwise
+// Returns true if the signature is valid and a non-nil [Secp256k1Signature], false otherwise
 //
 // Implements:
 //   - [VerifyingKey]
 func (key *Secp256k1PublicKey) Verify(msg []byte, sig Signature) bool {
 	switch sig := sig.(type) {
 	case *Secp256k1Signature:
+		if sig == nil {
+			return false
+		}
 		// Verification requires to pass the SHA-256 hash of the message
 		msg = util.Sha3256Hash([][]byte{msg})
 		return ethCrypto.VerifySignature(key.Bytes(), msg, sig.Bytes())
@@ -172,6 +175,44 @@ func (key *Secp256k1PublicKey) Verify(msg []byte, sig Signature) bool {
 
 //endregion
 
+//region Secp256k1PublicKey recovery
+
+// RecoverSecp256k1PublicKey recovers the [Secp256k1PublicKey] which signed the message, from the signature and its
+// recovery ID.  This is for signatures without the public key, e.g. compact signatures from EVM-style tooling.
+//
+// The message is hashed with SHA3-256 before recovery, the same as [Secp256k1PrivateKey.SignMessage].  The recovery ID
+// is 0 or 1, and the Ethereum style 27 or 28 are accepted too.
+//
+//	publicKey, err := RecoverSecp256k1PublicKey(message, signature, recoveryID)
+//	anyPublicKey, err := ToAnyPublicKey(publicKey)
+//	address := anyPublicKey.AuthKey() // The address of the account, if the key was never rotated
+//
+// A successful recovery doesn't mean the signature is from the expected signer, the recovered key must be checked.
+func RecoverSecp256k1PublicKey(message []byte, signature *Secp256k1Signature, recoveryID byte) (*Secp256k1PublicKey, error) {
+	if signature == nil {
+		return nil, fmt.Errorf("secp256k1 signature is nil")
+	}
+	v := recoveryID
+	if v == 27 || v == 28 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, fmt.Errorf("invalid secp256k1 recovery id %d, expected 0, 1, 27, or 28", recoveryID)
+	}
+
+	recoverable := make([]byte, ethCrypto.SignatureLength)
+	copy(recoverable, signature.Inner[:])
+	recoverable[Secp256k1SignatureLength] = v
+	hash := util.Sha3256Hash([][]byte{message})
+	publicKey, err := ethCrypto.SigToPub(hash, recoverable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover secp256k1 public key: %w", err)
+	}
+	return &Secp256k1PublicKey{Inner: publicKey}, nil
+}
+
+//endregion
+
 //region Secp256k1PublicKey CryptoMaterial
 
 // Bytes returns the raw bytes of the [Secp256k1PublicKey]
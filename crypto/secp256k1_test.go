@@ -1,9 +1,12 @@
 package crypto
 
 import (
+	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"math/big"
 	"testing"
 )
 
@@ -122,3 +125,146 @@ func TestGenerateSecp256k1Key(t *testing.T) {
 
 	assert.True(t, privateKey.VerifyingKey().Verify(msg, sig))
 }
+
+func TestSecp256k1PublicKey_VerifyTampered(t *testing.T) {
+	privateKey, err := GenerateSecp256k1Key()
+	assert.NoError(t, err)
+	publicKey := privateKey.VerifyingKey()
+	message := []byte("hello world")
+	signature, err := privateKey.SignMessage(message)
+	assert.NoError(t, err)
+	assert.True(t, publicKey.Verify(message, signature))
+
+	// A different message, or a changed signature must not verify
+	assert.False(t, publicKey.Verify([]byte("hello world!"), signature))
+	tampered := &Secp256k1Signature{Inner: signature.(*Secp256k1Signature).Inner}
+	tampered.Inner[10] ^= 0x01
+	assert.False(t, publicKey.Verify(message, tampered))
+
+	// Another key's signature must not verify
+	otherKey, err := GenerateSecp256k1Key()
+	assert.NoError(t, err)
+	assert.False(t, otherKey.VerifyingKey().Verify(message, signature))
+
+	// Nor other types, or nil
+	assert.False(t, publicKey.Verify(message, &Ed25519Signature{}))
+	assert.False(t, publicKey.Verify(message, (*Secp256k1Signature)(nil)))
+	assert.False(t, publicKey.Verify(message, nil))
+
+	// Wrapped in the single key types
+	anyPublicKey, err := ToAnyPublicKey(publicKey)
+	assert.NoError(t, err)
+	assert.True(t, anyPublicKey.Verify(message, &AnySignature{Variant: AnySignatureVariantSecp256k1, Signature: signature}))
+	assert.False(t, anyPublicKey.Verify(message, &AnySignature{Variant: AnySignatureVariantSecp256k1, Signature: tampered}))
+	assert.False(t, anyPublicKey.Verify(message, &AnySignature{Variant: AnySignatureVariantSecp256k1}))
+	assert.False(t, anyPublicKey.Verify(message, (*AnySignature)(nil)))
+	assert.False(t, anyPublicKey.Verify(message, signature))
+}
+
+func TestSecp256k1_LowS(t *testing.T) {
+	privateKey, err := GenerateSecp256k1Key()
+	assert.NoError(t, err)
+	halfOrder := new(big.Int).Rsh(ethCrypto.S256().Params().N, 1)
+
+	// Signatures must always be the 64-byte compact form with a low S, or they will be rejected on-chain
+	for i := 0; i < 100; i++ {
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig, err := privateKey.SignMessage(msg)
+		assert.NoError(t, err)
+		sigBytes := sig.Bytes()
+		assert.Len(t, sigBytes, Secp256k1SignatureLength)
+		s := new(big.Int).SetBytes(sigBytes[32:])
+		assert.True(t, s.Cmp(halfOrder) <= 0, "signature %d has a high S", i)
+		assert.True(t, privateKey.VerifyingKey().Verify(msg, sig))
+	}
+}
+
+func TestSecp256k1_SingleKeyAuthenticator(t *testing.T) {
+	privateKey := &Secp256k1PrivateKey{}
+	err := privateKey.FromHex(testSecp256k1PrivateKey)
+	assert.NoError(t, err)
+	signer := NewSingleSigner(privateKey)
+	message, err := util.ParseHex(testSecp256k1MessageEncoded)
+	assert.NoError(t, err)
+
+	authenticator, err := signer.Sign(message)
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAuthenticatorSingleSender, authenticator.Variant)
+
+	// SingleSender variant, Secp256k1 public key, Secp256k1 signature
+	authBytes, err := bcs.Serialize(authenticator)
+	assert.NoError(t, err)
+	publicKeyBytes, err := util.ParseHex(testSecp256k1PublicKey)
+	assert.NoError(t, err)
+	signatureBytes, err := util.ParseHex(testSecp256k1Signature)
+	assert.NoError(t, err)
+	expected := []byte{byte(AccountAuthenticatorSingleSender), byte(AnyPublicKeyVariantSecp256k1), Secp256k1PublicKeyLength}
+	expected = append(expected, publicKeyBytes...)
+	expected = append(expected, byte(AnySignatureVariantSecp256k1), Secp256k1SignatureLength)
+	expected = append(expected, signatureBytes...)
+	assert.Equal(t, expected, authBytes)
+
+	// The authentication key is derived with the single key scheme
+	authKey := AuthenticationKey{}
+	anyPublicKey, err := ToAnyPublicKey(privateKey.VerifyingKey())
+	assert.NoError(t, err)
+	authKey.FromPublicKey(anyPublicKey)
+	assert.Equal(t, testSecp256k1Address, authKey.ToHex())
+	assert.Equal(t, SingleKeyScheme, anyPublicKey.Scheme())
+}
+
+func TestRecoverSecp256k1PublicKey(t *testing.T) {
+	helloWorld, err := util.ParseHex(testSecp256k1MessageEncoded)
+	assert.NoError(t, err)
+
+	// Known message, signature, and recovery id vectors for testSecp256k1PrivateKey
+	vectors := []struct {
+		message    []byte
+		signature  string
+		recoveryID byte
+	}{
+		{helloWorld, testSecp256k1Signature, 0},
+		{[]byte("aptos"), "0xc553e48dbbbfd8156cff39cf83e1cdbacbd2511c1b91a18263a0fce82791af974d70ca422a1d679cf6beea232231e0daf4366514ba821b24343d57be0e80de1c", 0},
+		{[]byte("hello"), "0x2cf9929e0f9dc4bffee3339fc90d7f75341c3be0bd15e65dd33dd22bfa7cddd434910806576d6681cfa4b00731835bad210e5694bfe297fd5e18fe0eca4fe995", 1},
+		{[]byte("recovery"), "0x84ff45834b7aef37264e869f39685e5ceea43104a98e1f236a93183367d212a95b9393744070a25629ac485d1f3804e30db877f7589ffdeb854c051d3c0ac2cf", 1},
+	}
+	for _, vector := range vectors {
+		signature := &Secp256k1Signature{}
+		assert.NoError(t, signature.FromHex(vector.signature))
+
+		publicKey, err := RecoverSecp256k1PublicKey(vector.message, signature, vector.recoveryID)
+		assert.NoError(t, err)
+		assert.Equal(t, testSecp256k1PublicKey, publicKey.ToHex())
+		assert.True(t, publicKey.Verify(vector.message, signature))
+
+		// The Ethereum style recovery id is the same
+		publicKey, err = RecoverSecp256k1PublicKey(vector.message, signature, vector.recoveryID+27)
+		assert.NoError(t, err)
+		assert.Equal(t, testSecp256k1PublicKey, publicKey.ToHex())
+
+		// The wrong recovery id gives a different key
+		publicKey, err = RecoverSecp256k1PublicKey(vector.message, signature, 1-vector.recoveryID)
+		if err == nil {
+			assert.NotEqual(t, testSecp256k1PublicKey, publicKey.ToHex())
+		}
+	}
+
+	// The address can be derived from the recovered key
+	signature := &Secp256k1Signature{}
+	assert.NoError(t, signature.FromHex(testSecp256k1Signature))
+	publicKey, err := RecoverSecp256k1PublicKey(helloWorld, signature, 0)
+	assert.NoError(t, err)
+	anyPublicKey, err := ToAnyPublicKey(publicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, testSecp256k1Address, anyPublicKey.AuthKey().ToHex())
+
+	// Invalid inputs
+	_, err = RecoverSecp256k1PublicKey(helloWorld, signature, 2)
+	assert.ErrorContains(t, err, "recovery id 2")
+	_, err = RecoverSecp256k1PublicKey(helloWorld, signature, 29)
+	assert.Error(t, err)
+	_, err = RecoverSecp256k1PublicKey(helloWorld, nil, 0)
+	assert.Error(t, err)
+	_, err = RecoverSecp256k1PublicKey(helloWorld, &Secp256k1Signature{}, 0)
+	assert.Error(t, err)
+}
@@ -123,8 +123,10 @@ func (key *SingleSigner) PubKey() PublicKey {
 type AnyPublicKeyVariant uint32
 
 const (
-	AnyPublicKeyVariantEd25519   AnyPublicKeyVariant = 0 // AnyPublicKeyVariantEd25519 is the variant for [Ed25519PublicKey]
-	AnyPublicKeyVariantSecp256k1 AnyPublicKeyVariant = 1 // AnyPublicKeyVariantSecp256k1 is the variant for [Secp256k1PublicKey]
+	AnyPublicKeyVariantEd25519          AnyPublicKeyVariant = 0 // AnyPublicKeyVariantEd25519 is the variant for [Ed25519PublicKey]
+	AnyPublicKeyVariantSecp256k1        AnyPublicKeyVariant = 1 // AnyPublicKeyVariantSecp256k1 is the variant for [Secp256k1PublicKey]
+	AnyPublicKeyVariantKeyless          AnyPublicKeyVariant = 3 // AnyPublicKeyVariantKeyless is the variant for [KeylessPublicKey]
+	AnyPublicKeyVariantFederatedKeyless AnyPublicKeyVariant = 4 // AnyPublicKeyVariantFederatedKeyless is the variant for [FederatedKeylessPublicKey]
 )
 
 // AnyPublicKey is used by SingleSigner and MultiKey to allow for using different keys with the same structs
@@ -142,6 +144,10 @@ func ToAnyPublicKey(key VerifyingKey) (*AnyPublicKey, error) {
 		out.Variant = AnyPublicKeyVariantEd25519
 	case *Secp256k1PublicKey:
 		out.Variant = AnyPublicKeyVariantSecp256k1
+	case *KeylessPublicKey:
+		out.Variant = AnyPublicKeyVariantKeyless
+	case *FederatedKeylessPublicKey:
+		out.Variant = AnyPublicKeyVariantFederatedKeyless
 	case *AnyPublicKey:
 		// Passthrough for conversion
 		return key.(*AnyPublicKey), nil
@@ -156,11 +162,16 @@ func ToAnyPublicKey(key VerifyingKey) (*AnyPublicKey, error) {
 
 // Verify verifies the signature against the message
 //
+// Returns false if the signature is not a non-nil [AnySignature], or if the verification of the inner signature fails.
+//
 // Implements:
 //   - [VerifyingKey]
 func (key *AnyPublicKey) Verify(msg []byte, sig Signature) bool {
 	switch sig := sig.(type) {
 	case *AnySignature:
+		if sig == nil || sig.Signature == nil || key.PubKey == nil {
+			return false
+		}
 		return key.PubKey.Verify(msg, sig.Signature)
 	default:
 		return false
@@ -254,6 +265,10 @@ func (key *AnyPublicKey) UnmarshalBCS(des *bcs.Deserializer) {
 		key.PubKey = &Ed25519PublicKey{}
 	case AnyPublicKeyVariantSecp256k1:
 		key.PubKey = &Secp256k1PublicKey{}
+	case AnyPublicKeyVariantKeyless:
+		key.PubKey = &KeylessPublicKey{}
+	case AnyPublicKeyVariantFederatedKeyless:
+		key.PubKey = &FederatedKeylessPublicKey{}
 	default:
 		des.SetError(fmt.Errorf("unknown public key variant: %d", key.Variant))
 		return
@@ -272,6 +287,7 @@ type AnySignatureVariant uint32
 const (
 	AnySignatureVariantEd25519   AnySignatureVariant = 0 // AnySignatureVariantEd25519 is the variant for [Ed25519Signature]
 	AnySignatureVariantSecp256k1 AnySignatureVariant = 1 // AnySignatureVariantSecp256k1 is the variant for [Secp256k1Signature]
+	AnySignatureVariantKeyless   AnySignatureVariant = 3 // AnySignatureVariantKeyless is the variant for [KeylessSignature]
 )
 
 // AnySignature is a wrapper around signatures signed with SingleSigner and verified with AnyPublicKey
@@ -350,6 +366,8 @@ func (e *AnySignature) UnmarshalBCS(des *bcs.Deserializer) {
 		e.Signature = &Ed25519Signature{}
 	case AnySignatureVariantSecp256k1:
 		e.Signature = &Secp256k1Signature{}
+	case AnySignatureVariantKeyless:
+		e.Signature = &KeylessSignature{}
 	default:
 		des.SetError(fmt.Errorf("unknown signature variant: %d", e.Variant))
 		return
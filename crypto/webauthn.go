@@ -0,0 +1,226 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/internal/util"
+)
+
+// Passkeys sign with secp256r1 (P-256) keys through WebAuthn, and can be the ephemeral key of a keyless account.  These
+// are only supported for parsing e.g. transactions sent by keyless accounts with a passkey, not for signing, and
+// verification always fails.
+
+const (
+	Secp256r1PublicKeyLength = 65 // Secp256r1PublicKeyLength is the length of an uncompressed [Secp256r1PublicKey]
+	Secp256r1SignatureLength = 64 // Secp256r1SignatureLength is the length of a [WebAuthnSignature] signature, r then s
+)
+
+//region Secp256r1PublicKey
+
+// Secp256r1PublicKey is a secp256r1 (P-256) public key, uncompressed, e.g. the passkey used as the ephemeral key of a
+// keyless account
+//
+// Implements:
+//   - [VerifyingKey]
+//   - [CryptoMaterial]
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type Secp256r1PublicKey struct {
+	Inner []byte // Inner is the uncompressed public key, 0x04 then the x and y coordinates
+}
+
+//region Secp256r1PublicKey VerifyingKey implementation
+
+// Verify always returns false, as WebAuthn signatures aren't verified by the SDK
+//
+// Implements:
+//   - [VerifyingKey]
+func (key *Secp256r1PublicKey) Verify(_ []byte, _ Signature) bool {
+	return false
+}
+
+//endregion
+
+//region Secp256r1PublicKey CryptoMaterial implementation
+
+// Bytes returns the raw bytes of the [Secp256r1PublicKey]
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *Secp256r1PublicKey) Bytes() []byte {
+	return key.Inner
+}
+
+// FromBytes sets the [Secp256r1PublicKey] to the given bytes
+//
+// Returns an error if the bytes length is not [Secp256r1PublicKeyLength]
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *Secp256r1PublicKey) FromBytes(bytes []byte) (err error) {
+	if len(bytes) != Secp256r1PublicKeyLength {
+		return errors.New("invalid secp256r1 public key size")
+	}
+	key.Inner = bytes
+	return nil
+}
+
+// ToHex returns the hex string representation of the [Secp256r1PublicKey] with a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *Secp256r1PublicKey) ToHex() string {
+	return util.BytesToHex(key.Bytes())
+}
+
+// FromHex sets the [Secp256r1PublicKey] to the bytes represented by the hex string, with or without a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (key *Secp256r1PublicKey) FromHex(hexStr string) (err error) {
+	bytes, err := util.ParseHex(hexStr)
+	if err != nil {
+		return err
+	}
+	return key.FromBytes(bytes)
+}
+
+//endregion
+
+//region Secp256r1PublicKey bcs.Struct implementation
+
+// MarshalBCS serializes the [Secp256r1PublicKey] to BCS bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (key *Secp256r1PublicKey) MarshalBCS(ser *bcs.Serializer) {
+	ser.WriteBytes(key.Inner)
+}
+
+// UnmarshalBCS deserializes the [Secp256r1PublicKey] from BCS bytes
+//
+// Sets [bcs.Deserializer.Error] if the bytes length is not [Secp256r1PublicKeyLength], or if it fails to read the
+// required bytes.
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (key *Secp256r1PublicKey) UnmarshalBCS(des *bcs.Deserializer) {
+	kb := des.ReadBytes()
+	if des.Error() != nil {
+		return
+	}
+	err := key.FromBytes(kb)
+	if err != nil {
+		des.SetError(err)
+	}
+}
+
+//endregion
+//endregion
+
+//region WebAuthnSignature
+
+// webAuthnAssertionSignatureSecp256r1 is the only variant of the signature in a WebAuthn assertion
+const webAuthnAssertionSignatureSecp256r1 = 0
+
+// WebAuthnSignature is a signature by a passkey through WebAuthn, the parts of the authenticator's assertion response
+// needed to verify it.  The passkey signs the authenticator data and the SHA-256 of the client data JSON, whose
+// challenge commits to the transaction.
+//
+// Implements:
+//   - [Signature]
+//   - [CryptoMaterial]
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type WebAuthnSignature struct {
+	Signature         []byte // Signature is the secp256r1 signature, r then s, see [Secp256r1SignatureLength]
+	AuthenticatorData []byte // AuthenticatorData is the authenticator data of the assertion
+	ClientDataJson    []byte // ClientDataJson is the JSON of the client data of the assertion, including the challenge
+}
+
+//region WebAuthnSignature CryptoMaterial implementation
+
+// Bytes returns the BCS bytes of the [WebAuthnSignature]
+//
+// Implements:
+//   - [CryptoMaterial]
+func (e *WebAuthnSignature) Bytes() []byte {
+	val, _ := bcs.Serialize(e)
+	return val
+}
+
+// FromBytes sets the [WebAuthnSignature] to the given BCS bytes
+//
+// Implements:
+//   - [CryptoMaterial]
+func (e *WebAuthnSignature) FromBytes(bytes []byte) (err error) {
+	return bcs.Deserialize(e, bytes)
+}
+
+// ToHex returns the hex string representation of the [WebAuthnSignature], with a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (e *WebAuthnSignature) ToHex() string {
+	return util.BytesToHex(e.Bytes())
+}
+
+// FromHex sets the [WebAuthnSignature] to the bytes represented by the hex string, with or without a leading 0x
+//
+// Implements:
+//   - [CryptoMaterial]
+func (e *WebAuthnSignature) FromHex(hexStr string) (err error) {
+	bytes, err := util.ParseHex(hexStr)
+	if err != nil {
+		return err
+	}
+	return e.FromBytes(bytes)
+}
+
+//endregion
+
+//region WebAuthnSignature bcs.Struct implementation
+
+// MarshalBCS serializes the [WebAuthnSignature] to bytes
+//
+// Implements:
+//   - [bcs.Marshaler]
+func (e *WebAuthnSignature) MarshalBCS(ser *bcs.Serializer) {
+	ser.Uleb128(webAuthnAssertionSignatureSecp256r1)
+	ser.WriteBytes(e.Signature)
+	ser.WriteBytes(e.AuthenticatorData)
+	ser.WriteBytes(e.ClientDataJson)
+}
+
+// UnmarshalBCS deserializes the [WebAuthnSignature] from bytes
+//
+// Sets [bcs.Deserializer.Error] if the signature isn't secp256r1, or its length is not [Secp256r1SignatureLength].
+//
+// Implements:
+//   - [bcs.Unmarshaler]
+func (e *WebAuthnSignature) UnmarshalBCS(des *bcs.Deserializer) {
+	variant := des.Uleb128()
+	if des.Error() != nil {
+		return
+	}
+	if variant != webAuthnAssertionSignatureSecp256r1 {
+		des.SetError(fmt.Errorf("unknown webauthn assertion signature variant: %d", variant))
+		return
+	}
+	e.Signature = des.ReadBytes()
+	if des.Error() != nil {
+		return
+	}
+	if len(e.Signature) != Secp256r1SignatureLength {
+		des.SetError(errors.New("invalid secp256r1 signature size"))
+		return
+	}
+	e.AuthenticatorData = des.ReadBytes()
+	e.ClientDataJson = des.ReadBytes()
+}
+
+//endregion
+//endregion
@@ -0,0 +1,311 @@
+package aptos
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// NewEntryFunction builds an [EntryFunction] payload from Go values, serializing each argument to BCS according to
+// its Move type in paramTypes.  See [SerializeEntryFunctionArg] for the accepted Go types.
+//
+//	payload, err := NewEntryFunction(
+//		ModuleId{Address: AccountOne, Name: "aptos_account"},
+//		"transfer",
+//		[]TypeTag{},
+//		[]TypeTag{NewTypeTag(&AddressTag{}), NewTypeTag(&U64Tag{})},
+//		[]any{receiver, uint64(100)},
+//	)
+func NewEntryFunction(module ModuleId, function string, typeArgs []TypeTag, paramTypes []TypeTag, args []any) (*EntryFunction, error) {
+	serializedArgs, err := SerializeEntryFunctionArgs(paramTypes, args)
+	if err != nil {
+		return nil, fmt.Errorf("entry function %s::%s: %w", module.String(), function, err)
+	}
+	if typeArgs == nil {
+		typeArgs = []TypeTag{}
+	}
+	return &EntryFunction{
+		Module:   module,
+		Function: function,
+		ArgTypes: typeArgs,
+		Args:     serializedArgs,
+	}, nil
+}
+
+// NewEntryFunctionFromAbi builds an [EntryFunction] payload from Go values, type checking and serializing the
+// arguments against the function's ABI, as fetched with [NodeClient.AccountModule].  Leading signer parameters are
+// skipped, as they're not passed as arguments.  Generic parameters in the ABI are resolved with typeArgs.
+func NewEntryFunctionFromAbi(module ModuleId, function *api.MoveFunction, typeArgs []TypeTag, args []any) (*EntryFunction, error) {
+	paramTypes, err := entryFunctionAbiParams(function, typeArgs)
+	if err != nil {
+		return nil, fmt.Errorf("entry function %s::%s: %w", module.String(), function.Name, err)
+	}
+	return NewEntryFunction(module, function.Name, typeArgs, paramTypes, args)
+}
+
+// entryFunctionAbiParams resolves the types of the parameters of an entry function, without the leading signers
+func entryFunctionAbiParams(function *api.MoveFunction, typeArgs []TypeTag) ([]TypeTag, error) {
+	if !function.IsEntry {
+		return nil, fmt.Errorf("function is not an entry function")
+	}
+	if len(typeArgs) != len(function.GenericTypeParams) {
+		return nil, fmt.Errorf("expected %d type arguments, got %d", len(function.GenericTypeParams), len(typeArgs))
+	}
+	paramTypes := make([]TypeTag, 0, len(function.Params))
+	for _, param := range function.Params {
+		param = strings.TrimSpace(param)
+		if len(paramTypes) == 0 && (param == "signer" || param == "&signer") {
+			continue
+		}
+		paramType, err := parseTypeTag(param, typeArgs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse parameter type %s: %w", param, err)
+		}
+		paramTypes = append(paramTypes, paramType)
+	}
+	return paramTypes, nil
+}
+
+// SerializeEntryFunctionArgs serializes each argument to BCS according to the Move type at the same position in
+// argTypes, see [SerializeEntryFunctionArg]
+func SerializeEntryFunctionArgs(argTypes []TypeTag, args []any) ([][]byte, error) {
+	if len(argTypes) != len(args) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(argTypes), len(args))
+	}
+	serializedArgs := make([][]byte, len(args))
+	for i, arg := range args {
+		serialized, err := SerializeEntryFunctionArg(argTypes[i], arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i+1, err)
+		}
+		serializedArgs[i] = serialized
+	}
+	return serializedArgs, nil
+}
+
+// SerializeEntryFunctionArg serializes a Go value to BCS as the Move type, and returns an error if the value can't be
+// the type.  The accepted Go types are:
+//
+//   - bool for bool
+//   - any Go integer type in range for u8, u16, u32, and u64
+//   - any Go integer type, or [big.Int] by value or pointer, in range for u128 and u256
+//   - [AccountAddress] for address, and 0x1::object::Object<T>
+//   - string for 0x1::string::String
+//   - []byte for vector<u8>
+//   - slices and arrays of the accepted element type for vector<T>
+//   - nil for none, or a pointer to the accepted type for some, for 0x1::option::Option<T>.  [api.MoveOption] is also
+//     accepted, or a pointer to one, where a nil pointer is none.
+func SerializeEntryFunctionArg(argType TypeTag, arg any) ([]byte, error) {
+	ser := &bcs.Serializer{}
+	err := serializeEntryFunctionArg(ser, argType, arg)
+	if err != nil {
+		return nil, err
+	}
+	if ser.Error() != nil {
+		return nil, ser.Error()
+	}
+	return ser.ToBytes(), nil
+}
+
+func serializeEntryFunctionArg(ser *bcs.Serializer, argType TypeTag, arg any) error {
+	if argType.Value == nil {
+		return fmt.Errorf("missing type for %T", arg)
+	}
+	switch inner := argType.Value.(type) {
+	case *BoolTag:
+		value, ok := arg.(bool)
+		if !ok {
+			return argTypeMismatch(argType, arg)
+		}
+		ser.Bool(value)
+	case *U8Tag:
+		value, err := entryFunctionUint(argType, arg, math.MaxUint8)
+		if err != nil {
+			return err
+		}
+		ser.U8(uint8(value))
+	case *U16Tag:
+		value, err := entryFunctionUint(argType, arg, math.MaxUint16)
+		if err != nil {
+			return err
+		}
+		ser.U16(uint16(value))
+	case *U32Tag:
+		value, err := entryFunctionUint(argType, arg, math.MaxUint32)
+		if err != nil {
+			return err
+		}
+		ser.U32(uint32(value))
+	case *U64Tag:
+		value, err := entryFunctionUint(argType, arg, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		ser.U64(value)
+	case *U128Tag:
+		value, err := entryFunctionBigUint(argType, arg, 128)
+		if err != nil {
+			return err
+		}
+		ser.U128(*value)
+	case *U256Tag:
+		value, err := entryFunctionBigUint(argType, arg, 256)
+		if err != nil {
+			return err
+		}
+		ser.U256(*value)
+	case *AddressTag:
+		return serializeEntryFunctionAddress(ser, argType, arg)
+	case *SignerTag:
+		return fmt.Errorf("signer can't be passed as an argument")
+	case *VectorTag:
+		return serializeEntryFunctionVector(ser, argType, inner, arg)
+	case *StructTag:
+		return serializeEntryFunctionStruct(ser, argType, inner, arg)
+	default:
+		return fmt.Errorf("unsupported argument type %s", argType.String())
+	}
+	return nil
+}
+
+func serializeEntryFunctionAddress(ser *bcs.Serializer, argType TypeTag, arg any) error {
+	switch value := arg.(type) {
+	case AccountAddress:
+		ser.Struct(&value)
+	case *AccountAddress:
+		if value == nil {
+			return argTypeMismatch(argType, arg)
+		}
+		ser.Struct(value)
+	default:
+		return argTypeMismatch(argType, arg)
+	}
+	return nil
+}
+
+func serializeEntryFunctionVector(ser *bcs.Serializer, argType TypeTag, vectorTag *VectorTag, arg any) error {
+	// vector<u8> is commonly passed as []byte
+	if _, ok := vectorTag.TypeParam.Value.(*U8Tag); ok {
+		if value, ok := arg.([]byte); ok {
+			ser.WriteBytes(value)
+			return nil
+		}
+	}
+
+	reflected := reflect.ValueOf(arg)
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return argTypeMismatch(argType, arg)
+	}
+	ser.Uleb128(uint32(reflected.Len()))
+	for i := 0; i < reflected.Len(); i++ {
+		err := serializeEntryFunctionArg(ser, vectorTag.TypeParam, reflected.Index(i).Interface())
+		if err != nil {
+			return fmt.Errorf("%s element %d: %w", argType.String(), i, err)
+		}
+	}
+	return nil
+}
+
+func serializeEntryFunctionStruct(ser *bcs.Serializer, argType TypeTag, structTag *StructTag, arg any) error {
+	if structTag.Address != AccountOne {
+		return fmt.Errorf("unsupported argument type %s", argType.String())
+	}
+	switch {
+	case structTag.Module == "string" && structTag.Name == "String":
+		value, ok := arg.(string)
+		if !ok {
+			return argTypeMismatch(argType, arg)
+		}
+		ser.WriteString(value)
+		return nil
+	case structTag.Module == "object" && structTag.Name == "Object":
+		// Objects are passed by their address
+		return serializeEntryFunctionAddress(ser, argType, arg)
+	case structTag.Module == "option" && structTag.Name == "Option" && len(structTag.TypeParams) == 1:
+		return serializeEntryFunctionOption(ser, argType, structTag.TypeParams[0], arg)
+	default:
+		return fmt.Errorf("unsupported argument type %s", argType.String())
+	}
+}
+
+// serializeEntryFunctionOption serializes an option as a vector of 0 or 1 elements
+func serializeEntryFunctionOption(ser *bcs.Serializer, argType TypeTag, inner TypeTag, arg any) error {
+	var value any
+	some := false
+	reflected := reflect.ValueOf(arg)
+	if arg == nil || (reflected.Kind() == reflect.Pointer && reflected.IsNil()) {
+		// A nil pointer is none, including a nil *api.MoveOption, which would panic calling GetAny
+	} else if option, ok := arg.(interface{ GetAny() (any, bool) }); ok {
+		value, some = option.GetAny()
+	} else if reflected.Kind() != reflect.Pointer {
+		return argTypeMismatch(argType, arg)
+	} else {
+		value, some = reflected.Elem().Interface(), true
+	}
+
+	if !some {
+		ser.Uleb128(0)
+		return nil
+	}
+	ser.Uleb128(1)
+	err := serializeEntryFunctionArg(ser, inner, value)
+	if err != nil {
+		return fmt.Errorf("%s value: %w", argType.String(), err)
+	}
+	return nil
+}
+
+// entryFunctionUint converts any Go integer to a uint64, checking that it is in range
+func entryFunctionUint(argType TypeTag, arg any, max uint64) (uint64, error) {
+	var value uint64
+	reflected := reflect.ValueOf(arg)
+	switch reflected.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value = reflected.Uint()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		signed := reflected.Int()
+		if signed < 0 {
+			return 0, fmt.Errorf("value %d out of range for %s", signed, argType.String())
+		}
+		value = uint64(signed)
+	default:
+		return 0, argTypeMismatch(argType, arg)
+	}
+	if value > max {
+		return 0, fmt.Errorf("value %d out of range for %s", value, argType.String())
+	}
+	return value, nil
+}
+
+// entryFunctionBigUint converts any Go integer or [big.Int] to a [big.Int], checking that it fits in the bits
+func entryFunctionBigUint(argType TypeTag, arg any, bits int) (*big.Int, error) {
+	var value *big.Int
+	switch inner := arg.(type) {
+	case *big.Int:
+		value = inner
+	case big.Int:
+		value = &inner
+	default:
+		small, err := entryFunctionUint(argType, arg, math.MaxUint64)
+		if err != nil {
+			return nil, err
+		}
+		value = new(big.Int).SetUint64(small)
+	}
+	if value == nil {
+		return nil, argTypeMismatch(argType, arg)
+	}
+	if value.Sign() < 0 || value.BitLen() > bits {
+		return nil, fmt.Errorf("value %s out of range for %s", value.String(), argType.String())
+	}
+	return value, nil
+}
+
+func argTypeMismatch(argType TypeTag, arg any) error {
+	return fmt.Errorf("expected %s, got %T", argType.String(), arg)
+}
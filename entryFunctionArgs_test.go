@@ -0,0 +1,267 @@
+package aptos
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerializeEntryFunctionArg(t *testing.T) {
+	maxU128, ok := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.True(t, ok)
+	u128Bytes, err := bcs.SerializeU128(*maxU128)
+	assert.NoError(t, err)
+	u256Bytes, err := bcs.SerializeU256(*big.NewInt(1))
+	assert.NoError(t, err)
+	// u128 max - 1, and 2^200 + 1, little-endian
+	nearMaxU128 := new(big.Int).Sub(maxU128, big.NewInt(1))
+	nearMaxU128Bytes := append([]byte{0xfe}, bytes.Repeat([]byte{0xff}, 15)...)
+	bigU256 := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 200), big.NewInt(1))
+	bigU256Bytes := make([]byte, 32)
+	bigU256Bytes[0] = 0x01
+	bigU256Bytes[25] = 0x01
+	stringBytes, err := bcs.SerializeBytes([]byte("hello"))
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		argType  TypeTagImpl
+		arg      any
+		expected []byte
+	}{
+		"bool":           {&BoolTag{}, true, []byte{1}},
+		"u8":             {&U8Tag{}, uint8(0xff), []byte{0xff}},
+		"u8 from int":    {&U8Tag{}, 7, []byte{7}},
+		"u16":            {&U16Tag{}, uint16(0x0102), []byte{0x02, 0x01}},
+		"u32":            {&U32Tag{}, uint32(0x01020304), []byte{0x04, 0x03, 0x02, 0x01}},
+		"u64":            {&U64Tag{}, uint64(0x0102030405060708), []byte{0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01}},
+		"u128":           {&U128Tag{}, maxU128, u128Bytes},
+		"u128 near max":  {&U128Tag{}, nearMaxU128, nearMaxU128Bytes},
+		"u256":           {&U256Tag{}, 1, u256Bytes},
+		"u256 big.Int":   {&U256Tag{}, *bigU256, bigU256Bytes},
+		"address":        {&AddressTag{}, AccountThree, AccountThree[:]},
+		"address ptr":    {&AddressTag{}, &AccountThree, AccountThree[:]},
+		"object":         {NewObjectTag(NewStringTag()), AccountThree, AccountThree[:]},
+		"string":         {NewStringTag(), "hello", stringBytes},
+		"vector<u8>":     {NewVectorTag(&U8Tag{}), []byte{1, 2, 3}, []byte{3, 1, 2, 3}},
+		"vector<u16>":    {NewVectorTag(&U16Tag{}), []uint16{1, 2}, []byte{2, 1, 0, 2, 0}},
+		"vector<bool>":   {NewVectorTag(&BoolTag{}), [2]bool{true, false}, []byte{2, 1, 0}},
+		"vector<vector>": {NewVectorTag(NewVectorTag(&U8Tag{})), [][]byte{{1}, {}}, []byte{2, 1, 1, 0}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			serialized, err := SerializeEntryFunctionArg(NewTypeTag(test.argType), test.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, serialized)
+		})
+	}
+}
+
+func TestSerializeEntryFunctionArg_Errors(t *testing.T) {
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 128)
+	tests := map[string]struct {
+		argType TypeTagImpl
+		arg     any
+		message string
+	}{
+		"mismatch":        {&U64Tag{}, "100", "expected u64, got string"},
+		"address":         {&AddressTag{}, "0x1", "expected address, got string"},
+		"address nil":     {&AddressTag{}, (*AccountAddress)(nil), "expected address, got *types.AccountAddress"},
+		"object nil":      {NewObjectTag(NewStringTag()), (*AccountAddress)(nil), "got *types.AccountAddress"},
+		"u8 range":        {&U8Tag{}, 256, "out of range for u8"},
+		"negative":        {&U64Tag{}, -1, "out of range for u64"},
+		"u128 range":      {&U128Tag{}, tooBig, "out of range for u128"},
+		"u128 negative":   {&U128Tag{}, big.NewInt(-1), "value -1 out of range for u128"},
+		"u128 nil":        {&U128Tag{}, (*big.Int)(nil), "expected u128, got *big.Int"},
+		"u256 range":      {&U256Tag{}, new(big.Int).Lsh(big.NewInt(1), 256), "out of range for u256"},
+		"vector element":  {NewVectorTag(&U64Tag{}), []any{uint64(1), true}, "element 1: expected u64, got bool"},
+		"not a vector":    {NewVectorTag(&U64Tag{}), uint64(1), "expected vector<u64>, got uint64"},
+		"signer":          {&SignerTag{}, AccountOne, "signer"},
+		"unknown struct":  {&StructTag{Address: AccountThree, Module: "m", Name: "S"}, "a", "unsupported argument type"},
+		"string mismatch": {NewStringTag(), []byte("a"), "expected 0x1::string::String, got []uint8"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := SerializeEntryFunctionArg(NewTypeTag(test.argType), test.arg)
+			assert.ErrorContains(t, err, test.message)
+		})
+	}
+}
+
+func TestNewEntryFunction(t *testing.T) {
+	payload, err := NewEntryFunction(
+		ModuleId{Address: AccountOne, Name: "aptos_account"},
+		"transfer",
+		nil,
+		[]TypeTag{NewTypeTag(&AddressTag{}), NewTypeTag(&U64Tag{})},
+		[]any{AccountTwo, uint64(100)},
+	)
+	assert.NoError(t, err)
+
+	// It's the same as the hand built payload
+	expected, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, payload)
+
+	_, err = NewEntryFunction(ModuleId{Address: AccountOne, Name: "aptos_account"}, "transfer", nil, []TypeTag{NewTypeTag(&AddressTag{})}, []any{AccountTwo, uint64(1)})
+	assert.ErrorContains(t, err, "expected 1 arguments, got 2")
+	_, err = NewEntryFunction(ModuleId{Address: AccountOne, Name: "aptos_account"}, "transfer", nil, []TypeTag{NewTypeTag(&AddressTag{}), NewTypeTag(&U64Tag{})}, []any{AccountTwo, "1"})
+	assert.ErrorContains(t, err, "0x1::aptos_account::transfer: argument 2: expected u64, got string")
+}
+
+func TestNewEntryFunctionFromAbi(t *testing.T) {
+	abi := &api.MoveFunction{
+		Name:              "transfer_coins",
+		Visibility:        api.MoveVisibilityPublic,
+		IsEntry:           true,
+		GenericTypeParams: []*api.GenericTypeParam{{Constraints: []api.MoveAbility{}}},
+		Params:            []string{"&signer", "address", "u64"},
+		Return:            []string{},
+	}
+	module := ModuleId{Address: AccountOne, Name: "aptos_account"}
+
+	payload, err := NewEntryFunctionFromAbi(module, abi, []TypeTag{AptosCoinTypeTag}, []any{AccountTwo, uint64(100)})
+	assert.NoError(t, err)
+	assert.Equal(t, "transfer_coins", payload.Function)
+	assert.Equal(t, []TypeTag{AptosCoinTypeTag}, payload.ArgTypes)
+	amountBytes, err := bcs.SerializeU64(100)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{AccountTwo[:], amountBytes}, payload.Args)
+
+	// Generic parameters are resolved from the type arguments
+	abi.Params = []string{"&signer", "vector<T0>"}
+	payload, err = NewEntryFunctionFromAbi(module, abi, []TypeTag{NewTypeTag(&U8Tag{})}, []any{[]byte{1, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{2, 1, 2}}, payload.Args)
+
+	_, err = NewEntryFunctionFromAbi(module, abi, nil, []any{[]byte{1, 2}})
+	assert.ErrorContains(t, err, "expected 1 type arguments, got 0")
+	_, err = NewEntryFunctionFromAbi(module, abi, []TypeTag{NewTypeTag(&U8Tag{})}, []any{"0x0102"})
+	assert.ErrorContains(t, err, "expected vector<u8>, got string")
+
+	abi.IsEntry = false
+	_, err = NewEntryFunctionFromAbi(module, abi, []TypeTag{NewTypeTag(&U8Tag{})}, []any{[]byte{1, 2}})
+	assert.ErrorContains(t, err, "not an entry function")
+}
+
+func TestNewEntryFunctionFromAbi_NestedTypeArgument(t *testing.T) {
+	abi := &api.MoveFunction{
+		Name:              "transfer",
+		Visibility:        api.MoveVisibilityPublic,
+		IsEntry:           true,
+		GenericTypeParams: []*api.GenericTypeParam{{Constraints: []api.MoveAbility{}}},
+		Params:            []string{"&signer", "address", "u64"},
+		Return:            []string{},
+	}
+	module := ModuleId{Address: AccountOne, Name: "coin"}
+
+	// A generic coin transfer, of an LP coin with nested type arguments
+	typeArgs, err := ParseTypeTags("0x3::lp::LPCoin<0x1::aptos_coin::AptosCoin, vector<0xa::usdc::USDC>>")
+	assert.NoError(t, err)
+	payload, err := NewEntryFunctionFromAbi(module, abi, typeArgs, []any{AccountTwo, uint64(100)})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::coin::transfer<0x3::lp::LPCoin<0x1::aptos_coin::AptosCoin,vector<0xa::usdc::USDC>>>(0x2, 100)", PayloadString(payload, NewTypeTag(&AddressTag{}), NewTypeTag(&U64Tag{})))
+
+	// The type arguments are serialized as type tags, and round trip
+	payloadBytes, err := bcs.Serialize(payload)
+	assert.NoError(t, err)
+	decoded := &EntryFunction{}
+	err = bcs.Deserialize(decoded, payloadBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, decoded)
+	assert.Equal(t, "0x3::lp::LPCoin<0x1::aptos_coin::AptosCoin,vector<0xa::usdc::USDC>>", decoded.ArgTypes[0].String())
+
+	// The number of type arguments must match the generic parameters of the function
+	_, err = NewEntryFunctionFromAbi(module, abi, append(typeArgs, AptosCoinTypeTag), []any{AccountTwo, uint64(100)})
+	assert.ErrorContains(t, err, "expected 1 type arguments, got 2")
+
+	_, err = ParseTypeTags("0x1::aptos_coin::AptosCoin", "0x1::coin::CoinStore<")
+	assert.ErrorContains(t, err, "type argument 2")
+}
+
+func TestSerializeEntryFunctionArg_OptionAndVector(t *testing.T) {
+	amount := uint64(0x0102)
+	amountBytes := []byte{0x02, 0x01, 0, 0, 0, 0, 0, 0}
+	small := uint8(7)
+	addresses := append(append([]byte{2}, AccountOne[:]...), AccountThree[:]...)
+	amountOption := api.NewMoveOptionSome(amount)
+
+	optionU64 := NewOptionTag(&U64Tag{})
+	tests := map[string]struct {
+		argType  TypeTagImpl
+		arg      any
+		expected []byte
+	}{
+		"option some":             {optionU64, &amount, append([]byte{1}, amountBytes...)},
+		"option none":             {optionU64, nil, []byte{0}},
+		"option typed none":       {optionU64, (*uint64)(nil), []byte{0}},
+		"MoveOption some":         {optionU64, api.NewMoveOptionSome(amount), append([]byte{1}, amountBytes...)},
+		"MoveOption none":         {optionU64, api.NewMoveOptionNone[uint64](), []byte{0}},
+		"MoveOption pointer":      {optionU64, &amountOption, append([]byte{1}, amountBytes...)},
+		"MoveOption typed none":   {optionU64, (*api.MoveOption[uint64])(nil), []byte{0}},
+		"option<address>":         {NewOptionTag(&AddressTag{}), &AccountThree, append([]byte{1}, AccountThree[:]...)},
+		"option<String>":          {NewOptionTag(NewStringTag()), api.NewMoveOptionSome("hi"), []byte{1, 2, 'h', 'i'}},
+		"vector<address>":         {NewVectorTag(&AddressTag{}), []AccountAddress{AccountOne, AccountThree}, addresses},
+		"vector<address> empty":   {NewVectorTag(&AddressTag{}), []AccountAddress{}, []byte{0}},
+		"vector<option<u8>>":      {NewVectorTag(NewOptionTag(&U8Tag{})), []*uint8{&small, nil}, []byte{2, 1, 7, 0}},
+		"option<vector<address>>": {NewOptionTag(NewVectorTag(&AddressTag{})), &[]AccountAddress{AccountOne, AccountThree}, append([]byte{1}, addresses...)},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			serialized, err := SerializeEntryFunctionArg(NewTypeTag(test.argType), test.arg)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, serialized)
+		})
+	}
+
+	// The option is decoded the same way
+	serialized, err := SerializeEntryFunctionArg(NewTypeTag(optionU64), &amount)
+	assert.NoError(t, err)
+	payload := &EntryFunction{Module: ModuleId{Address: AccountOne, Name: "m"}, Function: "f", ArgTypes: []TypeTag{}, Args: [][]byte{serialized}}
+	assert.Equal(t, "0x1::m::f(some(258))", PayloadString(payload, NewTypeTag(optionU64)))
+}
+
+func TestSerializeEntryFunctionArg_OptionAndVectorErrors(t *testing.T) {
+	text := "100"
+	tests := map[string]struct {
+		argType TypeTagImpl
+		arg     any
+		message string
+	}{
+		"option not a pointer":    {NewOptionTag(&U64Tag{}), uint64(100), "expected 0x1::option::Option<u64>, got uint64"},
+		"option value mismatch":   {NewOptionTag(&U64Tag{}), &text, "0x1::option::Option<u64> value: expected u64, got string"},
+		"MoveOption mismatch":     {NewOptionTag(&U64Tag{}), api.NewMoveOptionSome(true), "value: expected u64, got bool"},
+		"option value range":      {NewOptionTag(&U8Tag{}), api.NewMoveOptionSome(300), "value 300 out of range for u8"},
+		"vector<address> element": {NewVectorTag(&AddressTag{}), []any{AccountOne, "0x2"}, "vector<address> element 1: expected address, got string"},
+		"option without type":     {&StructTag{Address: AccountOne, Module: "option", Name: "Option"}, nil, "unsupported argument type"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := SerializeEntryFunctionArg(NewTypeTag(test.argType), test.arg)
+			assert.ErrorContains(t, err, test.message)
+		})
+	}
+}
+
+func TestNewEntryFunctionFromAbi_Option(t *testing.T) {
+	abi := &api.MoveFunction{
+		Name:              "set_limit",
+		Visibility:        api.MoveVisibilityPublic,
+		IsEntry:           true,
+		GenericTypeParams: []*api.GenericTypeParam{},
+		Params:            []string{"&signer", "0x1::option::Option<u64>", "vector<address>"},
+		Return:            []string{},
+	}
+	module := ModuleId{Address: AccountThree, Name: "limits"}
+	payload, err := NewEntryFunctionFromAbi(module, abi, nil, []any{nil, []AccountAddress{AccountOne}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{0}, append([]byte{1}, AccountOne[:]...)}, payload.Args)
+
+	limit := uint64(5)
+	payload, err = NewEntryFunctionFromAbi(module, abi, nil, []any{&limit, []AccountAddress{}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{1, 5, 0, 0, 0, 0, 0, 0, 0}, {0}}, payload.Args)
+}
@@ -1,8 +1,61 @@
 package aptos
 
+import (
+	"sync"
+	"time"
+)
+
+// DefaultGasEstimateCacheTTL is the default length of time a gas estimate is cached by [NodeClient.EstimateGasPrice]
+const DefaultGasEstimateCacheTTL = time.Second
+
+// GasEstimateCacheTTL is an option to [NewClient] for how long a gas estimate is cached.  0 or less disables the cache.
+type GasEstimateCacheTTL time.Duration
+
 // EstimateGasInfo is returned by #EstimateGasPrice()
 type EstimateGasInfo struct {
 	DeprioritizedGasEstimate uint64 `json:"deprioritized_gas_estimate"` // DeprioritizedGasEstimate is the gas estimate for a transaction that is willing to be deprioritized and pay less
 	GasEstimate              uint64 `json:"gas_estimate"`               // GasEstimate is the gas estimate for a transaction that is willing to pay close to the median gas price
 	PrioritizedGasEstimate   uint64 `json:"prioritized_gas_estimate"`   // PrioritizedGasEstimate is the gas estimate for a transaction that is willing to pay more to be prioritized
 }
+
+// gasEstimateCache holds the last gas estimate for a short time, so building many transactions doesn't call the node
+// for each one
+type gasEstimateCache struct {
+	mutex     sync.Mutex
+	ttl       time.Duration
+	now       func() time.Time // now is the clock, replaceable for tests
+	info      EstimateGasInfo
+	fetchedAt time.Time
+	valid     bool
+}
+
+func newGasEstimateCache(ttl time.Duration) *gasEstimateCache {
+	return &gasEstimateCache{ttl: ttl, now: time.Now}
+}
+
+// get returns the cached estimate, if it hasn't expired
+func (cache *gasEstimateCache) get() (EstimateGasInfo, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if !cache.valid || cache.ttl <= 0 || cache.now().Sub(cache.fetchedAt) >= cache.ttl {
+		return EstimateGasInfo{}, false
+	}
+	return cache.info, true
+}
+
+// set caches the estimate from now
+func (cache *gasEstimateCache) set(info EstimateGasInfo) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.info = info
+	cache.fetchedAt = cache.now()
+	cache.valid = true
+}
+
+// setTTL changes the TTL, and clears the cache
+func (cache *gasEstimateCache) setTTL(ttl time.Duration) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.ttl = ttl
+	cache.valid = false
+}
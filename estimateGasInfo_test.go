@@ -0,0 +1,102 @@
+package aptos
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a clock that only moves when advanced
+type fakeClock struct {
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *fakeClock) Advance(duration time.Duration) {
+	clock.now = clock.now.Add(duration)
+}
+
+func newGasEstimateServerClient(t *testing.T, options ...any) (*Client, *atomic.Int32, *fakeClock) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/estimate_gas_price", r.URL.Path)
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"deprioritized_gas_estimate":100,"gas_estimate":150,"prioritized_gas_estimate":200}`))
+	})
+	for _, option := range options {
+		client.nodeClient.SetGasEstimateCacheTTL(time.Duration(option.(GasEstimateCacheTTL)))
+	}
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	client.nodeClient.gasEstimate.now = clock.Now
+	return client, &calls, clock
+}
+
+func TestEstimateGasPrice_Cache(t *testing.T) {
+	client, calls, clock := newGasEstimateServerClient(t)
+
+	info, err := client.EstimateGasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, EstimateGasInfo{DeprioritizedGasEstimate: 100, GasEstimate: 150, PrioritizedGasEstimate: 200}, info)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Hit
+	clock.Advance(DefaultGasEstimateCacheTTL - time.Millisecond)
+	cached, err := client.EstimateGasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, info, cached)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Miss after expiry
+	clock.Advance(time.Millisecond)
+	_, err = client.EstimateGasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Changing the TTL clears the cache
+	client.nodeClient.SetGasEstimateCacheTTL(time.Minute)
+	_, err = client.EstimateGasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	clock.Advance(30 * time.Second)
+	_, err = client.EstimateGasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestEstimateGasPrice_CacheDisabled(t *testing.T) {
+	client, calls, _ := newGasEstimateServerClient(t, GasEstimateCacheTTL(0))
+	for i := int32(1); i <= 3; i++ {
+		_, err := client.EstimateGasPrice()
+		assert.NoError(t, err)
+		assert.Equal(t, i, calls.Load())
+	}
+}
+
+func TestEstimateGasPrice_ErrorNotCached(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"deprioritized_gas_estimate":100,"gas_estimate":150,"prioritized_gas_estimate":200}`))
+	})
+	_, err := client.EstimateGasPrice()
+	assert.Error(t, err)
+	info, err := client.EstimateGasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(150), info.GasEstimate)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestNewClient_GasEstimateCacheTTL(t *testing.T) {
+	client, err := NewClient(NetworkConfig{Name: "mock", ChainId: 4, NodeUrl: "http://127.0.0.1:1/v1"}, GasEstimateCacheTTL(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, client.nodeClient.gasEstimate.ttl)
+}
@@ -0,0 +1,102 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// DefaultEventPageSize is the number of events requested from the node per page.  The node may cap this lower.
+const DefaultEventPageSize = 100
+
+// EventIterator pages through the V1 events of an event handle, by its creation number
+//
+// The iterator is not safe for concurrent use.
+//
+//	iter := client.EventsByCreationNumber(address, 2)
+//	for {
+//		event, ok, err := iter.Next(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		if !ok {
+//			break // no more events
+//		}
+//		// handle event
+//	}
+type EventIterator struct {
+	*Paginator[*api.Event]
+
+	rc             *NodeClient
+	address        AccountAddress
+	creationNumber uint64
+	pageSize       uint64 // pageSize is the limit requested per page, lowered to the node's cap once discovered
+
+	start     uint64 // start is the sequence number of the next page to fetch
+	shortPage uint64 // shortPage is the length of the last page if it was shorter than the limit, 0 otherwise
+}
+
+// EventsByCreationNumber returns an [EventIterator] over the events of the event handle with the creation number for
+// the account
+//
+// Optional arguments:
+//   - PageStart: uint64, the sequence number to start from. Default 0.
+//   - PageSize: uint64, the number of events to request per page. Default [DefaultEventPageSize].
+func (rc *NodeClient) EventsByCreationNumber(address AccountAddress, creationNumber uint64, opts ...PageOption) *EventIterator {
+	options := pageOptions{pageSize: DefaultEventPageSize}
+	for _, opt := range opts {
+		opt.applyPageOption(&options)
+	}
+	if options.pageSize == 0 {
+		options.pageSize = DefaultEventPageSize
+	}
+	it := &EventIterator{
+		rc:             rc,
+		address:        address,
+		creationNumber: creationNumber,
+		pageSize:       options.pageSize,
+		start:          options.start,
+	}
+	it.Paginator = NewPaginator(it.fetchPage)
+	return it
+}
+
+// EffectivePageSize is the number of events requested per page.  It starts as the requested [PageSize], and is lowered
+// to the node's maximum once a page shorter than requested is followed by more events, showing the node capped it.
+func (it *EventIterator) EffectivePageSize() uint64 {
+	return it.pageSize
+}
+
+// fetchPage fetches the next page of events, implementing [PageFetcher]
+//
+// The node may return fewer events than the limit, even if there are more available, so only an empty page is
+// considered the end of the events.  The next page is resumed from after the last sequence number received.  A short
+// page can't be told apart from the end of the events until the next page, so the limit is lowered then.
+func (it *EventIterator) fetchPage(ctx context.Context) ([]*api.Event, bool, error) {
+	au := it.rc.baseUrl.JoinPath("accounts", it.address.String(), "events", strconv.FormatUint(it.creationNumber, 10))
+	params := url.Values{}
+	params.Set("start", strconv.FormatUint(it.start, 10))
+	params.Set("limit", strconv.FormatUint(it.pageSize, 10))
+	au.RawQuery = params.Encode()
+
+	events, _, err := getWithResp[[]*api.Event](ctx, it.rc, au.String())
+	if err != nil {
+		return nil, false, fmt.Errorf("get events api err: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, false, nil
+	}
+	if it.shortPage > 0 {
+		// The last page was short mid-stream, so the node capped the limit
+		it.pageSize = it.shortPage
+	}
+	it.shortPage = 0
+	if uint64(len(events)) < it.pageSize {
+		it.shortPage = uint64(len(events))
+	}
+	it.start = events[len(events)-1].SequenceNumber + 1
+	return events, true, nil
+}
@@ -0,0 +1,145 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// newEventServerClient creates a client against a mock server with a number of events, which caps the limit at maxLimit
+func newEventServerClient(t *testing.T, numEvents uint64, maxLimit uint64) (*Client, *atomic.Int32) {
+	var calls atomic.Int32
+	client := newEventServerClientWithRequests(t, numEvents, maxLimit, func(start uint64, limit uint64) {
+		calls.Add(1)
+	})
+	return client, &calls
+}
+
+// newEventServerClientWithRequests is [newEventServerClient], calling onRequest with the start and limit of each request
+func newEventServerClientWithRequests(t *testing.T, numEvents uint64, maxLimit uint64, onRequest func(start uint64, limit uint64)) *Client {
+	return newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts/"+AccountOne.String()+"/events/2", r.URL.Path)
+		start, err := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		assert.NoError(t, err)
+		limit, err := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		assert.NoError(t, err)
+		onRequest(start, limit)
+		limit = min(limit, maxLimit)
+
+		events := make([]*api.Event, 0)
+		for i := start; i < numEvents && i < start+limit; i++ {
+			events = append(events, &api.Event{
+				Type:           "0x1::coin::WithdrawEvent",
+				Guid:           &api.GUID{CreationNumber: 2, AccountAddress: &AccountOne},
+				SequenceNumber: i,
+				RawData:        json.RawMessage(`{"amount":"` + strconv.FormatUint(i, 10) + `"}`),
+			})
+		}
+		blob, err := json.Marshal(events)
+		assert.NoError(t, err)
+		_, _ = w.Write(blob)
+	})
+}
+
+func TestEventIterator_MultiplePages(t *testing.T) {
+	// The node caps the limit, so it should resume from the last sequence number
+	client, calls := newEventServerClient(t, 7, 3)
+	iter := client.EventsByCreationNumber(AccountOne, 2)
+	for i := uint64(0); i < 7; i++ {
+		event, ok, err := iter.Next(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, i, event.SequenceNumber)
+		assert.Equal(t, strconv.FormatUint(i, 10), event.Data["amount"])
+	}
+	event, ok, err := iter.Next(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, event)
+	// 3 full pages, and an empty page to find the end
+	assert.Equal(t, int32(4), calls.Load())
+
+	// It stays done without calling the node again
+	_, ok, err = iter.Next(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestEventIterator_Collect(t *testing.T) {
+	client, _ := newEventServerClient(t, 10, 4)
+	iter := client.EventsByCreationNumber(AccountOne, 2)
+	events, err := iter.Collect(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Len(t, events, 5)
+	assert.Equal(t, uint64(4), events[4].SequenceNumber)
+
+	// Collect the rest
+	events, err = iter.Collect(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 5)
+	assert.Equal(t, uint64(5), events[0].SequenceNumber)
+	assert.Equal(t, uint64(9), events[4].SequenceNumber)
+}
+
+func TestEventIterator_CappedLimit(t *testing.T) {
+	// The node caps the limit below the requested page size, which is only known once the short page is followed by more
+	var limits []uint64
+	client := newEventServerClientWithRequests(t, 10, 3, func(start uint64, limit uint64) {
+		limits = append(limits, limit)
+	})
+	iter := client.EventsByCreationNumber(AccountOne, 2, PageSize(5))
+	assert.Equal(t, uint64(5), iter.EffectivePageSize())
+
+	events, err := iter.Collect(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 10)
+	for i, event := range events {
+		assert.Equal(t, uint64(i), event.SequenceNumber)
+	}
+	assert.Equal(t, uint64(3), iter.EffectivePageSize())
+	// 0-2 and 3-5 at the requested size, then 6-8, 9, and an empty page at the capped size
+	assert.Equal(t, []uint64{5, 5, 3, 3, 3}, limits)
+}
+
+func TestEventIterator_ShortLastPage(t *testing.T) {
+	// A short page at the end isn't a cap
+	var starts []uint64
+	client := newEventServerClientWithRequests(t, 7, 100, func(start uint64, limit uint64) {
+		starts = append(starts, start)
+		assert.Equal(t, uint64(5), limit)
+	})
+	iter := client.EventsByCreationNumber(AccountOne, 2, PageStart(2), PageSize(5))
+	events, err := iter.Collect(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 5)
+	assert.Equal(t, uint64(2), events[0].SequenceNumber)
+	assert.Equal(t, uint64(5), iter.EffectivePageSize())
+	assert.Equal(t, []uint64{2, 7}, starts)
+}
+
+func TestEventIterator_Empty(t *testing.T) {
+	client, calls := newEventServerClient(t, 0, 100)
+	events, err := client.EventsByCreationNumber(AccountOne, 2).Collect(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestEventIterator_Error(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"event handle not found","error_code":"resource_not_found","vm_error_code":null}`))
+	})
+	_, ok, err := client.EventsByCreationNumber(AccountOne, 2).Next(context.Background())
+	assert.False(t, ok)
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
@@ -119,7 +119,7 @@ func example(network aptos.NetworkConfig) {
 	if err != nil {
 		panic("Failed to submit transaction:" + err.Error())
 	}
-	txnHash := submitResult.Hash
+	txnHash := submitResult.Hash.String()
 
 	// Wait for the transaction
 	fmt.Printf("And we wait for the transaction %s to complete...\n", txnHash)
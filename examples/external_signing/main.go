@@ -143,7 +143,7 @@ func example(networkConfig aptos.NetworkConfig) {
 	if err != nil {
 		panic("Failed to submit transaction:" + err.Error())
 	}
-	txnHash := submitResult.Hash
+	txnHash := submitResult.Hash.String()
 
 	// Wait for the transaction
 	fmt.Printf("And we wait for the transaction %s to complete...\n", txnHash)
@@ -54,7 +54,7 @@ func example(networkConfig aptos.NetworkConfig) {
 	if err != nil {
 		panic("Failed to build sign and submit publish transaction:" + err.Error())
 	}
-	waitResponse, err := client.WaitForTransaction(response.Hash)
+	waitResponse, err := client.WaitForTransaction(response.Hash.String())
 	if err != nil {
 		panic("Failed to wait for publish transaction:" + err.Error())
 	}
@@ -108,7 +108,7 @@ func example(networkConfig aptos.NetworkConfig) {
 		panic("Failed to build sign and submit mint transaction:" + err.Error())
 	}
 	fmt.Printf("Submitted mint as: %s\n", response.Hash)
-	_, err = client.WaitForTransaction(response.Hash)
+	_, err = client.WaitForTransaction(response.Hash.String())
 	if err != nil {
 		panic("Failed to wait for publish transaction:" + err.Error())
 	}
@@ -140,7 +140,7 @@ func example(networkConfig aptos.NetworkConfig) {
 		panic("Failed to submit transaction:" + err.Error())
 	}
 	fmt.Printf("Submitted transfer as: %s\n", response.Hash)
-	err = client.PollForTransactions([]string{response.Hash})
+	err = client.PollForTransactions([]string{response.Hash.String()})
 	if err != nil {
 		panic("Failed to wait for transaction:" + err.Error())
 	}
@@ -212,7 +212,7 @@ func runScript(client *aptos.Client, alice *aptos.Account, bob *aptos.AccountAdd
 	if err != nil {
 		panic("Failed to submit transaction:" + err.Error())
 	}
-	txnHash := submitResult.Hash
+	txnHash := submitResult.Hash.String()
 
 	// 5. Wait for the transaction to complete
 	_, err = client.WaitForTransaction(txnHash)
@@ -305,13 +305,13 @@ func submitAndWait(client *aptos.Client, sender *aptos.Account, payload aptos.Tr
 		panic("Failed to submit transaction: " + err.Error())
 	}
 
-	txn, err := client.WaitForTransaction(submitResponse.Hash)
+	txn, err := client.WaitForTransaction(submitResponse.Hash.String())
 	if err != nil {
 		panic("Failed to wait for transaction: " + err.Error())
 	}
 
 	if !txn.Success {
-		panic("Transaction failed: " + submitResponse.Hash)
+		panic("Transaction failed: " + submitResponse.Hash.String())
 	}
 
 	// Now check that there's no event for failed multisig
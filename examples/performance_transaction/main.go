@@ -74,7 +74,7 @@ func example(networkConfig aptos.NetworkConfig) {
 	if err != nil {
 		panic("Failed to submit transaction:" + err.Error())
 	}
-	txnHash := submitResult.Hash
+	txnHash := submitResult.Hash.String()
 	println("Submit transaction:", time.Since(before).Milliseconds(), "ms")
 
 	// Wait for the transaction
@@ -68,7 +68,7 @@ func sendManyTransactionsSerially(networkConfig aptos.NetworkConfig, numTransact
 	}
 
 	// Wait on last transaction
-	response, err := client.WaitForTransaction(responses[numTransactions-1].Hash)
+	response, err := client.WaitForTransaction(responses[numTransactions-1].Hash.String())
 	if err != nil {
 		panic("Failed to wait for transaction:" + err.Error())
 	}
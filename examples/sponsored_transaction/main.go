@@ -107,7 +107,7 @@ func example(networkConfig aptos.NetworkConfig) {
 	if err != nil {
 		panic("Failed to submit transaction:" + err.Error())
 	}
-	txnHash := submitResult.Hash
+	txnHash := submitResult.Hash.String()
 	println("Submitted transaction hash:", txnHash)
 
 	// Wait for the transaction
@@ -177,7 +177,7 @@ func example(networkConfig aptos.NetworkConfig) {
 	if err != nil {
 		panic("Failed to submit transaction:" + err.Error())
 	}
-	txnHash = submitResult.Hash
+	txnHash = submitResult.Hash.String()
 	println("Submitted transaction hash:", txnHash)
 
 	// Wait for the transaction
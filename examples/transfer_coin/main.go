@@ -105,7 +105,7 @@ func example(networkConfig aptos.NetworkConfig) {
 	if err != nil {
 		panic("Failed to submit transaction:" + err.Error())
 	}
-	txnHash := submitResult.Hash
+	txnHash := submitResult.Hash.String()
 
 	// 5. Wait for the transaction to complete
 	_, err = client.WaitForTransaction(txnHash)
@@ -145,7 +145,7 @@ func example(networkConfig aptos.NetworkConfig) {
 		panic("Failed to sign transaction:" + err.Error())
 	}
 
-	_, err = client.WaitForTransaction(resp.Hash)
+	_, err = client.WaitForTransaction(resp.Hash.String())
 	if err != nil {
 		panic("Failed to wait for transaction:" + err.Error())
 	}
@@ -0,0 +1,51 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DefaultLedgerExpiration is the time for transactions built to expire after the ledger's timestamp, unless changed
+// with [NodeClient.SetLedgerExpiration]
+const DefaultLedgerExpiration = 30 * time.Second
+
+// LedgerExpiration will set a transaction to expire the duration after the ledger's timestamp, rather than after the
+// local clock as with [ExpirationSeconds], see [NodeClient.DefaultExpiration]
+type LedgerExpiration time.Duration
+
+// DefaultExpiration returns the expiration timestamp in seconds for a transaction to expire d after the ledger's
+// timestamp.  Expiration is checked against the ledger's timestamp, so this is right even if the local clock is off,
+// or the node is behind.
+//
+//	expiration, err := client.DefaultExpiration(DefaultLedgerExpiration)
+func (rc *NodeClient) DefaultExpiration(d time.Duration) (uint64, error) {
+	if d < 0 {
+		return 0, errors.New("expiration cannot be less than 0")
+	}
+	info, err := rc.Info()
+	if err != nil {
+		return 0, err
+	}
+	return ledgerExpirationSeconds(info, d)
+}
+
+// ledgerExpirationSeconds returns the expiration timestamp in seconds for a transaction to expire d after the ledger's
+// timestamp in the node info
+func ledgerExpirationSeconds(info NodeInfo, d time.Duration) (uint64, error) {
+	ledgerTimestamp, err := strconv.ParseUint(info.LedgerTimestampStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad ledger_timestamp %s: %w", info.LedgerTimestampStr, err)
+	}
+	return uint64(time.UnixMicro(int64(ledgerTimestamp)).Add(d).Unix()), nil
+}
+
+// SetLedgerExpiration sets transactions built for all future requests to expire d after the ledger's timestamp, by
+// default [DefaultLedgerExpiration], 0 to expire after the local clock with [DefaultExpirationSeconds].  Building a
+// transaction fetches the ledger info, along with the other requirements, unless it expires after the local clock.
+//
+// Either can be chosen for a single transaction, with the [LedgerExpiration] or [ExpirationSeconds] options.
+func (rc *NodeClient) SetLedgerExpiration(d time.Duration) {
+	rc.ledgerExpiration = d
+}
@@ -0,0 +1,93 @@
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testLedgerTimestamp is the ledger timestamp of the mock node, in microseconds, an hour behind the local clock
+var testLedgerTimestamp = time.Now().Add(-time.Hour).UnixMicro()
+
+// newExpirationServerClient creates a client against a mock node with a ledger timestamp of testLedgerTimestamp, and
+// counts the node info requests
+func newExpirationServerClient(t *testing.T, sender AccountAddress) (*Client, *atomic.Int32) {
+	var infoCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1":
+			infoCalls.Add(1)
+			_, _ = fmt.Fprintf(w, `{"chain_id": 4, "epoch": "1", "ledger_version": "1", "oldest_ledger_version": "0", "ledger_timestamp": "%d", "node_role": "full_node", "oldest_block_height": "0", "block_height": "1"}`, testLedgerTimestamp)
+		case "/v1/accounts/" + sender.String():
+			_, _ = fmt.Fprint(w, `{"sequence_number":"5","authentication_key":"`+sender.String()+`"}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(NetworkConfig{Name: "mock", ChainId: 4, NodeUrl: server.URL + "/v1"})
+	assert.NoError(t, err)
+	return client, &infoCalls
+}
+
+func TestDefaultExpiration(t *testing.T) {
+	client, _ := newExpirationServerClient(t, AccountOne)
+	ledgerSeconds := uint64(testLedgerTimestamp / 1_000_000)
+
+	expiration, err := client.DefaultExpiration(DefaultLedgerExpiration)
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerSeconds+30, expiration)
+
+	expiration, err = client.DefaultExpiration(0)
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerSeconds, expiration)
+
+	_, err = client.DefaultExpiration(-time.Second)
+	assert.ErrorContains(t, err, "less than 0")
+}
+
+func TestBuildTransaction_LedgerExpiration(t *testing.T) {
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	client, infoCalls := newExpirationServerClient(t, AccountOne)
+	ledgerSeconds := uint64(testLedgerTimestamp / 1_000_000)
+
+	// By default, it's DefaultLedgerExpiration after the ledger timestamp
+	rawTxn, err := client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, GasUnitPrice(100))
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerSeconds+30, rawTxn.ExpirationTimestampSeconds)
+	multiAgentTxn, err := client.BuildTransactionMultiAgent(AccountOne, TransactionPayload{Payload: payload}, GasUnitPrice(100), FeePayer(&AccountTwo))
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerSeconds+30, multiAgentTxn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData).RawTxn.ExpirationTimestampSeconds)
+	assert.Equal(t, int32(2), infoCalls.Load())
+
+	// For a single transaction
+	rawTxn, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, GasUnitPrice(100), LedgerExpiration(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerSeconds+60, rawTxn.ExpirationTimestampSeconds)
+	assert.Equal(t, int32(3), infoCalls.Load())
+
+	// Or from the local clock, without fetching the ledger timestamp
+	before := uint64(time.Now().Unix())
+	rawTxn, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, GasUnitPrice(100), ExpirationSeconds(10))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, rawTxn.ExpirationTimestampSeconds, before+10)
+	assert.Equal(t, int32(3), infoCalls.Load())
+
+	// For all transactions
+	client.SetLedgerExpiration(0)
+	before = uint64(time.Now().Unix())
+	rawTxn, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, GasUnitPrice(100))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, rawTxn.ExpirationTimestampSeconds, before+uint64(DefaultExpirationSeconds))
+	assert.Equal(t, int32(3), infoCalls.Load())
+
+	_, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, LedgerExpiration(0))
+	assert.ErrorContains(t, err, "LedgerExpiration must be more than 0")
+}
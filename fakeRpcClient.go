@@ -0,0 +1,317 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// FakeRpcClient is an in-memory [AptosRpcClient] for unit testing apps built on the SDK, without a node.  Accounts,
+// resources, view function results, and transactions are set up ahead of time, and transactions submitted to it are
+// recorded rather than executed.
+//
+//	fake := &FakeRpcClient{ChainId: 4}
+//	fake.SetResource(address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", map[string]any{
+//		"coin": map[string]any{"value": "100"},
+//	})
+//	var client AptosRpcClient = fake
+//
+// The zero value is ready to use, and it is safe for concurrent use.  Ledger versions are ignored, the latest state is
+// always returned.  Reads of state that isn't set up fail with an [api.Error] for which [IsNotFound] is true.
+//
+// Only some methods are faked, the rest are passed to the embedded AptosRpcClient, which can be a real client or another
+// fake.  If it's nil, calling those methods panics.
+type FakeRpcClient struct {
+	AptosRpcClient // AptosRpcClient handles the methods that aren't faked, nil if none are expected to be called
+
+	ChainId uint8 // ChainId is returned by [FakeRpcClient.GetChainId]
+
+	mutex        sync.Mutex
+	accounts     map[AccountAddress]AccountInfo
+	resources    map[AccountAddress]map[string]map[string]any
+	views        map[string]fakeViewResult
+	transactions map[api.Hash]*api.Transaction
+	submitted    []*SignedTransaction
+}
+
+// fakeViewResult is the canned result of a view function, see [FakeRpcClient.SetView]
+type fakeViewResult struct {
+	values []any
+	err    error
+}
+
+// SetAccount sets the sequence number and authentication key returned for the account
+func (fake *FakeRpcClient) SetAccount(address AccountAddress, info AccountInfo) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	if fake.accounts == nil {
+		fake.accounts = make(map[AccountAddress]AccountInfo)
+	}
+	fake.accounts[address] = info
+}
+
+// SetResource sets the JSON data of a resource at the account, as the node would return it e.g. u64 values as strings.
+// The account then exists, even without [FakeRpcClient.SetAccount].
+func (fake *FakeRpcClient) SetResource(address AccountAddress, resourceType string, data map[string]any) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	if fake.resources == nil {
+		fake.resources = make(map[AccountAddress]map[string]map[string]any)
+	}
+	if fake.resources[address] == nil {
+		fake.resources[address] = make(map[string]map[string]any)
+	}
+	fake.resources[address][resourceType] = data
+}
+
+// SetView sets the values returned by a view function, whatever the arguments, e.g. "0x1::coin::balance".  If viewErr
+// is set, it is returned instead.  It fails if the function isn't of the form <address>::<module>::<function>.
+func (fake *FakeRpcClient) SetView(function string, values []any, viewErr error) error {
+	key, err := fakeViewKey(function)
+	if err != nil {
+		return err
+	}
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	if fake.views == nil {
+		fake.views = make(map[string]fakeViewResult)
+	}
+	fake.views[key] = fakeViewResult{values: values, err: viewErr}
+	return nil
+}
+
+// SetTransaction sets a transaction returned by its hash
+func (fake *FakeRpcClient) SetTransaction(txn *api.Transaction) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	if fake.transactions == nil {
+		fake.transactions = make(map[api.Hash]*api.Transaction)
+	}
+	fake.transactions[txn.Hash()] = txn
+}
+
+// Submitted returns the transactions submitted so far, in order
+func (fake *FakeRpcClient) Submitted() []*SignedTransaction {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	out := make([]*SignedTransaction, len(fake.submitted))
+	copy(out, fake.submitted)
+	return out
+}
+
+// GetChainId returns the ChainId of the fake
+func (fake *FakeRpcClient) GetChainId() (chainId uint8, err error) {
+	return fake.ChainId, nil
+}
+
+// Account returns the account set with [FakeRpcClient.SetAccount].  An account with only resources has sequence number
+// 0, and an authentication key of its address.
+func (fake *FakeRpcClient) Account(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	if info, ok := fake.accounts[address]; ok {
+		return info, nil
+	}
+	if _, ok := fake.resources[address]; ok {
+		return AccountInfo{SequenceNumberStr: "0", AuthenticationKeyHex: address.StringLong()}, nil
+	}
+	return AccountInfo{}, fakeNotFound(api.ErrorCodeAccountNotFound, "Account not found by Address(%s)", address.String())
+}
+
+// AccountCtx is [FakeRpcClient.Account], but fails with the context's error if it's done
+func (fake *FakeRpcClient) AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
+	if err = ctx.Err(); err != nil {
+		return AccountInfo{}, err
+	}
+	return fake.Account(address, ledgerVersion...)
+}
+
+// AccountExists tells whether the account is set up, either with [FakeRpcClient.SetAccount] or
+// [FakeRpcClient.SetResource]
+func (fake *FakeRpcClient) AccountExists(address AccountAddress) (bool, error) {
+	_, err := fake.Account(address)
+	return err == nil, nil
+}
+
+// AccountResource returns the resource set with [FakeRpcClient.SetResource], with its type and data the same as the node
+func (fake *FakeRpcClient) AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
+	resourceData, err := fake.resourceData(address, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": resourceType, "data": resourceData}, nil
+}
+
+// AccountResourceCtx is [FakeRpcClient.AccountResource], but fails with the context's error if it's done
+func (fake *FakeRpcClient) AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fake.AccountResource(address, resourceType, ledgerVersion...)
+}
+
+// resourceData returns the data of the resource set with [FakeRpcClient.SetResource]
+func (fake *FakeRpcClient) resourceData(address AccountAddress, resourceType string) (map[string]any, error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	data, ok := fake.resources[address][resourceType]
+	if !ok {
+		return nil, fakeNotFound(api.ErrorCodeResourceNotFound, "Resource not found by Address(%s), Struct tag(%s)", address.String(), resourceType)
+	}
+	return data, nil
+}
+
+// AccountResources returns all the resources set with [FakeRpcClient.SetResource] for the account, ordered by type
+func (fake *FakeRpcClient) AccountResources(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	return fake.AccountResourcesUpTo(address, 0, ledgerVersion...)
+}
+
+// AccountResourcesCtx is [FakeRpcClient.AccountResources], but fails with the context's error if it's done
+func (fake *FakeRpcClient) AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fake.AccountResources(address, ledgerVersion...)
+}
+
+// AccountResourcesUpTo returns the resources set with [FakeRpcClient.SetResource] for the account, ordered by type, up
+// to maxResources, or all if maxResources is 0 or less
+func (fake *FakeRpcClient) AccountResourcesUpTo(address AccountAddress, maxResources int, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	accountResources, ok := fake.resources[address]
+	if !ok {
+		if _, ok = fake.accounts[address]; !ok {
+			return nil, fakeNotFound(api.ErrorCodeAccountNotFound, "Account not found by Address(%s)", address.String())
+		}
+	}
+	resources = make([]AccountResourceInfo, 0, len(accountResources))
+	for resourceType, data := range accountResources {
+		resources = append(resources, AccountResourceInfo{Type: resourceType, Data: data})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Type < resources[j].Type
+	})
+	if maxResources > 0 && len(resources) > maxResources {
+		resources = resources[:maxResources]
+	}
+	return resources, nil
+}
+
+// AccountResourcesBatch returns the resources set with [FakeRpcClient.SetResource], in the same order as
+// resourceTypes.  It fails if any are missing, options are ignored.
+func (fake *FakeRpcClient) AccountResourcesBatch(address AccountAddress, resourceTypes []string, options ...any) (resources []AccountResourceInfo, err error) {
+	resources = make([]AccountResourceInfo, len(resourceTypes))
+	for i, resourceType := range resourceTypes {
+		data, err := fake.resourceData(address, resourceType)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", resourceType, err)
+		}
+		resources[i] = AccountResourceInfo{Type: resourceType, Data: data}
+	}
+	return resources, nil
+}
+
+// View returns the values set with [FakeRpcClient.SetView] for the function
+func (fake *FakeRpcClient) View(payload *ViewPayload, ledgerVersion ...uint64) (vals []any, err error) {
+	return fake.view(payload.Module.String() + "::" + payload.Function)
+}
+
+// ViewCtx is [FakeRpcClient.View], but fails with the context's error if it's done
+func (fake *FakeRpcClient) ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) (vals []any, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fake.View(payload, ledgerVersion...)
+}
+
+// ViewJson returns the values set with [FakeRpcClient.SetView] for the function
+func (fake *FakeRpcClient) ViewJson(function string, typeArgs []string, args []any, ledgerVersion ...uint64) (vals []any, err error) {
+	return fake.view(function)
+}
+
+// view returns the canned result of the view function
+func (fake *FakeRpcClient) view(function string) ([]any, error) {
+	key, err := fakeViewKey(function)
+	if err != nil {
+		return nil, err
+	}
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	result, ok := fake.views[key]
+	if !ok {
+		return nil, fakeNotFound(api.ErrorCodeModuleNotFound, "View function not found %s", function)
+	}
+	return result.values, result.err
+}
+
+// TransactionByHash returns the transaction set with [FakeRpcClient.SetTransaction]
+func (fake *FakeRpcClient) TransactionByHash(txnHash string) (data *api.Transaction, err error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	hash, err := api.ParseHash(txnHash)
+	txn, ok := fake.transactions[hash]
+	if err != nil || !ok {
+		return nil, fakeNotFound(api.ErrorCodeTransactionNotFound, "Transaction not found by Transaction hash(%s)", txnHash)
+	}
+	return txn, nil
+}
+
+// TransactionByHashCtx is [FakeRpcClient.TransactionByHash], but fails with the context's error if it's done
+func (fake *FakeRpcClient) TransactionByHashCtx(ctx context.Context, txnHash string) (data *api.Transaction, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fake.TransactionByHash(txnHash)
+}
+
+// WaitForTransaction returns the user transaction set with [FakeRpcClient.SetTransaction], without waiting
+func (fake *FakeRpcClient) WaitForTransaction(txnHash string, options ...any) (data *api.UserTransaction, err error) {
+	txn, err := fake.TransactionByHash(txnHash)
+	if err != nil {
+		return nil, err
+	}
+	return txn.UserTransaction()
+}
+
+// SubmitTransaction records the transaction, see [FakeRpcClient.Submitted], and returns its hash.  It isn't executed,
+// so any effects must be set up separately e.g. with [FakeRpcClient.SetTransaction].
+func (fake *FakeRpcClient) SubmitTransaction(signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
+	hashStr, err := signedTransaction.Hash()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := api.ParseHash(hashStr)
+	if err != nil {
+		return nil, err
+	}
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.submitted = append(fake.submitted, signedTransaction)
+	return &api.SubmitTransactionResponse{Hash: hash}, nil
+}
+
+// SubmitTransactionCtx is [FakeRpcClient.SubmitTransaction], but fails with the context's error if it's done
+func (fake *FakeRpcClient) SubmitTransactionCtx(ctx context.Context, signedTransaction *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fake.SubmitTransaction(signedTransaction)
+}
+
+// fakeViewKey normalizes the address of a view function, so it matches however the address is written
+func fakeViewKey(function string) (string, error) {
+	address, module, name, err := ParseModuleFunction(function)
+	if err != nil {
+		return "", err
+	}
+	return address.String() + "::" + module + "::" + name, nil
+}
+
+// fakeNotFound returns a not found error the same as the node would
+func fakeNotFound(errorCode string, format string, args ...any) error {
+	return &api.Error{ErrorCode: errorCode, Message: fmt.Sprintf(format, args...)}
+}
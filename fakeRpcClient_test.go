@@ -0,0 +1,151 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAppBalance is app code under test, which only depends on the interface
+func testAppBalance(client AptosRpcClient, address AccountAddress) (uint64, error) {
+	type coinStore struct {
+		Coin struct {
+			Value api.U64 `json:"value"`
+		} `json:"coin"`
+	}
+	store, err := GetAccountResource[coinStore](client, address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>")
+	if err != nil {
+		return 0, err
+	}
+	return store.Coin.Value.ToUint64(), nil
+}
+
+func TestFakeRpcClient_Resource(t *testing.T) {
+	fake := &FakeRpcClient{ChainId: 4}
+	fake.SetResource(AccountTwo, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", map[string]any{
+		"coin":   map[string]any{"value": "4236137720"},
+		"frozen": false,
+	})
+	fake.SetResource(AccountTwo, "0x1::account::Account", map[string]any{"sequence_number": "0"})
+
+	balance, err := testAppBalance(fake, AccountTwo)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4236137720), balance)
+
+	resources, err := fake.AccountResources(AccountTwo)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "0x1::account::Account", resources[0].Type)
+	resources, err = fake.AccountResourcesUpTo(AccountTwo, 1)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	resources, err = fake.AccountResourcesBatch(AccountTwo, []string{"0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "0x1::account::Account"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::account::Account", resources[1].Type)
+
+	// The account exists with its resources
+	exists, err := fake.AccountExists(AccountTwo)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	info, err := fake.Account(AccountTwo)
+	assert.NoError(t, err)
+	sequenceNumber, err := info.SequenceNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), sequenceNumber)
+	chainId, err := fake.GetChainId()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(4), chainId)
+
+	// Missing state is not found, the same as a node
+	_, err = testAppBalance(fake, AccountThree)
+	assert.True(t, IsNotFound(err))
+	var notFound *ResourceNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	_, err = fake.Account(AccountThree)
+	assert.True(t, IsNotFound(err))
+	exists, err = fake.AccountExists(AccountThree)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	_, err = fake.AccountResources(AccountThree)
+	assert.True(t, IsNotFound(err))
+	_, err = fake.AccountResourcesBatch(AccountTwo, []string{"0x1::missing::Missing"})
+	assert.True(t, IsNotFound(err))
+}
+
+func TestFakeRpcClient_View(t *testing.T) {
+	fake := &FakeRpcClient{}
+	assert.NoError(t, fake.SetView("0x1::coin::balance", []any{"100"}, nil))
+	assert.NoError(t, fake.SetView("0x0000000000000000000000000000000000000000000000000000000000000001::chain_id::get", nil, errors.New("view failed")))
+	assert.Error(t, fake.SetView("balance", nil, nil))
+
+	vals, err := fake.ViewJson("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"100"}, vals)
+	vals, err = fake.View(&ViewPayload{Module: ModuleId{Address: AccountOne, Name: "coin"}, Function: "balance"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"100"}, vals)
+
+	_, err = fake.ViewJson("0x1::chain_id::get", nil, nil)
+	assert.ErrorContains(t, err, "view failed")
+	_, err = fake.ViewJson("0x1::coin::supply", nil, nil)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestFakeRpcClient_Transactions(t *testing.T) {
+	fake := &FakeRpcClient{ChainId: 4}
+	account := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     account.Address,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: transfer},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1700000000,
+		ChainId:                    4,
+	}
+	signedTxn, err := rawTxn.SignedTransaction(account)
+	assert.NoError(t, err)
+	hash, err := signedTxn.Hash()
+	assert.NoError(t, err)
+
+	// Submitting records the transaction
+	response, err := fake.SubmitTransaction(signedTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, response.Hash.String())
+	assert.Equal(t, []*SignedTransaction{signedTxn}, fake.Submitted())
+
+	// The transaction isn't committed until it's set up
+	_, err = fake.WaitForTransaction(hash)
+	assert.True(t, IsNotFound(err))
+
+	txn := &api.Transaction{}
+	err = json.Unmarshal([]byte(fmt.Sprintf(testUserTransactionJson, hash, true, "Executed successfully")), txn)
+	assert.NoError(t, err)
+	fake.SetTransaction(txn)
+	found, err := fake.TransactionByHash(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, txn, found)
+	userTxn, err := fake.WaitForTransaction(hash)
+	assert.NoError(t, err)
+	assert.True(t, userTxn.Success)
+}
+
+func TestFakeRpcClient_Fallback(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1", r.URL.Path)
+		_, _ = fmt.Fprint(w, testNodeInfoJson)
+	})
+
+	// Methods that aren't faked go to the embedded client
+	fake := &FakeRpcClient{AptosRpcClient: client}
+	info, err := fake.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), info.LedgerVersion())
+}
@@ -1,6 +1,7 @@
 package aptos
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -27,10 +28,50 @@ func NewFaucetClient(nodeClient *NodeClient, faucetUrl string) (*FaucetClient, e
 	}, nil
 }
 
-// Fund account with the given amount of AptosCoin
+// WithContext returns a copy of the client with every request, and the wait for the funding transactions, bound to the
+// context.  See [NodeClient.WithContext].
+func (faucetClient *FaucetClient) WithContext(ctx context.Context) *FaucetClient {
+	return &FaucetClient{
+		nodeClient: faucetClient.nodeClient.WithContext(ctx),
+		url:        faucetClient.url,
+	}
+}
+
+// FaucetWait is an option to [FaucetClient.FundTransactions], whether to wait for the funding transactions to be
+// committed.  Default true.
+type FaucetWait bool
+
+// Fund account with the given amount of AptosCoin, and waits for the funding transactions to be committed
 func (faucetClient *FaucetClient) Fund(address AccountAddress, amount uint64) error {
+	_, err := faucetClient.FundTransactions(address, amount)
+	return err
+}
+
+// FundTransactions funds the account with the given amount of AptosCoin, and returns the hashes of the funding
+// transactions.  The faucet submits the transactions asynchronously, so by default it waits for them to be committed.
+//
+// Options:
+//   - FaucetWait: bool, false returns the hashes without waiting. Default true.
+//   - PollPeriod: time.Duration, how often to poll for the transactions. Default 100ms.
+//   - PollTimeout: time.Duration, how long to wait for the transactions. Default 10s.
+func (faucetClient *FaucetClient) FundTransactions(address AccountAddress, amount uint64, options ...any) (txnHashes []string, err error) {
+	if faucetClient == nil {
+		return nil, errors.New("faucet client not initialized, the network has no faucet url")
+	}
 	if faucetClient.nodeClient == nil {
-		return errors.New("faucet's node-client not initialized")
+		return nil, errors.New("faucet's node-client not initialized")
+	}
+	wait := true
+	pollOptions := make([]any, 0, len(options))
+	for i, option := range options {
+		switch ovalue := option.(type) {
+		case FaucetWait:
+			wait = bool(ovalue)
+		case PollPeriod, PollTimeout:
+			pollOptions = append(pollOptions, ovalue)
+		default:
+			return nil, fmt.Errorf("FundTransactions arg %d bad type %T", i+1, option)
+		}
 	}
 
 	// Build URL
@@ -41,17 +82,90 @@ func (faucetClient *FaucetClient) Fund(address AccountAddress, amount uint64) er
 	mintUrl.RawQuery = params.Encode()
 
 	// Make request for funds
-	txnHashes, err := Post[[]string](faucetClient.nodeClient, mintUrl.String(), "text/plain", nil)
+	txnHashes, err = Post[[]string](faucetClient.nodeClient, mintUrl.String(), "text/plain", nil)
 	if err != nil {
-		return fmt.Errorf("response api decode error, %w", err)
+		return nil, fmt.Errorf("response api decode error, %w", err)
+	}
+	if !wait {
+		return txnHashes, nil
 	}
 
 	// Wait for fund transactions to go through
 	slog.Debug("FundAccount wait for transactions", "number of transactions", len(txnHashes))
 	if len(txnHashes) == 1 {
-		_, err = faucetClient.nodeClient.WaitForTransaction(txnHashes[0])
-		return err
+		_, err = faucetClient.nodeClient.WaitForTransaction(txnHashes[0], pollOptions...)
 	} else {
-		return faucetClient.nodeClient.PollForTransactions(txnHashes)
+		err = faucetClient.nodeClient.PollForTransactions(txnHashes, pollOptions...)
+	}
+	return txnHashes, err
+}
+
+// CreateAndFund generates a new Ed25519 account, and funds it with the given amount of AptosCoin.  It accepts the
+// same options as [FaucetClient.FundTransactions].
+func (faucetClient *FaucetClient) CreateAndFund(amount uint64, options ...any) (*Account, error) {
+	account, err := NewEd25519Account()
+	if err != nil {
+		return nil, err
+	}
+	_, err = faucetClient.FundTransactions(account.Address, amount, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund new account %s: %w", account.Address.String(), err)
+	}
+	return account, nil
+}
+
+// CreateAndFundMany generates count new Ed25519 accounts, and funds each with the given amount of AptosCoin, with at most
+// [DefaultBatchWorkers] funded at once, e.g. to set up accounts for a load test.
+//
+//	accounts, err := faucetClient.CreateAndFundMany(ctx, 100, 100_000_000, BatchWorkers(4))
+//
+// On failure, the accounts which were funded are still returned, in order, along with the errors of the others joined.
+// Accounts aren't started once the context is done.
+//
+// Options:
+//   - [BatchWorkers] the maximum number of accounts funded at once, defaults to [DefaultBatchWorkers]
+//   - The options of [FaucetClient.FundTransactions]
+func (faucetClient *FaucetClient) CreateAndFundMany(ctx context.Context, count int, amount uint64, options ...any) ([]*Account, error) {
+	if faucetClient == nil {
+		return nil, errors.New("faucet client not initialized, the network has no faucet url")
+	}
+	if faucetClient.nodeClient == nil {
+		return nil, errors.New("faucet's node-client not initialized")
+	}
+	workers := DefaultBatchWorkers
+	fundOptions := make([]any, 0, len(options))
+	for i, option := range options {
+		switch ovalue := option.(type) {
+		case BatchWorkers:
+			if ovalue < 1 {
+				return nil, fmt.Errorf("CreateAndFundMany BatchWorkers must be at least 1, got %d", ovalue)
+			}
+			workers = int(ovalue)
+		case FaucetWait, PollPeriod, PollTimeout:
+			fundOptions = append(fundOptions, ovalue)
+		default:
+			return nil, fmt.Errorf("CreateAndFundMany arg %d bad type %T", i+1, option)
+		}
+	}
+	if count <= 0 {
+		return []*Account{}, nil
+	}
+
+	bound := faucetClient.WithContext(ctx)
+	accounts := make([]*Account, count)
+	errs := make([]error, count)
+	runBatch(count, workers, func(i int) {
+		if errs[i] = ctx.Err(); errs[i] != nil {
+			return
+		}
+		accounts[i], errs[i] = bound.CreateAndFund(amount, fundOptions...)
+	})
+
+	funded := make([]*Account, 0, count)
+	for _, account := range accounts {
+		if account != nil {
+			funded = append(funded, account)
+		}
 	}
+	return funded, errors.Join(errs...)
 }
@@ -0,0 +1,195 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFaucetServerClient creates a client against a mock faucet and node, where the faucet submits the hashes and the
+// node reports each transaction as pending a number of times before it's committed
+func newFaucetServerClient(t *testing.T, hashes []string, pending int32) (*Client, *atomic.Int32) {
+	var lookups atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/mint":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "100", r.URL.Query().Get("amount"))
+			assert.NotEmpty(t, r.URL.Query().Get("address"))
+			_, _ = fmt.Fprintf(w, `["%s"]`, strings.Join(hashes, `","`))
+		case strings.HasPrefix(r.URL.Path, "/v1/transactions/by_hash/"):
+			hash := strings.TrimPrefix(r.URL.Path, "/v1/transactions/by_hash/")
+			if lookups.Add(1) <= pending {
+				_, _ = fmt.Fprintf(w, `{"type":"pending_transaction","hash":"%s","sender":"0x1","sequence_number":"0","max_gas_amount":"1","gas_unit_price":"1","expiration_timestamp_secs":"1","payload":{"function":"0x1::aptos_account::transfer","type_arguments":[],"arguments":[],"type":"entry_function_payload"},"signature":null}`, hash)
+				return
+			}
+			_, _ = fmt.Fprintf(w, testUserTransactionJson, hash, true, "Executed successfully")
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(NetworkConfig{
+		Name:      "mock",
+		ChainId:   4,
+		NodeUrl:   server.URL + "/v1",
+		FaucetUrl: server.URL,
+	})
+	assert.NoError(t, err)
+	return client, &lookups
+}
+
+func TestFaucet_FundTransactions(t *testing.T) {
+	client, lookups := newFaucetServerClient(t, []string{testTxnHash}, 2)
+	txnHashes, err := client.FundTransactions(AccountOne, 100, PollPeriod(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testTxnHash}, txnHashes)
+	// It waited until the transaction was committed
+	assert.Equal(t, int32(3), lookups.Load())
+
+	// Funding without the hashes still waits
+	err = client.Fund(AccountOne, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), lookups.Load())
+}
+
+func TestFaucet_FundTransactionsMultiple(t *testing.T) {
+	hashes := []string{testTxnHash, "0x1234000000000000000000000000000000000000000000000000000000000000"}
+	client, lookups := newFaucetServerClient(t, hashes, 0)
+	txnHashes, err := client.FundTransactions(AccountOne, 100, PollPeriod(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, hashes, txnHashes)
+	assert.Equal(t, int32(2), lookups.Load())
+}
+
+func TestFaucet_FundTransactionsNoWait(t *testing.T) {
+	client, lookups := newFaucetServerClient(t, []string{testTxnHash}, 0)
+	txnHashes, err := client.FundTransactions(AccountOne, 100, FaucetWait(false))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testTxnHash}, txnHashes)
+	assert.Equal(t, int32(0), lookups.Load())
+}
+
+func TestFaucet_FundTransactionsTimeout(t *testing.T) {
+	client, _ := newFaucetServerClient(t, []string{testTxnHash}, 1000)
+	txnHashes, err := client.FundTransactions(AccountOne, 100, PollPeriod(time.Millisecond), PollTimeout(10*time.Millisecond))
+	assert.ErrorContains(t, err, "timeout")
+	// The hashes are still returned to check on later
+	assert.Equal(t, []string{testTxnHash}, txnHashes)
+}
+
+func TestFaucet_CreateAndFund(t *testing.T) {
+	client, _ := newFaucetServerClient(t, []string{testTxnHash}, 0)
+	account, err := client.CreateAndFund(100, PollPeriod(time.Millisecond))
+	assert.NoError(t, err)
+	assert.NotNil(t, account)
+	assert.Equal(t, account.AuthKey()[:], account.Address[:])
+}
+
+func TestFaucet_Errors(t *testing.T) {
+	client, _ := newFaucetServerClient(t, []string{testTxnHash}, 0)
+	_, err := client.FundTransactions(AccountOne, 100, "wait")
+	assert.ErrorContains(t, err, "FundTransactions arg 1 bad type string")
+
+	// Networks without a faucet return an error
+	noFaucet, err := NewClient(NetworkConfig{Name: "mock", ChainId: 4, NodeUrl: "http://127.0.0.1:1/v1"})
+	assert.NoError(t, err)
+	err = noFaucet.Fund(AccountOne, 100)
+	assert.ErrorContains(t, err, "faucet client not initialized")
+	_, err = noFaucet.CreateAndFund(100)
+	assert.ErrorContains(t, err, "faucet client not initialized")
+	_, err = noFaucet.CreateAndFundMany(context.Background(), 2, 100)
+	assert.ErrorContains(t, err, "faucet client not initialized")
+}
+
+// newFaucetManyServerClient creates a client against a mock faucet which holds each mint request, to record how many
+// are in flight at once, and fails the mint requests for which fail returns true
+func newFaucetManyServerClient(t *testing.T, fail func(request int32) bool) (*Client, *atomic.Int32, *atomic.Int32) {
+	var requests, inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/mint", r.URL.Path)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if fail(requests.Add(1)) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprint(w, `{"message":"faucet is dry","error_code":"internal_error"}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `["%s"]`, testTxnHash)
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(NetworkConfig{
+		Name:      "mock",
+		ChainId:   4,
+		NodeUrl:   server.URL + "/v1",
+		FaucetUrl: server.URL,
+	})
+	assert.NoError(t, err)
+	return client, &requests, &maxInFlight
+}
+
+func TestFaucet_CreateAndFundMany(t *testing.T) {
+	client, requests, maxInFlight := newFaucetManyServerClient(t, func(int32) bool { return false })
+	accounts, err := client.CreateAndFundMany(context.Background(), 10, 100, BatchWorkers(3), FaucetWait(false))
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 10)
+	assert.Equal(t, int32(10), requests.Load())
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+
+	// Every account is new
+	addresses := make(map[AccountAddress]bool)
+	for _, account := range accounts {
+		addresses[account.Address] = true
+	}
+	assert.Len(t, addresses, 10)
+
+	accounts, err = client.CreateAndFundMany(context.Background(), 0, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, accounts)
+}
+
+func TestFaucet_CreateAndFundManyPartialFailure(t *testing.T) {
+	client, requests, _ := newFaucetManyServerClient(t, func(request int32) bool { return request%3 == 0 })
+	accounts, err := client.CreateAndFundMany(context.Background(), 9, 100, BatchWorkers(2), FaucetWait(false))
+	assert.ErrorContains(t, err, "faucet is dry")
+	assert.Equal(t, int32(9), requests.Load())
+	// The accounts funded before and after the failures are still returned
+	assert.Len(t, accounts, 6)
+	for _, account := range accounts {
+		assert.NotNil(t, account)
+	}
+}
+
+func TestFaucet_CreateAndFundManyCancelled(t *testing.T) {
+	client, requests, _ := newFaucetManyServerClient(t, func(int32) bool { return false })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	accounts, err := client.CreateAndFundMany(ctx, 5, 100, FaucetWait(false))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, accounts)
+	assert.Equal(t, int32(0), requests.Load())
+}
+
+func TestFaucet_CreateAndFundManyErrors(t *testing.T) {
+	client, _ := newFaucetServerClient(t, []string{testTxnHash}, 0)
+	_, err := client.CreateAndFundMany(context.Background(), 1, 100, "wait")
+	assert.ErrorContains(t, err, "CreateAndFundMany arg 1 bad type string")
+	_, err = client.CreateAndFundMany(context.Background(), 1, 100, BatchWorkers(0))
+	assert.ErrorContains(t, err, "BatchWorkers must be at least 1")
+}
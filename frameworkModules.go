@@ -0,0 +1,48 @@
+package aptos
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// frameworkModulesCache holds the ABIs of the framework modules at 0x1 once fetched, see [NodeClient.FrameworkModules]
+type frameworkModulesCache struct {
+	mutex   sync.Mutex
+	modules map[string]*api.MoveModule
+}
+
+// FrameworkModules fetches the ABIs of all the framework modules at 0x1, by module name e.g. "coin".  All pages of
+// modules are fetched.
+//
+//	modules, err := client.FrameworkModules()
+//	transfer := modules["aptos_account"].Function("transfer")
+//
+// Note this will be cached for the life of the client, as the framework is rarely upgraded.  Errors are not cached, so
+// a failed fetch is tried again on the next call.  Each call returns a new map of the cached ABIs.
+func (rc *NodeClient) FrameworkModules() (modules map[string]*api.MoveModule, err error) {
+	rc.frameworkModules.mutex.Lock()
+	defer rc.frameworkModules.mutex.Unlock()
+	if rc.frameworkModules.modules == nil {
+		au := rc.baseUrl.JoinPath("accounts", AccountOne.String(), "modules")
+		bytecodes, err := NewPaginator(cursorPageFetcher[*api.MoveBytecode](rc, au, 0)).All(rc.context())
+		if err != nil {
+			return nil, fmt.Errorf("get framework modules api err: %w", err)
+		}
+		fetched := make(map[string]*api.MoveModule, len(bytecodes))
+		for i, bytecode := range bytecodes {
+			if bytecode == nil || bytecode.Abi == nil {
+				return nil, fmt.Errorf("framework module %d has no ABI", i)
+			}
+			fetched[bytecode.Abi.Name] = bytecode.Abi
+		}
+		rc.frameworkModules.modules = fetched
+	}
+
+	modules = make(map[string]*api.MoveModule, len(rc.frameworkModules.modules))
+	for name, module := range rc.frameworkModules.modules {
+		modules[name] = module
+	}
+	return modules, nil
+}
@@ -0,0 +1,108 @@
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testChainIdModuleJson is the 0x1::chain_id framework module, trimmed of its bytecode
+const testChainIdModuleJson = `{
+	"bytecode": "0xa11ceb0b",
+	"abi": {
+		"address": "0x1",
+		"name": "chain_id",
+		"friends": ["0x1::genesis"],
+		"exposed_functions": [
+			{
+				"name": "get",
+				"visibility": "public",
+				"is_entry": false,
+				"is_view": true,
+				"generic_type_params": [],
+				"params": [],
+				"return": ["u8"]
+			}
+		],
+		"structs": [
+			{
+				"name": "ChainId",
+				"is_native": false,
+				"is_event": false,
+				"abilities": ["key"],
+				"generic_type_params": [],
+				"fields": [{"name": "id", "type": "u8"}]
+			}
+		]
+	}
+}`
+
+func TestFrameworkModules(t *testing.T) {
+	requests := atomic.Int32{}
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/v1/accounts/0x1/modules", r.URL.Path)
+		w.Header().Set("X-Aptos-Ledger-Version", "100")
+		switch r.URL.Query().Get("start") {
+		case "":
+			w.Header().Set("X-Aptos-Cursor", "cursor1")
+			_, _ = fmt.Fprintf(w, "[%s]", testModuleJson)
+		case "cursor1":
+			assert.Equal(t, "100", r.URL.Query().Get("ledger_version"))
+			_, _ = fmt.Fprintf(w, "[%s]", testChainIdModuleJson)
+		default:
+			t.Errorf("unexpected cursor %s", r.URL.Query().Get("start"))
+		}
+	})
+
+	modules, err := client.FrameworkModules()
+	assert.NoError(t, err)
+	assert.Len(t, modules, 2)
+	assert.Equal(t, int32(2), requests.Load())
+
+	assert.Equal(t, "aptos_account", modules["aptos_account"].Name)
+	assert.True(t, modules["aptos_account"].Function("transfer_coins").IsEntry)
+	chainId := modules["chain_id"]
+	assert.Equal(t, AccountOne, *chainId.Address)
+	assert.Equal(t, []string{"u8"}, chainId.Function("get").Return)
+	assert.Equal(t, "u8", chainId.Struct("ChainId").Fields[0].Type)
+
+	// The second call is cached, and changing the returned map doesn't change the cache
+	delete(modules, "chain_id")
+	modules, err = client.FrameworkModules()
+	assert.NoError(t, err)
+	assert.Len(t, modules, 2)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestFrameworkModules_Error(t *testing.T) {
+	fail := atomic.Bool{}
+	fail.Store(true)
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprint(w, `{"message":"internal error","error_code":"internal_error","vm_error_code":null}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, "[%s]", testChainIdModuleJson)
+	})
+
+	_, err := client.FrameworkModules()
+	assert.ErrorContains(t, err, "get framework modules api err")
+
+	// Errors aren't cached
+	fail.Store(false)
+	modules, err := client.FrameworkModules()
+	assert.NoError(t, err)
+	assert.Len(t, modules, 1)
+
+	// Modules without an ABI can't be keyed by name
+	client = newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[{"bytecode":"0xa11ceb0b"}]`)
+	})
+	_, err = client.FrameworkModules()
+	assert.ErrorContains(t, err, "has no ABI")
+}
@@ -0,0 +1,147 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// ConcurrentFungibleBalanceType is the resource holding the balance of a fungible store, as an aggregator, for fungible
+// assets with concurrent balances
+const ConcurrentFungibleBalanceType = "0x1::fungible_asset::ConcurrentFungibleBalance"
+
+// FungibleStore is a fungible asset store object owned by an account, see [Client.FungibleStores]
+type FungibleStore struct {
+	Address   AccountAddress // Address is the address of the store object
+	Metadata  AccountAddress // Metadata is the address of the fungible asset's metadata object e.g. 0xa for APT
+	Balance   *big.Int       // Balance is the balance in the smallest unit of the asset, including a concurrent balance
+	Frozen    bool           // Frozen is true if the store is frozen, and cannot be deposited to or withdrawn from
+	IsPrimary bool           // IsPrimary is true for the primary store of the owner for the asset
+}
+
+// FungibleStores returns all the fungible asset stores owned by the owner, both its primary stores and any other store
+// objects, with their balances read from the node.
+//
+//	stores, err := client.FungibleStores(owner)
+//	balances := SumFungibleStoreBalances(stores)
+//
+// The node has no index of the objects owned by an account, so the stores are found with the indexer, and it fails if
+// the network has no indexer url.  Stores created since the indexer's last processed version are missing, and stores
+// deleted or transferred to another owner since are skipped.  Coins which aren't migrated to fungible assets aren't included, see
+// [Client.GetFungibleAssetBalances].
+func (client *Client) FungibleStores(owner AccountAddress) ([]FungibleStore, error) {
+	if client.indexerClient == nil {
+		return nil, errors.New("indexer client not initialized, the network has no indexer url")
+	}
+	balances, err := client.indexerClient.GetFungibleAssetBalances(owner, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find fungible stores of %s: %w", owner.String(), err)
+	}
+
+	// Coin balances are keyed by the coin type rather than a store
+	indexed := make([]FABalance, 0, len(balances))
+	for _, balance := range balances {
+		if balance.TokenStandard == "v2" {
+			indexed = append(indexed, balance)
+		}
+	}
+
+	stores := make([]*FungibleStore, len(indexed))
+	errs := make([]error, len(indexed))
+	runBatch(len(indexed), DefaultBatchWorkers, func(i int) {
+		stores[i], errs[i] = client.nodeClient.fungibleStore(owner, indexed[i])
+	})
+	if err = errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	out := make([]FungibleStore, 0, len(stores))
+	for _, store := range stores {
+		if store != nil {
+			out = append(out, *store)
+		}
+	}
+	return out, nil
+}
+
+// SumFungibleStoreBalances totals the balances of the stores by their metadata, e.g. for an owner with many stores of
+// the same asset
+func SumFungibleStoreBalances(stores []FungibleStore) map[AccountAddress]*big.Int {
+	out := make(map[AccountAddress]*big.Int)
+	for _, store := range stores {
+		total, ok := out[store.Metadata]
+		if !ok {
+			total = new(big.Int)
+			out[store.Metadata] = total
+		}
+		if store.Balance != nil {
+			total.Add(total, store.Balance)
+		}
+	}
+	return out
+}
+
+// fungibleStore reads the store found by the indexer from the node, or returns nil if it no longer exists or is no
+// longer owned by the owner
+func (rc *NodeClient) fungibleStore(owner AccountAddress, indexed FABalance) (*FungibleStore, error) {
+	address := AccountAddress{}
+	err := address.ParseStringRelaxed(indexed.StorageId)
+	if err != nil {
+		return nil, fmt.Errorf("fungible store %s is not an address: %w", indexed.StorageId, err)
+	}
+
+	// The store object is small, so all its resources are fetched at once, rather than its store and balance separately
+	resources, err := rc.AccountResources(address)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get fungible store %s: %w", address.String(), err)
+	}
+	var store *api.FungibleStore
+	var concurrentBalance *AccountResourceInfo
+	var storeOwner *AccountAddress
+	for i := range resources {
+		switch resources[i].Type {
+		case api.FungibleStoreType:
+			store, err = api.ParseFungibleStore(resources[i].moveResource())
+			if err != nil {
+				return nil, fmt.Errorf("fungible store %s: %w", address.String(), err)
+			}
+		case ConcurrentFungibleBalanceType:
+			concurrentBalance = &resources[i]
+		case api.ObjectCoreType:
+			objectOwner, err := resources[i].GetAddress("owner")
+			if err != nil {
+				return nil, fmt.Errorf("fungible store %s: %w", address.String(), err)
+			}
+			storeOwner = &objectOwner
+		}
+	}
+	// The indexer may be behind a transfer of the store to another owner
+	if store == nil || storeOwner == nil || *storeOwner != owner {
+		return nil, nil
+	}
+
+	out := &FungibleStore{
+		Address:   address,
+		Metadata:  *store.Metadata,
+		Balance:   new(big.Int).SetUint64(store.Balance.ToUint64()),
+		Frozen:    store.Frozen,
+		IsPrimary: indexed.IsPrimary,
+	}
+	// With concurrent balances, the balance in the store is 0, and the balance is an aggregator in its own resource
+	if concurrentBalance != nil {
+		aggregator, err := concurrentBalance.moveResource().GetAggregator("balance")
+		if err != nil {
+			return nil, fmt.Errorf("fungible store %s: %w", address.String(), err)
+		}
+		if !aggregator.IsInline() {
+			return nil, fmt.Errorf("fungible store %s concurrent balance is not inline", address.String())
+		}
+		out.Balance.Add(out.Balance, aggregator.Value)
+	}
+	return out, nil
+}
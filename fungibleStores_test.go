@@ -0,0 +1,178 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPrimaryStore     = "0x8d4d6b552b5dc21cd2dec5c6b5cba6f2ed7b1e4ae346e4cb20b4584495d38b55"
+	testSecondaryStore   = "0x2ebb2ccac5e027a87fa0e2e5f656a3a4238d6a48d93ec9b610d570fc0aa0df12"
+	testDeletedStore     = "0x5b1c2f0e8a4d2c9b7e6f3a1d0c8b7a6f5e4d3c2b1a09f8e7d6c5b4a392817065"
+	testTransferredStore = "0x7e3a9c1b5d2f4e6a8c0b9d7f1e3a5c7b9d1f3e5a7c9b1d3f5e7a9c1b3d5f7e9a"
+)
+
+// testFungibleStoresResponse is a recorded response of current_fungible_asset_balances, with a coin, a primary store
+// and a secondary store of APT, a store which has since been deleted, and a store which has since been transferred to
+// another owner
+var testFungibleStoresResponse = fmt.Sprintf(`{
+	"data": {
+		"current_fungible_asset_balances": [
+			{
+				"asset_type": "0x1::aptos_coin::AptosCoin",
+				"amount": 500,
+				"owner_address": "%[1]s",
+				"storage_id": "0x1::aptos_coin::AptosCoin",
+				"is_primary": true,
+				"is_frozen": false,
+				"token_standard": "v1",
+				"last_transaction_version": 100
+			},
+			{
+				"asset_type": "0x000000000000000000000000000000000000000000000000000000000000000a",
+				"amount": 1000,
+				"owner_address": "%[1]s",
+				"storage_id": "%[2]s",
+				"is_primary": true,
+				"is_frozen": false,
+				"token_standard": "v2",
+				"last_transaction_version": 100
+			},
+			{
+				"asset_type": "0x000000000000000000000000000000000000000000000000000000000000000a",
+				"amount": 2000,
+				"owner_address": "%[1]s",
+				"storage_id": "%[3]s",
+				"is_primary": false,
+				"is_frozen": true,
+				"token_standard": "v2",
+				"last_transaction_version": 100
+			},
+			{
+				"asset_type": "0x000000000000000000000000000000000000000000000000000000000000000a",
+				"amount": 3000,
+				"owner_address": "%[1]s",
+				"storage_id": "%[4]s",
+				"is_primary": false,
+				"is_frozen": false,
+				"token_standard": "v2",
+				"last_transaction_version": 90
+			},
+			{
+				"asset_type": "0x000000000000000000000000000000000000000000000000000000000000000a",
+				"amount": 4000,
+				"owner_address": "%[1]s",
+				"storage_id": "%[5]s",
+				"is_primary": false,
+				"is_frozen": false,
+				"token_standard": "v2",
+				"last_transaction_version": 95
+			}
+		]
+	}
+}`, AccountThree.StringLong(), testPrimaryStore, testSecondaryStore, testDeletedStore, testTransferredStore)
+
+// testFungibleStoreResources are recorded resources of the store objects, the secondary store has a concurrent balance,
+// and the transferred store is owned by 0x4
+var testFungibleStoreResources = map[string]string{
+	testPrimaryStore: `[
+		{"type": "0x1::fungible_asset::FungibleStore", "data": {"balance": "1200", "frozen": false, "metadata": {"inner": "0xa"}}},
+		{"type": "0x1::object::ObjectCore", "data": {"allow_ungated_transfer": false, "guid_creation_num": "1125899906842625", "owner": "0x3", "transfer_events": {"counter": "0", "guid": {"id": {"addr": "0x0", "creation_num": "1125899906842624"}}}}}
+	]`,
+	testSecondaryStore: `[
+		{"type": "0x1::fungible_asset::ConcurrentFungibleBalance", "data": {"balance": {"max_value": "18446744073709551615", "value": "2500"}}},
+		{"type": "0x1::fungible_asset::FungibleStore", "data": {"balance": "0", "frozen": true, "metadata": {"inner": "0xa"}}},
+		{"type": "0x1::object::ObjectCore", "data": {"allow_ungated_transfer": true, "guid_creation_num": "1125899906842625", "owner": "0x3", "transfer_events": {"counter": "0", "guid": {"id": {"addr": "0x0", "creation_num": "1125899906842624"}}}}}
+	]`,
+	testTransferredStore: `[
+		{"type": "0x1::fungible_asset::FungibleStore", "data": {"balance": "4000", "frozen": false, "metadata": {"inner": "0xa"}}},
+		{"type": "0x1::object::ObjectCore", "data": {"allow_ungated_transfer": true, "guid_creation_num": "1125899906842626", "owner": "0x4", "transfer_events": {"counter": "1", "guid": {"id": {"addr": "0x0", "creation_num": "1125899906842624"}}}}}
+	]`,
+}
+
+// newFungibleStoresServerClient creates a client against a mock indexer and node with the recorded responses
+func newFungibleStoresServerClient(t *testing.T) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/graphql":
+			var request graphQLRequest
+			err := json.NewDecoder(r.Body).Decode(&request)
+			assert.NoError(t, err)
+			assert.Equal(t, FungibleAssetBalancesQuery, request.Query)
+			assert.Equal(t, map[string]any{
+				"owner_address": map[string]any{"_eq": AccountThree.StringLong()},
+			}, request.Variables["where"])
+			_, _ = fmt.Fprint(w, testFungibleStoresResponse)
+		case strings.HasPrefix(r.URL.Path, "/v1/accounts/") && strings.HasSuffix(r.URL.Path, "/resources"):
+			address := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/accounts/"), "/resources")
+			resources, ok := testFungibleStoreResources[address]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = fmt.Fprintf(w, `{"message":"Account not found by Address(%s)","error_code":"account_not_found"}`, address)
+				return
+			}
+			_, _ = fmt.Fprint(w, resources)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(NetworkConfig{
+		Name:       "mock",
+		ChainId:    4,
+		NodeUrl:    server.URL + "/v1",
+		IndexerUrl: server.URL + "/v1/graphql",
+	})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestClient_FungibleStores(t *testing.T) {
+	client := newFungibleStoresServerClient(t)
+	stores, err := client.FungibleStores(AccountThree)
+	assert.NoError(t, err)
+
+	// The coin, the deleted store and the transferred store are skipped, and balances are from the node rather than the indexer
+	apt := AccountAddress{}
+	assert.NoError(t, apt.ParseStringRelaxed("0xa"))
+	primary := AccountAddress{}
+	assert.NoError(t, primary.ParseStringRelaxed(testPrimaryStore))
+	secondary := AccountAddress{}
+	assert.NoError(t, secondary.ParseStringRelaxed(testSecondaryStore))
+	assert.Equal(t, []FungibleStore{
+		{Address: primary, Metadata: apt, Balance: big.NewInt(1200), IsPrimary: true},
+		{Address: secondary, Metadata: apt, Balance: big.NewInt(2500), Frozen: true},
+	}, stores)
+
+	balances := SumFungibleStoreBalances(stores)
+	assert.Equal(t, map[AccountAddress]*big.Int{apt: big.NewInt(3700)}, balances)
+}
+
+func TestClient_FungibleStoresNoIndexer(t *testing.T) {
+	client, err := NewClient(NetworkConfig{Name: "mock", ChainId: 4, NodeUrl: "http://127.0.0.1:1/v1"})
+	assert.NoError(t, err)
+	_, err = client.FungibleStores(AccountThree)
+	assert.ErrorContains(t, err, "indexer client not initialized")
+}
+
+func TestSumFungibleStoreBalances(t *testing.T) {
+	assert.Empty(t, SumFungibleStoreBalances(nil))
+
+	// Each metadata is totalled separately, without changing the balances of the stores
+	stores := []FungibleStore{
+		{Address: AccountOne, Metadata: AccountTwo, Balance: big.NewInt(5)},
+		{Address: AccountThree, Metadata: AccountFour, Balance: big.NewInt(7)},
+		{Address: AccountFour, Metadata: AccountTwo, Balance: big.NewInt(11)},
+	}
+	balances := SumFungibleStoreBalances(stores)
+	assert.Equal(t, map[AccountAddress]*big.Int{AccountTwo: big.NewInt(16), AccountFour: big.NewInt(7)}, balances)
+	assert.Equal(t, big.NewInt(5), stores[0].Balance)
+}
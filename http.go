@@ -1,10 +1,14 @@
 package aptos
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
 )
 
 // HttpErrSummaryLength is the maximum length of the body to include in the error message
@@ -53,3 +57,40 @@ func (he *HttpError) Error() string {
 		)
 	}
 }
+
+// ApiError parses the body as the structured error returned by the node, the bool is false if the body isn't one
+// e.g. the error is from a proxy in front of the node
+func (he *HttpError) ApiError() (*api.Error, bool) {
+	apiErr := &api.Error{}
+	err := json.Unmarshal(he.Body, apiErr)
+	if err != nil || apiErr.ErrorCode == "" {
+		return nil, false
+	}
+	return apiErr, true
+}
+
+// Unwrap returns the structured error returned by the node, if there is one, so that [errors.As] can be used to find
+// the [api.Error]:
+//
+//	var apiErr *api.Error
+//	if errors.As(err, &apiErr) && apiErr.ErrorCode == api.ErrorCodeAccountNotFound {
+//		...
+//	}
+func (he *HttpError) Unwrap() error {
+	apiErr, ok := he.ApiError()
+	if !ok {
+		return nil
+	}
+	return apiErr
+}
+
+// IsNotFound tells whether the error is from the node because something doesn't exist, e.g. an account, resource, or
+// transaction.  See [api.Error.IsNotFound].
+func IsNotFound(err error) bool {
+	var apiErr *api.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.IsNotFound()
+	}
+	var httpErr *HttpError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,75 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpError_ResourceNotFound(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{
+			"message": "Resource not found by Address(0x1), Struct tag(0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>) and Ledger version(123)",
+			"error_code": "resource_not_found",
+			"vm_error_code": null
+		}`)
+	})
+
+	_, err := client.AccountResource(AccountOne, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>")
+	assert.Error(t, err)
+
+	var apiErr *api.Error
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, api.ErrorCodeResourceNotFound, apiErr.ErrorCode)
+	assert.Equal(t, uint64(0), apiErr.VmErrorCode)
+	assert.Contains(t, apiErr.Message, "Resource not found by Address(0x1)")
+	assert.True(t, IsNotFound(err))
+
+	// The HTTP error is still available
+	var httpErr *HttpError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
+
+func TestHttpError_VmError(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(w, `{
+			"message": "Invalid transaction: Type: Validation Code: INVALID_SIGNATURE",
+			"error_code": "vm_error",
+			"vm_error_code": 1
+		}`)
+	})
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	_, err = client.SubmitTransaction(buildSignedTransferForTest(t, sender))
+	assert.Error(t, err)
+
+	var apiErr *api.Error
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, api.ErrorCodeVmError, apiErr.ErrorCode)
+	assert.Equal(t, uint64(1), apiErr.VmErrorCode)
+	assert.Equal(t, "Invalid transaction: Type: Validation Code: INVALID_SIGNATURE", apiErr.Message)
+	assert.False(t, IsNotFound(err))
+}
+
+func TestHttpError_NotStructured(t *testing.T) {
+	notFound := &HttpError{StatusCode: http.StatusNotFound, Body: []byte("<html>Not Found</html>")}
+	_, ok := notFound.ApiError()
+	assert.False(t, ok)
+	assert.Nil(t, notFound.Unwrap())
+	var apiErr *api.Error
+	assert.False(t, errors.As(notFound, &apiErr))
+
+	// A 404 without a body is still not found
+	assert.True(t, IsNotFound(fmt.Errorf("wrapped: %w", notFound)))
+	assert.False(t, IsNotFound(&HttpError{StatusCode: http.StatusInternalServerError}))
+	assert.False(t, IsNotFound(errors.New("not found")))
+	assert.False(t, IsNotFound(nil))
+}
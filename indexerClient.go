@@ -1,10 +1,16 @@
 package aptos
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
 	"github.com/hasura/go-graphql-client"
+	"io"
+	"math/big"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -12,21 +18,146 @@ import (
 
 // IndexerClient is a GraphQL client specifically for requesting for data from the Aptos indexer
 type IndexerClient struct {
-	inner *graphql.Client
+	inner      *graphql.Client
+	httpClient *http.Client
+	url        string
+	ctx        context.Context // Context of every query without one, nil for [context.Background]
 }
 
 // NewIndexerClient creates a new client specifically for requesting data from the indexer
 func NewIndexerClient(httpClient *http.Client, url string) *IndexerClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
 	// Reuse the HTTP client in the node client
 	client := graphql.NewClient(url, httpClient)
 	return &IndexerClient{
-		client,
+		inner:      client,
+		httpClient: httpClient,
+		url:        url,
 	}
 }
 
+// WithContext returns a copy of the client with every query that doesn't take a context bound to the context.  See
+// [NodeClient.WithContext].
+func (ic *IndexerClient) WithContext(ctx context.Context) *IndexerClient {
+	bound := *ic
+	bound.ctx = ctx
+	return &bound
+}
+
+// context is the context of queries by the client
+func (ic *IndexerClient) context() context.Context {
+	if ic.ctx == nil {
+		return context.Background()
+	}
+	return ic.ctx
+}
+
 // Query is a generic function for making any GraphQL query against the indexer
 func (ic *IndexerClient) Query(query any, variables map[string]any, options ...graphql.Option) error {
-	return ic.inner.Query(context.Background(), query, variables, options...)
+	return ic.inner.Query(ic.context(), query, variables, options...)
+}
+
+// GraphQLErrorLocation is the location in the query of a [GraphQLError]
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError is a single error in the `errors` of a GraphQL response
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLErrorLocation `json:"locations,omitempty"`
+	Path       []any                  `json:"path,omitempty"`
+	Extensions map[string]any         `json:"extensions,omitempty"`
+}
+
+// Error returns the message of the [GraphQLError]
+//
+// Implements:
+//   - [error]
+func (e *GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s at %v", e.Message, e.Path)
+}
+
+// GraphQLErrors is the `errors` of a GraphQL response, returned as an error by [IndexerClient.ExecQuery]
+type GraphQLErrors []*GraphQLError
+
+// Error returns all the messages of the [GraphQLErrors]
+//
+// Implements:
+//   - [error]
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "graphql errors: " + strings.Join(messages, "; ")
+}
+
+// ExecQuery runs a GraphQL query string against the indexer, and unmarshals the `data` of the response into out with
+// [json.Unmarshal].  This is for advanced users who want to run queries not built from a struct, see [IndexerClient.Query]
+// otherwise.
+//
+// If the response has any `errors`, they are returned as [GraphQLErrors].  The indexer may return partial data along
+// with errors, in which case out is still filled with the data that was returned.  A non-200 response is returned as an
+// [HttpError].
+//
+//	var out struct {
+//		ProcessorStatus []struct {
+//			LastSuccessVersion uint64 `json:"last_success_version"`
+//		} `json:"processor_status"`
+//	}
+//	err := client.ExecQuery(ctx, `query { processor_status { last_success_version } }`, nil, &out)
+func (ic *IndexerClient) ExecQuery(ctx context.Context, query string, variables map[string]any, out any) error {
+	body, err := json.Marshal(map[string]any{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to serialize graphql request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ic.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	response, err := ic.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusOK {
+		return NewHttpError(response)
+	}
+	blob, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	err = json.Unmarshal(blob, &envelope)
+	if err != nil {
+		return fmt.Errorf("failed to parse graphql response: %w", err)
+	}
+	if len(envelope.Data) != 0 && string(envelope.Data) != "null" && out != nil {
+		err = json.Unmarshal(envelope.Data, out)
+		if err != nil {
+			return fmt.Errorf("failed to parse graphql data: %w", err)
+		}
+	}
+	if len(envelope.Errors) != 0 {
+		return envelope.Errors
+	}
+	return nil
 }
 
 type CoinBalance struct {
@@ -64,6 +195,138 @@ func (ic *IndexerClient) GetCoinBalances(address AccountAddress) ([]CoinBalance,
 	return out, nil
 }
 
+// DefaultIndexerPageSize is the number of rows requested per page from the indexer, when paging through all rows
+const DefaultIndexerPageSize = 100
+
+// IndexerOffset will skip a number of rows of an indexer query
+type IndexerOffset uint64
+
+// IndexerLimit will limit the number of rows of an indexer query.  Only a single page is fetched when a limit is given.
+type IndexerLimit uint64
+
+// FungibleAssetBalancesQuery is the GraphQL query used by [IndexerClient.GetFungibleAssetBalances]
+//
+// It can be run directly with a custom `where` clause of type `current_fungible_asset_balances_bool_exp`, along with the
+// `offset` and `limit` variables.
+const FungibleAssetBalancesQuery = `query FungibleAssetBalances($where: current_fungible_asset_balances_bool_exp!, $offset: Int!, $limit: Int!) {
+	current_fungible_asset_balances(where: $where, offset: $offset, limit: $limit, order_by: [{asset_type: asc}, {storage_id: asc}]) {
+		asset_type
+		amount
+		owner_address
+		storage_id
+		is_primary
+		is_frozen
+		token_standard
+		last_transaction_version
+	}
+}`
+
+// FABalance is the current balance of a fungible asset store, or coin store, of an account from the indexer
+type FABalance struct {
+	AssetType              string         // AssetType is the metadata address for fungible assets, or the coin type for coins
+	Amount                 *big.Int       // Amount is the balance in the smallest unit of the asset
+	OwnerAddress           AccountAddress // OwnerAddress is the account owning the store
+	StorageId              string         // StorageId is the address of the fungible store, or the coin type for coins
+	IsPrimary              bool           // IsPrimary is true for the primary fungible store of the owner
+	IsFrozen               bool           // IsFrozen is true if the store is frozen
+	TokenStandard          string         // TokenStandard is either "v1" for coins or "v2" for fungible assets
+	LastTransactionVersion uint64         // LastTransactionVersion is the version of the last transaction to change the balance
+}
+
+// faBalanceJson is the JSON representation of a row of current_fungible_asset_balances
+type faBalanceJson struct {
+	AssetType              string         `json:"asset_type"`
+	Amount                 api.U128       `json:"amount"`
+	OwnerAddress           AccountAddress `json:"owner_address"`
+	StorageId              string         `json:"storage_id"`
+	IsPrimary              bool           `json:"is_primary"`
+	IsFrozen               bool           `json:"is_frozen"`
+	TokenStandard          string         `json:"token_standard"`
+	LastTransactionVersion api.U64        `json:"last_transaction_version"`
+}
+
+// normalizeAssetType converts a fungible asset metadata address to the long form used by the indexer, and leaves coin
+// types e.g. "0x1::aptos_coin::AptosCoin" as they are
+func normalizeAssetType(assetType string) string {
+	address := AccountAddress{}
+	if err := address.ParseStringRelaxed(assetType); err != nil {
+		return assetType
+	}
+	return address.StringLong()
+}
+
+// GetFungibleAssetBalances retrieves the current fungible asset balances of the owner, including coins paired with a
+// fungible asset.  If assetTypes is empty, all assets are returned, otherwise only the given asset types.  Fungible
+// assets may be given by their metadata address in short form e.g. "0xa", as the indexer's long form is looked up.
+//
+// Without a limit, all pages are fetched.
+//
+// Accepts options:
+//   - [IndexerOffset]
+//   - [IndexerLimit]
+func (ic *IndexerClient) GetFungibleAssetBalances(owner AccountAddress, assetTypes []string, options ...any) ([]FABalance, error) {
+	offset := uint64(0)
+	limit := uint64(DefaultIndexerPageSize)
+	havePageLimit := false
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case IndexerOffset:
+			offset = uint64(value)
+		case IndexerLimit:
+			limit = uint64(value)
+			havePageLimit = true
+		default:
+			return nil, fmt.Errorf("GetFungibleAssetBalances arg %d bad type %T", i+1, arg)
+		}
+	}
+
+	where := map[string]any{
+		"owner_address": map[string]any{"_eq": owner.StringLong()},
+	}
+	if len(assetTypes) != 0 {
+		normalized := make([]string, len(assetTypes))
+		for i, assetType := range assetTypes {
+			normalized[i] = normalizeAssetType(assetType)
+		}
+		where["asset_type"] = map[string]any{"_in": normalized}
+	}
+
+	out := make([]FABalance, 0)
+	for {
+		variables := map[string]any{
+			"where":  where,
+			"offset": offset,
+			"limit":  limit,
+		}
+		var response struct {
+			Balances []faBalanceJson `json:"current_fungible_asset_balances"`
+		}
+		err := ic.ExecQuery(ic.context(), FungibleAssetBalancesQuery, variables, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, balance := range response.Balances {
+			out = append(out, FABalance{
+				AssetType:              balance.AssetType,
+				Amount:                 balance.Amount.ToBigInt(),
+				OwnerAddress:           balance.OwnerAddress,
+				StorageId:              balance.StorageId,
+				IsPrimary:              balance.IsPrimary,
+				IsFrozen:               balance.IsFrozen,
+				TokenStandard:          balance.TokenStandard,
+				LastTransactionVersion: balance.LastTransactionVersion.ToUint64(),
+			})
+		}
+
+		// A short page is the last page
+		if havePageLimit || uint64(len(response.Balances)) < limit {
+			return out, nil
+		}
+		offset += limit
+	}
+}
+
 // GetProcessorStatus tells the most updated version of the transaction processor.  This helps to determine freshness of data.
 func (ic *IndexerClient) GetProcessorStatus(processorName string) (uint64, error) {
 	var q struct {
@@ -103,7 +366,9 @@ func (ic *IndexerClient) WaitOnIndexer(processorName string, requestedVersion ui
 		}
 
 		// Sleep and try again later
-		time.Sleep(sleepTime)
+		if err := sleepContext(ic.context(), sleepTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
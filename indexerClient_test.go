@@ -0,0 +1,238 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testFABalancesResponse is a recorded response of current_fungible_asset_balances, with a coin and a fungible asset
+const testFABalancesResponse = `{
+	"data": {
+		"current_fungible_asset_balances": [
+			{
+				"asset_type": "0x1::aptos_coin::AptosCoin",
+				"amount": 99934200,
+				"owner_address": "0x0000000000000000000000000000000000000000000000000000000000000001",
+				"storage_id": "0x1::aptos_coin::AptosCoin",
+				"is_primary": true,
+				"is_frozen": false,
+				"token_standard": "v1",
+				"last_transaction_version": 2069431296
+			},
+			{
+				"asset_type": "0x2ebb2ccac5e027a87fa0e2e5f656a3a4238d6a48d93ec9b610d570fc0aa0df12",
+				"amount": "340282366920938463463374607431768211455",
+				"owner_address": "0x0000000000000000000000000000000000000000000000000000000000000001",
+				"storage_id": "0x8d4d6b552b5dc21cd2dec5c6b5cba6f2ed7b1e4ae346e4cb20b4584495d38b55",
+				"is_primary": false,
+				"is_frozen": true,
+				"token_standard": "v2",
+				"last_transaction_version": "2069431300"
+			}
+		]
+	}
+}`
+
+// graphQLRequest is the body of a GraphQL request
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newIndexerServerClient creates an indexer client against a mock GraphQL server
+func newIndexerServerClient(t *testing.T, handler func(request graphQLRequest) string) *IndexerClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		var request graphQLRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		_, _ = fmt.Fprint(w, handler(request))
+	}))
+	t.Cleanup(server.Close)
+	return NewIndexerClient(server.Client(), server.URL)
+}
+
+func TestIndexerClient_GetFungibleAssetBalances(t *testing.T) {
+	client := newIndexerServerClient(t, func(request graphQLRequest) string {
+		assert.Equal(t, FungibleAssetBalancesQuery, request.Query)
+		assert.Equal(t, map[string]any{
+			"owner_address": map[string]any{"_eq": AccountOne.StringLong()},
+			"asset_type":    map[string]any{"_in": []any{"0x1::aptos_coin::AptosCoin", "0x2ebb2ccac5e027a87fa0e2e5f656a3a4238d6a48d93ec9b610d570fc0aa0df12"}},
+		}, request.Variables["where"])
+		assert.Equal(t, float64(0), request.Variables["offset"])
+		assert.Equal(t, float64(DefaultIndexerPageSize), request.Variables["limit"])
+		return testFABalancesResponse
+	})
+
+	balances, err := client.GetFungibleAssetBalances(AccountOne, []string{"0x1::aptos_coin::AptosCoin", "0x2ebb2ccac5e027a87fa0e2e5f656a3a4238d6a48d93ec9b610d570fc0aa0df12"})
+	assert.NoError(t, err)
+	assert.Len(t, balances, 2)
+
+	assert.Equal(t, "0x1::aptos_coin::AptosCoin", balances[0].AssetType)
+	assert.Equal(t, big.NewInt(99934200), balances[0].Amount)
+	assert.Equal(t, AccountOne, balances[0].OwnerAddress)
+	assert.True(t, balances[0].IsPrimary)
+	assert.False(t, balances[0].IsFrozen)
+	assert.Equal(t, "v1", balances[0].TokenStandard)
+	assert.Equal(t, uint64(2069431296), balances[0].LastTransactionVersion)
+
+	// Amounts larger than a u64 are kept
+	maxU128, ok := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.True(t, ok)
+	assert.Equal(t, maxU128, balances[1].Amount)
+	assert.Equal(t, "0x8d4d6b552b5dc21cd2dec5c6b5cba6f2ed7b1e4ae346e4cb20b4584495d38b55", balances[1].StorageId)
+	assert.False(t, balances[1].IsPrimary)
+	assert.True(t, balances[1].IsFrozen)
+	assert.Equal(t, "v2", balances[1].TokenStandard)
+	assert.Equal(t, uint64(2069431300), balances[1].LastTransactionVersion)
+}
+
+func TestIndexerClient_GetFungibleAssetBalances_ShortAddress(t *testing.T) {
+	// The indexer has the long form of metadata addresses, coin types are left as they are
+	client := newIndexerServerClient(t, func(request graphQLRequest) string {
+		assert.Equal(t, map[string]any{
+			"owner_address": map[string]any{"_eq": AccountOne.StringLong()},
+			"asset_type":    map[string]any{"_in": []any{"0x000000000000000000000000000000000000000000000000000000000000000a", "0x1::aptos_coin::AptosCoin"}},
+		}, request.Variables["where"])
+		return testFABalancesResponse
+	})
+
+	_, err := client.GetFungibleAssetBalances(AccountOne, []string{"0xa", "0x1::aptos_coin::AptosCoin"})
+	assert.NoError(t, err)
+}
+
+func TestIndexerClient_GetFungibleAssetBalances_Pages(t *testing.T) {
+	// 250 rows in total, which is more than 2 pages
+	calls := 0
+	client := newIndexerServerClient(t, func(request graphQLRequest) string {
+		calls++
+		// No asset types means no filter on them
+		assert.Equal(t, map[string]any{
+			"owner_address": map[string]any{"_eq": AccountOne.StringLong()},
+		}, request.Variables["where"])
+		offset := int(request.Variables["offset"].(float64))
+		limit := int(request.Variables["limit"].(float64))
+		rows := make([]map[string]any, 0)
+		for i := offset; i < 250 && i < offset+limit; i++ {
+			rows = append(rows, map[string]any{
+				"asset_type":               fmt.Sprintf("0x%d", i+10),
+				"amount":                   i,
+				"owner_address":            AccountOne.StringLong(),
+				"last_transaction_version": 1,
+			})
+		}
+		blob, err := json.Marshal(map[string]any{"data": map[string]any{"current_fungible_asset_balances": rows}})
+		assert.NoError(t, err)
+		return string(blob)
+	})
+
+	// A single page with a limit
+	balances, err := client.GetFungibleAssetBalances(AccountOne, nil, IndexerOffset(1), IndexerLimit(2))
+	assert.NoError(t, err)
+	assert.Len(t, balances, 2)
+	assert.Equal(t, "0x11", balances[0].AssetType)
+	assert.Equal(t, 1, calls)
+
+	// All pages from the offset without a limit
+	calls = 0
+	balances, err = client.GetFungibleAssetBalances(AccountOne, nil, IndexerOffset(1))
+	assert.NoError(t, err)
+	assert.Len(t, balances, 249)
+	assert.Equal(t, big.NewInt(249), balances[248].Amount)
+	assert.Equal(t, 3, calls)
+
+	_, err = client.GetFungibleAssetBalances(AccountOne, nil, 5)
+	assert.Error(t, err)
+}
+
+func TestIndexerClient_GetFungibleAssetBalances_Error(t *testing.T) {
+	client := newIndexerServerClient(t, func(request graphQLRequest) string {
+		return `{"errors":[{"message":"field 'current_fungible_asset_balances' not found in type: 'query_root'"}]}`
+	})
+	_, err := client.GetFungibleAssetBalances(AccountOne, nil)
+	assert.ErrorContains(t, err, "not found in type")
+}
+
+// testPartialGraphQLResponse is a response with partial data, and an error for the field that failed
+const testPartialGraphQLResponse = `{
+	"data": {
+		"processor_status": [{"last_success_version": 12345}],
+		"current_fungible_asset_balances": null
+	},
+	"errors": [
+		{
+			"message": "database query error",
+			"locations": [{"line": 3, "column": 2}],
+			"path": ["current_fungible_asset_balances"],
+			"extensions": {"code": "data-exception"}
+		}
+	]
+}`
+
+func TestIndexerClient_ExecQuery(t *testing.T) {
+	const query = `query Status($processor: String!) { processor_status(where: {processor: {_eq: $processor}}) { last_success_version } }`
+	client := newIndexerServerClient(t, func(request graphQLRequest) string {
+		assert.Equal(t, query, request.Query)
+		assert.Equal(t, map[string]any{"processor": "fungible_asset_processor"}, request.Variables)
+		return `{"data": {"processor_status": [{"last_success_version": 12345}]}}`
+	})
+
+	var out struct {
+		ProcessorStatus []struct {
+			LastSuccessVersion uint64 `json:"last_success_version"`
+		} `json:"processor_status"`
+	}
+	err := client.ExecQuery(context.Background(), query, map[string]any{"processor": "fungible_asset_processor"}, &out)
+	assert.NoError(t, err)
+	assert.Len(t, out.ProcessorStatus, 1)
+	assert.Equal(t, uint64(12345), out.ProcessorStatus[0].LastSuccessVersion)
+}
+
+func TestIndexerClient_ExecQuery_PartialData(t *testing.T) {
+	client := newIndexerServerClient(t, func(request graphQLRequest) string {
+		return testPartialGraphQLResponse
+	})
+
+	var out struct {
+		ProcessorStatus []struct {
+			LastSuccessVersion uint64 `json:"last_success_version"`
+		} `json:"processor_status"`
+		Balances []faBalanceJson `json:"current_fungible_asset_balances"`
+	}
+	err := client.ExecQuery(context.Background(), "query { ... }", nil, &out)
+
+	// The errors are returned, but the partial data is still filled in
+	var graphQLErrors GraphQLErrors
+	assert.ErrorAs(t, err, &graphQLErrors)
+	assert.Len(t, graphQLErrors, 1)
+	assert.Equal(t, "database query error", graphQLErrors[0].Message)
+	assert.Equal(t, []GraphQLErrorLocation{{Line: 3, Column: 2}}, graphQLErrors[0].Locations)
+	assert.Equal(t, []any{"current_fungible_asset_balances"}, graphQLErrors[0].Path)
+	assert.Equal(t, "data-exception", graphQLErrors[0].Extensions["code"])
+	assert.Equal(t, "graphql errors: database query error at [current_fungible_asset_balances]", err.Error())
+
+	assert.Len(t, out.ProcessorStatus, 1)
+	assert.Equal(t, uint64(12345), out.ProcessorStatus[0].LastSuccessVersion)
+	assert.Nil(t, out.Balances)
+}
+
+func TestIndexerClient_ExecQuery_HttpError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = fmt.Fprint(w, `{"message":"invalid api key"}`)
+	}))
+	t.Cleanup(server.Close)
+	client := NewIndexerClient(server.Client(), server.URL)
+
+	err := client.ExecQuery(context.Background(), "query { ... }", nil, nil)
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"strings"
 )
@@ -40,6 +41,20 @@ func NewEd25519Account() (*Account, error) {
 	return NewAccountFromSigner(privateKey)
 }
 
+// Ed25519PrivateKeyPrefix is the AIP-80 prefix of an Ed25519 private key string e.g. ed25519-priv-0x1234...
+const Ed25519PrivateKeyPrefix = "ed25519-priv-"
+
+// NewAccountFromPrivateKey creates a legacy Ed25519 account from the hex of an Ed25519 private key, with or without a
+// 0x prefix, or in the AIP-80 form with the [Ed25519PrivateKeyPrefix]
+func NewAccountFromPrivateKey(privateKey string) (*Account, error) {
+	key := &crypto.Ed25519PrivateKey{}
+	err := key.FromHex(strings.TrimPrefix(privateKey, Ed25519PrivateKeyPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Ed25519 private key: %w", err)
+	}
+	return NewAccountFromSigner(key)
+}
+
 // NewEd25519SingleSignerAccount creates a new random Ed25519 account
 func NewEd25519SingleSignerAccount() (*Account, error) {
 	privateKey, err := crypto.GenerateEd25519PrivateKey()
@@ -96,8 +111,33 @@ var ErrAddressTooShort = errors.New("AccountAddress too short")
 // ErrAddressTooLong is returned when an AccountAddress is too long
 var ErrAddressTooLong = errors.New("AccountAddress too long")
 
+// ErrAddressNotStrict is returned when an AccountAddress is not in either AIP-40 form
+var ErrAddressNotStrict = errors.New("AccountAddress must be 0x prefixed, and either the long form or a special address in the short form")
+
+// ParseStringStrict parses a string into an AccountAddress, following the AIP-40 rules
+//
+// The address must be 0x prefixed, and either the full 64 hex characters, or the short form of a special address
+// 0x0 to 0xf.  Use [AccountAddress.ParseStringRelaxed] for other forms.
+func (aa *AccountAddress) ParseStringStrict(x string) error {
+	if !strings.HasPrefix(x, "0x") {
+		return ErrAddressNotStrict
+	}
+	if len(x) != 3 && len(x) != 66 {
+		return ErrAddressNotStrict
+	}
+	var parsed AccountAddress
+	err := parsed.ParseStringRelaxed(x)
+	if err != nil {
+		return err
+	}
+	*aa = parsed
+	return nil
+}
+
 // ParseStringRelaxed parses a string into an AccountAddress
-// TODO: add strict mode checking
+//
+// The 0x prefix is optional, and leading zeros may be trimmed from any address.  Use
+// [AccountAddress.ParseStringStrict] to only accept the AIP-40 forms.
 func (aa *AccountAddress) ParseStringRelaxed(x string) error {
 	if strings.HasPrefix(x, "0x") {
 		x = x[2:]
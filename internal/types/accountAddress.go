@@ -15,6 +15,8 @@ import (
 //   - [bcs.Unmarshaler]
 //   - [json.Marshaler]
 //   - [json.Unmarshaler]
+//   - [encoding.TextMarshaler]
+//   - [encoding.TextUnmarshaler]
 type AccountAddress [32]byte
 
 // AccountZero is [AccountAddress] 0x0
@@ -32,6 +34,9 @@ var AccountThree = AccountAddress{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0
 // AccountFour is [AccountAddress] 0x4
 var AccountFour = AccountAddress{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4}
 
+// AccountTen is [AccountAddress] 0xa, the metadata of the APT fungible asset
+var AccountTen = AccountAddress{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 10}
+
 // IsSpecial Returns whether the address is a "special" address. Addresses are considered
 // special if the first 63 characters of the hex string are zero. In other words,
 // an address is special if the first 31 bytes are zero and the last byte is
@@ -80,18 +85,38 @@ func (aa *AccountAddress) StringLong() string {
 }
 
 // MarshalBCS Converts the AccountAddress to BCS encoded bytes
+//
+// An address is always the fixed 32 bytes, with no length prefix.  Short form addresses e.g. 0x1 are already left
+// padded with zeros when parsed.
 func (aa *AccountAddress) MarshalBCS(ser *bcs.Serializer) {
 	ser.FixedBytes(aa[:])
 }
 
 // UnmarshalBCS Converts the AccountAddress from BCS encoded bytes
+//
+// Reads exactly 32 bytes, and sets [bcs.Deserializer.Error] if there are fewer.
 func (aa *AccountAddress) UnmarshalBCS(des *bcs.Deserializer) {
 	des.ReadFixedBytesInto((*aa)[:])
 }
 
+// ToStringShort returns the AIP-40 short form of the [AccountAddress], the same as [AccountAddress.String]
+//
+// Only the special addresses 0x0 to 0xf are shortened, all other addresses are the full 64 hex characters.
+func (aa *AccountAddress) ToStringShort() string {
+	return aa.String()
+}
+
+// ToStringLong returns the AIP-40 long form of the [AccountAddress], always the full 64 hex characters, the same as
+// [AccountAddress.StringLong]
+func (aa *AccountAddress) ToStringLong() string {
+	return aa.StringLong()
+}
+
 // MarshalJSON converts the AccountAddress to JSON
+//
+// The long form is always used, so the output is unambiguous for any consumer.
 func (aa *AccountAddress) MarshalJSON() ([]byte, error) {
-	return json.Marshal(aa.String())
+	return json.Marshal(aa.StringLong())
 }
 
 // UnmarshalJSON converts the AccountAddress from JSON
@@ -108,6 +133,30 @@ func (aa *AccountAddress) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalText converts the AccountAddress to the long form hex string
+//
+// This is on the value, rather than the pointer, so that an AccountAddress can be used as a key of a JSON map, and
+// encodes the same when it isn't addressable.
+//
+// Implements:
+//   - [encoding.TextMarshaler]
+func (aa AccountAddress) MarshalText() ([]byte, error) {
+	return []byte(aa.StringLong()), nil
+}
+
+// UnmarshalText converts the AccountAddress from either the short or long form hex string, with or without a leading
+// 0x, the same as [AccountAddress.ParseStringRelaxed]
+//
+// Implements:
+//   - [encoding.TextUnmarshaler]
+func (aa *AccountAddress) UnmarshalText(text []byte) error {
+	err := aa.ParseStringRelaxed(string(text))
+	if err != nil {
+		return fmt.Errorf("failed to convert input to AccountAddress: %w", err)
+	}
+	return nil
+}
+
 // NamedObjectAddress derives a named object address based on the input address as the creator
 func (aa *AccountAddress) NamedObjectAddress(seed []byte) (accountAddress AccountAddress) {
 	return aa.DerivedAddress(seed, crypto.NamedObjectScheme)
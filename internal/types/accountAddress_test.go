@@ -1,7 +1,9 @@
 package types
 
 import (
+	"crypto/rand"
 	"encoding/json"
+	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -36,6 +38,45 @@ func TestSpecialAddresses(t *testing.T) {
 	err = addr.ParseStringRelaxed("0x4")
 	assert.NoError(t, err)
 	assert.Equal(t, AccountFour, addr)
+	err = addr.ParseStringRelaxed("0xa")
+	assert.NoError(t, err)
+	assert.Equal(t, AccountTen, addr)
+}
+
+func TestSpecialAddressConstants(t *testing.T) {
+	constants := map[byte]AccountAddress{
+		0x0: AccountZero,
+		0x1: AccountOne,
+		0x2: AccountTwo,
+		0x3: AccountThree,
+		0x4: AccountFour,
+		0xa: AccountTen,
+	}
+	for last, constant := range constants {
+		expected := [32]byte{}
+		expected[31] = last
+		assert.Equal(t, expected, [32]byte(constant))
+		assert.True(t, constant.IsSpecial())
+		assert.Equal(t, fmt.Sprintf("0x%x", last), constant.String())
+	}
+}
+
+func TestAccountAddress_IsSpecial(t *testing.T) {
+	tests := map[string]bool{
+		"0x0":  true,
+		"0xa":  true,
+		"0xf":  true,
+		"0x10": false,
+		"0xff": false,
+		"0x0100000000000000000000000000000000000000000000000000000000000001": false,
+		"0x1000000000000000000000000000000000000000000000000000000000000000": false,
+	}
+	for str, special := range tests {
+		var addr AccountAddress
+		err := addr.ParseStringRelaxed(str)
+		assert.NoError(t, err)
+		assert.Equal(t, special, addr.IsSpecial(), str)
+	}
 }
 
 func TestSerialize(t *testing.T) {
@@ -63,6 +104,43 @@ func TestSerialize(t *testing.T) {
 	}
 }
 
+func TestAccountAddress_BCSFixedLength(t *testing.T) {
+	// A short form address is left padded to the full 32 bytes, with no length prefix
+	addr := AccountAddress{}
+	err := addr.ParseStringRelaxed("0x1")
+	assert.NoError(t, err)
+	bytes, err := bcs.Serialize(&addr)
+	assert.NoError(t, err)
+	expected := make([]byte, 32)
+	expected[31] = 0x01
+	assert.Equal(t, expected, bytes)
+
+	err = addr.ParseStringRelaxed("0x1234")
+	assert.NoError(t, err)
+	bytes, err = bcs.Serialize(&addr)
+	assert.NoError(t, err)
+	assert.Len(t, bytes, 32)
+	assert.Equal(t, []byte{0x12, 0x34}, bytes[30:])
+
+	// A random address round trips
+	random := AccountAddress{}
+	_, err = rand.Read(random[:])
+	assert.NoError(t, err)
+	bytes, err = bcs.Serialize(&random)
+	assert.NoError(t, err)
+	assert.Equal(t, random[:], bytes)
+	roundTrip := AccountAddress{}
+	err = bcs.Deserialize(&roundTrip, bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, random, roundTrip)
+
+	// Too few bytes fails, and extra bytes are left over
+	err = bcs.Deserialize(&roundTrip, bytes[:31])
+	assert.Error(t, err)
+	err = bcs.Deserialize(&roundTrip, append(bytes, 0x00))
+	assert.Error(t, err)
+}
+
 func TestStringOutput(t *testing.T) {
 	inputs := [][]byte{
 		{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
@@ -141,7 +219,120 @@ func TestAccountAddress_JSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, &AccountOne, test.Address)
 
+	// Always marshals to the long form
 	b, err := json.Marshal(test)
 	assert.NoError(t, err)
-	assert.Equal(t, str, string(b))
+	assert.Equal(t, "{\"address\":\"0x0000000000000000000000000000000000000000000000000000000000000001\"}", string(b))
+
+	err = json.Unmarshal(b, &test)
+	assert.NoError(t, err)
+	assert.Equal(t, &AccountOne, test.Address)
+}
+
+func TestAccountAddress_Text(t *testing.T) {
+	// Always marshals to the long form
+	text, err := AccountOne.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000001", string(text))
+
+	// Parses both forms, with or without 0x
+	for _, str := range []string{"0x1", "1", "0x0000000000000000000000000000000000000000000000000000000000000001"} {
+		var addr AccountAddress
+		err = addr.UnmarshalText([]byte(str))
+		assert.NoError(t, err)
+		assert.Equal(t, AccountOne, addr)
+	}
+
+	var addr AccountAddress
+	err = addr.UnmarshalText([]byte("NotHex"))
+	assert.Error(t, err)
+}
+
+func TestAccountAddress_JSONMapKey(t *testing.T) {
+	random := AccountAddress{}
+	_, err := rand.Read(random[:])
+	assert.NoError(t, err)
+	balances := map[AccountAddress]uint64{
+		AccountOne: 100,
+		random:     5,
+	}
+
+	b, err := json.Marshal(balances)
+	assert.NoError(t, err)
+	// Map keys are sorted, so the output is deterministic
+	expected := fmt.Sprintf(`{"0x0000000000000000000000000000000000000000000000000000000000000001":100,"%s":5}`, random.StringLong())
+	if random.StringLong() < AccountOne.StringLong() {
+		expected = fmt.Sprintf(`{"%s":5,"0x0000000000000000000000000000000000000000000000000000000000000001":100}`, random.StringLong())
+	}
+	assert.Equal(t, expected, string(b))
+
+	decoded := map[AccountAddress]uint64{}
+	err = json.Unmarshal(b, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, balances, decoded)
+
+	// Short form keys also parse
+	err = json.Unmarshal([]byte(`{"0x1":7}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), decoded[AccountOne])
+
+	// Addresses that aren't addressable e.g. in a map value are also strings
+	b, err = json.Marshal(map[string]AccountAddress{"owner": AccountOne})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"owner":"0x0000000000000000000000000000000000000000000000000000000000000001"}`, string(b))
+}
+
+func TestAccountAddress_ShortAndLong(t *testing.T) {
+	// Every special address is shortened, and parses from both forms
+	for i := byte(0); i < 0x10; i++ {
+		var addr AccountAddress
+		addr[31] = i
+		short := addr.ToStringShort()
+		long := addr.ToStringLong()
+		assert.Equal(t, fmt.Sprintf("0x%x", i), short)
+		assert.Equal(t, fmt.Sprintf("0x%064x", i), long)
+		assert.True(t, addr.IsSpecial())
+
+		for _, str := range []string{short, long} {
+			var parsed AccountAddress
+			err := parsed.ParseStringStrict(str)
+			assert.NoError(t, err)
+			assert.Equal(t, addr, parsed)
+		}
+	}
+
+	// Non-special addresses are never shortened, even with leading zeros
+	var addr AccountAddress
+	addr[31] = 0x10
+	assert.False(t, addr.IsSpecial())
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000010", addr.ToStringShort())
+	assert.Equal(t, addr.ToStringLong(), addr.ToStringShort())
+
+	random := AccountAddress{}
+	_, err := rand.Read(random[:])
+	assert.NoError(t, err)
+	random[0] |= 0x80
+	assert.Len(t, random.ToStringShort(), 66)
+	assert.Equal(t, random.ToStringLong(), random.ToStringShort())
+	var parsed AccountAddress
+	err = parsed.ParseStringStrict(random.ToStringShort())
+	assert.NoError(t, err)
+	assert.Equal(t, random, parsed)
+}
+
+func TestAccountAddress_ParseStringStrict_Error(t *testing.T) {
+	var addr AccountAddress
+	// No prefix
+	assert.ErrorIs(t, addr.ParseStringStrict("1"), ErrAddressNotStrict)
+	assert.ErrorIs(t, addr.ParseStringStrict("0000000000000000000000000000000000000000000000000000000000000001"), ErrAddressNotStrict)
+	// Short forms of non-special addresses
+	assert.ErrorIs(t, addr.ParseStringStrict("0x10"), ErrAddressNotStrict)
+	assert.ErrorIs(t, addr.ParseStringStrict("0x01"), ErrAddressNotStrict)
+	assert.ErrorIs(t, addr.ParseStringStrict("0xbeef"), ErrAddressNotStrict)
+	assert.ErrorIs(t, addr.ParseStringStrict("0x"), ErrAddressNotStrict)
+	// Not hex
+	assert.Error(t, addr.ParseStringStrict("0xg"))
+	assert.Error(t, addr.ParseStringStrict("0x000000000000000000000000000000000000000000000000000000000000000g"))
+	// Unchanged on error
+	assert.Equal(t, AccountZero, addr)
 }
@@ -64,3 +64,18 @@ func TestNewAccountFromSignerWithAddressMulti(t *testing.T) {
 	_, err = NewAccountFromSigner(key, authenticationKey, authenticationKey)
 	assert.Error(t, err)
 }
+
+func TestNewAccountFromPrivateKey(t *testing.T) {
+	key, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+	authKey := key.AuthKey()
+
+	for _, privateKey := range []string{key.ToHex(), key.ToHex()[2:], Ed25519PrivateKeyPrefix + key.ToHex()} {
+		account, err := NewAccountFromPrivateKey(privateKey)
+		assert.NoError(t, err)
+		assert.Equal(t, authKey[:], account.Address[:])
+	}
+
+	_, err = NewAccountFromPrivateKey(Ed25519PrivateKeyPrefix)
+	assert.Error(t, err)
+}
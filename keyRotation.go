@@ -0,0 +1,231 @@
+package aptos
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+)
+
+// RotationProofChallenge is the challenge signed by both the current and the new key to rotate an account's
+// authentication key with 0x1::account::rotate_authentication_key.  It proves that the signer of the transaction owns
+// both keys.
+//
+// It serializes as the Move struct 0x1::account::RotationProofChallenge, prefixed with its type info, which is the
+// message that is signed:
+//
+//	challenge := &RotationProofChallenge{
+//		SequenceNumber: sequenceNumber,
+//		Originator:     account.Address,
+//		CurrentAuthKey: currentAuthKey,
+//		NewPublicKey:   newKey.PubKey().Bytes(),
+//	}
+//	message, err := bcs.Serialize(challenge)
+//
+// Implements:
+//   - [bcs.Marshaler]
+//   - [bcs.Unmarshaler]
+//   - [bcs.Struct]
+type RotationProofChallenge struct {
+	SequenceNumber uint64         // SequenceNumber is the sequence number of the rotation transaction
+	Originator     AccountAddress // Originator is the address of the account being rotated
+	CurrentAuthKey AccountAddress // CurrentAuthKey is the account's authentication key before rotation, as an address
+	NewPublicKey   []byte         // NewPublicKey is the bytes of the public key being rotated to
+}
+
+// rotationProofChallengeModule and rotationProofChallengeStruct are the type info of 0x1::account::RotationProofChallenge
+const (
+	rotationProofChallengeModule = "account"
+	rotationProofChallengeStruct = "RotationProofChallenge"
+)
+
+//region RotationProofChallenge bcs.Struct
+
+func (c *RotationProofChallenge) MarshalBCS(ser *bcs.Serializer) {
+	ser.Struct(&AccountOne)
+	ser.WriteString(rotationProofChallengeModule)
+	ser.WriteString(rotationProofChallengeStruct)
+	ser.U64(c.SequenceNumber)
+	ser.Struct(&c.Originator)
+	ser.Struct(&c.CurrentAuthKey)
+	ser.WriteBytes(c.NewPublicKey)
+}
+
+func (c *RotationProofChallenge) UnmarshalBCS(des *bcs.Deserializer) {
+	address := AccountAddress{}
+	des.Struct(&address)
+	module := des.ReadString()
+	name := des.ReadString()
+	if des.Error() != nil {
+		return
+	}
+	if address != AccountOne || module != rotationProofChallengeModule || name != rotationProofChallengeStruct {
+		des.SetError(fmt.Errorf("not a rotation proof challenge, type is %s::%s::%s", address.String(), module, name))
+		return
+	}
+	c.SequenceNumber = des.U64()
+	des.Struct(&c.Originator)
+	des.Struct(&c.CurrentAuthKey)
+	c.NewPublicKey = des.ReadBytes()
+}
+
+//endregion
+
+// RotateAuthenticationKeyPayload builds an EntryFunction payload for 0x1::account::rotate_authentication_key
+//
+// Args:
+//   - fromKey is the current public key of the account
+//   - toKey is the public key to rotate to
+//   - capRotateKey is the signature of the [RotationProofChallenge] by the current key
+//   - capUpdateTable is the signature of the [RotationProofChallenge] by the new key
+//
+// Only [crypto.Ed25519PublicKey] and [crypto.MultiEd25519PublicKey] keys can be rotated with it.
+func RotateAuthenticationKeyPayload(fromKey crypto.PublicKey, toKey crypto.PublicKey, capRotateKey crypto.Signature, capUpdateTable crypto.Signature) (payload *EntryFunction, err error) {
+	fromScheme, err := rotationKeyScheme(fromKey)
+	if err != nil {
+		return nil, fmt.Errorf("can't rotate from key: %w", err)
+	}
+	toScheme, err := rotationKeyScheme(toKey)
+	if err != nil {
+		return nil, fmt.Errorf("can't rotate to key: %w", err)
+	}
+
+	fromKeyBytes, err := bcs.SerializeBytes(fromKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	toKeyBytes, err := bcs.SerializeBytes(toKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	capRotateKeyBytes, err := bcs.SerializeBytes(capRotateKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	capUpdateTableBytes, err := bcs.SerializeBytes(capUpdateTable.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &EntryFunction{
+		Module: ModuleId{
+			Address: AccountOne,
+			Name:    "account",
+		},
+		Function: "rotate_authentication_key",
+		ArgTypes: []TypeTag{},
+		Args: [][]byte{
+			{fromScheme},
+			fromKeyBytes,
+			{toScheme},
+			toKeyBytes,
+			capRotateKeyBytes,
+			capUpdateTableBytes,
+		},
+	}, nil
+}
+
+// rotationKeyScheme is the scheme of the key for rotate_authentication_key, which only supports legacy keys
+func rotationKeyScheme(key crypto.PublicKey) (uint8, error) {
+	switch key.(type) {
+	case *crypto.Ed25519PublicKey:
+		return crypto.Ed25519Scheme, nil
+	case *crypto.MultiEd25519PublicKey:
+		return crypto.MultiEd25519Scheme, nil
+	default:
+		return 0, fmt.Errorf("unsupported key type %T, only Ed25519 and MultiEd25519 are supported", key)
+	}
+}
+
+// BuildKeyRotation builds a transaction rotating the account's authentication key to newKey, ready to be signed by
+// the account.  The [RotationProofChallenge] is signed by both the account's current key and the new key.
+//
+// The account's current authentication key and sequence number are fetched from on-chain, the sequence number can be
+// overridden with the [SequenceNumber] option.  It accepts the same other options as [NodeClient.BuildTransaction].
+//
+//	rawTxn, err := client.BuildKeyRotation(account, newKey)
+//	signedTxn, err := rawTxn.SignedTransaction(account)
+//	...
+//	// After the transaction is committed, the account must sign with the new key
+//	rotated, err := NewAccountFromSigner(newKey, *account.AuthKey())
+func (rc *NodeClient) BuildKeyRotation(account *Account, newKey crypto.Signer, options ...any) (rawTxn *RawTransaction, err error) {
+	var sequenceNumber uint64
+	haveSequenceNumber := false
+	for _, option := range options {
+		switch ovalue := option.(type) {
+		case SequenceNumber:
+			sequenceNumber = uint64(ovalue)
+			haveSequenceNumber = true
+		case *SequenceNumberManager:
+			return nil, fmt.Errorf("BuildKeyRotation doesn't support SequenceNumberManager, the challenge must be signed for the sequence number")
+		}
+	}
+
+	info, err := rc.Account(account.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account for key rotation: %w", err)
+	}
+	authKeyBytes, err := info.AuthenticationKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse authentication key for key rotation: %w", err)
+	}
+	currentAuthKey := AccountAddress{}
+	if len(authKeyBytes) != len(currentAuthKey) {
+		return nil, fmt.Errorf("invalid authentication key length %d", len(authKeyBytes))
+	}
+	copy(currentAuthKey[:], authKeyBytes)
+	if !haveSequenceNumber {
+		sequenceNumber, err = info.SequenceNumber()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sequence number for key rotation: %w", err)
+		}
+		options = append(options, SequenceNumber(sequenceNumber))
+	}
+
+	challenge := &RotationProofChallenge{
+		SequenceNumber: sequenceNumber,
+		Originator:     account.Address,
+		CurrentAuthKey: currentAuthKey,
+		NewPublicKey:   newKey.PubKey().Bytes(),
+	}
+	message, err := bcs.Serialize(challenge)
+	if err != nil {
+		return nil, err
+	}
+	capRotateKey, err := account.SignMessage(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign rotation proof challenge with current key: %w", err)
+	}
+	capUpdateTable, err := newKey.SignMessage(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign rotation proof challenge with new key: %w", err)
+	}
+
+	payload, err := RotateAuthenticationKeyPayload(account.PubKey(), newKey.PubKey(), capRotateKey, capUpdateTable)
+	if err != nil {
+		return nil, err
+	}
+	return rc.BuildTransaction(account.Address, TransactionPayload{Payload: payload}, options...)
+}
+
+// AccountAuthKeyMatches tells whether the account's current on-chain authentication key is the one derived from the
+// public key, e.g. to detect that the key of an account has been rotated elsewhere
+//
+//	matches, err := client.AccountAuthKeyMatches(account.Address, account.PubKey())
+//	if err == nil && !matches {
+//		// The key was rotated, so the local key can no longer sign for the account
+//	}
+//
+// An account that doesn't exist on-chain yet fails with an error for which [IsNotFound] is true.
+func (rc *NodeClient) AccountAuthKeyMatches(address AccountAddress, publicKey crypto.PublicKey) (bool, error) {
+	info, err := rc.Account(address)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch account for authentication key: %w", err)
+	}
+	authKeyBytes, err := info.AuthenticationKey()
+	if err != nil {
+		return false, fmt.Errorf("failed to parse authentication key: %w", err)
+	}
+	return bytes.Equal(authKeyBytes, publicKey.AuthKey()[:]), nil
+}
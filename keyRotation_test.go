@@ -0,0 +1,174 @@
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotationProofChallenge_BCS(t *testing.T) {
+	originator := AccountAddress{}
+	err := originator.ParseStringRelaxed("0x1111111111111111111111111111111111111111111111111111111111111111")
+	assert.NoError(t, err)
+	currentAuthKey := AccountAddress{}
+	err = currentAuthKey.ParseStringRelaxed("0x2222222222222222222222222222222222222222222222222222222222222222")
+	assert.NoError(t, err)
+	newPublicKey := make([]byte, 32)
+	for i := range newPublicKey {
+		newPublicKey[i] = 0x33
+	}
+
+	challenge := &RotationProofChallenge{
+		SequenceNumber: 5,
+		Originator:     originator,
+		CurrentAuthKey: currentAuthKey,
+		NewPublicKey:   newPublicKey,
+	}
+	challengeBytes, err := bcs.Serialize(challenge)
+	assert.NoError(t, err)
+
+	// 0x1::account::RotationProofChallenge type info, then the fields
+	expected := "0000000000000000000000000000000000000000000000000000000000000001" +
+		"076163636f756e74" +
+		"16526f746174696f6e50726f6f664368616c6c656e6765" +
+		"0500000000000000" +
+		"1111111111111111111111111111111111111111111111111111111111111111" +
+		"2222222222222222222222222222222222222222222222222222222222222222" +
+		"203333333333333333333333333333333333333333333333333333333333333333"
+	assert.Equal(t, expected, fmt.Sprintf("%x", challengeBytes))
+
+	decoded := &RotationProofChallenge{}
+	err = bcs.Deserialize(decoded, challengeBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, challenge, decoded)
+
+	// Other types are rejected
+	otherBytes := append([]byte{}, challengeBytes...)
+	otherBytes[33] = 'b'
+	err = bcs.Deserialize(&RotationProofChallenge{}, otherBytes)
+	assert.Error(t, err)
+}
+
+func TestBuildKeyRotation(t *testing.T) {
+	account := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	newKey := &crypto.Ed25519PrivateKey{}
+	err := newKey.FromHex("0x2222222222222222222222222222222222222222222222222222222222222222")
+	assert.NoError(t, err)
+
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1":
+			_, _ = fmt.Fprint(w, testNodeInfoJson)
+		default:
+			assert.Equal(t, "/v1/accounts/"+account.Address.String(), r.URL.Path)
+			_, _ = fmt.Fprintf(w, `{"sequence_number": "7", "authentication_key": "%s"}`, account.AuthKey().ToHex())
+		}
+	})
+
+	rawTxn, err := client.BuildKeyRotation(account, newKey, MaxGasAmount(1000), GasUnitPrice(100))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), rawTxn.SequenceNumber)
+	assert.Equal(t, account.Address, rawTxn.Sender)
+
+	payload, ok := rawTxn.Payload.Payload.(*EntryFunction)
+	assert.True(t, ok)
+	assert.Equal(t, ModuleId{Address: AccountOne, Name: "account"}, payload.Module)
+	assert.Equal(t, "rotate_authentication_key", payload.Function)
+	assert.Len(t, payload.Args, 6)
+	assert.Equal(t, []byte{crypto.Ed25519Scheme}, payload.Args[0])
+	assert.Equal(t, []byte{crypto.Ed25519Scheme}, payload.Args[2])
+	newPublicKeyBytes, err := bcs.SerializeBytes(newKey.PubKey().Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, newPublicKeyBytes, payload.Args[3])
+
+	// Both keys sign the challenge
+	message, err := bcs.Serialize(&RotationProofChallenge{
+		SequenceNumber: 7,
+		Originator:     account.Address,
+		CurrentAuthKey: account.Address,
+		NewPublicKey:   newKey.PubKey().Bytes(),
+	})
+	assert.NoError(t, err)
+	for i, verifyingKey := range map[int]crypto.PublicKey{4: account.PubKey(), 5: newKey.PubKey()} {
+		des := bcs.NewDeserializer(payload.Args[i])
+		signature := &crypto.Ed25519Signature{}
+		err = signature.FromBytes(des.ReadBytes())
+		assert.NoError(t, err)
+		assert.True(t, verifyingKey.Verify(message, signature))
+	}
+
+	// The transaction is signed by the current key
+	signedTxn, err := rawTxn.SignedTransaction(account)
+	assert.NoError(t, err)
+	assert.NoError(t, signedTxn.Verify())
+}
+
+func TestBuildKeyRotation_Options(t *testing.T) {
+	account := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1":
+			_, _ = fmt.Fprint(w, testNodeInfoJson)
+		default:
+			_, _ = fmt.Fprintf(w, `{"sequence_number": "7", "authentication_key": "%s"}`, account.AuthKey().ToHex())
+		}
+	})
+	newKey, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+
+	// The sequence number can be chosen
+	rawTxn, err := client.BuildKeyRotation(account, newKey, SequenceNumber(9), MaxGasAmount(1000), GasUnitPrice(100))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), rawTxn.SequenceNumber)
+
+	_, err = client.BuildKeyRotation(account, newKey, client.NewSequenceNumberManager(account.Address))
+	assert.Error(t, err)
+
+	// Only legacy keys can be rotated to
+	singleKey, err := NewEd25519SingleSenderAccount()
+	assert.NoError(t, err)
+	_, err = client.BuildKeyRotation(account, singleKey.Signer, MaxGasAmount(1000), GasUnitPrice(100))
+	assert.ErrorContains(t, err, "only Ed25519 and MultiEd25519 are supported")
+}
+
+func TestAccountAuthKeyMatches(t *testing.T) {
+	account := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	rotatedKey, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+
+	authKey := account.AuthKey().ToHex()
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + account.Address.String():
+			_, _ = fmt.Fprintf(w, `{"sequence_number": "7", "authentication_key": "%s"}`, authKey)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"message":"Account not found","error_code":"account_not_found","vm_error_code":null}`)
+		}
+	})
+
+	// The key the account was created with
+	matches, err := client.AccountAuthKeyMatches(account.Address, account.PubKey())
+	assert.NoError(t, err)
+	assert.True(t, matches)
+	matches, err = client.AccountAuthKeyMatches(account.Address, rotatedKey.PubKey())
+	assert.NoError(t, err)
+	assert.False(t, matches)
+
+	// After the account's key is rotated, the original key no longer matches
+	authKey = rotatedKey.AuthKey().ToHex()
+	matches, err = client.AccountAuthKeyMatches(account.Address, account.PubKey())
+	assert.NoError(t, err)
+	assert.False(t, matches)
+	matches, err = client.AccountAuthKeyMatches(account.Address, rotatedKey.PubKey())
+	assert.NoError(t, err)
+	assert.True(t, matches)
+
+	// The account must exist
+	_, err = client.AccountAuthKeyMatches(AccountTwo, account.PubKey())
+	assert.True(t, IsNotFound(err))
+}
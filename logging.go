@@ -0,0 +1,71 @@
+package aptos
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RedactedBody is logged in place of the body of a request that is not logged e.g. a signed transaction
+const RedactedBody = "[redacted]"
+
+// maxLoggedBodyLength is the most bytes of a request body that are logged
+const maxLoggedBodyLength = 4096
+
+// attemptHook is called after each attempt at sending a request, with the response or error
+type attemptHook func(attempt int, response *http.Response, err error, latency time.Duration)
+
+// newRequestLogger creates an [attemptHook] logging each attempt of the request at debug level
+func newRequestLogger(logger *slog.Logger, req *http.Request) attemptHook {
+	ctx := req.Context()
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return nil
+	}
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+	}
+	if body, ok := requestBodyForLog(req); ok {
+		attrs = append(attrs, slog.String("body", body))
+	}
+	return func(attempt int, response *http.Response, err error, latency time.Duration) {
+		record := append(attrs[:len(attrs):len(attrs)], slog.Duration("latency", latency))
+		if attempt > 1 {
+			record = append(record, slog.Int("attempt", attempt))
+		}
+		if response != nil {
+			record = append(record, slog.Int("status", response.StatusCode))
+		}
+		if err != nil {
+			record = append(record, slog.String("error", err.Error()))
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "aptos node request", record...)
+	}
+}
+
+// requestBodyForLog is the body of the request to log.  Signed transactions are redacted, and only JSON or text bodies
+// that can be replayed are read.
+func requestBodyForLog(req *http.Request) (string, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", false
+	}
+	contentType := req.Header.Get("Content-Type")
+	if contentType == ContentTypeAptosSignedTxnBcs {
+		return RedactedBody, true
+	}
+	if req.GetBody == nil || !(strings.HasPrefix(contentType, "application/json") || strings.HasPrefix(contentType, "text/")) {
+		return "", false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	defer func() { _ = body.Close() }()
+	blob, err := io.ReadAll(io.LimitReader(body, maxLoggedBodyLength))
+	if err != nil {
+		return "", false
+	}
+	return string(blob), true
+}
@@ -1,6 +1,9 @@
 package aptos
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 )
 
@@ -19,3 +22,36 @@ func (mod *ModuleId) UnmarshalBCS(des *bcs.Deserializer) {
 	mod.Address.UnmarshalBCS(des)
 	mod.Name = des.ReadString()
 }
+
+// String returns the module id in the form address::name e.g. 0x1::coin
+func (mod *ModuleId) String() string {
+	return mod.Address.String() + "::" + mod.Name
+}
+
+// ParseModuleFunction parses a Move function ID of the form <address>::<module>::<function> e.g. 0x1::coin::transfer.
+// The address can be in the short or long form, with or without a leading 0x, and the module and function must be
+// valid Move identifiers.
+//
+//	address, module, function, err := ParseModuleFunction("0x1::coin::transfer")
+//	payload := &EntryFunction{
+//		Module:   ModuleId{Address: address, Name: module},
+//		Function: function,
+//		...
+//	}
+func ParseModuleFunction(functionId string) (moduleAddr AccountAddress, moduleName string, funcName string, err error) {
+	parts := strings.Split(functionId, "::")
+	if len(parts) != 3 {
+		return AccountAddress{}, "", "", fmt.Errorf("function %q is not of the form <address>::<module>::<function>", functionId)
+	}
+	err = moduleAddr.ParseStringRelaxed(parts[0])
+	if err != nil {
+		return AccountAddress{}, "", "", fmt.Errorf("function %q has invalid address: %w", functionId, err)
+	}
+	if !isMoveIdentifier(parts[1]) {
+		return AccountAddress{}, "", "", fmt.Errorf("function %q has invalid module name %q", functionId, parts[1])
+	}
+	if !isMoveIdentifier(parts[2]) {
+		return AccountAddress{}, "", "", fmt.Errorf("function %q has invalid function name %q", functionId, parts[2])
+	}
+	return moduleAddr, parts[1], parts[2], nil
+}
@@ -0,0 +1,61 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseModuleFunction(t *testing.T) {
+	address, module, function, err := ParseModuleFunction("0x1::coin::transfer")
+	assert.NoError(t, err)
+	assert.Equal(t, AccountOne, address)
+	assert.Equal(t, "coin", module)
+	assert.Equal(t, "transfer", function)
+
+	// Short, unprefixed, and long addresses
+	expected := AccountAddress{}
+	expected[30] = 0x12
+	expected[31] = 0x34
+	for _, functionId := range []string{
+		"0x1234::my_module::do_thing",
+		"1234::my_module::do_thing",
+		"0x" + expected.StringLong()[2:] + "::my_module::do_thing",
+	} {
+		address, module, function, err = ParseModuleFunction(functionId)
+		assert.NoError(t, err, functionId)
+		assert.Equal(t, expected, address, functionId)
+		assert.Equal(t, "my_module", module, functionId)
+		assert.Equal(t, "do_thing", function, functionId)
+	}
+
+	// The parts can be used as an entry function
+	address, module, function, err = ParseModuleFunction("0x1::aptos_account::transfer")
+	assert.NoError(t, err)
+	entryFunction := &EntryFunction{Module: ModuleId{Address: address, Name: module}, Function: function}
+	assert.Equal(t, "0x1::aptos_account", entryFunction.Module.String())
+}
+
+func TestParseModuleFunction_Invalid(t *testing.T) {
+	for _, functionId := range []string{
+		"",
+		"0x1",
+		"0x1::coin",
+		"0x1::coin::",
+		"::coin::transfer",
+		"0x1::::transfer",
+		"0x1:coin:transfer",
+		"0x1::coin::transfer::extra",
+		"0xzz::coin::transfer",
+		"0x1::coin::transfer<u64>",
+		"0x1::1coin::transfer",
+		"0x1:: coin::transfer",
+		"0x" + AccountOne.StringLong()[2:] + "00::coin::transfer",
+	} {
+		address, module, function, err := ParseModuleFunction(functionId)
+		assert.Error(t, err, functionId)
+		assert.Equal(t, AccountAddress{}, address, functionId)
+		assert.Empty(t, module, functionId)
+		assert.Empty(t, function, functionId)
+	}
+}
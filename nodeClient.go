@@ -2,16 +2,19 @@ package aptos
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aptos-labs/aptos-go-sdk/api"
@@ -35,13 +38,23 @@ const ContentTypeAptosViewFunctionBcs = "application/x.aptos.view_function+bcs"
 
 // NodeClient is a client for interacting with an Aptos node API
 type NodeClient struct {
-	client  *http.Client      // HTTP client to use for requests
-	baseUrl *url.URL          // Base URL of the node e.g. https://fullnode.testnet.aptoslabs.com/v1
-	chainId uint8             // Chain ID of the network e.g. 2 for Testnet
-	headers map[string]string // Headers to be added to every transaction
+	client           *http.Client           // HTTP client to use for requests
+	baseUrl          *url.URL               // Base URL of the node e.g. https://fullnode.testnet.aptoslabs.com/v1
+	chainId          uint8                  // Chain ID of the network e.g. 2 for Testnet
+	headers          map[string]string      // Headers to be added to every transaction
+	retryPolicy      *RetryPolicy           // Retry policy for failed requests, nil if requests are not retried
+	gasEstimate      *gasEstimateCache      // Cache of the last gas estimate
+	fetchedChainId   *chainIdCache          // Cache of the chain ID fetched from the node, see [NodeClient.GetChainId]
+	frameworkModules *frameworkModulesCache // Cache of the framework module ABIs, see [NodeClient.FrameworkModules]
+	ctx              context.Context        // Context of every request, nil for [context.Background], see [NodeClient.WithContext]
+	logger           *slog.Logger           // Logger for every request at debug level, nil to not log, see [NodeClient.WithLogger]
+
+	requestTimeout     time.Duration // Timeout of each request, 0 for none, see [NodeClient.SetRequestTimeout]
+	gzipRequestMinSize int64         // Minimum size of request bodies to compress, 0 for none, see [NodeClient.SetGzipRequests]
+	ledgerExpiration   time.Duration // Expiration of transactions after the ledger timestamp, 0 for the local clock, see [NodeClient.SetLedgerExpiration]
 }
 
-// NewNodeClient creates a new client for interacting with an Aptos node API
+// NewNodeClient creates a new client for interacting with an Aptos node API, using [NewDefaultTransport]
 func NewNodeClient(rpcUrl string, chainId uint8) (*NodeClient, error) {
 	// Set cookie jar so cookie stickiness applies to connections
 	// TODO Add appropriate suffix list
@@ -50,8 +63,9 @@ func NewNodeClient(rpcUrl string, chainId uint8) (*NodeClient, error) {
 		return nil, err
 	}
 	defaultClient := &http.Client{
-		Jar:     jar,
-		Timeout: 60 * time.Second,
+		Jar:       jar,
+		Timeout:   60 * time.Second,
+		Transport: NewDefaultTransport(),
 	}
 
 	return NewNodeClientWithHttpClient(rpcUrl, chainId, defaultClient)
@@ -64,13 +78,69 @@ func NewNodeClientWithHttpClient(rpcUrl string, chainId uint8, client *http.Clie
 		return nil, fmt.Errorf("failed to parse RPC url '%s': %w", rpcUrl, err)
 	}
 	return &NodeClient{
-		client:  client,
-		baseUrl: baseUrl,
-		chainId: chainId,
-		headers: make(map[string]string),
+		client:           client,
+		baseUrl:          baseUrl,
+		chainId:          chainId,
+		headers:          make(map[string]string),
+		gasEstimate:      newGasEstimateCache(DefaultGasEstimateCacheTTL),
+		fetchedChainId:   &chainIdCache{},
+		ledgerExpiration: DefaultLedgerExpiration,
+		frameworkModules: &frameworkModulesCache{},
 	}, nil
 }
 
+// WithContext returns a copy of the client with every request bound to the context, so they can be cancelled, or have
+// a deadline.  It includes any waiting between requests, e.g. when polling for a transaction or retrying.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	info, err := client.WithContext(ctx).Account(address)
+//
+// It's a convenience for methods without a context argument.  For a single call, prefer the Ctx variant e.g.
+// [NodeClient.AccountCtx], which doesn't need a copy of the client.
+//
+// The copy shares the HTTP client, headers, retry policy, gas estimate cache, chain ID cache, and framework
+// modules cache with the original client.
+func (rc *NodeClient) WithContext(ctx context.Context) *NodeClient {
+	bound := *rc
+	bound.ctx = ctx
+	return &bound
+}
+
+// WithLogger returns a copy of the client which logs every request to the node at debug level, with the method, URL,
+// status code, latency, and attempt number if retried.  A nil logger turns off logging.
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+//	client = client.WithLogger(logger)
+//
+// JSON request bodies are logged, but signed transactions being submitted or simulated are redacted.  The copy shares
+// its configuration with the original client, see [NodeClient.WithContext].
+func (rc *NodeClient) WithLogger(logger *slog.Logger) *NodeClient {
+	bound := *rc
+	bound.logger = logger
+	return &bound
+}
+
+// context is the context of requests by the client
+func (rc *NodeClient) context() context.Context {
+	if rc.ctx == nil {
+		return context.Background()
+	}
+	return rc.ctx
+}
+
+// sleepContext waits for the duration, returning early with the error of the context if it is done first
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // SetTimeout adjusts the HTTP client timeout
 //
 //	client.SetTimeout(5 * time.Millisecond)
@@ -92,6 +162,19 @@ func (rc *NodeClient) RemoveHeader(key string) {
 	delete(rc.headers, key)
 }
 
+// SetRetryPolicy sets the policy to retry failed requests for all future requests
+//
+//	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: 200 * time.Millisecond})
+func (rc *NodeClient) SetRetryPolicy(policy RetryPolicy) {
+	policy = policy.withDefaults()
+	rc.retryPolicy = &policy
+}
+
+// RemoveRetryPolicy disables retries for all future requests
+func (rc *NodeClient) RemoveRetryPolicy() {
+	rc.retryPolicy = nil
+}
+
 // Info gets general information about the blockchain
 func (rc *NodeClient) Info() (info NodeInfo, err error) {
 	info, err = Get[NodeInfo](rc, rc.baseUrl.String())
@@ -100,40 +183,104 @@ func (rc *NodeClient) Info() (info NodeInfo, err error) {
 	}
 
 	// Cache the ChainId for later calls, because performance
-	rc.chainId = info.ChainId
+	rc.cacheChainId(info.ChainId)
 	return info, err
 }
 
+// LedgerInfo gets the current state of the blockchain, the same as [NodeClient.Info] but with numeric fields parsed
+//
+// The chain ID is cached, so [NodeClient.GetChainId] won't fetch it again.
+func (rc *NodeClient) LedgerInfo() (info *api.LedgerInfo, err error) {
+	info, err = Get[*api.LedgerInfo](rc, rc.baseUrl.String())
+	if err != nil {
+		return nil, fmt.Errorf("get ledger info api err: %w", err)
+	}
+
+	// The chain ID never changes, so cache it the same as Info
+	rc.cacheChainId(info.ChainId)
+	return info, nil
+}
+
 // Account gets information about an account for a given address
 //
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 func (rc *NodeClient) Account(address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
+	return rc.AccountCtx(rc.context(), address, ledgerVersion...)
+}
+
+// AccountCtx is [NodeClient.Account], but the request is bound to the context
+func (rc *NodeClient) AccountCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (info AccountInfo, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String())
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
-	info, err = Get[AccountInfo](rc, au.String())
+	withLedgerVersion(au, ledgerVersion)
+	info, err = get[AccountInfo](ctx, rc, au.String())
 	if err != nil {
 		return info, fmt.Errorf("get account info api err: %w", err)
 	}
 	return info, nil
 }
 
+// AccountExists checks whether an account exists on chain.  An account that doesn't exist returns false with no error,
+// and any other failure e.g. the node being unavailable returns an error.
+//
+//	exists, err := client.AccountExists(address)
+//	if err != nil {
+//		return err
+//	}
+//	if !exists {
+//		// Fund or create the account
+//	}
+func (rc *NodeClient) AccountExists(address AccountAddress) (bool, error) {
+	_, err := rc.Account(address)
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.ErrorCode == api.ErrorCodeAccountNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// withLedgerVersion sets the ledger_version query parameter of the URL to the first ledgerVersion, if one is given, to
+// read the state at that version instead of the latest
+func withLedgerVersion(au *url.URL, ledgerVersion []uint64) {
+	if len(ledgerVersion) > 0 {
+		params := au.Query()
+		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
+		au.RawQuery = params.Encode()
+	}
+}
+
 // AccountResource fetches a resource for an account into a JSON-like map[string]any.
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 //
-// For fetching raw Move structs as BCS, See #AccountResourceBCS
+// For fetching raw Move structs as BCS, See [NodeClient.AccountResourceBCS]
 func (rc *NodeClient) AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
+	return rc.AccountResourceCtx(rc.context(), address, resourceType, ledgerVersion...)
+}
+
+// AccountResourceCtx is [NodeClient.AccountResource], but the request is bound to the context
+func (rc *NodeClient) AccountResourceCtx(ctx context.Context, address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", resourceType)
 	// TODO: offer a list of known-good resourceType string constants
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
+	withLedgerVersion(au, ledgerVersion)
+	data, err = get[map[string]any](ctx, rc, au.String())
+	if err != nil {
+		return nil, fmt.Errorf("get resource api err: %w", err)
 	}
-	data, err = Get[map[string]any](rc, au.String())
+	return data, nil
+}
+
+// AccountResourceBCS fetches a resource for an account as the BCS bytes of the Move struct, which is more compact than
+// the JSON form.  The bytes can be decoded with [bcs.Deserialize], or with [AccountResourceBCSInto].
+//
+//	data, err := client.AccountResourceBCS(address, "0x1::account::Account")
+//
+// Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
+func (rc *NodeClient) AccountResourceBCS(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data []byte, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", resourceType)
+	withLedgerVersion(au, ledgerVersion)
+	data, err = rc.GetBCS(au.String())
 	if err != nil {
 		return nil, fmt.Errorf("get resource api err: %w", err)
 	}
@@ -143,18 +290,35 @@ func (rc *NodeClient) AccountResource(address AccountAddress, resourceType strin
 // AccountResources fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 // For fetching raw Move structs as BCS, See #AccountResourcesBCS
+//
+// The node returns the resources a page at a time, so every page is fetched by following the X-Aptos-Cursor header,
+// and the results are merged.  To cap the number of resources fetched, see [NodeClient.AccountResourcesUpTo].
 func (rc *NodeClient) AccountResources(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	return rc.AccountResourcesCtx(rc.context(), address, ledgerVersion...)
+}
+
+// AccountResourcesCtx is [NodeClient.AccountResources], but the requests are bound to the context
+func (rc *NodeClient) AccountResourcesCtx(ctx context.Context, address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	return rc.accountResourcesUpTo(ctx, address, 0, ledgerVersion)
+}
+
+// AccountResourcesUpTo fetches resources for an account the same as [NodeClient.AccountResources], but stops once
+// maxResources have been fetched.  If maxResources is 0 or less, all resources are fetched.
+//
+//	resources, err := client.AccountResourcesUpTo(address, 100)
+func (rc *NodeClient) AccountResourcesUpTo(address AccountAddress, maxResources int, ledgerVersion ...uint64) (resources []AccountResourceInfo, err error) {
+	return rc.accountResourcesUpTo(rc.context(), address, maxResources, ledgerVersion)
+}
+
+// accountResourcesUpTo is [NodeClient.AccountResourcesUpTo], but the requests are bound to the context
+func (rc *NodeClient) accountResourcesUpTo(ctx context.Context, address AccountAddress, maxResources int, ledgerVersion []uint64) (resources []AccountResourceInfo, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
-	resources, err = Get[[]AccountResourceInfo](rc, au.String())
+	withLedgerVersion(au, ledgerVersion)
+	resources, err = NewPaginator(cursorPageFetcher[AccountResourceInfo](rc, au, 0)).Collect(ctx, maxResources)
 	if err != nil {
 		return nil, fmt.Errorf("get resources api err: %w", err)
 	}
-	return resources, err
+	return resources, nil
 }
 
 // AccountResourcesByPages fetches resources for an account into a JSON-like map[string]any in AccountResourceInfo.Data
@@ -165,38 +329,20 @@ func (rc *NodeClient) AccountResourcesByPages(
 	ledgerVersion uint64,
 	pageSize uint64,
 ) (resources []AccountResourceInfo, err error) {
-	var cursor string
-	params := url.Values{}
-	params.Set("ledger_version", strconv.FormatUint(ledgerVersion, 10))
-	if pageSize > 0 {
-		params.Set("limit", strconv.FormatUint(pageSize, 10))
-	}
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
-	for {
-		if cursor != "" {
-			params.Set("start", cursor)
-		}
-		au.RawQuery = params.Encode()
-		page, response, pageErr := GetWithResp[[]AccountResourceInfo](rc, au.String())
-		if pageErr != nil {
-			return nil, fmt.Errorf("get resources api err: %w", pageErr)
-		}
-		resources = append(resources, page...)
-		if cursor = response.Header.Get("X-Aptos-Cursor"); cursor == "" {
-			return resources, nil
-		}
+	withLedgerVersion(au, []uint64{ledgerVersion})
+	resources, err = NewPaginator(cursorPageFetcher[AccountResourceInfo](rc, au, pageSize)).All(rc.context())
+	if err != nil {
+		return nil, fmt.Errorf("get resources api err: %w", err)
 	}
+	return resources, nil
 }
 
 // AccountResourcesBCS fetches account resources as raw Move struct BCS blobs in AccountResourceRecord.Data []byte
 // Optionally, a ledgerVersion can be given to get the account state at a specific ledger version
 func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion ...uint64) (resources []AccountResourceRecord, err error) {
 	au := rc.baseUrl.JoinPath("accounts", address.String(), "resources")
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
+	withLedgerVersion(au, ledgerVersion)
 	blob, err := rc.GetBCS(au.String())
 	if err != nil {
 		return nil, err
@@ -208,25 +354,145 @@ func (rc *NodeClient) AccountResourcesBCS(address AccountAddress, ledgerVersion
 	return
 }
 
+// AccountModule fetches a single module for an account by name, with its bytecode and ABI
+// Optionally, a ledgerVersion can be given to get the module at a specific ledger version
+//
+//	module, err := client.AccountModule(AccountOne, "coin")
+//	balance := module.Abi.Function("balance")
+func (rc *NodeClient) AccountModule(address AccountAddress, moduleName string, ledgerVersion ...uint64) (module *api.MoveBytecode, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "module", moduleName)
+	withLedgerVersion(au, ledgerVersion)
+	module, err = Get[*api.MoveBytecode](rc, au.String())
+	if err != nil {
+		return nil, fmt.Errorf("get module api err: %w", err)
+	}
+	return module, nil
+}
+
+// AccountModules fetches all modules for an account, with their bytecode and ABIs
+// Optionally, a ledgerVersion can be given to get the modules at a specific ledger version
+func (rc *NodeClient) AccountModules(address AccountAddress, ledgerVersion ...uint64) (modules []*api.MoveBytecode, err error) {
+	au := rc.baseUrl.JoinPath("accounts", address.String(), "modules")
+	withLedgerVersion(au, ledgerVersion)
+	modules, err = Get[[]*api.MoveBytecode](rc, au.String())
+	if err != nil {
+		return nil, fmt.Errorf("get modules api err: %w", err)
+	}
+	return modules, nil
+}
+
+// AtVersion is an option to read the state at a specific ledger version instead of the latest, for reads that take
+// options e.g. [NodeClient.AccountResourcesBatch].  Other reads take an optional ledgerVersion argument.
+type AtVersion uint64
+
+// DefaultBatchWorkers is the default number of concurrent requests for batch APIs e.g. [NodeClient.AccountResourcesBatch]
+const DefaultBatchWorkers = 8
+
+// BatchWorkers will set the maximum number of concurrent requests for a batch API
+type BatchWorkers int
+
+// PartialResults will return any successful results alongside the error for a batch API
+type PartialResults bool
+
+// AccountResourcesBatch fetches multiple resources for an account concurrently, returning them in the same order as
+// resourceTypes
+//
+// Failures for individual resources are collected and returned as a single joined error.  By default, no resources are
+// returned if any fail.  If [PartialResults] is set, all resources are returned along with the error, and failed resources
+// will only have the Type set.
+//
+// Accepts options:
+//   - [BatchWorkers] the maximum number of concurrent requests, defaults to [DefaultBatchWorkers]
+//   - [PartialResults]
+//   - [AtVersion] to read all the resources at the same ledger version, defaults to the latest
+func (rc *NodeClient) AccountResourcesBatch(address AccountAddress, resourceTypes []string, options ...any) (resources []AccountResourceInfo, err error) {
+	workers := DefaultBatchWorkers
+	partialResults := false
+	var ledgerVersion []uint64
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case AtVersion:
+			ledgerVersion = []uint64{uint64(value)}
+		case BatchWorkers:
+			if value < 1 {
+				return nil, fmt.Errorf("AccountResourcesBatch BatchWorkers must be at least 1, got %d", value)
+			}
+			workers = int(value)
+		case PartialResults:
+			partialResults = bool(value)
+		default:
+			return nil, fmt.Errorf("AccountResourcesBatch arg %d bad type %T", i+1, arg)
+		}
+	}
+	resources = make([]AccountResourceInfo, len(resourceTypes))
+	errs := make([]error, len(resourceTypes))
+
+	runBatch(len(resourceTypes), workers, func(i int) {
+		resourceType := resourceTypes[i]
+		au := rc.baseUrl.JoinPath("accounts", address.String(), "resource", resourceType)
+		withLedgerVersion(au, ledgerVersion)
+		resource, innerErr := Get[AccountResourceInfo](rc, au.String())
+		if innerErr != nil {
+			errs[i] = fmt.Errorf("get resource %s api err: %w", resourceType, innerErr)
+			resources[i] = AccountResourceInfo{Type: resourceType}
+		} else {
+			resources[i] = resource
+		}
+	})
+
+	err = errors.Join(errs...)
+	if err != nil && !partialResults {
+		return nil, err
+	}
+	return resources, err
+}
+
+// runBatch calls fn for each index up to n, with at most workers calls at once, returning once they're all done
+//
+// Each worker pulls the next index, so results written by index stay in the same order as the input.
+func runBatch(n int, workers int, fn func(i int)) {
+	workers = min(workers, n)
+	indices := make(chan int, n)
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // TransactionByHash gets info on a transaction
 // The transaction may be pending or recently committed.  If the transaction is a [api.PendingTransaction], then it is
 // still in the mempool.  If the transaction is any other type, it has been committed.
 //
 //	data, err := c.TransactionByHash("0xabcd")
 //	if err != nil {
-//		if httpErr, ok := err.(aptos.HttpError) {
-//			if httpErr.StatusCode == 404 {
-//				// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
-//			}
-//		}
-//	} else {
-//		if data["type"] == "pending_transaction" {
-//			// known to local mempool, but not committed yet
+//		if IsNotFound(err) {
+//			// if we're sure this has been submitted, assume it is still pending elsewhere in the mempool
 //		}
+//	} else if data.IsPending() {
+//		// known to local mempool, but not committed yet
+//	} else if !data.IsSuccess() {
+//		// committed, but failed
 //	}
 func (rc *NodeClient) TransactionByHash(txnHash string) (data *api.Transaction, err error) {
+	return rc.TransactionByHashCtx(rc.context(), txnHash)
+}
+
+// TransactionByHashCtx is [NodeClient.TransactionByHash], but the request is bound to the context
+func (rc *NodeClient) TransactionByHashCtx(ctx context.Context, txnHash string) (data *api.Transaction, err error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/by_hash", txnHash)
-	data, err = Get[*api.Transaction](rc, restUrl.String())
+	data, err = get[*api.Transaction](ctx, rc, restUrl.String())
 	if err != nil {
 		return data, fmt.Errorf("get transaction api err: %w", err)
 	}
@@ -236,8 +502,13 @@ func (rc *NodeClient) TransactionByHash(txnHash string) (data *api.Transaction,
 // TransactionByVersion gets info on a transaction by version number
 // The transaction will have been committed.  The response will not be of the type [api.PendingTransaction].
 func (rc *NodeClient) TransactionByVersion(version uint64) (data *api.CommittedTransaction, err error) {
+	return rc.TransactionByVersionCtx(rc.context(), version)
+}
+
+// TransactionByVersionCtx is [NodeClient.TransactionByVersion], but the request is bound to the context
+func (rc *NodeClient) TransactionByVersionCtx(ctx context.Context, version uint64) (data *api.CommittedTransaction, err error) {
 	restUrl := rc.baseUrl.JoinPath("transactions/by_version", strconv.FormatUint(version, 10))
-	data, err = Get[*api.CommittedTransaction](rc, restUrl.String())
+	data, err = get[*api.CommittedTransaction](ctx, rc, restUrl.String())
 	if err != nil {
 		return data, fmt.Errorf("get transaction api err: %w", err)
 	}
@@ -290,22 +561,23 @@ func (rc *NodeClient) getBlockCommon(restUrl *url.URL, withTransactions bool) (b
 	numTransactions := block.LastVersion - block.FirstVersion + 1
 	retrievedTransactions := uint64(len(block.Transactions))
 
-	// Transaction is always not pending, so it will never be nil
-	cursor := block.Transactions[len(block.Transactions)-1].Version()
-
 	// TODO: I maybe should pull these concurrently, but not for now
 	for retrievedTransactions < numTransactions {
+		// Continue after the last transaction retrieved
+		cursor := block.FirstVersion + retrievedTransactions
 		numToPull := numTransactions - retrievedTransactions
 		transactions, innerError := rc.Transactions(&cursor, &numToPull)
 		if innerError != nil {
 			// We will still return the block, since we did so much work for it
 			return block, innerError
 		}
+		if len(transactions) == 0 {
+			return block, fmt.Errorf("get block api err: no transactions returned from version %d", cursor)
+		}
 
 		// Add transactions to the list
 		block.Transactions = append(block.Transactions, transactions...)
 		retrievedTransactions = uint64(len(block.Transactions))
-		cursor = block.Transactions[len(block.Transactions)-1].Version()
 	}
 	return
 }
@@ -357,7 +629,9 @@ func (rc *NodeClient) PollForTransaction(hash string, options ...any) (*api.User
 		if time.Now().After(deadline) {
 			return nil, errors.New("PollForTransaction timeout")
 		}
-		time.Sleep(period)
+		if err := sleepContext(rc.context(), period); err != nil {
+			return nil, fmt.Errorf("PollForTransaction: %w", err)
+		}
 		txn, err := rc.TransactionByHash(hash)
 		if err == nil {
 			if txn.Type == api.TransactionVariantPending {
@@ -388,7 +662,9 @@ func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) er
 		if time.Now().After(deadline) {
 			return errors.New("PollForTransactions timeout")
 		}
-		time.Sleep(period)
+		if err := sleepContext(rc.context(), period); err != nil {
+			return fmt.Errorf("PollForTransactions: %w", err)
+		}
 		for _, hash := range txnHashes {
 			if !hashSet[hash] {
 				// already done
@@ -409,17 +685,108 @@ func (rc *NodeClient) PollForTransactions(txnHashes []string, options ...any) er
 	return nil
 }
 
+// WaitOption is an option to WaitForTransactionByHash, either a [PollPeriod] or a [PollTimeout]
+type WaitOption interface {
+	applyWaitOption(opts *waitOptions)
+}
+
+// waitOptions are the resolved options for WaitForTransactionByHash
+type waitOptions struct {
+	period  time.Duration
+	timeout time.Duration
+}
+
+func (p PollPeriod) applyWaitOption(opts *waitOptions) {
+	opts.period = time.Duration(p)
+}
+
+func (p PollTimeout) applyWaitOption(opts *waitOptions) {
+	opts.timeout = time.Duration(p)
+}
+
+// TransactionFailedError is returned when a transaction was committed, but failed to execute
+type TransactionFailedError struct {
+	Hash     string // Hash of the transaction
+	VmStatus string // VmStatus of the failure e.g. "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006)"
+}
+
+// Error returns a string representation of the TransactionFailedError
+//
+// Implements:
+//   - [error]
+func (e *TransactionFailedError) Error() string {
+	return fmt.Sprintf("transaction %s failed: %s", e.Hash, e.VmStatus)
+}
+
+// WaitForTransactionByHash polls for a transaction until it is committed, the timeout expires, or the context is
+// cancelled.  Not found and pending transactions are polled again, as the transaction may not have propagated to the
+// node yet.
+//
+// If the transaction was committed but failed, the transaction is returned along with a [TransactionFailedError]
+// containing the vm_status.
+//
+// Optional arguments:
+//   - PollPeriod: time.Duration, how often to poll for the transaction. Default 100ms.
+//   - PollTimeout: time.Duration, how long to wait for the transaction. Default 10s.
+func (rc *NodeClient) WaitForTransactionByHash(ctx context.Context, hash string, opts ...WaitOption) (*api.Transaction, error) {
+	options := waitOptions{period: 100 * time.Millisecond, timeout: 10 * time.Second}
+	for _, opt := range opts {
+		opt.applyWaitOption(&options)
+	}
+	ctx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+
+	restUrl := rc.baseUrl.JoinPath("transactions/by_hash", hash)
+	ticker := time.NewTicker(options.period)
+	defer ticker.Stop()
+	for {
+		txn, _, err := getWithResp[*api.Transaction](ctx, rc, restUrl.String())
+		if err != nil {
+			var httpErr *HttpError
+			if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+				if ctx.Err() != nil {
+					return nil, fmt.Errorf("WaitForTransactionByHash %s: %w", hash, ctx.Err())
+				}
+				return nil, fmt.Errorf("get transaction api err: %w", err)
+			}
+			// Not found yet, keep polling
+		} else if txn.Type != api.TransactionVariantPending {
+			// done!
+			slog.Debug("txn done", "hash", hash)
+			if success := txn.Success(); success != nil && !*success {
+				failedErr := &TransactionFailedError{Hash: hash}
+				if userTxn, innerErr := txn.UserTransaction(); innerErr == nil {
+					failedErr.VmStatus = userTxn.VmStatus
+				}
+				return txn, failedErr
+			}
+			return txn, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("WaitForTransactionByHash %s: %w", hash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 // Transactions Get recent transactions.
 //
 // Arguments:
 //   - start is a version number. Nil for most recent transactions.
 //   - limit is a number of transactions to return. 'about a hundred' by default.
 func (rc *NodeClient) Transactions(start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error) {
+	return rc.TransactionsCtx(rc.context(), start, limit)
+}
+
+// TransactionsCtx is [NodeClient.Transactions], but the requests are bound to the context
+func (rc *NodeClient) TransactionsCtx(ctx context.Context, start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error) {
 	return rc.handleTransactions(start, limit, func(txns *[]*api.CommittedTransaction) uint64 {
 		txn := (*txns)[len(*txns)-1]
 		return txn.Version()
 	}, func(start *uint64, limit *uint64) ([]*api.CommittedTransaction, error) {
-		return rc.transactionsInner(start, limit)
+		return rc.transactionsInner(ctx, start, limit)
 	})
 }
 
@@ -538,8 +905,8 @@ func (rc *NodeClient) transactionsConcurrent(
 	}
 }
 
-// transactionsInner fetches the transactions from the node in a single request
-func (rc *NodeClient) transactionsInner(start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error) {
+// transactionsInner fetches the transactions from the node in a single request bound to the context
+func (rc *NodeClient) transactionsInner(ctx context.Context, start *uint64, limit *uint64) (data []*api.CommittedTransaction, err error) {
 	au := rc.baseUrl.JoinPath("transactions")
 	params := url.Values{}
 	if start != nil {
@@ -551,7 +918,7 @@ func (rc *NodeClient) transactionsInner(start *uint64, limit *uint64) (data []*a
 	if len(params) != 0 {
 		au.RawQuery = params.Encode()
 	}
-	data, err = Get[[]*api.CommittedTransaction](rc, au.String())
+	data, err = get[[]*api.CommittedTransaction](ctx, rc, au.String())
 	if err != nil {
 		return data, fmt.Errorf("get transactions api err: %w", err)
 	}
@@ -581,23 +948,62 @@ func (rc *NodeClient) accountTransactionsInner(account AccountAddress, start *ui
 
 // SubmitTransaction submits a signed transaction to the network
 func (rc *NodeClient) SubmitTransaction(signedTxn *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
+	return rc.SubmitTransactionCtx(rc.context(), signedTxn)
+}
+
+// SubmitTransactionCtx is [NodeClient.SubmitTransaction], but the request is bound to the context
+func (rc *NodeClient) SubmitTransactionCtx(ctx context.Context, signedTxn *SignedTransaction) (data *api.SubmitTransactionResponse, err error) {
 	sblob, err := bcs.Serialize(signedTxn)
 	if err != nil {
 		return
 	}
-	bodyReader := bytes.NewReader(sblob)
+	return rc.SubmitTransactionBCSCtx(ctx, sblob)
+}
+
+// SubmitTransactionBCS submits an already BCS serialized [SignedTransaction] to the network
+//
+// This is useful for transactions that are signed offline, or outside the SDK.
+func (rc *NodeClient) SubmitTransactionBCS(signedTxn []byte) (data *api.SubmitTransactionResponse, err error) {
+	return rc.SubmitTransactionBCSCtx(rc.context(), signedTxn)
+}
+
+// SubmitTransactionBCSCtx is [NodeClient.SubmitTransactionBCS], but the request is bound to the context
+func (rc *NodeClient) SubmitTransactionBCSCtx(ctx context.Context, signedTxn []byte) (data *api.SubmitTransactionResponse, err error) {
+	bodyReader := bytes.NewReader(signedTxn)
 	au := rc.baseUrl.JoinPath("transactions")
-	data, err = Post[*api.SubmitTransactionResponse](rc, au.String(), ContentTypeAptosSignedTxnBcs, bodyReader)
+	data, err = post[*api.SubmitTransactionResponse](ctx, rc, au.String(), ContentTypeAptosSignedTxnBcs, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("submit transaction api err: %w", err)
 	}
 	return data, nil
 }
 
+// SubmitTransactionBCSResponse submits an already BCS serialized [SignedTransaction] to the network, as
+// [NodeClient.SubmitTransactionBCS], but asks the node for a BCS response and returns its raw bytes
+func (rc *NodeClient) SubmitTransactionBCSResponse(signedTxn []byte) (response []byte, err error) {
+	au := rc.baseUrl.JoinPath("transactions")
+	response, err = rc.postBCS(rc.context(), au.String(), ContentTypeAptosSignedTxnBcs, bytes.NewReader(signedTxn))
+	if err != nil {
+		return nil, fmt.Errorf("submit transaction api err: %w", err)
+	}
+	return response, nil
+}
+
 // BatchSubmitTransaction submits a collection of signed transactions to the network in a single request
 //
 // It will return the responses in the same order as the input transactions that failed.  If the response is empty, then
-// all transactions succeeded.
+// all transactions succeeded.  A partial failure is not an error, the failures are by the index of the transaction in
+// signedTxns, see [api.BatchSubmitTransactionResponse.Failure].
+//
+//	response, err := client.BatchSubmitTransaction(signedTxns)
+//	if err != nil {
+//		return err // the whole batch was rejected
+//	}
+//	for i := range signedTxns {
+//		if failure := response.Failure(uint32(i)); failure != nil {
+//			// handle failure of signedTxns[i]
+//		}
+//	}
 func (rc *NodeClient) BatchSubmitTransaction(signedTxns []*SignedTransaction) (response *api.BatchSubmitTransactionResponse, err error) {
 	sblob, err := bcs.SerializeSequenceOnly(signedTxns)
 	if err != nil {
@@ -607,7 +1013,7 @@ func (rc *NodeClient) BatchSubmitTransaction(signedTxns []*SignedTransaction) (r
 	au := rc.baseUrl.JoinPath("transactions/batch")
 	response, err = Post[*api.BatchSubmitTransactionResponse](rc, au.String(), ContentTypeAptosSignedTxnBcs, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("submit transaction api err: %w", err)
+		return nil, fmt.Errorf("batch submit transaction api err: %w", err)
 	}
 	return response, nil
 }
@@ -623,18 +1029,14 @@ type EstimatePrioritizedGasUnitPrice bool
 
 // SimulateTransaction simulates a transaction
 //
-// TODO: This needs to support RawTransactionWithData
+// TODO: This needs to support multi-agent RawTransactionWithData, for fee payer see [NodeClient.SimulateFeePayerTransaction]
 // TODO: Support multikey simulation
 func (rc *NodeClient) SimulateTransaction(rawTxn *RawTransaction, sender TransactionSigner, options ...any) (data []*api.UserTransaction, err error) {
 	// build authenticator for simulation
-	derivationScheme := sender.PubKey().Scheme()
-	switch derivationScheme {
-	case crypto.MultiEd25519Scheme:
-	case crypto.MultiKeyScheme:
-		// todo: add support for multikey simulation on the node
-		return nil, fmt.Errorf("currently unsupported sender derivation scheme %v", derivationScheme)
+	auth, err := simulationAuthenticator(sender)
+	if err != nil {
+		return nil, err
 	}
-	auth := sender.SimulationAuthenticator()
 
 	// generate signed transaction for simulation (with zero signature)
 	signedTxn, err := rawTxn.SignedTransactionWithAuthenticator(auth)
@@ -646,10 +1048,53 @@ func (rc *NodeClient) SimulateTransaction(rawTxn *RawTransaction, sender Transac
 	if err != nil {
 		return
 	}
-	bodyReader := bytes.NewReader(sblob)
-	au := rc.baseUrl.JoinPath("transactions/simulate")
+	return rc.SimulateTransactionBCS(sblob, options...)
+}
 
-	// parse simulate tx options
+// SimulateTransactionBCS simulates an already BCS serialized [SignedTransaction]
+//
+// The transaction must be signed with a simulation authenticator e.g. [crypto.Signer.SimulationAuthenticator], as
+// simulation will fail for a valid signature.
+//
+// Accepts options:
+//   - [EstimateGasUnitPrice]
+//   - [EstimateMaxGasAmount]
+//   - [EstimatePrioritizedGasUnitPrice]
+func (rc *NodeClient) SimulateTransactionBCS(signedTxn []byte, options ...any) (data []*api.UserTransaction, err error) {
+	au, err := rc.simulateTransactionUrl(options)
+	if err != nil {
+		return nil, err
+	}
+	data, err = Post[[]*api.UserTransaction](rc, au.String(), ContentTypeAptosSignedTxnBcs, bytes.NewReader(signedTxn))
+	if err != nil {
+		return nil, fmt.Errorf("simulate transaction api err: %w", err)
+	}
+
+	return data, nil
+}
+
+// SimulateTransactionBCSResponse simulates an already BCS serialized [SignedTransaction], as
+// [NodeClient.SimulateTransactionBCS], but asks the node for a BCS response and returns its raw bytes
+//
+// Accepts options:
+//   - [EstimateGasUnitPrice]
+//   - [EstimateMaxGasAmount]
+//   - [EstimatePrioritizedGasUnitPrice]
+func (rc *NodeClient) SimulateTransactionBCSResponse(signedTxn []byte, options ...any) (response []byte, err error) {
+	au, err := rc.simulateTransactionUrl(options)
+	if err != nil {
+		return nil, err
+	}
+	response, err = rc.postBCS(rc.context(), au.String(), ContentTypeAptosSignedTxnBcs, bytes.NewReader(signedTxn))
+	if err != nil {
+		return nil, fmt.Errorf("simulate transaction api err: %w", err)
+	}
+	return response, nil
+}
+
+// simulateTransactionUrl returns the url to simulate a transaction, with the query parameters of the options
+func (rc *NodeClient) simulateTransactionUrl(options []any) (*url.URL, error) {
+	au := rc.baseUrl.JoinPath("transactions/simulate")
 	params := url.Values{}
 	for i, arg := range options {
 		switch value := arg.(type) {
@@ -660,33 +1105,326 @@ func (rc *NodeClient) SimulateTransaction(rawTxn *RawTransaction, sender Transac
 		case EstimatePrioritizedGasUnitPrice:
 			params.Set("estimate_prioritized_gas_unit_price", strconv.FormatBool(bool(value)))
 		default:
-			err = fmt.Errorf("SimulateTransaction arg %d bad type %T", i+1, arg)
-			return
+			return nil, fmt.Errorf("SimulateTransaction arg %d bad type %T", i+1, arg)
 		}
 	}
 	if len(params) != 0 {
 		au.RawQuery = params.Encode()
 	}
+	return au, nil
+}
 
-	data, err = Post[[]*api.UserTransaction](rc, au.String(), ContentTypeAptosSignedTxnBcs, bodyReader)
+// simulationAuthenticator returns the zero signature authenticator of the sender for simulation, if the node supports
+// simulating its scheme
+func simulationAuthenticator(sender TransactionSigner) (*crypto.AccountAuthenticator, error) {
+	derivationScheme := sender.PubKey().Scheme()
+	switch derivationScheme {
+	case crypto.MultiEd25519Scheme:
+	case crypto.MultiKeyScheme:
+		// todo: add support for multikey simulation on the node
+		return nil, fmt.Errorf("currently unsupported sender derivation scheme %v", derivationScheme)
+	}
+	return sender.SimulationAuthenticator(), nil
+}
+
+// FeePayerSimulation is the result of [NodeClient.SimulateFeePayerTransaction]
+type FeePayerSimulation struct {
+	Transaction  *api.UserTransaction // Transaction is the simulated transaction, check Success and VmStatus
+	FeePayer     AccountAddress       // FeePayer is the account that pays for the gas of the transaction
+	GasUsed      uint64               // GasUsed is the gas units used by the transaction, all paid by the fee payer
+	GasUnitPrice uint64               // GasUnitPrice is the price per gas unit, estimated by the node if requested
+}
+
+// FeePayerOctas is the amount the fee payer is charged for the gas, in octas, before any storage refund
+func (sim *FeePayerSimulation) FeePayerOctas() uint64 {
+	return sim.GasUsed * sim.GasUnitPrice
+}
+
+// SimulateFeePayerTransaction simulates a fee payer transaction signed only by the sender, so the gas can be shown
+// before the fee payer commits to paying it.  The fee payer, and any secondary signers, are given a
+// [crypto.NoAccountAuthenticator], which the node only accepts for simulation.
+//
+// The fee payer can be [AccountZero] if it isn't known yet, in which case the node doesn't check a fee payer's balance.
+//
+//	rawTxn, _ := client.BuildFeePayerTransaction(sender.Address, payload, sponsor)
+//	sim, err := client.SimulateFeePayerTransaction(rawTxn, sender, EstimateGasUnitPrice(true), EstimateMaxGasAmount(true))
+//	fmt.Println("sponsor pays up to", sim.FeePayerOctas())
+//
+// Accepts options:
+//   - [EstimateGasUnitPrice]
+//   - [EstimateMaxGasAmount]
+//   - [EstimatePrioritizedGasUnitPrice]
+func (rc *NodeClient) SimulateFeePayerTransaction(rawTxn *RawTransactionWithData, sender TransactionSigner, options ...any) (*FeePayerSimulation, error) {
+	for i, arg := range options {
+		switch arg.(type) {
+		case EstimateGasUnitPrice, EstimateMaxGasAmount, EstimatePrioritizedGasUnitPrice:
+		default:
+			return nil, fmt.Errorf("SimulateFeePayerTransaction arg %d bad type %T", i+1, arg)
+		}
+	}
+	if rawTxn == nil || rawTxn.Variant != MultiAgentWithFeePayerRawTransactionWithDataVariant {
+		return nil, errors.New("transaction is not a fee payer transaction")
+	}
+	feePayerTxn := rawTxn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	if feePayerTxn.FeePayer == nil {
+		return nil, errors.New("fee payer address is not set")
+	}
+
+	senderAuth, err := simulationAuthenticator(sender)
 	if err != nil {
-		return nil, fmt.Errorf("simulate transaction api err: %w", err)
+		return nil, err
+	}
+	secondaryAuths := make([]crypto.AccountAuthenticator, len(feePayerTxn.SecondarySigners))
+	for i := range secondaryAuths {
+		secondaryAuths[i] = *crypto.NewNoAccountAuthenticator()
+	}
+	signedTxn, ok := rawTxn.ToFeePayerSignedTransaction(senderAuth, crypto.NewNoAccountAuthenticator(), secondaryAuths)
+	if !ok {
+		return nil, errors.New("failed to build fee payer signed transaction")
 	}
 
-	return data, nil
+	sblob, err := bcs.Serialize(signedTxn)
+	if err != nil {
+		return nil, err
+	}
+	txns, err := rc.SimulateTransactionBCS(sblob, options...)
+	if err != nil {
+		return nil, err
+	}
+	if len(txns) == 0 {
+		return nil, errors.New("simulate transaction api returned no transactions")
+	}
+	return &FeePayerSimulation{
+		Transaction:  txns[0],
+		FeePayer:     *feePayerTxn.FeePayer,
+		GasUsed:      txns[0].GasUsed,
+		GasUnitPrice: txns[0].GasUnitPrice,
+	}, nil
 }
 
-// GetChainId gets the chain ID of the network
-func (rc *NodeClient) GetChainId() (chainId uint8, err error) {
-	if rc.chainId == 0 {
-		// Calling Info will cache the ChainId
-		info, err := rc.Info()
-		if err != nil {
-			return 0, err
+// SimulatePayload builds a transaction for the payload, and simulates it without sending it to the blockchain
+//
+// By default, the node estimates both the gas unit price and the max gas amount.  Providing [GasUnitPrice] or
+// [MaxGasAmount] uses the value given instead of estimating it, and the estimate options override either way.  A failed
+// simulation is not an error, check Success and VmStatus on the returned transaction.
+//
+// Accepts options:
+//   - [MaxGasAmount]
+//   - [GasUnitPrice]
+//   - [ExpirationSeconds]
+//   - [LedgerExpiration]
+//   - [SequenceNumber]
+//   - [ChainIdOption]
+//   - [EstimateGasUnitPrice]
+//   - [EstimateMaxGasAmount]
+//   - [EstimatePrioritizedGasUnitPrice]
+func (rc *NodeClient) SimulatePayload(sender TransactionSigner, payload TransactionPayload, options ...any) (data *api.UserTransaction, err error) {
+	buildOptions := make([]any, 0, len(options)+1)
+	estimateGasUnitPrice := EstimateGasUnitPrice(true)
+	estimateMaxGasAmount := EstimateMaxGasAmount(true)
+	simOptions := make([]any, 0)
+	haveGasUnitPrice := false
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case MaxGasAmount:
+			estimateMaxGasAmount = false
+			buildOptions = append(buildOptions, value)
+		case GasUnitPrice:
+			estimateGasUnitPrice = false
+			haveGasUnitPrice = true
+			buildOptions = append(buildOptions, value)
+		case ExpirationSeconds, LedgerExpiration, SequenceNumber, ChainIdOption:
+			buildOptions = append(buildOptions, value)
+		case EstimateGasUnitPrice:
+			estimateGasUnitPrice = value
+		case EstimateMaxGasAmount:
+			estimateMaxGasAmount = value
+		case EstimatePrioritizedGasUnitPrice:
+			simOptions = append(simOptions, value)
+		default:
+			err = fmt.Errorf("SimulatePayload arg %d bad type %T", i+1, arg)
+			return
+		}
+	}
+	// The price will be estimated by the node, so there's no need to fetch an estimate to build the transaction
+	if !haveGasUnitPrice && bool(estimateGasUnitPrice) {
+		buildOptions = append(buildOptions, GasUnitPrice(0))
+	}
+	simOptions = append(simOptions, estimateGasUnitPrice, estimateMaxGasAmount)
+
+	rawTxn, err := rc.BuildTransaction(sender.AccountAddress(), payload, buildOptions...)
+	if err != nil {
+		return nil, err
+	}
+	txns, err := rc.SimulateTransaction(rawTxn, sender, simOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if len(txns) == 0 {
+		return nil, errors.New("simulate transaction api returned no transactions")
+	}
+	return txns[0], nil
+}
+
+// DefaultGasBuffer is the default multiplier on the simulated gas used for the max gas amount in
+// [NodeClient.BuildAndSimulate]
+const DefaultGasBuffer = 1.5
+
+// GasBuffer will set the multiplier on the simulated gas used for the max gas amount in [NodeClient.BuildAndSimulate],
+// it must be at least 1
+type GasBuffer float64
+
+// SimulationFailedError is returned when a transaction failed to execute in simulation
+type SimulationFailedError struct {
+	VmStatus    string               // VmStatus of the failure e.g. "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006)"
+	Transaction *api.UserTransaction // Transaction is the simulated transaction
+}
+
+// Error returns a string representation of the SimulationFailedError
+//
+// Implements:
+//   - [error]
+func (e *SimulationFailedError) Error() string {
+	return fmt.Sprintf("transaction simulation failed: %s", e.VmStatus)
+}
+
+// BuildAndSimulate builds a transaction for the payload, simulates it, and returns it with the max gas amount set from
+// the simulated gas used, ready for signing
+//
+// The max gas amount is the gas used multiplied by the [GasBuffer], rounded up.  The gas unit price is from
+// [NodeClient.EstimateGasPrice] unless provided.  The transaction is simulated with [DefaultMaxGasAmount], or the
+// [MaxGasAmount] given, which also caps the final max gas amount.  If the simulation fails, a [SimulationFailedError]
+// with the vm_status is returned instead.
+//
+//	rawTxn, err := client.BuildAndSimulate(sender, payload, GasBuffer(2))
+//	signedTxn, err := rawTxn.SignedTransaction(sender)
+//
+// Accepts options:
+//   - [GasBuffer]
+//   - [MaxGasAmount]
+//   - [GasUnitPrice]
+//   - [ExpirationSeconds]
+//   - [LedgerExpiration]
+//   - [SequenceNumber]
+//   - [SequenceNumberManager] pointer, to take the next sequence number from it
+//   - [ChainIdOption]
+func (rc *NodeClient) BuildAndSimulate(sender TransactionSigner, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error) {
+	gasBuffer := float64(DefaultGasBuffer)
+	maxGasAmount := DefaultMaxGasAmount
+	buildOptions := make([]any, 0, len(options)+1)
+	var sequenceNumberManager *SequenceNumberManager
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case GasBuffer:
+			gasBuffer = float64(value)
+			if math.IsNaN(gasBuffer) || gasBuffer < 1 {
+				return nil, fmt.Errorf("BuildAndSimulate arg %d GasBuffer %v must be at least 1", i+1, gasBuffer)
+			}
+		case MaxGasAmount:
+			maxGasAmount = uint64(value)
+		case *SequenceNumberManager:
+			sequenceNumberManager = value
+			buildOptions = append(buildOptions, value)
+		case GasUnitPrice, ExpirationSeconds, LedgerExpiration, SequenceNumber, ChainIdOption:
+			buildOptions = append(buildOptions, value)
+		default:
+			return nil, fmt.Errorf("BuildAndSimulate arg %d bad type %T", i+1, arg)
 		}
-		return info.ChainId, nil
 	}
-	return rc.chainId, nil
+	buildOptions = append(buildOptions, MaxGasAmount(maxGasAmount))
+
+	rawTxn, err = rc.BuildTransaction(sender.AccountAddress(), payload, buildOptions...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && sequenceNumberManager != nil {
+			// The sequence number was taken but won't be used, so it needs to be fetched again
+			sequenceNumberManager.Reset()
+		}
+	}()
+
+	txns, err := rc.SimulateTransaction(rawTxn, sender)
+	if err != nil {
+		return nil, err
+	}
+	if len(txns) == 0 {
+		return nil, errors.New("simulate transaction api returned no transactions")
+	}
+	simTxn := txns[0]
+	if !simTxn.Success {
+		return nil, &SimulationFailedError{VmStatus: simTxn.VmStatus, Transaction: simTxn}
+	}
+
+	rawTxn.MaxGasAmount = bufferGas(simTxn.GasUsed, gasBuffer, maxGasAmount)
+	return rawTxn, nil
+}
+
+// bufferGas multiplies the gas used by the buffer, rounding up, and capped at maxGasAmount
+func bufferGas(gasUsed uint64, buffer float64, maxGasAmount uint64) uint64 {
+	buffered := math.Ceil(float64(gasUsed) * buffer)
+	if buffered >= float64(maxGasAmount) {
+		return maxGasAmount
+	}
+	return uint64(buffered)
+}
+
+// chainIdCache holds the chain ID fetched from the node, for the life of the client and its copies, as the chain ID of a
+// network never changes
+type chainIdCache struct {
+	mutex   sync.Mutex
+	chainId uint8 // chainId is 0 until fetched
+}
+
+// get returns the cached chain ID, false if it hasn't been fetched
+func (cache *chainIdCache) get() (uint8, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.chainId, cache.chainId != 0
+}
+
+// set caches the chain ID
+func (cache *chainIdCache) set(chainId uint8) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.chainId = chainId
+}
+
+// GetChainId gets the chain ID of the network.  Unless the chain ID was given when creating the client, it is fetched
+// from the node once, and cached for the client and its copies e.g. from [NodeClient.WithContext].  Transactions are built with this chain ID,
+// unless a [ChainIdOption] is given.
+func (rc *NodeClient) GetChainId() (chainId uint8, err error) {
+	if chainId, ok := rc.cachedChainId(); ok {
+		return chainId, nil
+	}
+	// Calling Info will cache the ChainId
+	info, err := rc.Info()
+	if err != nil {
+		return 0, err
+	}
+	return info.ChainId, nil
+}
+
+// SetChainId overrides the chain ID of the network for all future transactions built, 0 to use the chain ID of the node,
+// see [NodeClient.GetChainId]
+func (rc *NodeClient) SetChainId(chainId uint8) {
+	rc.chainId = chainId
+}
+
+// cachedChainId returns the chain ID set for the client, or else the one fetched from the node, false if there is
+// neither
+func (rc *NodeClient) cachedChainId() (uint8, bool) {
+	if rc.chainId != 0 {
+		return rc.chainId, true
+	}
+	return rc.fetchedChainId.get()
+}
+
+// cacheChainId caches the chain ID fetched from the node, see [NodeClient.GetChainId]
+func (rc *NodeClient) cacheChainId(chainId uint8) {
+	if chainId != 0 {
+		rc.fetchedChainId.set(chainId)
+	}
 }
 
 // MaxGasAmount will set the max gas amount in gas units for a transaction
@@ -695,7 +1433,8 @@ type MaxGasAmount uint64
 // GasUnitPrice will set the gas unit price in octas (1/10^8 APT) for a transaction
 type GasUnitPrice uint64
 
-// ExpirationSeconds will set the number of seconds from the current time to expire a transaction
+// ExpirationSeconds will set the number of seconds from the current time to expire a transaction, rather than after the
+// ledger's timestamp as by default, see [NodeClient.SetLedgerExpiration]
 type ExpirationSeconds int64
 
 // FeePayer will set the fee payer for a transaction
@@ -719,18 +1458,22 @@ type ChainIdOption uint8
 //   - [MaxGasAmount]
 //   - [GasUnitPrice]
 //   - [ExpirationSeconds]
+//   - [LedgerExpiration]
 //   - [SequenceNumber]
+//   - [SequenceNumberManager] pointer, to take the next sequence number from it
 //   - [ChainIdOption]
 func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransaction, err error) {
 
 	maxGasAmount := DefaultMaxGasAmount
 	gasUnitPrice := DefaultGasUnitPrice
 	expirationSeconds := DefaultExpirationSeconds
+	ledgerExpiration := rc.ledgerExpiration
 	sequenceNumber := uint64(0)
 	haveSequenceNumber := false
 	chainId := uint8(0)
 	haveChainId := false
 	haveGasUnitPrice := false
+	var sequenceNumberManager *SequenceNumberManager
 
 	for opti, option := range options {
 		switch ovalue := option.(type) {
@@ -745,9 +1488,18 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 				err = errors.New("ExpirationSeconds cannot be less than 0")
 				return nil, err
 			}
+			ledgerExpiration = 0
+		case LedgerExpiration:
+			ledgerExpiration = time.Duration(ovalue)
+			if ledgerExpiration <= 0 {
+				err = errors.New("LedgerExpiration must be more than 0")
+				return nil, err
+			}
 		case SequenceNumber:
 			sequenceNumber = uint64(ovalue)
 			haveSequenceNumber = true
+		case *SequenceNumberManager:
+			sequenceNumberManager = ovalue
 		case ChainIdOption:
 			chainId = uint8(ovalue)
 			haveChainId = true
@@ -757,7 +1509,20 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 		}
 	}
 
-	return rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
+	if !haveSequenceNumber && sequenceNumberManager != nil {
+		sequenceNumber, err = sequenceNumberManager.nextFor(sender)
+		if err != nil {
+			return nil, err
+		}
+		haveSequenceNumber = true
+	}
+
+	rawTxn, err = rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, ledgerExpiration, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
+	if err != nil && sequenceNumberManager != nil {
+		// The sequence number was taken but won't be used, so it needs to be fetched again
+		sequenceNumberManager.Reset()
+	}
+	return rawTxn, err
 }
 
 // BuildTransactionMultiAgent builds a raw transaction for signing with fee payer or multi-agent
@@ -768,7 +1533,9 @@ func (rc *NodeClient) BuildTransaction(sender AccountAddress, payload Transactio
 //   - [MaxGasAmount]
 //   - [GasUnitPrice]
 //   - [ExpirationSeconds]
+//   - [LedgerExpiration]
 //   - [SequenceNumber]
+//   - [SequenceNumberManager] pointer, to take the next sequence number from it
 //   - [ChainIdOption]
 //   - [FeePayer]
 //   - [AdditionalSigners]
@@ -777,11 +1544,13 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 	maxGasAmount := DefaultMaxGasAmount
 	gasUnitPrice := DefaultGasUnitPrice
 	expirationSeconds := DefaultExpirationSeconds
+	ledgerExpiration := rc.ledgerExpiration
 	sequenceNumber := uint64(0)
 	haveSequenceNumber := false
 	chainId := uint8(0)
 	haveChainId := false
 	haveGasUnitPrice := false
+	var sequenceNumberManager *SequenceNumberManager
 
 	var feePayer *AccountAddress
 	var additionalSigners []AccountAddress
@@ -799,9 +1568,18 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 				err = errors.New("ExpirationSeconds cannot be less than 0")
 				return nil, err
 			}
+			ledgerExpiration = 0
+		case LedgerExpiration:
+			ledgerExpiration = time.Duration(ovalue)
+			if ledgerExpiration <= 0 {
+				err = errors.New("LedgerExpiration must be more than 0")
+				return nil, err
+			}
 		case SequenceNumber:
 			sequenceNumber = uint64(ovalue)
 			haveSequenceNumber = true
+		case *SequenceNumberManager:
+			sequenceNumberManager = ovalue
 		case ChainIdOption:
 			chainId = uint8(ovalue)
 			haveChainId = true
@@ -815,9 +1593,21 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 		}
 	}
 
+	if !haveSequenceNumber && sequenceNumberManager != nil {
+		sequenceNumber, err = sequenceNumberManager.nextFor(sender)
+		if err != nil {
+			return nil, err
+		}
+		haveSequenceNumber = true
+	}
+
 	// Build the base raw transaction
-	rawTxn, err := rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
+	rawTxn, err := rc.buildTransactionInner(sender, payload, maxGasAmount, gasUnitPrice, haveGasUnitPrice, expirationSeconds, ledgerExpiration, sequenceNumber, haveSequenceNumber, chainId, haveChainId)
 	if err != nil {
+		if sequenceNumberManager != nil {
+			// The sequence number was taken but won't be used, so it needs to be fetched again
+			sequenceNumberManager.Reset()
+		}
 		return nil, err
 	}
 
@@ -842,6 +1632,43 @@ func (rc *NodeClient) BuildTransactionMultiAgent(sender AccountAddress, payload
 	}
 }
 
+// BuildFeePayerTransaction builds a raw transaction for signing by the sender and a fee payer
+//
+// The fee payer can be [AccountZero] if it isn't known when the sender signs.  Accepts the same options as
+// [NodeClient.BuildTransactionMultiAgent], other than [FeePayer].
+//
+//	rawTxn, err := client.BuildFeePayerTransaction(sender.Address, payload, sponsor.Address)
+//	signedTxn, err := rawTxn.FeePayerSignedTransaction(sender, sponsor)
+func (rc *NodeClient) BuildFeePayerTransaction(sender AccountAddress, payload TransactionPayload, feePayer AccountAddress, options ...any) (rawTxn *RawTransactionWithData, err error) {
+	for i, option := range options {
+		if _, ok := option.(FeePayer); ok {
+			return nil, fmt.Errorf("BuildFeePayerTransaction arg %d FeePayer is not allowed, the fee payer is already set", i+4)
+		}
+	}
+	return rc.BuildTransactionMultiAgent(sender, payload, append(options, FeePayer(&feePayer))...)
+}
+
+// BuildMultiAgentTransaction builds a raw transaction for signing by the sender and the secondary signers
+//
+// Accepts the same options as [NodeClient.BuildTransactionMultiAgent], other than [FeePayer] and [AdditionalSigners].
+//
+//	rawTxn, err := client.BuildMultiAgentTransaction(sender.Address, []AccountAddress{receiver.Address}, payload)
+//	signedTxn, err := rawTxn.MultiAgentSignedTransaction(sender, receiver)
+func (rc *NodeClient) BuildMultiAgentTransaction(sender AccountAddress, secondarySigners []AccountAddress, payload TransactionPayload, options ...any) (rawTxn *RawTransactionWithData, err error) {
+	for i, option := range options {
+		switch option.(type) {
+		case FeePayer:
+			return nil, fmt.Errorf("BuildMultiAgentTransaction arg %d FeePayer is not allowed, use BuildFeePayerTransaction", i+4)
+		case AdditionalSigners:
+			return nil, fmt.Errorf("BuildMultiAgentTransaction arg %d AdditionalSigners is not allowed, the secondary signers are already set", i+4)
+		}
+	}
+	if secondarySigners == nil {
+		secondarySigners = []AccountAddress{}
+	}
+	return rc.BuildTransactionMultiAgent(sender, payload, append(options, AdditionalSigners(secondarySigners))...)
+}
+
 func (rc *NodeClient) buildTransactionInner(
 	sender AccountAddress,
 	payload TransactionPayload,
@@ -849,6 +1676,7 @@ func (rc *NodeClient) buildTransactionInner(
 	gasUnitPrice uint64,
 	haveGasUnitPrice bool,
 	expirationSeconds int64,
+	ledgerExpiration time.Duration,
 	sequenceNumber uint64,
 	haveSequenceNumber bool,
 	chainId uint8,
@@ -872,10 +1700,15 @@ func (rc *NodeClient) buildTransactionInner(
 		}()
 	}
 
-	// Fetch ChainId which may be cached
+	// Fetch ChainId which may be cached, or comes with the ledger timestamp below
 	var chainIdErrChannel chan error
+	needChainId := false
 	if !haveChainId {
-		if rc.chainId == 0 {
+		if cached, ok := rc.cachedChainId(); ok {
+			chainId = cached
+		} else if ledgerExpiration > 0 {
+			needChainId = true
+		} else {
 			chainIdErrChannel = make(chan error, 1)
 			go func() {
 				chain, innerErr := rc.GetChainId()
@@ -887,8 +1720,6 @@ func (rc *NodeClient) buildTransactionInner(
 				}
 				close(chainIdErrChannel)
 			}()
-		} else {
-			chainId = rc.chainId
 		}
 	}
 
@@ -915,6 +1746,28 @@ func (rc *NodeClient) buildTransactionInner(
 		}()
 	}
 
+	// Fetch the ledger timestamp for the expiration, unless it's from the local clock, along with the ChainId if needed
+	var expirationTimestampSeconds uint64
+	var expirationErrChannel chan error
+	if ledgerExpiration > 0 {
+		expirationErrChannel = make(chan error, 1)
+		go func() {
+			info, innerErr := rc.Info()
+			if innerErr == nil {
+				expirationTimestampSeconds, innerErr = ledgerExpirationSeconds(info, ledgerExpiration)
+			}
+			if innerErr != nil {
+				expirationErrChannel <- innerErr
+			} else {
+				if needChainId {
+					chainId = info.ChainId
+				}
+				expirationErrChannel <- nil
+			}
+			close(expirationErrChannel)
+		}()
+	}
+
 	// TODO: optionally simulate for max gas
 	// Wait on the errors
 	if chainIdErrChannel != nil {
@@ -935,8 +1788,14 @@ func (rc *NodeClient) buildTransactionInner(
 			return nil, gasPriceErr
 		}
 	}
-
-	expirationTimestampSeconds := uint64(time.Now().Unix() + expirationSeconds)
+	if expirationErrChannel != nil {
+		expirationErr := <-expirationErrChannel
+		if expirationErr != nil {
+			return nil, expirationErr
+		}
+	} else {
+		expirationTimestampSeconds = uint64(time.Now().Unix() + expirationSeconds)
+	}
 
 	// Base raw transaction used for all requests
 	rawTxn = &RawTransaction{
@@ -963,14 +1822,18 @@ func (vp *ViewPayload) MarshalBCS(ser *bcs.Serializer) {
 	vp.Module.MarshalBCS(ser)
 	ser.WriteString(vp.Function)
 	bcs.SerializeSequence(vp.ArgTypes, ser)
-	ser.Uleb128(uint32(len(vp.Args)))
-	for _, a := range vp.Args {
-		ser.WriteBytes(a)
-	}
+	bcs.SerializeSequenceWithFunction(vp.Args, ser, func(ser *bcs.Serializer, arg []byte) {
+		ser.WriteBytes(arg)
+	})
 }
 
 // View calls a view function on the blockchain and returns the return value of the function
 func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) (data []any, err error) {
+	return rc.ViewCtx(rc.context(), payload, ledgerVersion...)
+}
+
+// ViewCtx is [NodeClient.View], but the request is bound to the context
+func (rc *NodeClient) ViewCtx(ctx context.Context, payload *ViewPayload, ledgerVersion ...uint64) (data []any, err error) {
 	serializer := bcs.Serializer{}
 	payload.MarshalBCS(&serializer)
 	err = serializer.Error()
@@ -980,13 +1843,9 @@ func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) (data
 	sblob := serializer.ToBytes()
 	bodyReader := bytes.NewReader(sblob)
 	au := rc.baseUrl.JoinPath("view")
-	if len(ledgerVersion) > 0 {
-		params := url.Values{}
-		params.Set("ledger_version", strconv.FormatUint(ledgerVersion[0], 10))
-		au.RawQuery = params.Encode()
-	}
+	withLedgerVersion(au, ledgerVersion)
 
-	data, err = Post[[]any](rc, au.String(), ContentTypeAptosViewFunctionBcs, bodyReader)
+	data, err = post[[]any](ctx, rc, au.String(), ContentTypeAptosViewFunctionBcs, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("view function api err: %w", err)
 	}
@@ -994,16 +1853,28 @@ func (rc *NodeClient) View(payload *ViewPayload, ledgerVersion ...uint64) (data
 }
 
 // EstimateGasPrice estimates the gas price given on-chain data
-// TODO: add caching for some period of time
+//
+// The estimate is cached for [DefaultGasEstimateCacheTTL], which can be changed with [NodeClient.SetGasEstimateCacheTTL]
 func (rc *NodeClient) EstimateGasPrice() (info EstimateGasInfo, err error) {
+	if info, ok := rc.gasEstimate.get(); ok {
+		return info, nil
+	}
 	au := rc.baseUrl.JoinPath("estimate_gas_price")
 	info, err = Get[EstimateGasInfo](rc, au.String())
 	if err != nil {
 		return info, fmt.Errorf("estimate gas price err: %w", err)
 	}
+	rc.gasEstimate.set(info)
 	return info, nil
 }
 
+// SetGasEstimateCacheTTL sets how long a gas estimate is cached by [NodeClient.EstimateGasPrice], 0 disables the cache
+//
+//	client.SetGasEstimateCacheTTL(5 * time.Second)
+func (rc *NodeClient) SetGasEstimateCacheTTL(ttl time.Duration) {
+	rc.gasEstimate.setTTL(ttl)
+}
+
 // AccountAPTBalance fetches the balance of an account of APT.  Response is in octas or 1/10^8 APT.
 func (rc *NodeClient) AccountAPTBalance(account AccountAddress) (balance uint64, err error) {
 	accountBytes, err := bcs.Serialize(&account)
@@ -1021,7 +1892,14 @@ func (rc *NodeClient) AccountAPTBalance(account AccountAddress) (balance uint64,
 	if err != nil {
 		return 0, err
 	}
-	return StrToUint64(values[0].(string))
+	if len(values) != 1 {
+		return 0, fmt.Errorf("bad balance response, expected 1 value, got %d", len(values))
+	}
+	balanceStr, ok := values[0].(string)
+	if !ok {
+		return 0, fmt.Errorf("bad balance response type %T", values[0])
+	}
+	return StrToUint64(balanceStr)
 }
 
 // BuildSignAndSubmitTransaction builds, signs, and submits a transaction to the network
@@ -1052,38 +1930,30 @@ func (rc *NodeClient) NodeHealthCheck(durationSecs ...uint64) (api.HealthCheckRe
 
 // Get makes a GET request to the endpoint and parses the response into the given type with JSON
 func Get[T any](rc *NodeClient, getUrl string) (out T, err error) {
-	out, _, err = GetWithResp[T](rc, getUrl)
+	return get[T](rc.context(), rc, getUrl)
+}
+
+// get is [Get], but the request is bound to the context
+func get[T any](ctx context.Context, rc *NodeClient, getUrl string) (out T, err error) {
+	out, _, err = getWithResp[T](ctx, rc, getUrl)
 	return
 }
 
 // GetWithResp makes a GET request to the endpoint and parses the response into the given type with JSON
 func GetWithResp[T any](rc *NodeClient, getUrl string) (out T, response *http.Response, err error) {
-	req, err := http.NewRequest("GET", getUrl, nil)
+	return getWithResp[T](rc.context(), rc, getUrl)
+}
+
+// getWithResp is [GetWithResp], but the request is bound to the context
+func getWithResp[T any](ctx context.Context, rc *NodeClient, getUrl string) (out T, response *http.Response, err error) {
+	req, err := rc.newRequest(ctx, "GET", getUrl, "", "", nil)
 	if err != nil {
 		return out, nil, err
 	}
-	req.Header.Set(ClientHeader, ClientHeaderValue)
-
-	// Set all preset headers
-	for key, value := range rc.headers {
-		req.Header.Set(key, value)
-	}
-
-	response, err = rc.client.Do(req)
+	blob, response, err := rc.doRead(req)
 	if err != nil {
-		err = fmt.Errorf("GET %s, %w", getUrl, err)
-		return out, response, err
-	}
-
-	if response.StatusCode >= 400 {
-		err = NewHttpError(response)
 		return out, response, err
 	}
-	blob, err := io.ReadAll(response.Body)
-	if err != nil {
-		return out, response, fmt.Errorf("error getting response data, %w", err)
-	}
-	_ = response.Body.Close()
 	err = json.Unmarshal(blob, &out)
 	if err != nil {
 		return out, response, err
@@ -1093,71 +1963,125 @@ func GetWithResp[T any](rc *NodeClient, getUrl string) (out T, response *http.Re
 
 // GetBCS makes a GET request to the endpoint and parses the response into the given type with BCS
 func (rc *NodeClient) GetBCS(getUrl string) (out []byte, err error) {
-	req, err := http.NewRequest("GET", getUrl, nil)
+	return rc.getBCS(rc.context(), getUrl)
+}
+
+// getBCS is [NodeClient.GetBCS], but the request is bound to the context
+func (rc *NodeClient) getBCS(ctx context.Context, getUrl string) (out []byte, err error) {
+	req, err := rc.newRequest(ctx, "GET", getUrl, "", "application/x-bcs", nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Accept", "application/x-bcs")
-	req.Header.Set(ClientHeader, ClientHeaderValue)
-
-	// Set all preset headers
-	for key, value := range rc.headers {
-		req.Header.Set(key, value)
-	}
+	out, _, err = rc.doRead(req)
+	return out, err
+}
 
-	response, err := rc.client.Do(req)
+// postBCS makes a POST request bound to the context to the endpoint with the given body and returns the BCS response
+func (rc *NodeClient) postBCS(ctx context.Context, postUrl string, contentType string, body io.Reader) (out []byte, err error) {
+	req, err := rc.newRequest(ctx, "POST", postUrl, contentType, "application/x-bcs", body)
 	if err != nil {
-		err = fmt.Errorf("GET %s, %w", getUrl, err)
-		return
-	}
-	if response.StatusCode >= 400 {
-		err = NewHttpError(response)
-		return
-	}
-	blob, err := io.ReadAll(response.Body)
-	if err != nil {
-		err = fmt.Errorf("error getting response data, %w", err)
-		return
+		return nil, err
 	}
-	_ = response.Body.Close()
-	return blob, nil
+	out, _, err = rc.doRead(req)
+	return out, err
 }
 
 // Post makes a POST request to the endpoint with the given body and parses the response into the given type with JSON
 func Post[T any](rc *NodeClient, postUrl string, contentType string, body io.Reader) (data T, err error) {
+	return post[T](rc.context(), rc, postUrl, contentType, body)
+}
+
+// post is [Post], but the request is bound to the context
+func post[T any](ctx context.Context, rc *NodeClient, postUrl string, contentType string, body io.Reader) (data T, err error) {
 	if body == nil {
 		body = http.NoBody
 	}
-	req, err := http.NewRequest("POST", postUrl, body)
+	req, err := rc.newRequest(ctx, "POST", postUrl, contentType, "application/json", body)
+	if err != nil {
+		return data, err
+	}
+	blob, _, err := rc.doRead(req)
 	if err != nil {
 		return data, err
 	}
-	req.Header.Set("Content-Type", contentType)
+	err = json.Unmarshal(blob, &data)
+	return data, err
+}
+
+// newRequest creates a request to the node bound to the context, with the client's headers.  The content type and
+// accept headers are only set if given.
+func (rc *NodeClient) newRequest(ctx context.Context, method string, requestUrl string, contentType string, accept string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
 	req.Header.Set(ClientHeader, ClientHeaderValue)
 
 	// Set all preset headers
 	for key, value := range rc.headers {
 		req.Header.Set(key, value)
 	}
+	return req, nil
+}
 
-	response, err := rc.client.Do(req)
+// doRead sends the request with [NodeClient.do], and reads the whole response body.  The body is closed on every
+// path, and an error status is returned as an [HttpError].
+func (rc *NodeClient) doRead(req *http.Request) (blob []byte, response *http.Response, err error) {
+	response, err = rc.do(req)
 	if err != nil {
-		err = fmt.Errorf("POST %s, %w", postUrl, err)
-		return data, err
+		if response != nil {
+			_ = response.Body.Close()
+		}
+		return nil, response, fmt.Errorf("%s %s, %w", req.Method, req.URL.String(), err)
 	}
 	if response.StatusCode >= 400 {
-		err = NewHttpError(response)
-		return data, err
+		// The body is read and closed into the error
+		return nil, response, NewHttpError(response)
 	}
-	blob, err := io.ReadAll(response.Body)
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	blob, err = io.ReadAll(response.Body)
 	if err != nil {
-		err = fmt.Errorf("error getting response data, %w", err)
-		return data, err
+		return nil, response, fmt.Errorf("error getting response data, %w", err)
 	}
-	_ = response.Body.Close()
+	return blob, response, nil
+}
 
-	err = json.Unmarshal(blob, &data)
-	return data, err
+// do sends the request, retrying if there is a retry policy, and logging each attempt if there is a logger.  Each
+// attempt has the request timeout, if there is one.  Responses may be gzip compressed, and large requests are compressed
+// if set up, see [NodeClient.SetGzipRequests].
+func (rc *NodeClient) do(req *http.Request) (*http.Response, error) {
+	var onAttempt attemptHook
+	if rc.logger != nil {
+		onAttempt = newRequestLogger(rc.logger, req)
+	}
+	if rc.gzipRequestMinSize > 0 {
+		var err error
+		req, err = gzipRequest(req, rc.gzipRequestMinSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	send := withGzipResponses(rc.client.Do)
+	if rc.requestTimeout > 0 {
+		send = withRequestTimeout(send, rc.requestTimeout)
+	}
+	if rc.retryPolicy == nil {
+		start := time.Now()
+		response, err := send(req)
+		if onAttempt != nil {
+			onAttempt(1, response, err, time.Since(start))
+		}
+		return response, err
+	}
+	return rc.retryPolicy.do(send, req, onAttempt)
 }
 
 // ConcResponse is a concurrent response wrapper as a return type for all APIs.  It is meant to specifically be used in channels.
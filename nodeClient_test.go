@@ -1,7 +1,22 @@
 package aptos
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -20,3 +35,1511 @@ func TestPollForTransaction(t *testing.T) {
 	assert.Less(t, dt, 20*time.Millisecond)
 	assert.Error(t, err)
 }
+
+// newMockServerClient creates a client pointed at a local mock node server
+func newMockServerClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewClient(NetworkConfig{
+		Name:    "mock",
+		ChainId: 4,
+		NodeUrl: server.URL + "/v1",
+	})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestAccountResourcesBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := maxInFlight.Load()
+			if current <= old || maxInFlight.CompareAndSwap(old, current) {
+				break
+			}
+		}
+
+		resourceType, _ := url.PathUnescape(r.URL.EscapedPath()[strings.LastIndex(r.URL.EscapedPath(), "/")+1:])
+		// Reverse the latency, so later resources complete first
+		index, _ := strconv.Atoi(strings.TrimPrefix(resourceType, "0x1::test::Resource"))
+		time.Sleep(time.Duration(20-index) * time.Millisecond)
+		_, _ = fmt.Fprintf(w, `{"type":"%s","data":{"index":"%d"}}`, resourceType, index)
+	})
+
+	resourceTypes := make([]string, 20)
+	for i := range resourceTypes {
+		resourceTypes[i] = fmt.Sprintf("0x1::test::Resource%d", i)
+	}
+
+	resources, err := client.AccountResourcesBatch(AccountOne, resourceTypes, BatchWorkers(4))
+	assert.NoError(t, err)
+	assert.Len(t, resources, len(resourceTypes))
+	for i, resource := range resources {
+		assert.Equal(t, resourceTypes[i], resource.Type)
+		assert.Equal(t, strconv.Itoa(i), resource.Data["index"])
+	}
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(4))
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+
+	// Default number of workers
+	maxInFlight.Store(0)
+	resources, err = client.AccountResourcesBatch(AccountOne, resourceTypes)
+	assert.NoError(t, err)
+	assert.Len(t, resources, len(resourceTypes))
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(DefaultBatchWorkers))
+
+	// Empty input
+	resources, err = client.AccountResourcesBatch(AccountOne, []string{})
+	assert.NoError(t, err)
+	assert.Empty(t, resources)
+
+	// Bad options
+	_, err = client.AccountResourcesBatch(AccountOne, resourceTypes, BatchWorkers(0))
+	assert.Error(t, err)
+	_, err = client.AccountResourcesBatch(AccountOne, resourceTypes, 5)
+	assert.Error(t, err)
+}
+
+func TestAccountResourcesBatch_Failures(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		resourceType, _ := url.PathUnescape(r.URL.EscapedPath()[strings.LastIndex(r.URL.EscapedPath(), "/")+1:])
+		if strings.HasSuffix(resourceType, "Missing") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, `{"message":"Resource not found","error_code":"resource_not_found","vm_error_code":null}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"type":"%s","data":{}}`, resourceType)
+	})
+
+	resourceTypes := []string{"0x1::test::A", "0x1::test::Missing", "0x1::test::B", "0x1::other::Missing"}
+	resources, err := client.AccountResourcesBatch(AccountOne, resourceTypes)
+	assert.Error(t, err)
+	assert.Nil(t, resources)
+	assert.Contains(t, err.Error(), "0x1::test::Missing")
+	assert.Contains(t, err.Error(), "0x1::other::Missing")
+
+	// Both errors are joined, and can still be inspected
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+
+	// Partial results keep the successful resources
+	resources, err = client.AccountResourcesBatch(AccountOne, resourceTypes, PartialResults(true))
+	assert.Error(t, err)
+	assert.Len(t, resources, len(resourceTypes))
+	for i, resource := range resources {
+		assert.Equal(t, resourceTypes[i], resource.Type)
+	}
+	assert.NotNil(t, resources[0].Data)
+	assert.Nil(t, resources[1].Data)
+	assert.NotNil(t, resources[2].Data)
+	assert.Nil(t, resources[3].Data)
+}
+
+// buildSignedTransferForTest builds an APT transfer offline, without needing a node
+func buildSignedTransferForTest(t *testing.T, sender *Account) *SignedTransaction {
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     sender.AccountAddress(),
+		SequenceNumber:             5,
+		Payload:                    TransactionPayload{Payload: payload},
+		MaxGasAmount:               DefaultMaxGasAmount,
+		GasUnitPrice:               DefaultGasUnitPrice,
+		ExpirationTimestampSeconds: uint64(time.Now().Unix() + DefaultExpirationSeconds),
+		ChainId:                    4,
+	}
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+	return signedTxn
+}
+
+func TestSubmitTransactionBCS(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTxn := buildSignedTransferForTest(t, sender)
+	signedTxnBytes, err := bcs.Serialize(signedTxn)
+	assert.NoError(t, err)
+	expectedHash, err := signedTxn.Hash()
+	assert.NoError(t, err)
+
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/transactions", r.URL.Path)
+		assert.Equal(t, ContentTypeAptosSignedTxnBcs, r.Header.Get("Content-Type"))
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+
+		// Round trip the submitted transaction
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, signedTxnBytes, body)
+		submitted := &SignedTransaction{Transaction: &RawTransaction{}, Authenticator: &TransactionAuthenticator{}}
+		err = bcs.Deserialize(submitted, body)
+		assert.NoError(t, err)
+		assert.NoError(t, submitted.Verify())
+		hash, err := submitted.Hash()
+		assert.NoError(t, err)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, `{
+			"hash": "%s",
+			"sender": "%s",
+			"sequence_number": "5",
+			"max_gas_amount": "100000",
+			"gas_unit_price": "100",
+			"expiration_timestamp_secs": "1000",
+			"payload": {"type": "entry_function_payload", "function": "0x1::aptos_account::transfer", "type_arguments": [], "arguments": ["0x2", "100"]},
+			"signature": null
+		}`, hash, submitted.Transaction.(*RawTransaction).Sender.String())
+	})
+
+	response, err := client.SubmitTransactionBCS(signedTxnBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash, response.Hash.String())
+	assert.Equal(t, sender.AccountAddress(), *response.Sender)
+	assert.Equal(t, uint64(5), response.SequenceNumber)
+
+	// The non-BCS API gives the same result
+	response, err = client.SubmitTransaction(signedTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash, response.Hash.String())
+}
+
+func TestSimulateTransactionBCS(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTxn := buildSignedTransferForTest(t, sender)
+	simulationTxn, err := signedTxn.Transaction.(*RawTransaction).SignedTransactionWithAuthenticator(sender.SimulationAuthenticator())
+	assert.NoError(t, err)
+	signedTxnBytes, err := bcs.Serialize(simulationTxn)
+	assert.NoError(t, err)
+
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/transactions/simulate", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("estimate_max_gas_amount"))
+		assert.Equal(t, ContentTypeAptosSignedTxnBcs, r.Header.Get("Content-Type"))
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, signedTxnBytes, body)
+		_, _ = fmt.Fprint(w, `[{
+			"type": "user_transaction",
+			"version": "1",
+			"hash": "0xae3f1f751c6cacd61f46054a5e9e39ca9f094802875befbc54ceecbcdf6eff69",
+			"accumulator_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+			"state_change_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+			"event_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+			"gas_used": "9",
+			"success": true,
+			"vm_status": "Executed successfully",
+			"changes": [],
+			"events": [],
+			"sender": "0x1",
+			"sequence_number": "5",
+			"max_gas_amount": "100000",
+			"gas_unit_price": "100",
+			"expiration_timestamp_secs": "1000",
+			"payload": {"type": "entry_function_payload", "function": "0x1::aptos_account::transfer", "type_arguments": [], "arguments": ["0x2", "100"]},
+			"signature": null,
+			"timestamp": "1000"
+		}]`)
+	})
+
+	response, err := client.SimulateTransactionBCS(signedTxnBytes, EstimateMaxGasAmount(true))
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.True(t, response[0].Success)
+	assert.Equal(t, uint64(9), response[0].GasUsed)
+
+	_, err = client.SimulateTransactionBCS(signedTxnBytes, 5)
+	assert.Error(t, err)
+}
+
+func TestSubmitTransactionBCSResponse(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTxn := buildSignedTransferForTest(t, sender)
+	signedTxnBytes, err := bcs.Serialize(signedTxn)
+	assert.NoError(t, err)
+	bcsResponse := []byte{0x01, 0x02, 0x03}
+
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, ContentTypeAptosSignedTxnBcs, r.Header.Get("Content-Type"))
+		assert.Equal(t, "application/x-bcs", r.Header.Get("Accept"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, signedTxnBytes, body)
+
+		switch r.URL.Path {
+		case "/v1/transactions":
+			w.WriteHeader(http.StatusAccepted)
+		case "/v1/transactions/simulate":
+			assert.Equal(t, "true", r.URL.Query().Get("estimate_gas_unit_price"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/x-bcs")
+		_, _ = w.Write(bcsResponse)
+	})
+
+	response, err := client.SubmitTransactionBCSResponse(signedTxnBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, bcsResponse, response)
+
+	response, err = client.SimulateTransactionBCSResponse(signedTxnBytes, EstimateGasUnitPrice(true))
+	assert.NoError(t, err)
+	assert.Equal(t, bcsResponse, response)
+
+	_, err = client.SimulateTransactionBCSResponse(signedTxnBytes, 5)
+	assert.Error(t, err)
+}
+
+func TestSimulateFeePayerTransaction(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	sponsor, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTransfer := buildSignedTransferForTest(t, sender)
+	rawTxn := NewFeePayerTransaction(signedTransfer.Transaction.(*RawTransaction), sponsor.AccountAddress())
+	senderAddress := sender.AccountAddress()
+	sponsorAddress := sponsor.AccountAddress()
+
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/transactions/simulate", r.URL.Path)
+		assert.Equal(t, url.Values{
+			"estimate_gas_unit_price": []string{"true"},
+			"estimate_max_gas_amount": []string{"true"},
+		}, r.URL.Query())
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		// The sender has a zero signature, and the fee payer has no authenticator
+		signedTxn := &SignedTransaction{Transaction: &RawTransaction{}, Authenticator: &TransactionAuthenticator{}}
+		err = bcs.Deserialize(signedTxn, body)
+		assert.NoError(t, err)
+		assert.Equal(t, TransactionAuthenticatorFeePayer, signedTxn.Authenticator.Variant)
+		auth := signedTxn.Authenticator.Auth.(*FeePayerTransactionAuthenticator)
+		assert.Equal(t, sender.SimulationAuthenticator(), auth.Sender)
+		assert.Equal(t, sponsor.AccountAddress(), *auth.FeePayer)
+		assert.Equal(t, crypto.NewNoAccountAuthenticator(), auth.FeePayerAuthenticator)
+		assert.Empty(t, auth.SecondarySigners)
+		assert.Error(t, signedTxn.Verify())
+
+		_, _ = fmt.Fprintf(w, `[{
+			"type": "user_transaction",
+			"version": "1",
+			"hash": "0xae3f1f751c6cacd61f46054a5e9e39ca9f094802875befbc54ceecbcdf6eff69",
+			"accumulator_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+			"state_change_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+			"event_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+			"gas_used": "12",
+			"success": true,
+			"vm_status": "Executed successfully",
+			"changes": [],
+			"events": [],
+			"sender": "%s",
+			"sequence_number": "5",
+			"max_gas_amount": "100000",
+			"gas_unit_price": "150",
+			"expiration_timestamp_secs": "1000",
+			"payload": {"type": "entry_function_payload", "function": "0x1::aptos_account::transfer", "type_arguments": [], "arguments": ["0x2", "100"]},
+			"signature": {
+				"type": "fee_payer_signature",
+				"sender": {"type": "ed25519_signature", "public_key": "%s", "signature": "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+				"secondary_signer_addresses": [],
+				"secondary_signers": [],
+				"fee_payer_address": "%s",
+				"fee_payer_signer": {"type": "no_account_signature"}
+			},
+			"timestamp": "1000"
+		}]`, senderAddress.String(), sender.PubKey().ToHex(), sponsorAddress.String())
+	})
+
+	sim, err := client.SimulateFeePayerTransaction(rawTxn, sender, EstimateGasUnitPrice(true), EstimateMaxGasAmount(true))
+	assert.NoError(t, err)
+	assert.True(t, sim.Transaction.Success)
+	assert.Equal(t, sponsor.AccountAddress(), sim.FeePayer)
+	assert.Equal(t, uint64(12), sim.GasUsed)
+	assert.Equal(t, uint64(150), sim.GasUnitPrice)
+	assert.Equal(t, uint64(1800), sim.FeePayerOctas())
+	assert.Equal(t, api.SignatureVariantFeePayer, sim.Transaction.Signature.Type)
+
+	// Bad options
+	_, err = client.SimulateFeePayerTransaction(rawTxn, sender, EstimateGasUnitPrice(true), 5)
+	assert.EqualError(t, err, "SimulateFeePayerTransaction arg 2 bad type int")
+}
+
+func TestSimulateFeePayerTransaction_Invalid(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTransfer := buildSignedTransferForTest(t, sender)
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+
+	// Not a fee payer transaction
+	multiAgentTxn := NewMultiAgentTransaction(signedTransfer.Transaction.(*RawTransaction), AccountTwo)
+	_, err = client.SimulateFeePayerTransaction(multiAgentTxn, sender)
+	assert.Error(t, err)
+
+	// No fee payer address
+	feePayerTxn := NewFeePayerTransaction(signedTransfer.Transaction.(*RawTransaction), AccountZero)
+	feePayerTxn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData).FeePayer = nil
+	_, err = client.SimulateFeePayerTransaction(feePayerTxn, sender)
+	assert.ErrorContains(t, err, "fee payer address is not set")
+}
+
+// newSimulationServerClient creates a client against a mock server, which checks the simulation query params and
+// responds with the simulated transaction's success and vm_status
+func newSimulationServerClient(t *testing.T, sender *Account, expectedParams url.Values, success bool, vmStatus string) *Client {
+	address := sender.AccountAddress()
+	return newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + address.String():
+			_, _ = fmt.Fprint(w, `{"sequence_number":"5","authentication_key":"`+address.String()+`"}`)
+		case "/v1/transactions/simulate":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, expectedParams, r.URL.Query())
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			signedTxn := &SignedTransaction{Transaction: &RawTransaction{}, Authenticator: &TransactionAuthenticator{}}
+			err = bcs.Deserialize(signedTxn, body)
+			assert.NoError(t, err)
+			rawTxn := signedTxn.Transaction.(*RawTransaction)
+			assert.Equal(t, uint64(5), rawTxn.SequenceNumber)
+			assert.Equal(t, uint8(4), rawTxn.ChainId)
+			// Simulation must not carry a valid signature
+			assert.Error(t, signedTxn.Verify())
+			_, _ = fmt.Fprintf(w, "["+testUserTransactionJson+"]", testTxnHash, success, vmStatus)
+		case "/v1":
+			_, _ = fmt.Fprint(w, testNodeInfoJson)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestSimulatePayload(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	// Both estimates by default
+	client := newSimulationServerClient(t, sender, url.Values{
+		"estimate_gas_unit_price": []string{"true"},
+		"estimate_max_gas_amount": []string{"true"},
+	}, true, vmStatusSuccess)
+	simTxn, err := client.SimulatePayload(sender, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.True(t, simTxn.Success)
+	assert.Equal(t, vmStatusSuccess, simTxn.VmStatus)
+	assert.Equal(t, uint64(5), simTxn.GasUsed)
+	assert.NotNil(t, simTxn.Changes)
+
+	// Providing the gas params turns off the estimates
+	client = newSimulationServerClient(t, sender, url.Values{
+		"estimate_gas_unit_price": []string{"false"},
+		"estimate_max_gas_amount": []string{"false"},
+	}, true, vmStatusSuccess)
+	_, err = client.SimulatePayload(sender, TransactionPayload{Payload: payload}, MaxGasAmount(2000), GasUnitPrice(150))
+	assert.NoError(t, err)
+
+	// The estimate options override
+	client = newSimulationServerClient(t, sender, url.Values{
+		"estimate_gas_unit_price":             []string{"false"},
+		"estimate_max_gas_amount":             []string{"true"},
+		"estimate_prioritized_gas_unit_price": []string{"true"},
+	}, true, vmStatusSuccess)
+	_, err = client.SimulatePayload(sender, TransactionPayload{Payload: payload}, GasUnitPrice(150), MaxGasAmount(2000), EstimateMaxGasAmount(true), EstimatePrioritizedGasUnitPrice(true))
+	assert.NoError(t, err)
+
+	// Bad options
+	_, err = client.SimulatePayload(sender, TransactionPayload{Payload: payload}, 5)
+	assert.Error(t, err)
+}
+
+func TestSimulatePayload_Failed(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	vmStatus := "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction"
+	client := newSimulationServerClient(t, sender, url.Values{
+		"estimate_gas_unit_price": []string{"true"},
+		"estimate_max_gas_amount": []string{"true"},
+	}, false, vmStatus)
+
+	// A failed simulation is not an error
+	simTxn, err := client.SimulatePayload(sender, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.False(t, simTxn.Success)
+	assert.Equal(t, vmStatus, simTxn.VmStatus)
+}
+
+// newBuildAndSimulateServerClient creates a client against a mock server, which estimates the gas price at 150, and
+// simulates with the success and vm_status, checking the gas the transaction was simulated with
+func newBuildAndSimulateServerClient(t *testing.T, sender *Account, simulatedMaxGas uint64, success bool, vmStatus string) *Client {
+	address := sender.AccountAddress()
+	return newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/" + address.String():
+			_, _ = fmt.Fprint(w, `{"sequence_number":"5","authentication_key":"`+address.String()+`"}`)
+		case "/v1/estimate_gas_price":
+			_, _ = fmt.Fprint(w, `{"deprioritized_gas_estimate":100,"gas_estimate":150,"prioritized_gas_estimate":200}`)
+		case "/v1/transactions/simulate":
+			// The gas is set by the client, not estimated by the node
+			assert.Empty(t, r.URL.Query())
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			signedTxn := &SignedTransaction{Transaction: &RawTransaction{}, Authenticator: &TransactionAuthenticator{}}
+			err = bcs.Deserialize(signedTxn, body)
+			assert.NoError(t, err)
+			rawTxn := signedTxn.Transaction.(*RawTransaction)
+			assert.Equal(t, simulatedMaxGas, rawTxn.MaxGasAmount)
+			assert.Equal(t, uint64(150), rawTxn.GasUnitPrice)
+			_, _ = fmt.Fprintf(w, "["+testUserTransactionJson+"]", testTxnHash, success, vmStatus)
+		case "/v1":
+			_, _ = fmt.Fprint(w, testNodeInfoJson)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestBuildAndSimulate(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	// The simulation uses 5 gas, so 1.5x is rounded up to 8
+	client := newBuildAndSimulateServerClient(t, sender, DefaultMaxGasAmount, true, vmStatusSuccess)
+	rawTxn, err := client.BuildAndSimulate(sender, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), rawTxn.MaxGasAmount)
+	assert.Equal(t, uint64(150), rawTxn.GasUnitPrice)
+	assert.Equal(t, uint64(5), rawTxn.SequenceNumber)
+
+	// The finalized transaction can be signed
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+	assert.NoError(t, signedTxn.Verify())
+
+	rawTxn, err = client.BuildAndSimulate(sender, TransactionPayload{Payload: payload}, GasBuffer(3))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(15), rawTxn.MaxGasAmount)
+
+	// The max gas amount given is simulated with, and caps the buffered gas
+	client = newBuildAndSimulateServerClient(t, sender, 12, true, vmStatusSuccess)
+	rawTxn, err = client.BuildAndSimulate(sender, TransactionPayload{Payload: payload}, MaxGasAmount(12), GasBuffer(3))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), rawTxn.MaxGasAmount)
+
+	// Bad options
+	_, err = client.BuildAndSimulate(sender, TransactionPayload{Payload: payload}, GasBuffer(0.5))
+	assert.ErrorContains(t, err, "must be at least 1")
+	_, err = client.BuildAndSimulate(sender, TransactionPayload{Payload: payload}, EstimateMaxGasAmount(true))
+	assert.ErrorContains(t, err, "bad type")
+}
+
+func TestBuildAndSimulate_Failed(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	vmStatus := "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction"
+	client := newBuildAndSimulateServerClient(t, sender, DefaultMaxGasAmount, false, vmStatus)
+	rawTxn, err := client.BuildAndSimulate(sender, TransactionPayload{Payload: payload})
+	assert.Nil(t, rawTxn)
+	var simErr *SimulationFailedError
+	assert.ErrorAs(t, err, &simErr)
+	assert.Equal(t, vmStatus, simErr.VmStatus)
+	assert.False(t, simErr.Transaction.Success)
+	assert.ErrorContains(t, err, "EINSUFFICIENT_BALANCE")
+}
+
+func TestBufferGas(t *testing.T) {
+	assert.Equal(t, uint64(0), bufferGas(0, 1.5, 100))
+	assert.Equal(t, uint64(10), bufferGas(10, 1, 100))
+	assert.Equal(t, uint64(15), bufferGas(10, 1.5, 100))
+	assert.Equal(t, uint64(16), bufferGas(11, 1.4, 100))
+	assert.Equal(t, uint64(100), bufferGas(80, 1.5, 100))
+	assert.Equal(t, uint64(100), bufferGas(1<<62, 1000, 100))
+}
+
+// testUserTransactionJson is a committed user transaction, with the success and vm_status to be filled in
+const testUserTransactionJson = `{
+	"version": "100",
+	"hash": "%s",
+	"state_change_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"event_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"state_checkpoint_hash": null,
+	"gas_used": "5",
+	"success": %t,
+	"vm_status": "%s",
+	"accumulator_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"changes": [],
+	"sender": "0x1",
+	"sequence_number": "5",
+	"max_gas_amount": "2000",
+	"gas_unit_price": "100",
+	"expiration_timestamp_secs": "1719968695",
+	"payload": {
+		"function": "0x1::aptos_account::transfer",
+		"type_arguments": [],
+		"arguments": ["0x2", "100"],
+		"type": "entry_function_payload"
+	},
+	"signature": {
+		"public_key": "0x5e10e3db4e3c700142b9a3e18c40038db5903f2dedfe41d09aca74a8c68565d6",
+		"signature": "0xa95686dab2c93cf1720e300b929e3656cc6cdc3a8389dc12bb9bd5a17ae3af975bee9d618f080266e3a60f1e2968220a83d773e2b3902edfe54127ed0a7b290b",
+		"type": "ed25519_signature"
+	},
+	"events": [],
+	"timestamp": "1719965096135309",
+	"type": "user_transaction"
+}`
+
+const testTxnHash = "0xf4d07fdb8b5151971886a910e516d418a790dd5f6e068b0588066518a395a600"
+
+// newWaitServerClient creates a client against a mock server that returns 404 for the transaction a number of times,
+// then the committed transaction
+func newWaitServerClient(t *testing.T, notFound int32, success bool, vmStatus string) (*Client, *atomic.Int32) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions/by_hash/"+testTxnHash, r.URL.Path)
+		if calls.Add(1) <= notFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"message":"Transaction not found","error_code":"transaction_not_found","vm_error_code":null}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, testUserTransactionJson, testTxnHash, success, vmStatus)
+	})
+	return client, &calls
+}
+
+func TestTransactionByHash(t *testing.T) {
+	pendingJson := fmt.Sprintf(`{
+		"hash": "%s",
+		"sender": "0x1",
+		"sequence_number": "5",
+		"max_gas_amount": "2000",
+		"gas_unit_price": "100",
+		"expiration_timestamp_secs": "1719968695",
+		"payload": {
+			"function": "0x1::aptos_account::transfer",
+			"type_arguments": [],
+			"arguments": ["0x2", "100"],
+			"type": "entry_function_payload"
+		},
+		"signature": {
+			"public_key": "0x5e10e3db4e3c700142b9a3e18c40038db5903f2dedfe41d09aca74a8c68565d6",
+			"signature": "0xa95686dab2c93cf1720e300b929e3656cc6cdc3a8389dc12bb9bd5a17ae3af975bee9d618f080266e3a60f1e2968220a83d773e2b3902edfe54127ed0a7b290b",
+			"type": "ed25519_signature"
+		},
+		"type": "pending_transaction"
+	}`, testTxnHash)
+
+	tests := map[string]struct {
+		body    string
+		variant api.TransactionVariant
+		pending bool
+		success bool
+	}{
+		"Pending":   {pendingJson, api.TransactionVariantPending, true, false},
+		"Committed": {fmt.Sprintf(testUserTransactionJson, testTxnHash, true, vmStatusSuccess), api.TransactionVariantUser, false, true},
+		"Failed":    {fmt.Sprintf(testUserTransactionJson, testTxnHash, false, "Out of gas"), api.TransactionVariantUser, false, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/transactions/by_hash/"+testTxnHash, r.URL.Path)
+				_, _ = fmt.Fprint(w, test.body)
+			})
+			txn, err := client.TransactionByHash(testTxnHash)
+			assert.NoError(t, err)
+			assert.Equal(t, test.variant, txn.Type)
+			assert.Equal(t, test.pending, txn.IsPending())
+			assert.Equal(t, test.success, txn.IsSuccess())
+			assert.Equal(t, testTxnHash, txn.Hash().String())
+			if test.pending {
+				pending, err := txn.PendingTransaction()
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(5), pending.SequenceNumber)
+				assert.Nil(t, txn.Version())
+			} else {
+				userTxn, err := txn.UserTransaction()
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(100), userTxn.Version)
+			}
+		})
+	}
+}
+
+func TestTransactionByHash_NotFound(t *testing.T) {
+	client, _ := newWaitServerClient(t, 1, true, vmStatusSuccess)
+	_, err := client.TransactionByHash(testTxnHash)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestWaitForTransactionByHash(t *testing.T) {
+	client, calls := newWaitServerClient(t, 3, true, "Executed successfully")
+	txn, err := client.WaitForTransactionByHash(context.Background(), testTxnHash, PollPeriod(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), calls.Load())
+	assert.Equal(t, api.TransactionVariantUser, txn.Type)
+	assert.Equal(t, testTxnHash, txn.Hash().String())
+	assert.True(t, *txn.Success())
+	assert.Equal(t, uint64(100), *txn.Version())
+}
+
+func TestWaitForTransactionByHash_Failed(t *testing.T) {
+	vmStatus := "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): Not enough coins to complete transaction"
+	client, calls := newWaitServerClient(t, 1, false, vmStatus)
+	txn, err := client.WaitForTransactionByHash(context.Background(), testTxnHash, PollPeriod(time.Millisecond))
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+
+	var failedErr *TransactionFailedError
+	assert.ErrorAs(t, err, &failedErr)
+	assert.Equal(t, testTxnHash, failedErr.Hash)
+	assert.Equal(t, vmStatus, failedErr.VmStatus)
+
+	// The failed transaction is still returned
+	assert.NotNil(t, txn)
+	assert.False(t, *txn.Success())
+}
+
+func TestWaitForTransactionByHash_Timeout(t *testing.T) {
+	client, _ := newWaitServerClient(t, 1000, true, "Executed successfully")
+	start := time.Now()
+	_, err := client.WaitForTransactionByHash(context.Background(), testTxnHash, PollPeriod(2*time.Millisecond), PollTimeout(20*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestWaitForTransactionByHash_Cancelled(t *testing.T) {
+	client, _ := newWaitServerClient(t, 1000, true, "Executed successfully")
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err := client.WaitForTransactionByHash(ctx, testTxnHash, PollPeriod(2*time.Millisecond))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWaitForTransactionByHash_OtherError(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(w, `{"message":"invalid hash","error_code":"invalid_input","vm_error_code":null}`)
+	})
+	_, err := client.WaitForTransactionByHash(context.Background(), "0xzz", PollPeriod(time.Millisecond))
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+}
+
+// testBlockJson is a recorded block of versions 10 to 14, with the transactions to be filled in
+const testBlockJson = `{
+	"block_height": "5",
+	"block_hash": "0x014e30aafd9f715ab6262322bf919abebd66d948f6822ffb8a2699a57722fb80",
+	"block_timestamp": "1665609760857472",
+	"first_version": "10",
+	"last_version": "14",
+	"transactions": %s
+}`
+
+// testStateCheckpointJson is a state checkpoint transaction, with the version to be filled in
+const testStateCheckpointJson = `{
+	"type": "state_checkpoint_transaction",
+	"version": "%d",
+	"hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"accumulator_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"state_change_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"event_root_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"state_checkpoint_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+	"gas_used": "0",
+	"success": true,
+	"vm_status": "Executed successfully",
+	"changes": [],
+	"timestamp": "1665609760857472"
+}`
+
+// testStateCheckpointsJson is a JSON list of state checkpoint transactions for the versions [start, end)
+func testStateCheckpointsJson(start uint64, end uint64) string {
+	txns := make([]string, 0)
+	for version := start; version < end; version++ {
+		txns = append(txns, fmt.Sprintf(testStateCheckpointJson, version))
+	}
+	return "[" + strings.Join(txns, ",") + "]"
+}
+
+func TestBlockByHeight(t *testing.T) {
+	transactionsCalls := 0
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/blocks/by_height/5":
+			if r.URL.Query().Get("with_transactions") == "true" {
+				// The node only returns the first page of transactions
+				_, _ = fmt.Fprintf(w, testBlockJson, testStateCheckpointsJson(10, 12))
+			} else {
+				assert.Equal(t, "false", r.URL.Query().Get("with_transactions"))
+				_, _ = fmt.Fprintf(w, testBlockJson, "null")
+			}
+		case "/v1/transactions":
+			transactionsCalls++
+			assert.Equal(t, "12", r.URL.Query().Get("start"))
+			assert.Equal(t, "3", r.URL.Query().Get("limit"))
+			_, _ = fmt.Fprint(w, testStateCheckpointsJson(12, 15))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	block, err := client.BlockByHeight(5, false)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), block.BlockHeight)
+	assert.Equal(t, "0x014e30aafd9f715ab6262322bf919abebd66d948f6822ffb8a2699a57722fb80", block.BlockHash.String())
+	assert.Equal(t, uint64(1665609760857472), block.BlockTimestamp)
+	assert.Equal(t, uint64(10), block.FirstVersion)
+	assert.Equal(t, uint64(14), block.LastVersion)
+	assert.Empty(t, block.Transactions)
+	assert.Equal(t, 0, transactionsCalls)
+
+	// The rest of the transactions are fetched after the ones in the block, without duplicates
+	block, err = client.BlockByHeight(5, true)
+	assert.NoError(t, err)
+	assert.Len(t, block.Transactions, 5)
+	for i, txn := range block.Transactions {
+		assert.Equal(t, uint64(10+i), txn.Version())
+	}
+	assert.Equal(t, 1, transactionsCalls)
+}
+
+func TestBlockByVersion(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/blocks/by_version/12":
+			// No transactions at all in the block response
+			_, _ = fmt.Fprintf(w, testBlockJson, "[]")
+		case "/v1/transactions":
+			assert.Equal(t, "10", r.URL.Query().Get("start"))
+			_, _ = fmt.Fprint(w, testStateCheckpointsJson(10, 15))
+		case "/v1/blocks/by_version/1000":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"message":"block not found","error_code":"block_not_found"}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	block, err := client.BlockByVersion(12, true)
+	assert.NoError(t, err)
+	assert.Len(t, block.Transactions, 5)
+	assert.Equal(t, uint64(10), block.Transactions[0].Version())
+	assert.Equal(t, uint64(14), block.Transactions[4].Version())
+
+	_, err = client.BlockByVersion(1000, false)
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
+
+// testModuleJson is a recorded, trimmed, 0x1::aptos_account module
+const testModuleJson = `{
+	"bytecode": "0xa11ceb0b060000000c01001002103003409301",
+	"abi": {
+		"address": "0x1",
+		"name": "aptos_account",
+		"friends": ["0x1::genesis", "0x1::resource_account"],
+		"exposed_functions": [
+			{
+				"name": "transfer",
+				"visibility": "public",
+				"is_entry": true,
+				"is_view": false,
+				"generic_type_params": [],
+				"params": ["&signer", "address", "u64"],
+				"return": []
+			},
+			{
+				"name": "transfer_coins",
+				"visibility": "public",
+				"is_entry": true,
+				"is_view": false,
+				"generic_type_params": [{"constraints": []}],
+				"params": ["&signer", "address", "u64"],
+				"return": []
+			},
+			{
+				"name": "batch_transfer_coins",
+				"visibility": "public",
+				"is_entry": true,
+				"is_view": false,
+				"generic_type_params": [{"constraints": []}],
+				"params": ["&signer", "vector<address>", "vector<u64>"],
+				"return": []
+			},
+			{
+				"name": "can_receive_direct_coin_transfers",
+				"visibility": "public",
+				"is_entry": false,
+				"is_view": true,
+				"generic_type_params": [],
+				"params": ["address"],
+				"return": ["bool"]
+			}
+		],
+		"structs": [
+			{
+				"name": "DirectTransferConfig",
+				"is_native": false,
+				"abilities": ["key"],
+				"generic_type_params": [],
+				"fields": [
+					{"name": "allow_arbitrary_coin_transfers", "type": "bool"},
+					{"name": "update_coin_transfer_events", "type": "0x1::event::EventHandle<0x1::aptos_account::DirectCoinTransferConfigUpdatedEvent>"}
+				]
+			}
+		]
+	}
+}`
+
+func TestAccountModule(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/accounts/0x1/module/aptos_account":
+			assert.Equal(t, "7", r.URL.Query().Get("ledger_version"))
+			_, _ = fmt.Fprint(w, testModuleJson)
+		case "/v1/accounts/0x1/modules":
+			_, _ = fmt.Fprintf(w, "[%s,%s]", testModuleJson, testModuleJson)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	module, err := client.AccountModule(AccountOne, "aptos_account", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, api.HexBytes{0xa1, 0x1c, 0xeb, 0x0b, 0x06, 0x00, 0x00, 0x00, 0x0c, 0x01, 0x00, 0x10, 0x02, 0x10, 0x30, 0x03, 0x40, 0x93, 0x01}, module.Bytecode)
+	abi := module.Abi
+	assert.Equal(t, AccountOne, *abi.Address)
+	assert.Equal(t, "aptos_account", abi.Name)
+	assert.Equal(t, []string{"0x1::genesis", "0x1::resource_account"}, abi.Friends)
+	assert.Len(t, abi.ExposedFunctions, 4)
+
+	transferCoins := abi.Function("transfer_coins")
+	assert.NotNil(t, transferCoins)
+	assert.True(t, transferCoins.IsEntry)
+	assert.Equal(t, api.MoveVisibilityPublic, transferCoins.Visibility)
+	assert.Len(t, transferCoins.GenericTypeParams, 1)
+	assert.Empty(t, transferCoins.GenericTypeParams[0].Constraints)
+	assert.Equal(t, []string{"&signer", "address", "u64"}, transferCoins.Params)
+
+	view := abi.Function("can_receive_direct_coin_transfers")
+	assert.True(t, view.IsView)
+	assert.Equal(t, []string{"bool"}, view.Return)
+	assert.Nil(t, abi.Function("missing"))
+
+	config := abi.Struct("DirectTransferConfig")
+	assert.Equal(t, []api.MoveAbility{api.MoveAbilityKey}, config.Abilities)
+	assert.Equal(t, "allow_arbitrary_coin_transfers", config.Fields[0].Name)
+	assert.Equal(t, "bool", config.Fields[0].Type)
+	assert.Nil(t, abi.Struct("missing"))
+
+	// The generic function's ABI can build a payload
+	payload, err := NewEntryFunctionFromAbi(ModuleId{Address: AccountOne, Name: abi.Name}, abi.Function("batch_transfer_coins"), []TypeTag{AptosCoinTypeTag}, []any{[]AccountAddress{AccountTwo}, []uint64{100}})
+	assert.NoError(t, err)
+	assert.Equal(t, "batch_transfer_coins", payload.Function)
+	assert.Len(t, payload.Args, 2)
+
+	modules, err := client.AccountModules(AccountOne)
+	assert.NoError(t, err)
+	assert.Len(t, modules, 2)
+	assert.Equal(t, module, modules[0])
+}
+
+func TestAccountModule_NotFound(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{"message":"Module not found by Address(0x1), Module name(missing) and Ledger version(100)","error_code":"module_not_found","vm_error_code":null}`)
+	})
+	_, err := client.AccountModule(AccountOne, "missing")
+	assert.True(t, IsNotFound(err))
+}
+
+func TestAccountExists(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		body     string
+		exists   bool
+		errorStr string
+	}{
+		"Exists": {
+			status: http.StatusOK,
+			body:   `{"sequence_number":"5","authentication_key":"0x0000000000000000000000000000000000000000000000000000000000000001"}`,
+			exists: true,
+		},
+		"NotFound": {
+			status: http.StatusNotFound,
+			body:   `{"message":"Account not found by Address(0x1) and Ledger version(100)","error_code":"account_not_found","vm_error_code":null}`,
+			exists: false,
+		},
+		"NodeError": {
+			status:   http.StatusInternalServerError,
+			body:     `{"message":"internal error","error_code":"internal_error","vm_error_code":null}`,
+			errorStr: "internal error",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/accounts/"+AccountOne.String(), r.URL.Path)
+				w.WriteHeader(test.status)
+				_, _ = fmt.Fprint(w, test.body)
+			})
+			exists, err := client.AccountExists(AccountOne)
+			if test.errorStr != "" {
+				assert.ErrorContains(t, err, test.errorStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.exists, exists)
+		})
+	}
+}
+
+func TestAccountAPTBalance(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		body     string
+		balance  uint64
+		errorStr string
+	}{
+		"Balance": {
+			status:  http.StatusOK,
+			body:    `["12345"]`,
+			balance: 12345,
+		},
+		"NodeError": {
+			status:   http.StatusBadRequest,
+			body:     `{"message":"Invalid input","error_code":"invalid_input","vm_error_code":null}`,
+			errorStr: "Invalid input",
+		},
+		"BadResponse": {
+			status:   http.StatusOK,
+			body:     `[]`,
+			errorStr: "expected 1 value",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/view", r.URL.Path)
+				_, _ = io.ReadAll(r.Body)
+				w.WriteHeader(test.status)
+				_, _ = fmt.Fprint(w, test.body)
+			})
+			balance, err := client.AccountAPTBalance(AccountOne)
+			if test.errorStr != "" {
+				assert.ErrorContains(t, err, test.errorStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.balance, balance)
+		})
+	}
+}
+
+func TestBatchSubmitTransaction(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTxns := []*SignedTransaction{
+		buildSignedTransferForTest(t, sender),
+		buildSignedTransferForTest(t, sender),
+		buildSignedTransferForTest(t, sender),
+	}
+
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions/batch", r.URL.Path)
+		assert.Equal(t, ContentTypeAptosSignedTxnBcs, r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		des := bcs.NewDeserializer(body)
+		received := bcs.DeserializeSequence[SignedTransaction](des)
+		assert.NoError(t, des.Error())
+		assert.Len(t, received, 3)
+
+		// The node returns 206 for a partially accepted batch
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = fmt.Fprint(w, `{"transaction_failures": [
+			{"error": {"message": "Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_OLD", "error_code": "vm_error", "vm_error_code": 3}, "transaction_index": 0},
+			{"error": {"message": "Invalid transaction: Type: Validation Code: INVALID_SIGNATURE", "error_code": "vm_error", "vm_error_code": 1}, "transaction_index": 2}
+		]}`)
+	})
+
+	response, err := client.BatchSubmitTransaction(signedTxns)
+	assert.NoError(t, err)
+	assert.Len(t, response.TransactionFailures, 2)
+	assert.Equal(t, uint64(3), response.Failure(0).VmErrorCode)
+	assert.Nil(t, response.Failure(1))
+	assert.Equal(t, "vm_error", response.Failure(2).ErrorCode)
+	assert.Contains(t, response.Failure(2).Message, "INVALID_SIGNATURE")
+
+	failures := response.Failures()
+	assert.Len(t, failures, 2)
+	assert.Equal(t, response.Failure(2), failures[2])
+}
+
+func TestBatchSubmitTransaction_AllAccepted(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprint(w, `{"transaction_failures": []}`)
+	})
+	response, err := client.BatchSubmitTransaction([]*SignedTransaction{buildSignedTransferForTest(t, sender)})
+	assert.NoError(t, err)
+	assert.Empty(t, response.TransactionFailures)
+	assert.Nil(t, response.Failure(0))
+	assert.Empty(t, response.Failures())
+}
+
+func TestBatchSubmitTransaction_Rejected(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		_, _ = fmt.Fprint(w, `{"message": "Submitted too many transactions: 101, max: 100", "error_code": "invalid_input", "vm_error_code": null}`)
+	})
+	_, err = client.BatchSubmitTransaction([]*SignedTransaction{buildSignedTransferForTest(t, sender)})
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.StatusCode)
+}
+
+func TestWithContext_DeadlineMidRequest(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Hang until the client gives up
+			<-r.Context().Done()
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"sequence_number": "1", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.WithContext(ctx).Account(AccountOne)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// The original client isn't bound to the context
+	info, err := client.Account(AccountOne)
+	assert.NoError(t, err)
+	sequenceNumber, err := info.SequenceNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), sequenceNumber)
+}
+
+func TestWithContext_CancelSubmit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Cancel once the request has been received, the body must be read to notice the client going away
+		_, _ = io.ReadAll(r.Body)
+		cancel()
+		<-r.Context().Done()
+	})
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.WithContext(ctx).SubmitTransaction(buildSignedTransferForTest(t, sender))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestWithContext_CancelPolling(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"type": "pending_transaction", "hash": "%s", "sender": "0x1", "sequence_number": "1", "max_gas_amount": "1", "gas_unit_price": "1", "expiration_timestamp_secs": "1", "payload": {"type": "entry_function_payload", "function": "0x1::aptos_account::transfer", "type_arguments": [], "arguments": []}, "signature": {"type": "ed25519_signature", "public_key": "0x0", "signature": "0x0"}}`, testTxnHash)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.WithContext(ctx).WaitForTransaction(testTxnHash, PollPeriod(10*time.Millisecond), PollTimeout(time.Minute))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	err = client.WithContext(ctx).PollForTransactions([]string{testTxnHash}, PollTimeout(time.Minute))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithContext_CancelRetry(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.nodeClient.SetRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Minute, MaxDelay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.WithContext(ctx).Info()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	// It gave up during the first backoff
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestWithContext_Faucet(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+	faucetClient, err := NewFaucetClient(client.nodeClient, client.nodeClient.baseUrl.String())
+	assert.NoError(t, err)
+
+	// An already cancelled context doesn't call the faucet
+	_, err = faucetClient.WithContext(ctx).FundTransactions(AccountOne, 100)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCtxVariants_Deadline(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Hang until the client gives up
+		_, _ = io.ReadAll(r.Body)
+		<-r.Context().Done()
+	})
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	start := uint64(0)
+	limit := uint64(10)
+
+	calls := map[string]func(ctx context.Context) error{
+		"AccountCtx": func(ctx context.Context) error {
+			_, err := client.AccountCtx(ctx, AccountOne)
+			return err
+		},
+		"AccountResourceCtx": func(ctx context.Context) error {
+			_, err := client.AccountResourceCtx(ctx, AccountOne, "0x1::account::Account")
+			return err
+		},
+		"AccountResourcesCtx": func(ctx context.Context) error {
+			_, err := client.AccountResourcesCtx(ctx, AccountOne)
+			return err
+		},
+		"TransactionByHashCtx": func(ctx context.Context) error {
+			_, err := client.TransactionByHashCtx(ctx, testTxnHash)
+			return err
+		},
+		"TransactionByVersionCtx": func(ctx context.Context) error {
+			_, err := client.TransactionByVersionCtx(ctx, 1)
+			return err
+		},
+		"TransactionsCtx": func(ctx context.Context) error {
+			_, err := client.TransactionsCtx(ctx, &start, &limit)
+			return err
+		},
+		"SubmitTransactionCtx": func(ctx context.Context) error {
+			_, err := client.SubmitTransactionCtx(ctx, buildSignedTransferForTest(t, sender))
+			return err
+		},
+		"ViewCtx": func(ctx context.Context) error {
+			_, err := client.ViewCtx(ctx, &ViewPayload{
+				Module:   ModuleId{Address: AccountOne, Name: "timestamp"},
+				Function: "now_seconds",
+				ArgTypes: []TypeTag{},
+				Args:     [][]byte{},
+			})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			callStart := time.Now()
+			assert.ErrorIs(t, call(ctx), context.DeadlineExceeded)
+			assert.Less(t, time.Since(callStart), 5*time.Second)
+		})
+	}
+}
+
+func TestCtxVariants_WithoutDeadline(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"sequence_number": "1", "authentication_key": "0x0000000000000000000000000000000000000000000000000000000000000001"}`)
+	})
+
+	// The methods without a context argument, and the Ctx variants with a live context, behave the same
+	info, err := client.Account(AccountOne)
+	assert.NoError(t, err)
+	infoCtx, err := client.AccountCtx(context.Background(), AccountOne)
+	assert.NoError(t, err)
+	assert.Equal(t, info, infoCtx)
+
+	// A context set with WithContext doesn't apply to the Ctx variants, only their own context does
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.WithContext(ctx).AccountCtx(context.Background(), AccountOne)
+	assert.NoError(t, err)
+	_, err = client.AccountCtx(ctx, AccountOne)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// newLoggedMockServerClient creates a client against a mock server, logging requests as JSON to the buffer
+func newLoggedMockServerClient(t *testing.T, handler http.HandlerFunc, options ...any) (*Client, *bytes.Buffer) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient(NetworkConfig{
+		Name:    "mock",
+		ChainId: 4,
+		NodeUrl: server.URL + "/v1",
+	}, append(options, logger)...)
+	assert.NoError(t, err)
+	return client, logs
+}
+
+// parseLogRecords parses the JSON log records, one per line
+func parseLogRecords(t *testing.T, logs *bytes.Buffer) []map[string]any {
+	records := make([]map[string]any, 0)
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := map[string]any{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestClientLogger(t *testing.T) {
+	client, logs := newLoggedMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `["100"]`)
+	})
+	_, err := client.View(&ViewPayload{
+		Module:   ModuleId{Address: AccountOne, Name: "coin"},
+		Function: "balance",
+		ArgTypes: []TypeTag{AptosCoinTypeTag},
+		Args:     [][]byte{AccountOne[:]},
+	})
+	assert.NoError(t, err)
+	_, err = client.ViewJson("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne})
+	assert.NoError(t, err)
+
+	records := parseLogRecords(t, logs)
+	assert.Len(t, records, 2)
+	for _, record := range records {
+		assert.Equal(t, "DEBUG", record["level"])
+		assert.Equal(t, "aptos node request", record["msg"])
+		assert.Equal(t, "POST", record["method"])
+		assert.Contains(t, record["url"], "/v1/view")
+		assert.Equal(t, float64(http.StatusOK), record["status"])
+		assert.Contains(t, record, "latency")
+		assert.NotContains(t, record, "attempt")
+	}
+	// BCS bodies aren't logged, JSON bodies are
+	assert.NotContains(t, records[0], "body")
+	assert.Contains(t, records[1]["body"], `"function":"0x1::coin::balance"`)
+}
+
+func TestClientLogger_RedactsSubmit(t *testing.T) {
+	client, logs := newLoggedMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, testUserTransactionJson, testTxnHash, true, "Executed successfully")
+	})
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	_, err = client.SubmitTransaction(buildSignedTransferForTest(t, sender))
+	assert.NoError(t, err)
+
+	records := parseLogRecords(t, logs)
+	assert.Len(t, records, 1)
+	assert.Equal(t, RedactedBody, records[0]["body"])
+	assert.Equal(t, float64(http.StatusAccepted), records[0]["status"])
+	assert.Contains(t, records[0]["url"], "/v1/transactions")
+}
+
+func TestClientLogger_Retries(t *testing.T) {
+	var calls atomic.Int32
+	client, logs := newLoggedMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"chain_id": 4, "epoch": "1", "ledger_version": "10", "oldest_ledger_version": "0", "ledger_timestamp": "1", "node_role": "full_node", "oldest_block_height": "0", "block_height": "5", "git_hash": "abc"}`)
+	}, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+	_, err := client.Info()
+	assert.NoError(t, err)
+	records := parseLogRecords(t, logs)
+	assert.Len(t, records, 3)
+	assert.Equal(t, float64(http.StatusServiceUnavailable), records[0]["status"])
+	assert.NotContains(t, records[0], "attempt")
+	assert.Equal(t, float64(2), records[1]["attempt"])
+	assert.Equal(t, float64(3), records[2]["attempt"])
+	assert.Equal(t, float64(http.StatusOK), records[2]["status"])
+	assert.Equal(t, "GET", records[2]["method"])
+}
+
+func TestClientLogger_Error(t *testing.T) {
+	client, logs := newLoggedMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.WithContext(ctx).Info()
+	assert.Error(t, err)
+	records := parseLogRecords(t, logs)
+	assert.Len(t, records, 1)
+	assert.Contains(t, records[0]["error"], "context canceled")
+	assert.NotContains(t, records[0], "status")
+}
+
+func TestClientLogger_Disabled(t *testing.T) {
+	client, logs := newLoggedMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `["100"]`)
+	})
+	_, err := client.WithLogger(nil).ViewJson("0x1::coin::balance", []string{}, []any{})
+	assert.NoError(t, err)
+	assert.Empty(t, logs.String())
+
+	// Below debug level, nothing is logged
+	infoLogs := &bytes.Buffer{}
+	_, err = client.WithLogger(slog.New(slog.NewJSONHandler(infoLogs, nil))).ViewJson("0x1::coin::balance", []string{}, []any{})
+	assert.NoError(t, err)
+	assert.Empty(t, infoLogs.String())
+}
+
+func TestLedgerVersionQuery(t *testing.T) {
+	var query url.Values
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = io.ReadAll(r.Body)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/resources"):
+			_, _ = w.Write([]byte(`[]`))
+		case strings.Contains(r.URL.Path, "/resource/"):
+			_, _ = w.Write([]byte(`{"type":"0x1::account::Account","data":{}}`))
+		case strings.HasSuffix(r.URL.Path, "/view"):
+			_, _ = w.Write([]byte(`["1"]`))
+		case strings.HasSuffix(r.URL.Path, "/item"):
+			_, _ = w.Write([]byte(`"1"`))
+		default:
+			_, _ = w.Write([]byte(`{"sequence_number":"0","authentication_key":"0x1"}`))
+		}
+	})
+	viewPayload := &ViewPayload{Module: ModuleId{Address: AccountOne, Name: "chain_id"}, Function: "get"}
+
+	reads := map[string]func(ledgerVersion ...uint64) error{
+		"Account": func(ledgerVersion ...uint64) error {
+			_, err := client.Account(AccountOne, ledgerVersion...)
+			return err
+		},
+		"AccountResource": func(ledgerVersion ...uint64) error {
+			_, err := client.AccountResource(AccountOne, "0x1::account::Account", ledgerVersion...)
+			return err
+		},
+		"AccountResources": func(ledgerVersion ...uint64) error {
+			_, err := client.AccountResources(AccountOne, ledgerVersion...)
+			return err
+		},
+		"AccountResourcesBatch": func(ledgerVersion ...uint64) error {
+			options := make([]any, 0, 1)
+			for _, version := range ledgerVersion {
+				options = append(options, AtVersion(version))
+			}
+			_, err := client.AccountResourcesBatch(AccountOne, []string{"0x1::account::Account"}, options...)
+			return err
+		},
+		"View": func(ledgerVersion ...uint64) error {
+			_, err := client.View(viewPayload, ledgerVersion...)
+			return err
+		},
+		"ViewJson": func(ledgerVersion ...uint64) error {
+			_, err := client.ViewJson("0x1::chain_id::get", nil, nil, ledgerVersion...)
+			return err
+		},
+		"GetTableItem": func(ledgerVersion ...uint64) error {
+			var out string
+			return client.GetTableItem("0x123", "address", "u64", AccountOne, &out, ledgerVersion...)
+		},
+	}
+	for name, read := range reads {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, read(42))
+			assert.Equal(t, "42", query.Get("ledger_version"))
+
+			// Omitting it reads the latest
+			assert.NoError(t, read())
+			assert.False(t, query.Has("ledger_version"))
+		})
+	}
+}
+
+// closeTrackingBody is a response body which fails to be read, and records whether it was closed
+type closeTrackingBody struct {
+	closed atomic.Bool
+}
+
+func (body *closeTrackingBody) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func (body *closeTrackingBody) Close() error {
+	body.closed.Store(true)
+	return nil
+}
+
+// closeTrackingTransport responds to every request with the status, and a new [closeTrackingBody]
+type closeTrackingTransport struct {
+	status int
+	bodies []*closeTrackingBody
+}
+
+func (tt *closeTrackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := &closeTrackingBody{}
+	tt.bodies = append(tt.bodies, body)
+	return &http.Response{StatusCode: tt.status, Status: http.StatusText(tt.status), Header: http.Header{}, Body: body, Request: req}, nil
+}
+
+func TestNodeClient_ClosesResponseBody(t *testing.T) {
+	// The body is closed for an error status, and when reading it fails
+	for _, status := range []int{http.StatusOK, http.StatusInternalServerError} {
+		transport := &closeTrackingTransport{status: status}
+		client, err := NewNodeClientWithHttpClient("http://localhost:8080/v1", 4, &http.Client{Transport: transport})
+		assert.NoError(t, err)
+
+		_, err = client.GetBCS("http://localhost:8080/v1/accounts/0x1")
+		assert.Error(t, err)
+		_, _, err = GetWithResp[map[string]any](client, "http://localhost:8080/v1/accounts/0x1")
+		assert.Error(t, err)
+		_, err = Post[map[string]any](client, "http://localhost:8080/v1/view", "application/json", nil)
+		assert.Error(t, err)
+		_, err = client.postBCS(client.context(), "http://localhost:8080/v1/view", "application/json", nil)
+		assert.Error(t, err)
+
+		assert.Len(t, transport.bodies, 4)
+		for _, body := range transport.bodies {
+			assert.True(t, body.closed.Load())
+		}
+	}
+}
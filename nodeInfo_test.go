@@ -3,10 +3,15 @@ package aptos
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type levelCounts struct {
@@ -181,3 +186,177 @@ func TestOldestBlockHeight(t *testing.T) {
 	assert.Equal(t, uint64(0), info.OldestBlockHeight())
 	assert.Equal(t, 1, lc.countingHandler.counts.get(slog.LevelError))
 }
+
+func TestLedgerInfo(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/v1", r.URL.Path)
+		_, _ = fmt.Fprint(w, `{
+			"chain_id": 2,
+			"epoch": "1234",
+			"ledger_version": "5678901",
+			"oldest_ledger_version": "0",
+			"ledger_timestamp": "1719520421743738",
+			"node_role": "full_node",
+			"oldest_block_height": "0",
+			"block_height": "123456",
+			"git_hash": "1bd2d53e5bd77ca8b0f8ab7e0fc0d8a7b3c1a6c9"
+		}`)
+	}))
+	defer server.Close()
+
+	// No chain ID, so it has to be fetched
+	client, err := NewNodeClient(server.URL+"/v1", 0)
+	assert.NoError(t, err)
+
+	info, err := client.LedgerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), info.ChainId)
+	assert.Equal(t, uint64(1234), info.Epoch.ToUint64())
+	assert.Equal(t, uint64(5678901), info.LedgerVersion.ToUint64())
+	assert.Equal(t, uint64(0), info.OldestLedgerVersion.ToUint64())
+	assert.Equal(t, uint64(1719520421743738), info.LedgerTimestamp.ToUint64())
+	assert.Equal(t, "full_node", info.NodeRole)
+	assert.Equal(t, uint64(123456), info.BlockHeight.ToUint64())
+	assert.Equal(t, 1, calls)
+
+	// The chain ID is cached
+	chainId, err := client.GetChainId()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), chainId)
+	assert.Equal(t, 1, calls)
+
+	// The ledger info itself isn't
+	_, err = client.LedgerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestGetChainId_Cached(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = fmt.Fprint(w, `{"chain_id": 2, "epoch": "1", "ledger_version": "1", "oldest_ledger_version": "0", "ledger_timestamp": "1", "node_role": "full_node", "oldest_block_height": "0", "block_height": "1"}`)
+	}))
+	defer server.Close()
+	client, err := NewNodeClient(server.URL+"/v1", 0)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		chainId, err := client.GetChainId()
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), chainId)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+// newChainIdServer creates a mock node with chain ID 2, which builds transactions, counting the requests for the chain ID
+func newChainIdServer(t *testing.T, sender AccountAddress) (*httptest.Server, *atomic.Int32) {
+	var infoCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1":
+			infoCalls.Add(1)
+			_, _ = fmt.Fprint(w, `{"chain_id": 2, "epoch": "1", "ledger_version": "1", "oldest_ledger_version": "0", "ledger_timestamp": "1", "node_role": "full_node", "oldest_block_height": "0", "block_height": "1"}`)
+		case "/v1/accounts/" + sender.String():
+			_, _ = fmt.Fprint(w, `{"sequence_number":"5","authentication_key":"`+sender.String()+`"}`)
+		case "/v1/estimate_gas_price":
+			_, _ = fmt.Fprint(w, `{"deprioritized_gas_estimate":100,"gas_estimate":150,"prioritized_gas_estimate":200}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &infoCalls
+}
+
+func TestBuildTransaction_ChainIdFetched(t *testing.T) {
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	server, infoCalls := newChainIdServer(t, AccountOne)
+	client, err := NewNodeClient(server.URL+"/v1", 0)
+	assert.NoError(t, err)
+	// Expire from the local clock, so the node info is only fetched for the chain ID
+	client.SetLedgerExpiration(0)
+
+	// The chain ID is fetched for the first transaction, then cached
+	for i := 0; i < 3; i++ {
+		rawTxn, err := client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload})
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), rawTxn.ChainId)
+	}
+	assert.Equal(t, int32(1), infoCalls.Load())
+
+	// The cache is shared with copies of the client
+	rawTxn, err := client.WithContext(context.Background()).BuildTransaction(AccountOne, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), rawTxn.ChainId)
+	assert.Equal(t, int32(1), infoCalls.Load())
+
+	// But not with other clients of the same node, which fetch it again
+	other, err := NewNodeClient(server.URL+"/v1", 0)
+	assert.NoError(t, err)
+	other.SetLedgerExpiration(0)
+	rawTxn, err = other.BuildTransaction(AccountOne, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), rawTxn.ChainId)
+	assert.Equal(t, int32(2), infoCalls.Load())
+
+	// The option overrides it for one transaction
+	rawTxn, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload}, ChainIdOption(5))
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(5), rawTxn.ChainId)
+
+	// And SetChainId overrides it for the client
+	client.SetChainId(6)
+	rawTxn, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(6), rawTxn.ChainId)
+	chainId, err := client.GetChainId()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(6), chainId)
+	client.SetChainId(0)
+	rawTxn, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), rawTxn.ChainId)
+	assert.Equal(t, int32(2), infoCalls.Load())
+}
+
+func TestBuildTransaction_ChainIdConfigured(t *testing.T) {
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	server, infoCalls := newChainIdServer(t, AccountOne)
+
+	// A chain ID given for the client isn't fetched
+	client, err := NewNodeClient(server.URL+"/v1", 4)
+	assert.NoError(t, err)
+	client.SetLedgerExpiration(0)
+	rawTxn, err := client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(4), rawTxn.ChainId)
+	assert.Equal(t, int32(0), infoCalls.Load())
+}
+
+func TestBuildTransaction_ChainIdWithLedgerExpiration(t *testing.T) {
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	server, infoCalls := newChainIdServer(t, AccountOne)
+	client, err := NewNodeClient(server.URL+"/v1", 0)
+	assert.NoError(t, err)
+
+	// The node info fetched for the ledger timestamp also gives the chain ID, so it's only fetched once
+	rawTxn, err := client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), rawTxn.ChainId)
+	assert.Equal(t, uint64(DefaultLedgerExpiration/time.Second), rawTxn.ExpirationTimestampSeconds)
+	assert.Equal(t, int32(1), infoCalls.Load())
+
+	// And the chain ID is cached for when the expiration is from the local clock
+	client.SetLedgerExpiration(0)
+	rawTxn, err = client.BuildTransaction(AccountOne, TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), rawTxn.ChainId)
+	assert.Equal(t, int32(1), infoCalls.Load())
+}
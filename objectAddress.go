@@ -0,0 +1,32 @@
+package aptos
+
+// CreateObjectAddress derives the address of a named object created by creator with the seed, the same as
+// 0x1::object::create_object_address on-chain
+//
+//	address := CreateObjectAddress(creator, []byte("my_object"))
+func CreateObjectAddress(creator AccountAddress, seed []byte) AccountAddress {
+	return creator.NamedObjectAddress(seed)
+}
+
+// CreateResourceAddress derives the address of a resource account created by source with the seed, the same as
+// 0x1::account::create_resource_address on-chain
+func CreateResourceAddress(source AccountAddress, seed []byte) AccountAddress {
+	return source.ResourceAccount(seed)
+}
+
+// CreateTokenAddress derives the address of a named token v2 in a collection created by creator, the same as
+// 0x4::token::create_token_address on-chain.  The seed is the collection name and token name joined by "::".
+func CreateTokenAddress(creator AccountAddress, collection string, name string) AccountAddress {
+	return CreateObjectAddress(creator, CreateTokenSeed(collection, name))
+}
+
+// CreateCollectionAddress derives the address of a named token v2 collection created by creator, the same as
+// 0x4::collection::create_collection_address on-chain
+func CreateCollectionAddress(creator AccountAddress, collection string) AccountAddress {
+	return CreateObjectAddress(creator, []byte(collection))
+}
+
+// CreateTokenSeed is the seed of a named token v2, the collection name and token name joined by "::"
+func CreateTokenSeed(collection string, name string) []byte {
+	return []byte(collection + "::" + name)
+}
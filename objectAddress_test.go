@@ -0,0 +1,42 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testObjectCreator is the creator of the derived addresses below, which are sha3-256(creator | seed | scheme)
+//
+// These vectors aren't captured from mainnet, they were computed with a separate sha3-256 implementation (Python's
+// hashlib) from the schemes in aptos-framework's object.move and account.move.  Known on-chain addresses would be a
+// stronger check, and should replace them.
+const testObjectCreator = "0xc67545d6f3d36ed01efc9b28cbfd0c1ae326d5d262dd077a29539bcee0edce9e"
+
+func parseTestAddress(t *testing.T, address string) AccountAddress {
+	parsed := AccountAddress{}
+	err := parsed.ParseStringRelaxed(address)
+	assert.NoError(t, err)
+	return parsed
+}
+
+func TestCreateObjectAddress(t *testing.T) {
+	creator := parseTestAddress(t, testObjectCreator)
+	assert.Equal(t, parseTestAddress(t, "0x526f9581b206466f478c96173d29ef350e365be8c7be98cbf8eb1cf5f17b7550"), CreateObjectAddress(creator, []byte("my_object")))
+	assert.Equal(t, parseTestAddress(t, "0x2d015bb908cab653badf3a4bbd38b10a7dbfebe295b928e1485521240c8b2de5"), CreateObjectAddress(AccountOne, []byte("primary_store")))
+}
+
+func TestCreateResourceAddress(t *testing.T) {
+	creator := parseTestAddress(t, testObjectCreator)
+	assert.Equal(t, parseTestAddress(t, "0x0345ed0cfe65bfd8fb9d2fd2885b8e0d185a23549ec1607eca1546b463c75677"), CreateResourceAddress(creator, []byte("seed")))
+	assert.Equal(t, parseTestAddress(t, "0xfb141e7ff9b50f744f0bcca2f7cdd0dd730397ff012e8ebbf18957352ad913cf"), CreateResourceAddress(creator, []byte{}))
+	// The scheme differs from a named object with the same seed
+	assert.NotEqual(t, CreateObjectAddress(creator, []byte("seed")), CreateResourceAddress(creator, []byte("seed")))
+}
+
+func TestCreateTokenAddress(t *testing.T) {
+	creator := parseTestAddress(t, testObjectCreator)
+	assert.Equal(t, []byte("Aptos Monkeys::Monkey #1"), CreateTokenSeed("Aptos Monkeys", "Monkey #1"))
+	assert.Equal(t, parseTestAddress(t, "0x6dc84d4df7cc23f8fe90f1329da8a9e45642f3cc54257c800a96d75e3e021d5b"), CreateTokenAddress(creator, "Aptos Monkeys", "Monkey #1"))
+	assert.Equal(t, parseTestAddress(t, "0xcd8e6e7db5eac55a76f388cd7ac638adc6e09f5ea9d4d92028b2b2d1c7b1a88a"), CreateCollectionAddress(creator, "Aptos Monkeys"))
+}
@@ -0,0 +1,140 @@
+package aptos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// PageFetcher fetches the next page of a [Paginator].  It returns the items of the page, and whether there may be more
+// pages after it.
+//
+// The fetcher keeps track of its own position e.g. a cursor or sequence number, and must only advance it when a page is
+// fetched successfully, so that the page is retried on the next call after an error.
+type PageFetcher[T any] func(ctx context.Context) (items []T, more bool, err error)
+
+// Paginator iterates over the items of a paginated endpoint, fetching a page at a time as needed
+//
+// The paginator is not safe for concurrent use.
+//
+//	paginator := NewPaginator(fetcher)
+//	for {
+//		item, ok, err := paginator.Next(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		if !ok {
+//			break // no more items
+//		}
+//		// handle item
+//	}
+type Paginator[T any] struct {
+	fetch  PageFetcher[T]
+	buffer []T  // buffer is the remaining items of the current page
+	done   bool // done is set once the fetcher has no more pages
+}
+
+// NewPaginator creates a [Paginator] over the pages returned by the fetcher
+func NewPaginator[T any](fetch PageFetcher[T]) *Paginator[T] {
+	return &Paginator[T]{fetch: fetch}
+}
+
+// Next returns the next item, fetching another page if needed.  Empty pages are skipped, as long as the fetcher has
+// more pages.
+//
+// The bool is false once there are no more items.  On an error, calling Next again will retry the same page.
+func (p *Paginator[T]) Next(ctx context.Context) (item T, ok bool, err error) {
+	for len(p.buffer) == 0 {
+		if p.done {
+			return item, false, nil
+		}
+		if err = p.fetchPage(ctx); err != nil {
+			return item, false, err
+		}
+	}
+	item = p.buffer[0]
+	p.buffer = p.buffer[1:]
+	return item, true, nil
+}
+
+// All gathers all the remaining items into a slice
+//
+// On an error, the items collected so far are returned with the error.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	items := make([]T, 0, len(p.buffer))
+	for {
+		items = append(items, p.buffer...)
+		p.buffer = nil
+		if p.done {
+			return items, nil
+		}
+		if err := p.fetchPage(ctx); err != nil {
+			return items, err
+		}
+	}
+}
+
+// Collect gathers the remaining items into a slice, up to max items.  If max is 0 or less, all items are collected.
+//
+// On an error, the items collected so far are returned with the error.
+func (p *Paginator[T]) Collect(ctx context.Context, max int) ([]T, error) {
+	if max <= 0 {
+		return p.All(ctx)
+	}
+	items := make([]T, 0)
+	for len(items) < max {
+		item, ok, err := p.Next(ctx)
+		if err != nil {
+			return items, err
+		}
+		if !ok {
+			break
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// fetchPage fetches the next page into the buffer
+func (p *Paginator[T]) fetchPage(ctx context.Context) error {
+	items, more, err := p.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	p.buffer = items
+	p.done = !more
+	return nil
+}
+
+// cursorPageFetcher returns a [PageFetcher] for endpoints paged by the X-Aptos-Cursor header, such as account
+// resources.  The first page is requested without a start, and each following page from the cursor of the last.  There
+// are no more pages once the node doesn't return a cursor.
+//
+// Any query parameters already on the URL e.g. the ledger version are kept for every page.  Without a ledger version,
+// the following pages are pinned to the ledger version of the first, so that the pages are a consistent view of the
+// account.  A pageSize of 0 leaves the limit to the node's default.
+func cursorPageFetcher[T any](rc *NodeClient, au *url.URL, pageSize uint64) PageFetcher[T] {
+	params := au.Query()
+	if pageSize > 0 {
+		params.Set("limit", strconv.FormatUint(pageSize, 10))
+	}
+	cursor := ""
+	return func(ctx context.Context) ([]T, bool, error) {
+		pageUrl := *au
+		if cursor != "" {
+			params.Set("start", cursor)
+		}
+		pageUrl.RawQuery = params.Encode()
+		page, response, err := getWithResp[[]T](ctx, rc, pageUrl.String())
+		if err != nil {
+			return nil, false, err
+		}
+		if !params.Has("ledger_version") {
+			if ledgerVersion := response.Header.Get("X-Aptos-Ledger-Version"); ledgerVersion != "" {
+				params.Set("ledger_version", ledgerVersion)
+			}
+		}
+		cursor = response.Header.Get("X-Aptos-Cursor")
+		return page, cursor != "", nil
+	}
+}
@@ -0,0 +1,224 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testPageFetcher returns a [PageFetcher] over the pages in order, which fails once at failAt if it's not negative
+func testPageFetcher(pages [][]int, failAt int) (PageFetcher[int], *int) {
+	calls := 0
+	next := 0
+	failed := false
+	return func(ctx context.Context) ([]int, bool, error) {
+		calls++
+		if next == failAt && !failed {
+			failed = true
+			return nil, false, errors.New("page failed")
+		}
+		page := pages[next]
+		next++
+		return page, next < len(pages), nil
+	}, &calls
+}
+
+func TestPaginator_Next(t *testing.T) {
+	// Empty pages in the middle are skipped
+	fetch, calls := testPageFetcher([][]int{{1, 2}, {}, {3}, {4, 5}}, -1)
+	paginator := NewPaginator(fetch)
+	ctx := context.Background()
+
+	items := make([]int, 0)
+	for {
+		item, ok, err := paginator.Next(ctx)
+		assert.NoError(t, err)
+		if !ok {
+			break
+		}
+		items = append(items, item)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, 4, *calls)
+
+	// It stays done, without fetching again
+	_, ok, err := paginator.Next(ctx)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 4, *calls)
+}
+
+func TestPaginator_Retry(t *testing.T) {
+	fetch, _ := testPageFetcher([][]int{{1, 2}, {3}}, 1)
+	paginator := NewPaginator(fetch)
+	ctx := context.Background()
+
+	item, ok, err := paginator.Next(ctx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, item)
+
+	// All returns what it has so far with the error
+	items, err := paginator.All(ctx)
+	assert.ErrorContains(t, err, "page failed")
+	assert.Equal(t, []int{2}, items)
+
+	// The failed page is retried
+	items, err = paginator.All(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, items)
+}
+
+func TestPaginator_Empty(t *testing.T) {
+	paginator := NewPaginator(func(ctx context.Context) ([]int, bool, error) {
+		return nil, false, nil
+	})
+	items, err := paginator.All(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestCursorPageFetcher(t *testing.T) {
+	// Three pages, linked by the cursor header
+	pages := map[string]struct {
+		body   string
+		cursor string
+	}{
+		"":        {`[{"type":"0x1::account::Account","data":{}},{"type":"0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>","data":{}}]`, "cursor1"},
+		"cursor1": {`[]`, "cursor2"},
+		"cursor2": {`[{"type":"0x1::object::ObjectCore","data":{}}]`, ""},
+	}
+	requests := 0
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/v1/accounts/"+AccountOne.String()+"/resources", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		assert.Equal(t, "100", r.URL.Query().Get("ledger_version"))
+		page, ok := pages[r.URL.Query().Get("start")]
+		if !assert.True(t, ok) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if page.cursor != "" {
+			w.Header().Set("X-Aptos-Cursor", page.cursor)
+		}
+		_, _ = fmt.Fprint(w, page.body)
+	})
+
+	resources, err := client.nodeClient.AccountResourcesByPages(AccountOne, 100, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, requests)
+	assert.Len(t, resources, 3)
+	assert.Equal(t, "0x1::account::Account", resources[0].Type)
+	assert.Equal(t, "0x1::object::ObjectCore", resources[2].Type)
+}
+
+func TestCursorPageFetcher_Error(t *testing.T) {
+	requests := 0
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("start") == "" {
+			w.Header().Set("X-Aptos-Cursor", "cursor1")
+			_, _ = fmt.Fprint(w, `[{"type":"0x1::account::Account","data":{}}]`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprint(w, `{"message":"internal error","error_code":"internal_error","vm_error_code":null}`)
+	})
+
+	au := client.nodeClient.baseUrl.JoinPath("accounts", AccountOne.String(), "resources")
+	paginator := NewPaginator(cursorPageFetcher[AccountResourceInfo](client.nodeClient, au, 0))
+	resources, err := paginator.All(context.Background())
+	assert.ErrorContains(t, err, "internal error")
+	assert.Len(t, resources, 1)
+
+	// The failed page is requested again from the same cursor
+	_, _, err = paginator.Next(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 3, requests)
+}
+
+func TestPaginator_Collect(t *testing.T) {
+	fetcher, calls := testPageFetcher([][]int{{1, 2}, {3, 4}, {5}}, -1)
+	paginator := NewPaginator(fetcher)
+	items, err := paginator.Collect(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.Equal(t, 2, *calls)
+
+	// The rest are still available, and 0 collects everything
+	items, err = paginator.Collect(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, items)
+}
+
+// newTwoPageResourcesClient serves the account resources over two pages linked by the cursor header, recording the
+// query of each request
+func newTwoPageResourcesClient(t *testing.T) (*Client, *[]map[string]string) {
+	queries := make([]map[string]string, 0)
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts/"+AccountOne.String()+"/resources", r.URL.Path)
+		query := r.URL.Query()
+		queries = append(queries, map[string]string{"start": query.Get("start"), "ledger_version": query.Get("ledger_version")})
+		w.Header().Set("X-Aptos-Ledger-Version", "150")
+		switch query.Get("start") {
+		case "":
+			w.Header().Set("X-Aptos-Cursor", "cursor1")
+			_, _ = fmt.Fprint(w, `[{"type":"0x1::account::Account","data":{}},{"type":"0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>","data":{}}]`)
+		case "cursor1":
+			_, _ = fmt.Fprint(w, `[{"type":"0x1::object::ObjectCore","data":{}}]`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	return client, &queries
+}
+
+func TestAccountResources_Paginated(t *testing.T) {
+	client, queries := newTwoPageResourcesClient(t)
+
+	resources, err := client.AccountResources(AccountOne)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+	assert.Equal(t, "0x1::account::Account", resources[0].Type)
+	assert.Equal(t, "0x1::object::ObjectCore", resources[2].Type)
+
+	// The second page is pinned to the ledger version of the first
+	assert.Equal(t, []map[string]string{
+		{"start": "", "ledger_version": ""},
+		{"start": "cursor1", "ledger_version": "150"},
+	}, *queries)
+}
+
+func TestAccountResources_PaginatedAtLedgerVersion(t *testing.T) {
+	client, queries := newTwoPageResourcesClient(t)
+
+	resources, err := client.AccountResources(AccountOne, 120)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+	assert.Equal(t, []map[string]string{
+		{"start": "", "ledger_version": "120"},
+		{"start": "cursor1", "ledger_version": "120"},
+	}, *queries)
+}
+
+func TestAccountResourcesUpTo(t *testing.T) {
+	client, queries := newTwoPageResourcesClient(t)
+
+	// The cap is within the first page, so the second isn't fetched
+	resources, err := client.AccountResourcesUpTo(AccountOne, 1)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, "0x1::account::Account", resources[0].Type)
+	assert.Len(t, *queries, 1)
+
+	// A cap past the end gets everything
+	resources, err = client.AccountResourcesUpTo(AccountOne, 10)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 3)
+	assert.Len(t, *queries, 3)
+}
@@ -0,0 +1,206 @@
+package aptos
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// knownEntryFunctionParams are the parameter types of common framework entry functions, for decoding their arguments
+// in [PayloadString] without an ABI
+var knownEntryFunctionParams = map[string][]TypeTag{
+	"0x1::aptos_account::transfer":          {NewTypeTag(&AddressTag{}), NewTypeTag(&U64Tag{})},
+	"0x1::aptos_account::transfer_coins":    {NewTypeTag(&AddressTag{}), NewTypeTag(&U64Tag{})},
+	"0x1::aptos_account::create_account":    {NewTypeTag(&AddressTag{})},
+	"0x1::coin::transfer":                   {NewTypeTag(&AddressTag{}), NewTypeTag(&U64Tag{})},
+	"0x1::primary_fungible_store::transfer": {NewTypeTag(NewObjectTag(&StructTag{Address: AccountOne, Module: "fungible_asset", Name: "Metadata"})), NewTypeTag(&AddressTag{}), NewTypeTag(&U64Tag{})},
+	"0x1::object::transfer_call":            {NewTypeTag(&AddressTag{}), NewTypeTag(&AddressTag{})},
+}
+
+// PayloadString renders a transaction payload in a human-readable form for debugging, as a call of the function with
+// its type arguments and arguments e.g.
+//
+//	0x1::aptos_account::transfer_coins<0x1::aptos_coin::AptosCoin>(0x2, 100)
+//
+// Entry function arguments are only BCS bytes, so they're decoded with paramTypes if given, or the parameter types of
+// common framework functions.  Any argument which can't be decoded as its type is rendered as hex.  Script arguments
+// carry their types, and multisig payloads render the entry function they call.
+//
+//	fmt.Println(PayloadString(rawTxn.Payload.Payload))
+//	fmt.Println(PayloadString(payload, NewTypeTag(&AddressTag{}), NewTypeTag(NewVectorTag(&U64Tag{}))))
+func PayloadString(payload TransactionPayloadImpl, paramTypes ...TypeTag) string {
+	switch inner := payload.(type) {
+	case *EntryFunction:
+		return entryFunctionString(inner, paramTypes)
+	case *Script:
+		return scriptString(inner)
+	case *Multisig:
+		if inner.Payload == nil {
+			return fmt.Sprintf("multisig %s()", inner.MultisigAddress.String())
+		}
+		entryFunction, ok := inner.Payload.Payload.(*EntryFunction)
+		if !ok {
+			return fmt.Sprintf("multisig %s(unknown payload %T)", inner.MultisigAddress.String(), inner.Payload.Payload)
+		}
+		return fmt.Sprintf("multisig %s(%s)", inner.MultisigAddress.String(), entryFunctionString(entryFunction, paramTypes))
+	case nil:
+		return "<nil>"
+	default:
+		return fmt.Sprintf("unknown payload %T", payload)
+	}
+}
+
+// entryFunctionString renders an entry function as a call, decoding the arguments with the parameter types if known
+func entryFunctionString(payload *EntryFunction, paramTypes []TypeTag) string {
+	name := payload.Module.String() + "::" + payload.Function
+	if len(paramTypes) == 0 {
+		paramTypes = knownEntryFunctionParams[name]
+	}
+
+	out := strings.Builder{}
+	out.WriteString(name)
+	writeTypeArgs(&out, payload.ArgTypes)
+	out.WriteRune('(')
+	for i, arg := range payload.Args {
+		if i != 0 {
+			out.WriteString(", ")
+		}
+		// The types are only hints, so any mismatch falls back to the raw bytes
+		if len(paramTypes) == len(payload.Args) {
+			if decoded, err := decodeArgString(arg, paramTypes[i]); err == nil {
+				out.WriteString(decoded)
+				continue
+			}
+		}
+		out.WriteString(BytesToHex(arg))
+	}
+	out.WriteRune(')')
+	return out.String()
+}
+
+// scriptString renders a script as a call, with the size of its code
+func scriptString(payload *Script) string {
+	out := strings.Builder{}
+	out.WriteString(fmt.Sprintf("script[%d bytes]", len(payload.Code)))
+	writeTypeArgs(&out, payload.ArgTypes)
+	out.WriteRune('(')
+	for i, arg := range payload.Args {
+		if i != 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(scriptArgString(&arg))
+	}
+	out.WriteRune(')')
+	return out.String()
+}
+
+func writeTypeArgs(out *strings.Builder, typeArgs []TypeTag) {
+	if len(typeArgs) == 0 {
+		return
+	}
+	out.WriteRune('<')
+	for i, typeArg := range typeArgs {
+		if i != 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(typeArg.String())
+	}
+	out.WriteRune('>')
+}
+
+// scriptArgString renders a script argument by its variant
+func scriptArgString(arg *ScriptArgument) string {
+	switch value := arg.Value.(type) {
+	case AccountAddress:
+		return value.String()
+	case []byte:
+		return BytesToHex(value)
+	case big.Int:
+		return value.String()
+	case *big.Int:
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// decodeArgString decodes a BCS argument as the Move type, and renders it.  All the bytes must be used.
+func decodeArgString(arg []byte, argType TypeTag) (string, error) {
+	des := bcs.NewDeserializer(arg)
+	out := decodeValueString(des, argType)
+	if des.Error() != nil {
+		return "", des.Error()
+	}
+	if des.Remaining() != 0 {
+		return "", fmt.Errorf("%d bytes remaining decoding %s", des.Remaining(), argType.String())
+	}
+	return out, nil
+}
+
+func decodeValueString(des *bcs.Deserializer, argType TypeTag) string {
+	switch inner := argType.Value.(type) {
+	case *BoolTag:
+		return fmt.Sprint(des.Bool())
+	case *U8Tag:
+		return fmt.Sprint(des.U8())
+	case *U16Tag:
+		return fmt.Sprint(des.U16())
+	case *U32Tag:
+		return fmt.Sprint(des.U32())
+	case *U64Tag:
+		return fmt.Sprint(des.U64())
+	case *U128Tag:
+		value := des.U128()
+		return value.String()
+	case *U256Tag:
+		value := des.U256()
+		return value.String()
+	case *AddressTag:
+		address := AccountAddress{}
+		des.Struct(&address)
+		return address.String()
+	case *VectorTag:
+		if _, ok := inner.TypeParam.Value.(*U8Tag); ok {
+			return BytesToHex(des.ReadBytes())
+		}
+		length := des.Uleb128()
+		items := make([]string, 0, min(int(length), des.Remaining()))
+		for i := uint32(0); i < length && des.Error() == nil; i++ {
+			items = append(items, decodeValueString(des, inner.TypeParam))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *StructTag:
+		return decodeStructString(des, argType, inner)
+	default:
+		des.SetError(fmt.Errorf("can't decode argument type %s", argType.String()))
+		return ""
+	}
+}
+
+// decodeStructString decodes the framework structs that can be passed as arguments
+func decodeStructString(des *bcs.Deserializer, argType TypeTag, structTag *StructTag) string {
+	if structTag.Address == AccountOne {
+		switch {
+		case structTag.Module == "string" && structTag.Name == "String":
+			return fmt.Sprintf("%q", des.ReadString())
+		case structTag.Module == "object" && structTag.Name == "Object":
+			address := AccountAddress{}
+			des.Struct(&address)
+			return address.String()
+		case structTag.Module == "option" && structTag.Name == "Option" && len(structTag.TypeParams) == 1:
+			switch des.Uleb128() {
+			case 0:
+				return "none"
+			case 1:
+				return "some(" + decodeValueString(des, structTag.TypeParams[0]) + ")"
+			default:
+				des.SetError(fmt.Errorf("bad option length decoding %s", argType.String()))
+				return ""
+			}
+		}
+	}
+	des.SetError(fmt.Errorf("can't decode argument type %s", argType.String()))
+	return ""
+}
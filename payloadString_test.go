@@ -0,0 +1,98 @@
+package aptos
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadString_AptTransfer(t *testing.T) {
+	payload, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::aptos_account::transfer(0x2, 100)", PayloadString(payload))
+
+	// Type arguments are rendered
+	coinType := TypeTag{&StructTag{Address: AccountThree, Module: "coin", Name: "Coin"}}
+	payload, err = CoinTransferPayload(&coinType, AccountTwo, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1::aptos_account::transfer_coins<0x3::coin::Coin>(0x2, 100)", PayloadString(payload))
+
+	// On-chain multisig renders the entry function it calls
+	multisig := &Multisig{
+		MultisigAddress: AccountThree,
+		Payload: &MultisigTransactionPayload{
+			Variant: MultisigTransactionPayloadVariantEntryFunction,
+			Payload: payload,
+		},
+	}
+	assert.Equal(t, "multisig 0x3(0x1::aptos_account::transfer_coins<0x3::coin::Coin>(0x2, 100))", PayloadString(multisig))
+	assert.Equal(t, "multisig 0x3()", PayloadString(&Multisig{MultisigAddress: AccountThree}))
+}
+
+func TestPayloadString_EntryFunctionTypes(t *testing.T) {
+	paramTypes := []TypeTag{
+		NewTypeTag(&BoolTag{}),
+		NewTypeTag(&U128Tag{}),
+		NewTypeTag(NewStringTag()),
+		NewTypeTag(NewVectorTag(&U64Tag{})),
+		NewTypeTag(NewVectorTag(&U8Tag{})),
+		NewTypeTag(NewOptionTag(&AddressTag{})),
+		NewTypeTag(NewOptionTag(&U8Tag{})),
+	}
+	payload, err := NewEntryFunction(ModuleId{Address: AccountFour, Name: "test"}, "all_types", nil, paramTypes[:5], []any{
+		true,
+		big.NewInt(12345678901234567),
+		"hello \"world\"",
+		[]uint64{1, 2, 3},
+		[]byte{0xca, 0xfe},
+	})
+	assert.NoError(t, err)
+	// Options are vectors of 0 or 1 items
+	payload.Args = append(payload.Args, append([]byte{0x01}, AccountTwo[:]...), []byte{0x00})
+
+	// With the types, the arguments are decoded
+	assert.Equal(t, `0x4::test::all_types(true, 12345678901234567, "hello \"world\"", [1, 2, 3], 0xcafe, some(0x2), none)`, PayloadString(payload, paramTypes...))
+
+	// Without them, they're hex
+	assert.Equal(t, `0x4::test::all_types(0x01, 0x874b6b5d54dc2b000000000000000000, 0x0d68656c6c6f2022776f726c6422, 0x03010000000000000002000000000000000300000000000000, 0x02cafe, 0x010000000000000000000000000000000000000000000000000000000000000002, 0x00)`, PayloadString(payload))
+
+	// Arguments that don't match their types fall back to hex, without affecting the others
+	mismatched := []TypeTag{NewTypeTag(&BoolTag{}), NewTypeTag(&U8Tag{})}
+	payload.Args = payload.Args[:2]
+	assert.Equal(t, "0x4::test::all_types(true, 0x874b6b5d54dc2b000000000000000000)", PayloadString(payload, mismatched...))
+
+	// Including unsupported types
+	unsupportedType := NewTypeTag(&StructTag{Address: AccountFour, Module: "test", Name: "Struct"})
+	payload.Args = payload.Args[:1]
+	assert.Equal(t, "0x4::test::all_types(0x01)", PayloadString(payload, unsupportedType))
+}
+
+func TestPayloadString_Script(t *testing.T) {
+	payload := &Script{
+		Code:     []byte{0xa1, 0x1c, 0xeb, 0x0b, 0x07},
+		ArgTypes: []TypeTag{AptosCoinTypeTag},
+		Args: []ScriptArgument{
+			{Variant: ScriptArgumentAddress, Value: AccountTwo},
+			{Variant: ScriptArgumentU64, Value: uint64(100)},
+			{Variant: ScriptArgumentU128, Value: *big.NewInt(5)},
+			{Variant: ScriptArgumentU8Vector, Value: []byte{0x01, 0x02}},
+			{Variant: ScriptArgumentBool, Value: false},
+		},
+	}
+	expected := "script[5 bytes]<0x1::aptos_coin::AptosCoin>(0x2, 100, 5, 0x0102, false)"
+	assert.Equal(t, expected, PayloadString(payload))
+
+	// The same after a round trip through BCS
+	payloadBytes, err := bcs.Serialize(&TransactionPayload{Payload: payload})
+	assert.NoError(t, err)
+	decoded := &TransactionPayload{}
+	err = bcs.Deserialize(decoded, payloadBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, PayloadString(decoded.Payload))
+}
+
+func TestPayloadString_Nil(t *testing.T) {
+	assert.Equal(t, "<nil>", PayloadString(nil))
+}
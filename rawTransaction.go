@@ -1,6 +1,7 @@
 package aptos
 
 import (
+	"errors"
 	"fmt"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/aptos-labs/aptos-go-sdk/crypto"
@@ -9,24 +10,46 @@ import (
 
 //region RawTransaction
 
-var rawTransactionPrehash []byte
-
 const rawTransactionPrehashStr = "APTOS::RawTransaction"
 
+// rawTransactionPrehash is the sha3-256 of [rawTransactionPrehashStr], computed once
+var rawTransactionPrehash = prehash(rawTransactionPrehashStr)
+
+// prehash computes the sha3-256 domain separator of a signing message
+func prehash(domain string) []byte {
+	b32 := sha3.Sum256([]byte(domain))
+	return b32[:]
+}
+
 // RawTransactionPrehash Return the sha3-256 prehash for RawTransaction
 // Do not write to the []byte returned
 func RawTransactionPrehash() []byte {
-	// Cache the prehash
-	if rawTransactionPrehash == nil {
-		b32 := sha3.Sum256([]byte(rawTransactionPrehashStr))
-		out := make([]byte, len(b32))
-		copy(out, b32[:])
-		rawTransactionPrehash = out
-		return out
-	}
 	return rawTransactionPrehash
 }
 
+// SigningMessage returns the exact bytes signed for the transaction, the sha3-256 of "APTOS::RawTransaction" followed
+// by the BCS of the transaction, e.g. for a hardware wallet or other external signer.  See [SigningMessageWithData] for
+// multi-agent and fee payer transactions.
+//
+//	message, err := SigningMessage(rawTxn)
+//	signature, err := externalSigner.SignMessage(message)
+func SigningMessage(rawTxn *RawTransaction) ([]byte, error) {
+	if rawTxn == nil {
+		return nil, errors.New("raw transaction is nil")
+	}
+	return rawTxn.SigningMessage()
+}
+
+// SigningMessageWithData returns the exact bytes signed for a multi-agent or fee payer transaction, the sha3-256 of
+// "APTOS::RawTransactionWithData" followed by the BCS of the transaction.  Every signer of the transaction, including
+// the fee payer, signs the same message.
+func SigningMessageWithData(rawTxnWithData *RawTransactionWithData) ([]byte, error) {
+	if rawTxnWithData == nil {
+		return nil, errors.New("raw transaction with data is nil")
+	}
+	return rawTxnWithData.SigningMessage()
+}
+
 type RawTransactionImpl interface {
 	bcs.Struct
 
@@ -129,22 +152,15 @@ func (txn *RawTransaction) Sign(signer crypto.Signer) (authenticator *crypto.Acc
 
 //region RawTransactionWithData
 
-var rawTransactionWithDataPrehash []byte
-
 const rawTransactionWithDataPrehashStr = "APTOS::RawTransactionWithData"
 
+// rawTransactionWithDataPrehash is the sha3-256 of [rawTransactionWithDataPrehashStr], computed once
+var rawTransactionWithDataPrehash = prehash(rawTransactionWithDataPrehashStr)
+
 // RawTransactionWithDataPrehash Return the sha3-256 prehash for RawTransactionWithData
 // Do not write to the []byte returned
 func RawTransactionWithDataPrehash() []byte {
-	// Cache the prehash
-	if rawTransactionWithDataPrehash == nil {
-		b32 := sha3.Sum256([]byte(rawTransactionWithDataPrehashStr))
-		out := make([]byte, len(b32))
-		copy(out, b32[:])
-		rawTransactionPrehash = out
-		return out
-	}
-	return rawTransactionPrehash
+	return rawTransactionWithDataPrehash
 }
 
 type RawTransactionWithDataVariant uint32
@@ -158,13 +174,44 @@ type RawTransactionWithDataImpl interface {
 	bcs.Struct
 }
 
-// TODO: make a function to make creating this easier
-
 type RawTransactionWithData struct {
 	Variant RawTransactionWithDataVariant
 	Inner   RawTransactionWithDataImpl
 }
 
+// NewFeePayerTransaction wraps a [RawTransaction] as a fee payer transaction, without needing a node
+//
+// The fee payer can be [AccountZero] if it isn't known when the sender signs, and then set later with
+// [RawTransactionWithData.SetFeePayer] before the fee payer signs.
+func NewFeePayerTransaction(rawTxn *RawTransaction, feePayer AccountAddress, additionalSigners ...AccountAddress) *RawTransactionWithData {
+	if additionalSigners == nil {
+		additionalSigners = []AccountAddress{}
+	}
+	return &RawTransactionWithData{
+		Variant: MultiAgentWithFeePayerRawTransactionWithDataVariant,
+		Inner: &MultiAgentWithFeePayerRawTransactionWithData{
+			RawTxn:           rawTxn,
+			FeePayer:         &feePayer,
+			SecondarySigners: additionalSigners,
+		},
+	}
+}
+
+// NewMultiAgentTransaction wraps a [RawTransaction] as a multi-agent transaction with the secondary signers, without
+// needing a node
+func NewMultiAgentTransaction(rawTxn *RawTransaction, secondarySigners ...AccountAddress) *RawTransactionWithData {
+	if secondarySigners == nil {
+		secondarySigners = []AccountAddress{}
+	}
+	return &RawTransactionWithData{
+		Variant: MultiAgentRawTransactionWithDataVariant,
+		Inner: &MultiAgentRawTransactionWithData{
+			RawTxn:           rawTxn,
+			SecondarySigners: secondarySigners,
+		},
+	}
+}
+
 func (txn *RawTransactionWithData) SetFeePayer(
 	feePayer AccountAddress,
 ) bool {
@@ -223,6 +270,81 @@ func (txn *RawTransactionWithData) ToFeePayerSignedTransaction(
 	}, true
 }
 
+// FeePayerSignedTransaction signs a fee payer transaction with the sender, fee payer, and any additional signers, and
+// combines the signatures into a [SignedTransaction]
+//
+// The additional signers must be in the same order as the secondary signer addresses of the transaction.  If the
+// signatures are collected separately, use [RawTransactionWithData.ToFeePayerSignedTransaction] to combine them.
+func (txn *RawTransactionWithData) FeePayerSignedTransaction(sender crypto.Signer, feePayer crypto.Signer, additionalSigners ...crypto.Signer) (*SignedTransaction, error) {
+	if txn.Variant != MultiAgentWithFeePayerRawTransactionWithDataVariant {
+		return nil, fmt.Errorf("transaction is not a fee payer transaction, variant %d", txn.Variant)
+	}
+	feePayerTxn := txn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	if feePayerTxn.FeePayer == nil {
+		return nil, errors.New("fee payer address is not set")
+	}
+	if len(additionalSigners) != len(feePayerTxn.SecondarySigners) {
+		return nil, fmt.Errorf("expected %d additional signers, got %d", len(feePayerTxn.SecondarySigners), len(additionalSigners))
+	}
+
+	senderAuth, err := txn.Sign(sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign as sender: %w", err)
+	}
+	feePayerAuth, err := txn.Sign(feePayer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign as fee payer: %w", err)
+	}
+	additionalAuths := make([]crypto.AccountAuthenticator, len(additionalSigners))
+	for i, signer := range additionalSigners {
+		auth, err := txn.Sign(signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign as additional signer %d: %w", i, err)
+		}
+		additionalAuths[i] = *auth
+	}
+
+	signedTxn, ok := txn.ToFeePayerSignedTransaction(senderAuth, feePayerAuth, additionalAuths)
+	if !ok {
+		return nil, errors.New("failed to build fee payer signed transaction")
+	}
+	return signedTxn, nil
+}
+
+// MultiAgentSignedTransaction signs a multi-agent transaction with the sender and the secondary signers, and combines
+// the signatures into a [SignedTransaction]
+//
+// The secondary signers must be in the same order as the secondary signer addresses of the transaction.  If the
+// signatures are collected separately, use [RawTransactionWithData.ToMultiAgentSignedTransaction] to combine them.
+func (txn *RawTransactionWithData) MultiAgentSignedTransaction(sender crypto.Signer, secondarySigners ...crypto.Signer) (*SignedTransaction, error) {
+	if txn.Variant != MultiAgentRawTransactionWithDataVariant {
+		return nil, fmt.Errorf("transaction is not a multi-agent transaction, variant %d", txn.Variant)
+	}
+	multiAgent := txn.Inner.(*MultiAgentRawTransactionWithData)
+	if len(secondarySigners) != len(multiAgent.SecondarySigners) {
+		return nil, fmt.Errorf("expected %d secondary signers, got %d", len(multiAgent.SecondarySigners), len(secondarySigners))
+	}
+
+	senderAuth, err := txn.Sign(sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign as sender: %w", err)
+	}
+	secondaryAuths := make([]crypto.AccountAuthenticator, len(secondarySigners))
+	for i, signer := range secondarySigners {
+		auth, err := txn.Sign(signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign as secondary signer %d: %w", i, err)
+		}
+		secondaryAuths[i] = *auth
+	}
+
+	signedTxn, ok := txn.ToMultiAgentSignedTransaction(senderAuth, secondaryAuths)
+	if !ok {
+		return nil, errors.New("failed to build multi-agent signed transaction")
+	}
+	return signedTxn, nil
+}
+
 //region RawTransactionWithData Signer
 
 func (txn *RawTransactionWithData) Sign(signer crypto.Signer) (authenticator *crypto.AccountAuthenticator, err error) {
@@ -0,0 +1,90 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestTimeoutOption is an option to [NewClient] to time out each request to the node, see [WithRequestTimeout]
+type RequestTimeoutOption struct {
+	Timeout time.Duration
+}
+
+// WithRequestTimeout is an option to [NewClient] to time out each request to the node, without having to pass a
+// context to every call, see [NodeClient.SetRequestTimeout]
+//
+//	client, err := NewClient(MainnetConfig, WithRequestTimeout(5*time.Second))
+func WithRequestTimeout(timeout time.Duration) RequestTimeoutOption {
+	return RequestTimeoutOption{Timeout: timeout}
+}
+
+// RequestTimeoutError is returned when a request to the node takes longer than the request timeout, see
+// [NodeClient.SetRequestTimeout]
+type RequestTimeoutError struct {
+	Timeout time.Duration // Timeout is the request timeout that was exceeded
+	Err     error         // Err is the error from sending the request
+}
+
+// Error returns the timeout, and the error from sending the request
+//
+// Implements:
+//   - [error]
+func (e *RequestTimeoutError) Error() string {
+	return fmt.Sprintf("request timed out after %s: %s", e.Timeout, e.Err)
+}
+
+// Unwrap returns the error from sending the request, which is a [context.DeadlineExceeded]
+func (e *RequestTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// SetRequestTimeout sets a timeout on each request for all future requests, 0 or less for no timeout.
+//
+//	client.SetRequestTimeout(5 * time.Second)
+//
+// The timeout is a deadline derived from the context of the request, so the shorter of the two applies, see
+// [NodeClient.WithContext].  With a [RetryPolicy], the timeout is for each attempt rather than the whole request, and an
+// attempt that times out is retried by [DefaultRetryable].  Unlike [NodeClient.SetTimeout], it doesn't change the
+// HTTP client, which may be shared.
+func (rc *NodeClient) SetRequestTimeout(timeout time.Duration) {
+	rc.requestTimeout = timeout
+}
+
+// sendFunc sends a single attempt of a request
+type sendFunc func(req *http.Request) (*http.Response, error)
+
+// withRequestTimeout wraps the send, so each attempt is bound to a context with the timeout.  The context is released
+// once the response body is closed, so the body can still be read after the send returns.
+func withRequestTimeout(send sendFunc, timeout time.Duration) sendFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		response, err := send(req.WithContext(ctx))
+		if err != nil {
+			// Only a timeout of this attempt is a RequestTimeoutError, not the caller's own deadline
+			if errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+				err = &RequestTimeoutError{Timeout: timeout, Err: err}
+			}
+			cancel()
+			return response, err
+		}
+		response.Body = &cancelOnCloseBody{ReadCloser: response.Body, cancel: cancel}
+		return response, nil
+	}
+}
+
+// cancelOnCloseBody is a response body which cancels the context of its request when closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body, then cancels the context
+func (body *cancelOnCloseBody) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+	return err
+}
@@ -0,0 +1,95 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSlowServerClient serves the node info, but responds slowly to the first slowRequests requests
+func newSlowServerClient(t *testing.T, slowRequests int32) (*Client, *atomic.Int32) {
+	requests := &atomic.Int32{}
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= slowRequests {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+		_, _ = fmt.Fprint(w, testNodeInfoJson)
+	})
+	return client, requests
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: "http://localhost:8080/v1"}, WithRequestTimeout(2*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, client.nodeClient.requestTimeout)
+
+	// The HTTP client isn't changed
+	assert.Equal(t, 60*time.Second, client.nodeClient.client.Timeout)
+}
+
+func TestSetRequestTimeout(t *testing.T) {
+	client, requests := newSlowServerClient(t, 1)
+	client.SetRequestTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := client.Info()
+	assert.Less(t, time.Since(start), 5*time.Second)
+	var timeoutErr *RequestTimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, 50*time.Millisecond, timeoutErr.Timeout)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), requests.Load())
+
+	// A fast response is read in full, after the send returns
+	info, err := client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), info.LedgerVersion())
+}
+
+func TestSetRequestTimeout_ShorterContextDeadline(t *testing.T) {
+	client, _ := newSlowServerClient(t, 1)
+	client.SetRequestTimeout(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.WithContext(ctx).Info()
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// The caller's own deadline isn't a request timeout
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	var timeoutErr *RequestTimeoutError
+	assert.False(t, errors.As(err, &timeoutErr))
+}
+
+func TestSetRequestTimeout_PerAttempt(t *testing.T) {
+	// The first attempt times out, and the retry succeeds
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	client, requests := newSlowServerClient(t, 1)
+	client.nodeClient.SetRetryPolicy(policy)
+	client.SetRequestTimeout(50 * time.Millisecond)
+
+	info, err := client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), info.LedgerVersion())
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Every attempt times out
+	client, requests = newSlowServerClient(t, 10)
+	client.nodeClient.SetRetryPolicy(policy)
+	client.SetRequestTimeout(50 * time.Millisecond)
+	_, err = client.Info()
+	var timeoutErr *RequestTimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, int32(3), requests.Load())
+}
@@ -0,0 +1,111 @@
+package aptos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+)
+
+// AccountResourceClient is the ability to fetch an account's resources as JSON, see [GetAccountResource].  It is
+// implemented by [Client] and [NodeClient].
+type AccountResourceClient interface {
+	AccountResource(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data map[string]any, err error)
+}
+
+// AccountResourceBCSClient is the ability to fetch an account's resources as BCS, see [AccountResourceBCSInto].  It is
+// implemented by [Client] and [NodeClient].
+type AccountResourceBCSClient interface {
+	AccountResourceBCS(address AccountAddress, resourceType string, ledgerVersion ...uint64) (data []byte, err error)
+}
+
+// ResourceNotFoundError is returned by [GetAccountResource] and [AccountResourceBCSInto] when the account doesn't have the resource, or the account
+// doesn't exist.  It wraps the error from the node, so [IsNotFound] is also true for it.
+type ResourceNotFoundError struct {
+	Address      AccountAddress // Address is the account the resource was fetched from
+	ResourceType string         // ResourceType is the type of the resource e.g. 0x1::account::Account
+	Err          error          // Err is the error from the node
+}
+
+// Error returns the address and type of the missing resource
+//
+// Implements:
+//   - [error]
+func (e *ResourceNotFoundError) Error() string {
+	return fmt.Sprintf("resource %s not found at %s", e.ResourceType, e.Address.String())
+}
+
+// Unwrap returns the error from the node
+func (e *ResourceNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// GetAccountResource fetches a resource of an account, and decodes its data into T with [json.Unmarshal].  The fields
+// of T should follow the JSON form of the Move struct e.g. with u64 fields as strings, or [api.U64].
+//
+//	type CoinStore struct {
+//		Coin struct {
+//			Value api.U64 `json:"value"`
+//		} `json:"coin"`
+//		Frozen bool `json:"frozen"`
+//	}
+//	store, err := GetAccountResource[CoinStore](client, address, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>")
+//
+// Returns a [ResourceNotFoundError] if the account doesn't have the resource, or doesn't exist.  Optionally, a
+// ledgerVersion can be given to get the resource at a specific ledger version.
+func GetAccountResource[T any](client AccountResourceClient, address AccountAddress, resourceType string, ledgerVersion ...uint64) (*T, error) {
+	resource, err := client.AccountResource(address, resourceType, ledgerVersion...)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, &ResourceNotFoundError{Address: address, ResourceType: resourceType, Err: err}
+		}
+		return nil, err
+	}
+	data, ok := resource["data"]
+	if !ok {
+		return nil, errors.New("resource response has no data")
+	}
+	blob, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	out := new(T)
+	err = json.Unmarshal(blob, out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode resource %s as %T: %w", resourceType, out, err)
+	}
+	return out, nil
+}
+
+// AccountResourceBCSInto fetches a resource of an account as BCS, and decodes it into T with [bcs.Deserialize].  *T
+// must implement [bcs.Unmarshaler], following the field order of the Move struct.  All the bytes must be used.
+//
+//	type Account struct {
+//		AuthenticationKey []byte
+//		SequenceNumber    uint64
+//		...
+//	}
+//	func (a *Account) UnmarshalBCS(des *bcs.Deserializer) { ... }
+//	account, err := AccountResourceBCSInto[Account](client, address, "0x1::account::Account")
+//
+// Returns a [ResourceNotFoundError] if the account doesn't have the resource, or doesn't exist.  Optionally, a
+// ledgerVersion can be given to get the resource at a specific ledger version.
+func AccountResourceBCSInto[T any, PT interface {
+	*T
+	bcs.Unmarshaler
+}](client AccountResourceBCSClient, address AccountAddress, resourceType string, ledgerVersion ...uint64) (*T, error) {
+	data, err := client.AccountResourceBCS(address, resourceType, ledgerVersion...)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, &ResourceNotFoundError{Address: address, ResourceType: resourceType, Err: err}
+		}
+		return nil, err
+	}
+	out := new(T)
+	err = bcs.Deserialize(PT(out), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode resource %s as %T: %w", resourceType, out, err)
+	}
+	return out, nil
+}
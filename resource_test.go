@@ -2,9 +2,14 @@ package aptos
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/aptos-labs/aptos-go-sdk/api"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
 	"github.com/stretchr/testify/assert"
 	"io"
+	"math"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -31,3 +36,229 @@ func TestMoveResourceBCS(t *testing.T) {
 	assert.Equal(t, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", resources[0].Tag.String())
 	assert.Equal(t, "0x1::account::Account", resources[1].Tag.String())
 }
+
+// testCoinStore is a user defined struct for 0x1::coin::CoinStore
+type testCoinStore struct {
+	Coin struct {
+		Value api.U64 `json:"value"`
+	} `json:"coin"`
+	Frozen        bool `json:"frozen"`
+	DepositEvents struct {
+		Counter api.U64 `json:"counter"`
+		Guid    struct {
+			Id struct {
+				Addr        *AccountAddress `json:"addr"`
+				CreationNum api.U64         `json:"creation_num"`
+			} `json:"id"`
+		} `json:"guid"`
+	} `json:"deposit_events"`
+}
+
+func TestGetAccountResource(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts/"+AccountOne.String()+"/resource/0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", r.URL.Path)
+		assert.Equal(t, "12", r.URL.Query().Get("ledger_version"))
+		_, _ = w.Write([]byte(`{
+			"type": "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+			"data": {
+				"coin": {"value": "18446744073709551615"},
+				"deposit_events": {"counter": "3", "guid": {"id": {"addr": "0x1", "creation_num": "2"}}},
+				"frozen": true,
+				"withdraw_events": {"counter": "0", "guid": {"id": {"addr": "0x1", "creation_num": "3"}}}
+			}
+		}`))
+	})
+
+	store, err := GetAccountResource[testCoinStore](client, AccountOne, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, api.U64(math.MaxUint64), store.Coin.Value)
+	assert.True(t, store.Frozen)
+	assert.Equal(t, api.U64(3), store.DepositEvents.Counter)
+	assert.Equal(t, AccountOne, *store.DepositEvents.Guid.Id.Addr)
+	assert.Equal(t, api.U64(2), store.DepositEvents.Guid.Id.CreationNum)
+
+	// Also with the node client, and into a map
+	data, err := GetAccountResource[map[string]any](client.nodeClient, AccountOne, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, true, (*data)["frozen"])
+}
+
+func TestGetAccountResource_NotFound(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Resource not found by Address(0x1), Struct tag(0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>) and Ledger version(5)","error_code":"resource_not_found","vm_error_code":null}`))
+	})
+
+	store, err := GetAccountResource[testCoinStore](client, AccountOne, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>")
+	assert.Nil(t, store)
+	var notFound *ResourceNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, AccountOne, notFound.Address)
+	assert.Equal(t, "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", notFound.ResourceType)
+	assert.True(t, IsNotFound(err))
+	var apiErr *api.Error
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, api.ErrorCodeResourceNotFound, apiErr.ErrorCode)
+}
+
+func TestGetAccountResource_Errors(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "Bad") {
+			_, _ = w.Write([]byte(`{"type": "0x1::test::Bad", "data": {"coin": "not a struct"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	// Other errors are passed through
+	_, err := GetAccountResource[testCoinStore](client, AccountOne, "0x1::test::Other")
+	var notFound *ResourceNotFoundError
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &notFound))
+
+	// The data must match the type
+	_, err = GetAccountResource[testCoinStore](client, AccountOne, "0x1::test::Bad")
+	assert.ErrorContains(t, err, "failed to decode resource 0x1::test::Bad")
+}
+
+// testEventHandle is the BCS layout of 0x1::event::EventHandle
+type testEventHandle struct {
+	Counter     uint64
+	CreationNum uint64
+	Addr        AccountAddress
+}
+
+func (h *testEventHandle) MarshalBCS(ser *bcs.Serializer) {
+	ser.U64(h.Counter)
+	ser.U64(h.CreationNum)
+	ser.Struct(&h.Addr)
+}
+
+func (h *testEventHandle) UnmarshalBCS(des *bcs.Deserializer) {
+	h.Counter = des.U64()
+	h.CreationNum = des.U64()
+	des.Struct(&h.Addr)
+}
+
+// testAccountResource is the BCS layout of 0x1::account::Account
+type testAccountResource struct {
+	AuthenticationKey       []byte
+	SequenceNumber          uint64
+	GuidCreationNum         uint64
+	CoinRegisterEvents      testEventHandle
+	KeyRotationEvents       testEventHandle
+	RotationCapabilityOffer *AccountAddress
+	SignerCapabilityOffer   *AccountAddress
+}
+
+func (a *testAccountResource) MarshalBCS(ser *bcs.Serializer) {
+	ser.WriteBytes(a.AuthenticationKey)
+	ser.U64(a.SequenceNumber)
+	ser.U64(a.GuidCreationNum)
+	ser.Struct(&a.CoinRegisterEvents)
+	ser.Struct(&a.KeyRotationEvents)
+	bcs.SerializeOption(ser, a.RotationCapabilityOffer, func(ser *bcs.Serializer, item AccountAddress) {
+		ser.Struct(&item)
+	})
+	bcs.SerializeOption(ser, a.SignerCapabilityOffer, func(ser *bcs.Serializer, item AccountAddress) {
+		ser.Struct(&item)
+	})
+}
+
+func (a *testAccountResource) UnmarshalBCS(des *bcs.Deserializer) {
+	a.AuthenticationKey = des.ReadBytes()
+	a.SequenceNumber = des.U64()
+	a.GuidCreationNum = des.U64()
+	des.Struct(&a.CoinRegisterEvents)
+	des.Struct(&a.KeyRotationEvents)
+	a.RotationCapabilityOffer = bcs.DeserializeOption(des, func(des *bcs.Deserializer, out *AccountAddress) {
+		des.Struct(out)
+	})
+	a.SignerCapabilityOffer = bcs.DeserializeOption(des, func(des *bcs.Deserializer, out *AccountAddress) {
+		des.Struct(out)
+	})
+}
+
+func TestAccountResourceBCS(t *testing.T) {
+	// The 0x1::account::Account resource from TestMoveResourceBCS
+	blob, err := decodeB64("AgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABBGNvaW4JQ29pblN0b3JlAQcAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQphcHRvc19jb2luCUFwdG9zQ29pbgBpKsLrCwAAAAAAAgAAAAAAAAACAAAAAAAAANGdA6RyqwjAFP2cXRokfP3YJqHHNb55lM2GQFYwd6a7AAAAAAAAAAADAAAAAAAAANGdA6RyqwjAFP2cXRokfP3YJqHHNb55lM2GQFYwd6a7AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAEHYWNjb3VudAdBY2NvdW50AJMBINGdA6RyqwjAFP2cXRokfP3YJqHHNb55lM2GQFYwd6a7AAAAAAAAAAAEAAAAAAAAAAEAAAAAAAAAAAAAAAAAAADRnQOkcqsIwBT9nF0aJHz92CahxzW+eZTNhkBWMHemuwAAAAAAAAAAAQAAAAAAAADRnQOkcqsIwBT9nF0aJHz92CahxzW+eZTNhkBWMHemuwAA")
+	assert.NoError(t, err)
+	records := bcs.DeserializeSequence[AccountResourceRecord](bcs.NewDeserializer(blob))
+	accountBytes := records[1].Data
+
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts/"+AccountOne.String()+"/resource/0x1::account::Account", r.URL.Path)
+		assert.Equal(t, "application/x-bcs", r.Header.Get("Accept"))
+		assert.Equal(t, "12", r.URL.Query().Get("ledger_version"))
+		w.Header().Set("Content-Type", "application/x-bcs")
+		_, _ = w.Write(accountBytes)
+	})
+
+	data, err := client.AccountResourceBCS(AccountOne, "0x1::account::Account", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, accountBytes, data)
+
+	account, err := AccountResourceBCSInto[testAccountResource](client, AccountOne, "0x1::account::Account", 12)
+	assert.NoError(t, err)
+	assert.Len(t, account.AuthenticationKey, 32)
+	assert.Equal(t, uint64(0), account.SequenceNumber)
+	assert.Equal(t, uint64(4), account.GuidCreationNum)
+	assert.Equal(t, uint64(1), account.CoinRegisterEvents.Counter)
+	assert.Equal(t, uint64(0), account.CoinRegisterEvents.CreationNum)
+	assert.Equal(t, uint64(1), account.KeyRotationEvents.CreationNum)
+	assert.Nil(t, account.RotationCapabilityOffer)
+	assert.Nil(t, account.SignerCapabilityOffer)
+
+	// The address of the event handles is the account itself, the same as its authentication key
+	assert.Equal(t, account.AuthenticationKey, account.CoinRegisterEvents.Addr[:])
+
+	// It serializes back to the same bytes
+	reserialized, err := bcs.Serialize(account)
+	assert.NoError(t, err)
+	assert.Equal(t, accountBytes, reserialized)
+}
+
+func TestAccountResourceBCSInto_Errors(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "Missing") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Resource not found","error_code":"resource_not_found","vm_error_code":null}`))
+			return
+		}
+		// Too short for the struct
+		_, _ = w.Write([]byte{0x01})
+	})
+
+	_, err := AccountResourceBCSInto[testAccountResource](client.nodeClient, AccountOne, "0x1::test::Missing")
+	var notFound *ResourceNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "0x1::test::Missing", notFound.ResourceType)
+
+	_, err = AccountResourceBCSInto[testAccountResource](client.nodeClient, AccountOne, "0x1::test::Short")
+	assert.ErrorContains(t, err, "failed to decode resource 0x1::test::Short")
+}
+
+func TestAccountResourceInfo_Getters(t *testing.T) {
+	resource := &AccountResourceInfo{}
+	err := json.Unmarshal([]byte(`{
+		"type": "0x1::fungible_asset::FungibleStore",
+		"data": {"balance": "1000", "frozen": true, "metadata": {"inner": "0xa"}, "name": "store"}
+	}`), resource)
+	assert.NoError(t, err)
+
+	balance, err := resource.GetU64("balance")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), balance)
+	frozen, err := resource.GetBool("frozen")
+	assert.NoError(t, err)
+	assert.True(t, frozen)
+	metadata, err := resource.GetAddress("metadata.inner")
+	assert.NoError(t, err)
+	assert.Equal(t, AccountAddress{31: 0xa}, metadata)
+	name, err := resource.GetString("name")
+	assert.NoError(t, err)
+	assert.Equal(t, "store", name)
+
+	_, err = resource.GetU64("missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts = 3                      // DefaultRetryMaxAttempts is the default number of attempts, including the first request
+	DefaultRetryBaseDelay   = 100 * time.Millisecond // DefaultRetryBaseDelay is the default delay before the first retry
+	DefaultRetryMaxDelay    = 5 * time.Second        // DefaultRetryMaxDelay is the default maximum delay between retries
+)
+
+// RetryPolicy will retry requests to the node that fail with a transient error, with exponential backoff and jitter.
+// Retries are off by default, and can be enabled by passing it as an option to [NewClient] or with
+// [NodeClient.SetRetryPolicy]
+//
+// Any zero fields will use the defaults.
+//
+//	client, err := NewClient(MainnetConfig, RetryPolicy{MaxAttempts: 5})
+type RetryPolicy struct {
+	MaxAttempts int           // MaxAttempts is the total number of attempts, including the first request. Default [DefaultRetryMaxAttempts]
+	BaseDelay   time.Duration // BaseDelay is the delay before the first retry, it doubles on each retry.  Default [DefaultRetryBaseDelay]
+	MaxDelay    time.Duration // MaxDelay is the maximum delay between retries, including any Retry-After header.  Default [DefaultRetryMaxDelay]
+
+	// Retryable decides whether a request should be retried from the response or error.  Default [DefaultRetryable]
+	Retryable func(response *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy is a [RetryPolicy] with all default values
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		BaseDelay:   DefaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+		Retryable:   DefaultRetryable,
+	}
+}
+
+// DefaultRetryable retries on network errors, 429 Too Many Requests, and 5xx server errors
+//
+// Requests that were cancelled by their context are not retried, but an attempt that exceeded the request timeout is,
+// see [NodeClient.SetRequestTimeout].
+func DefaultRetryable(response *http.Response, err error) bool {
+	if err != nil {
+		var timeoutErr *RequestTimeoutError
+		if errors.As(err, &timeoutErr) {
+			return true
+		}
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+}
+
+// withDefaults fills in any zero fields with the defaults
+func (policy RetryPolicy) withDefaults() RetryPolicy {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultRetryBaseDelay
+	}
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = DefaultRetryMaxDelay
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = DefaultRetryable
+	}
+	return policy
+}
+
+// delay is the time to wait before the given retry, starting at 1
+//
+// A Retry-After header on a 429 or 503 takes precedence over the backoff, but is still capped by the MaxDelay.
+func (policy *RetryPolicy) delay(retry int, response *http.Response) time.Duration {
+	if response != nil && (response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable) {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			return min(retryAfter, policy.MaxDelay)
+		}
+	}
+
+	// Exponential backoff, with half of it as jitter to spread out retries from concurrent requests
+	// Doubling stops once it reaches the max delay, so the shift can't overflow
+	backoff := policy.MaxDelay
+	if retry-1 < 63 && policy.BaseDelay <= policy.MaxDelay>>(retry-1) {
+		backoff = policy.BaseDelay << (retry - 1)
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// do sends the request with send, retrying by the policy, calling onAttempt if set after each attempt
+//
+// Requests with a body are only retried if the body can be replayed e.g. it was made from a [bytes.Reader]
+func (policy *RetryPolicy) do(send sendFunc, req *http.Request, onAttempt attemptHook) (response *http.Response, err error) {
+	canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		response, err = send(req)
+		if onAttempt != nil {
+			onAttempt(attempt, response, err, time.Since(start))
+		}
+		if attempt >= policy.MaxAttempts || !canReplay || !policy.Retryable(response, err) {
+			return response, err
+		}
+
+		delay := policy.delay(attempt, response)
+		if response != nil {
+			// Drain the body, so the connection can be reused
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
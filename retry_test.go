@@ -0,0 +1,205 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testNodeInfoJson = `{
+	"chain_id": 4,
+	"epoch": "1",
+	"ledger_version": "100",
+	"oldest_ledger_version": "0",
+	"ledger_timestamp": "1000",
+	"node_role": "full_node",
+	"oldest_block_height": "0",
+	"block_height": "10",
+	"git_hash": "abcd"
+}`
+
+// newFlakyServerClient creates a client against a mock server that fails with the status code a number of times,
+// then succeeds
+func newFlakyServerClient(t *testing.T, failures int32, statusCode int, options ...any) (*Client, *atomic.Int32) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(statusCode)
+			_, _ = fmt.Fprint(w, `{"message":"flaky","error_code":"internal_error","vm_error_code":null}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, testNodeInfoJson)
+	})
+	for _, option := range options {
+		client.nodeClient.SetRetryPolicy(option.(RetryPolicy))
+	}
+	return client, &calls
+}
+
+func TestRetryPolicy_RetriesThenSucceeds(t *testing.T) {
+	client, calls := newFlakyServerClient(t, 2, http.StatusServiceUnavailable, RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	})
+	info, err := client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(4), info.ChainId)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryPolicy_GivesUp(t *testing.T) {
+	client, calls := newFlakyServerClient(t, 5, http.StatusInternalServerError, RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	})
+	_, err := client.Info()
+	assert.Error(t, err)
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+	assert.Contains(t, string(httpErr.Body), "flaky")
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryPolicy_DisabledByDefault(t *testing.T) {
+	client, calls := newFlakyServerClient(t, 1, http.StatusServiceUnavailable)
+	_, err := client.Info()
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicy_NotRetryable(t *testing.T) {
+	client, calls := newFlakyServerClient(t, 1, http.StatusNotFound, RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+	})
+	_, err := client.Info()
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Custom predicate
+	client, calls = newFlakyServerClient(t, 1, http.StatusNotFound, RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		Retryable: func(response *http.Response, err error) bool {
+			return err == nil && response.StatusCode == http.StatusNotFound
+		},
+	})
+	_, err = client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryPolicy_RetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var firstCall time.Time
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			firstCall = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		assert.GreaterOrEqual(t, time.Since(firstCall), 900*time.Millisecond)
+		_, _ = fmt.Fprint(w, testNodeInfoJson)
+	})
+	client.nodeClient.SetRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    2 * time.Second,
+	})
+	_, err := client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryPolicy_ReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, body)
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = fmt.Fprint(w, `["0x1"]`)
+	})
+	client.nodeClient.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond})
+	out, err := Post[[]string](client.nodeClient, client.nodeClient.baseUrl.String(), "application/octet-stream", bytes.NewReader([]byte{1, 2, 3}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0x1"}, out)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryPolicy_NetworkError(t *testing.T) {
+	client, err := NewClient(NetworkConfig{
+		Name:    "closed",
+		ChainId: 4,
+		// Nothing should be listening on this port
+		NodeUrl: "http://127.0.0.1:1/v1",
+	}, RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: 10 * time.Millisecond})
+	assert.NoError(t, err)
+	start := time.Now()
+	_, err = client.Info()
+	assert.Error(t, err)
+	// Two retries at least 5ms each with jitter
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	assert.True(t, DefaultRetryable(nil, errors.New("connection reset")))
+	assert.False(t, DefaultRetryable(nil, context.Canceled))
+	assert.False(t, DefaultRetryable(nil, fmt.Errorf("wrapped %w", context.DeadlineExceeded)))
+	assert.True(t, DefaultRetryable(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.True(t, DefaultRetryable(&http.Response{StatusCode: http.StatusInternalServerError}, nil))
+	assert.True(t, DefaultRetryable(&http.Response{StatusCode: http.StatusGatewayTimeout}, nil))
+	assert.False(t, DefaultRetryable(&http.Response{StatusCode: http.StatusOK}, nil))
+	assert.False(t, DefaultRetryable(&http.Response{StatusCode: http.StatusBadRequest}, nil))
+	assert.False(t, DefaultRetryable(&http.Response{StatusCode: http.StatusNotFound}, nil))
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}.withDefaults()
+	for retry := 1; retry < 40; retry++ {
+		delay := policy.delay(retry, nil)
+		expected := min(policy.BaseDelay<<(min(retry, 32)-1), policy.MaxDelay)
+		if retry >= 32 {
+			expected = policy.MaxDelay
+		}
+		assert.GreaterOrEqual(t, delay, expected/2)
+		assert.LessOrEqual(t, delay, expected)
+	}
+
+	// A large base delay with many attempts doesn't overflow
+	policy = RetryPolicy{MaxAttempts: 40, BaseDelay: 10 * time.Second, MaxDelay: time.Hour}.withDefaults()
+	for retry := 1; retry < 70; retry++ {
+		delay := policy.delay(retry, nil)
+		expected := policy.MaxDelay
+		if retry <= 9 {
+			expected = policy.BaseDelay << (retry - 1)
+		}
+		assert.GreaterOrEqual(t, delay, expected/2)
+		assert.LessOrEqual(t, delay, expected)
+	}
+
+	// Retry-After is capped by the max delay
+	policy = RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}.withDefaults()
+	response := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	response.Header.Set("Retry-After", "2")
+	assert.Equal(t, time.Second, policy.delay(1, response))
+	response.Header.Set("Retry-After", "0")
+	assert.Equal(t, time.Duration(0), policy.delay(1, response))
+	response.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.Equal(t, time.Duration(0), policy.delay(1, response))
+}
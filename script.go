@@ -15,6 +15,35 @@ type Script struct {
 	Args     []ScriptArgument // The arguments
 }
 
+// NewScript builds a [Script] payload from compiled script bytecode, with its type arguments and arguments.  It checks
+// the bytecode isn't empty, and that every argument can be serialized as its variant, so the transaction won't fail to
+// serialize later.
+//
+//	script, err := NewScript(code, []TypeTag{AptosCoinTypeTag}, []ScriptArgument{
+//		{Variant: ScriptArgumentAddress, Value: dest},
+//		{Variant: ScriptArgumentU64, Value: uint64(100)},
+//	})
+//	rawTxn, err := client.BuildTransaction(sender.Address, TransactionPayload{Payload: script})
+//
+// Arguments of other types, e.g. structs, can be given already BCS encoded, see [SerializedScriptArgument].
+func NewScript(code []byte, typeArgs []TypeTag, args []ScriptArgument) (*Script, error) {
+	if len(code) == 0 {
+		return nil, fmt.Errorf("script bytecode is empty")
+	}
+	for i := range args {
+		if _, err := bcs.Serialize(&args[i]); err != nil {
+			return nil, fmt.Errorf("script argument %d: %w", i, err)
+		}
+	}
+	if typeArgs == nil {
+		typeArgs = []TypeTag{}
+	}
+	if args == nil {
+		args = []ScriptArgument{}
+	}
+	return &Script{Code: code, ArgTypes: typeArgs, Args: args}, nil
+}
+
 //region Script TransactionPayloadImpl
 
 func (s *Script) PayloadType() TransactionPayloadVariant {
@@ -48,15 +77,16 @@ func (s *Script) UnmarshalBCS(des *bcs.Deserializer) {
 type ScriptArgumentVariant uint32
 
 const (
-	ScriptArgumentU8       ScriptArgumentVariant = 0 // u8 type argument
-	ScriptArgumentU64      ScriptArgumentVariant = 1 // u64 type argument
-	ScriptArgumentU128     ScriptArgumentVariant = 2 // u128 type argument
-	ScriptArgumentAddress  ScriptArgumentVariant = 3 // address type argument
-	ScriptArgumentU8Vector ScriptArgumentVariant = 4 // vector<u8> type argument
-	ScriptArgumentBool     ScriptArgumentVariant = 5 // bool type argument
-	ScriptArgumentU16      ScriptArgumentVariant = 6 // u16 type argument
-	ScriptArgumentU32      ScriptArgumentVariant = 7 //	u32 type argument
-	ScriptArgumentU256     ScriptArgumentVariant = 8 //	u256 type argument
+	ScriptArgumentU8         ScriptArgumentVariant = 0 // u8 type argument
+	ScriptArgumentU64        ScriptArgumentVariant = 1 // u64 type argument
+	ScriptArgumentU128       ScriptArgumentVariant = 2 // u128 type argument
+	ScriptArgumentAddress    ScriptArgumentVariant = 3 // address type argument
+	ScriptArgumentU8Vector   ScriptArgumentVariant = 4 // vector<u8> type argument
+	ScriptArgumentBool       ScriptArgumentVariant = 5 // bool type argument
+	ScriptArgumentU16        ScriptArgumentVariant = 6 // u16 type argument
+	ScriptArgumentU32        ScriptArgumentVariant = 7 //	u32 type argument
+	ScriptArgumentU256       ScriptArgumentVariant = 8 //	u256 type argument
+	ScriptArgumentSerialized ScriptArgumentVariant = 9 // BCS encoded argument of any other type, see [SerializedScriptArgument]
 )
 
 // ScriptArgument a Move script argument, which encodes its type with it
@@ -65,6 +95,18 @@ type ScriptArgument struct {
 	Value   any                   // The value of the argument
 }
 
+// SerializedScriptArgument BCS encodes a value as a [ScriptArgumentSerialized] argument, for types without their own
+// variant, e.g. a struct implementing [bcs.Marshaler]
+//
+//	arg, err := SerializedScriptArgument(&moduleId)
+func SerializedScriptArgument(value bcs.Marshaler) (ScriptArgument, error) {
+	bytes, err := bcs.Serialize(value)
+	if err != nil {
+		return ScriptArgument{}, err
+	}
+	return ScriptArgument{Variant: ScriptArgumentSerialized, Value: bytes}, nil
+}
+
 //region ScriptArgument bcs.Struct
 // TODO: consider making a separate function to parse the value at input time rather than build time
 
@@ -96,17 +138,19 @@ func (sa *ScriptArgument) MarshalBCS(ser *bcs.Serializer) {
 		}
 		ser.U64(value)
 	case ScriptArgumentU128:
-		value, ok := (sa.Value).(big.Int)
+		value, ok := scriptArgumentBigInt(sa.Value)
 		if !ok {
-			ser.SetError(fmt.Errorf("invalid input type (%T) for ScriptArgument128, must be big.Int", sa.Value))
+			ser.SetError(fmt.Errorf("invalid input type (%T) for ScriptArgument128, must be big.Int or *big.Int", sa.Value))
+			return
 		}
-		ser.U128(value)
+		ser.U128(*value)
 	case ScriptArgumentU256:
-		value, ok := (sa.Value).(big.Int)
+		value, ok := scriptArgumentBigInt(sa.Value)
 		if !ok {
-			ser.SetError(fmt.Errorf("invalid input type (%T) for ScriptArgument256, must be big.Int", sa.Value))
+			ser.SetError(fmt.Errorf("invalid input type (%T) for ScriptArgument256, must be big.Int or *big.Int", sa.Value))
+			return
 		}
-		ser.U256(value)
+		ser.U256(*value)
 	case ScriptArgumentAddress:
 		addr, ok := (sa.Value).(AccountAddress)
 		if !ok {
@@ -125,6 +169,14 @@ func (sa *ScriptArgument) MarshalBCS(ser *bcs.Serializer) {
 			ser.SetError(fmt.Errorf("invalid input type (%T) for ScriptArgumentBool, must be bool", sa.Value))
 		}
 		ser.Bool(value)
+	case ScriptArgumentSerialized:
+		bytes, ok := (sa.Value).([]byte)
+		if !ok {
+			ser.SetError(fmt.Errorf("invalid input type (%T) for ScriptArgumentSerialized, must be []byte", sa.Value))
+		}
+		ser.WriteBytes(bytes)
+	default:
+		ser.SetError(fmt.Errorf("unsupported script argument variant %d", sa.Variant))
 	}
 }
 
@@ -151,6 +203,22 @@ func (sa *ScriptArgument) UnmarshalBCS(des *bcs.Deserializer) {
 		sa.Value = des.ReadBytes()
 	case ScriptArgumentBool:
 		sa.Value = des.Bool()
+	case ScriptArgumentSerialized:
+		sa.Value = des.ReadBytes()
+	default:
+		des.SetError(fmt.Errorf("unsupported script argument variant %d", sa.Variant))
+	}
+}
+
+// scriptArgumentBigInt returns the value of a u128 or u256 argument, which may be a [big.Int] or a non-nil *big.Int
+func scriptArgumentBigInt(value any) (*big.Int, bool) {
+	switch inner := value.(type) {
+	case big.Int:
+		return &inner, true
+	case *big.Int:
+		return inner, inner != nil
+	default:
+		return nil, false
 	}
 }
 
@@ -0,0 +1,100 @@
+package aptos
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScript(t *testing.T) {
+	serialized, err := SerializedScriptArgument(&AccountThree)
+	assert.NoError(t, err)
+	assert.Equal(t, ScriptArgumentSerialized, serialized.Variant)
+
+	script, err := NewScript([]byte{0xa1, 0x1c, 0xeb, 0x0b}, []TypeTag{AptosCoinTypeTag}, []ScriptArgument{
+		{Variant: ScriptArgumentU64, Value: uint64(100)},
+		{Variant: ScriptArgumentAddress, Value: AccountTwo},
+		serialized,
+	})
+	assert.NoError(t, err)
+	payload := TransactionPayload{Payload: script}
+	payloadBytes, err := bcs.Serialize(&payload)
+	assert.NoError(t, err)
+
+	expected := "00" + // script payload variant
+		"04a11ceb0b" + // code
+		"01" + "07" + "0000000000000000000000000000000000000000000000000000000000000001" + "0a6170746f735f636f696e" + "094170746f73436f696e" + "00" + // <0x1::aptos_coin::AptosCoin>
+		"03" + // 3 args
+		"01" + "6400000000000000" + // u64 100
+		"03" + "0000000000000000000000000000000000000000000000000000000000000002" + // address 0x2
+		"09" + "20" + "0000000000000000000000000000000000000000000000000000000000000003" // serialized address 0x3
+	assert.Equal(t, expected, hex.EncodeToString(payloadBytes))
+
+	// Round trips
+	decoded := &TransactionPayload{}
+	err = bcs.Deserialize(decoded, payloadBytes)
+	assert.NoError(t, err)
+	decodedScript := decoded.Payload.(*Script)
+	assert.Equal(t, script.Code, decodedScript.Code)
+	assert.Equal(t, AptosCoinTypeTag.String(), decodedScript.ArgTypes[0].String())
+	assert.Equal(t, script.Args, decodedScript.Args)
+
+	// Signs as a transaction
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	rawTxn := testRawTransactionForDeserialize(sender.Address, script)
+	signedTxn, err := rawTxn.SignedTransaction(sender)
+	assert.NoError(t, err)
+	assert.NoError(t, signedTxn.Verify())
+	decodedTxn := assertSignedTransactionRoundTrip(t, signedTxn)
+	assert.Equal(t, script.Args, decodedTxn.Transaction.(*RawTransaction).Payload.Payload.(*Script).Args)
+}
+
+func TestNewScript_NoArgs(t *testing.T) {
+	script, err := NewScript([]byte{0xa1, 0x1c, 0xeb, 0x0b}, nil, nil)
+	assert.NoError(t, err)
+	scriptBytes, err := bcs.Serialize(script)
+	assert.NoError(t, err)
+	assert.Equal(t, "04a11ceb0b0000", hex.EncodeToString(scriptBytes))
+}
+
+func TestScriptArgument_BigInt(t *testing.T) {
+	maxU128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	byValue, err := bcs.Serialize(&ScriptArgument{Variant: ScriptArgumentU128, Value: *maxU128})
+	assert.NoError(t, err)
+	byPointer, err := bcs.Serialize(&ScriptArgument{Variant: ScriptArgumentU128, Value: maxU128})
+	assert.NoError(t, err)
+	assert.Equal(t, "02"+strings.Repeat("ff", 16), hex.EncodeToString(byValue))
+	assert.Equal(t, byValue, byPointer)
+
+	u256, err := bcs.Serialize(&ScriptArgument{Variant: ScriptArgumentU256, Value: big.NewInt(0x0102)})
+	assert.NoError(t, err)
+	assert.Equal(t, "08"+"0201"+strings.Repeat("00", 30), hex.EncodeToString(u256))
+
+	// Out of range values are rejected, rather than truncated
+	_, err = NewScript([]byte{0xa1}, nil, []ScriptArgument{{Variant: ScriptArgumentU128, Value: new(big.Int).Add(maxU128, big.NewInt(1))}})
+	assert.ErrorContains(t, err, "out of range for u128")
+	_, err = NewScript([]byte{0xa1}, nil, []ScriptArgument{{Variant: ScriptArgumentU256, Value: big.NewInt(-1)}})
+	assert.ErrorContains(t, err, "out of range for u256")
+	_, err = NewScript([]byte{0xa1}, nil, []ScriptArgument{{Variant: ScriptArgumentU256, Value: (*big.Int)(nil)}})
+	assert.ErrorContains(t, err, "must be big.Int or *big.Int")
+}
+
+func TestNewScript_Errors(t *testing.T) {
+	_, err := NewScript(nil, nil, nil)
+	assert.ErrorContains(t, err, "empty")
+	_, err = NewScript([]byte{}, nil, nil)
+	assert.ErrorContains(t, err, "empty")
+
+	// Arguments must be the type of their variant
+	_, err = NewScript([]byte{0xa1}, nil, []ScriptArgument{{Variant: ScriptArgumentU64, Value: 100}})
+	assert.ErrorContains(t, err, "script argument 0")
+	_, err = NewScript([]byte{0xa1}, nil, []ScriptArgument{{Variant: ScriptArgumentSerialized, Value: "0x01"}})
+	assert.ErrorContains(t, err, "ScriptArgumentSerialized")
+	_, err = NewScript([]byte{0xa1}, nil, []ScriptArgument{{Variant: 100, Value: uint64(1)}})
+	assert.ErrorContains(t, err, "unsupported script argument variant 100")
+}
@@ -0,0 +1,132 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// VM status codes for sequence number mismatches, returned by the node on submission
+const (
+	vmErrorSequenceNumberTooOld = 3 // vmErrorSequenceNumberTooOld is SEQUENCE_NUMBER_TOO_OLD
+	vmErrorSequenceNumberTooNew = 4 // vmErrorSequenceNumberTooNew is SEQUENCE_NUMBER_TOO_NEW
+)
+
+// SequenceNumberManager hands out sequence numbers for a single account, so that many transactions can be built and
+// submitted concurrently without fetching the sequence number for each one
+//
+// The on-chain sequence number is fetched on first use, and then each call to [SequenceNumberManager.Next] returns the
+// next number.  If submission fails because of a sequence number mismatch, pass the error to
+// [SequenceNumberManager.HandleError] to fetch it again on the next use.
+//
+// It can be passed as an option to [NodeClient.BuildTransaction] and [NodeClient.BuildTransactionMultiAgent]:
+//
+//	manager := client.NewSequenceNumberManager(sender.Address)
+//	rawTxn, err := client.BuildTransaction(sender.Address, payload, manager)
+//	...
+//	_, err = client.SubmitTransaction(signedTxn)
+//	manager.HandleError(err)
+//
+// It is safe for concurrent use.
+type SequenceNumberManager struct {
+	rc      *NodeClient
+	address AccountAddress
+
+	mutex  sync.Mutex
+	next   uint64 // next is the next sequence number to hand out
+	synced bool   // synced is false when next needs to be fetched from on-chain
+}
+
+// NewSequenceNumberManager creates a [SequenceNumberManager] for the account.  Nothing is fetched until first use.
+func (rc *NodeClient) NewSequenceNumberManager(address AccountAddress) *SequenceNumberManager {
+	return &SequenceNumberManager{
+		rc:      rc,
+		address: address,
+	}
+}
+
+// Address is the account the sequence numbers are for
+func (m *SequenceNumberManager) Address() AccountAddress {
+	return m.address
+}
+
+// Next returns the next sequence number, fetching it from on-chain if it hasn't been yet, or after a reset
+//
+// Concurrent callers wait on the fetch, so every caller gets a unique number.
+func (m *SequenceNumberManager) Next() (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.synced {
+		err := m.syncLocked()
+		if err != nil {
+			return 0, err
+		}
+	}
+	next := m.next
+	m.next++
+	return next, nil
+}
+
+// nextFor returns the next sequence number, checking the manager is for the sender
+func (m *SequenceNumberManager) nextFor(sender AccountAddress) (uint64, error) {
+	if m.address != sender {
+		return 0, fmt.Errorf("sequence number manager is for %s, not the sender %s", m.address.String(), sender.String())
+	}
+	return m.Next()
+}
+
+// Resync fetches the sequence number from on-chain now, the next call to [SequenceNumberManager.Next] will return it
+func (m *SequenceNumberManager) Resync() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.syncLocked()
+}
+
+// Reset causes the sequence number to be fetched from on-chain on the next call to [SequenceNumberManager.Next]
+func (m *SequenceNumberManager) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.synced = false
+}
+
+// HandleError resets the manager if the error is from the node reporting a sequence number mismatch, and tells whether
+// it did.  Nil and other errors are ignored.
+func (m *SequenceNumberManager) HandleError(err error) bool {
+	if !IsSequenceNumberMismatch(err) {
+		return false
+	}
+	m.Reset()
+	return true
+}
+
+// syncLocked fetches the sequence number, the mutex must be held
+func (m *SequenceNumberManager) syncLocked() error {
+	account, err := m.rc.Account(m.address)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sequence number: %w", err)
+	}
+	sequenceNumber, err := account.SequenceNumber()
+	if err != nil {
+		return fmt.Errorf("failed to fetch sequence number: %w", err)
+	}
+	m.next = sequenceNumber
+	m.synced = true
+	return nil
+}
+
+// IsSequenceNumberMismatch tells whether the error is from the node rejecting a transaction with
+// SEQUENCE_NUMBER_TOO_OLD or SEQUENCE_NUMBER_TOO_NEW
+func IsSequenceNumberMismatch(err error) bool {
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	if apiErr, ok := httpErr.ApiError(); ok {
+		if apiErr.VmErrorCode == vmErrorSequenceNumberTooOld || apiErr.VmErrorCode == vmErrorSequenceNumberTooNew {
+			return true
+		}
+	}
+	body := string(httpErr.Body)
+	return strings.Contains(body, "SEQUENCE_NUMBER_TOO_OLD") || strings.Contains(body, "SEQUENCE_NUMBER_TOO_NEW")
+}
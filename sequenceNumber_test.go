@@ -0,0 +1,149 @@
+package aptos
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSequenceNumberServerClient creates a client against a mock server, which returns the node info, and the on-chain
+// sequence number for [AccountOne], and fails to estimate the gas price
+func newSequenceNumberServerClient(t *testing.T, onChain *atomic.Uint64) (*Client, *atomic.Int32) {
+	var calls atomic.Int32
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/estimate_gas_price" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Path == "/v1" {
+			_, _ = fmt.Fprint(w, testNodeInfoJson)
+			return
+		}
+		assert.Equal(t, "/v1/accounts/"+AccountOne.String(), r.URL.Path)
+		calls.Add(1)
+		_, _ = fmt.Fprintf(w, `{"sequence_number":"%d","authentication_key":"%s"}`, onChain.Load(), AccountOne.StringLong())
+	})
+	return client, &calls
+}
+
+func TestSequenceNumberManager_Concurrent(t *testing.T) {
+	var onChain atomic.Uint64
+	onChain.Store(5)
+	client, calls := newSequenceNumberServerClient(t, &onChain)
+	manager := client.NewSequenceNumberManager(AccountOne)
+	assert.Equal(t, AccountOne, manager.Address())
+
+	const numAcquires = 50
+	results := make(chan uint64, numAcquires)
+	var wg sync.WaitGroup
+	for i := 0; i < numAcquires; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			next, err := manager.Next()
+			assert.NoError(t, err)
+			results <- next
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// Every number is handed out exactly once, with a single fetch
+	seen := make(map[uint64]bool)
+	for next := range results {
+		assert.False(t, seen[next], "sequence number %d handed out twice", next)
+		seen[next] = true
+	}
+	for i := uint64(5); i < 5+numAcquires; i++ {
+		assert.True(t, seen[i], "sequence number %d not handed out", i)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestSequenceNumberManager_Resync(t *testing.T) {
+	var onChain atomic.Uint64
+	onChain.Store(5)
+	client, calls := newSequenceNumberServerClient(t, &onChain)
+	manager := client.NewSequenceNumberManager(AccountOne)
+
+	next, err := manager.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), next)
+	next, err = manager.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), next)
+
+	// Other errors are ignored
+	assert.False(t, manager.HandleError(nil))
+	assert.False(t, manager.HandleError(&HttpError{StatusCode: http.StatusBadRequest, Body: []byte(`{"message":"Invalid transaction: Type: Validation Code: INSUFFICIENT_BALANCE_FOR_TRANSACTION_FEE","error_code":"vm_error","vm_error_code":5}`)}))
+	next, err = manager.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), next)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Another process submitted transactions, so the node reports a mismatch and it's fetched again
+	onChain.Store(20)
+	tooOld := fmt.Errorf("submit transaction api err: %w", &HttpError{
+		StatusCode: http.StatusBadRequest,
+		Body:       []byte(`{"message":"Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_OLD","error_code":"vm_error","vm_error_code":3}`),
+	})
+	assert.True(t, IsSequenceNumberMismatch(tooOld))
+	assert.True(t, manager.HandleError(tooOld))
+	next, err = manager.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), next)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// A forced resync
+	onChain.Store(30)
+	err = manager.Resync()
+	assert.NoError(t, err)
+	next, err = manager.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(30), next)
+	assert.Equal(t, int32(3), calls.Load())
+
+	assert.True(t, IsSequenceNumberMismatch(&HttpError{Body: []byte(`{"message":"Invalid transaction: Type: Validation Code: SEQUENCE_NUMBER_TOO_NEW","error_code":"vm_error","vm_error_code":4}`)}))
+}
+
+func TestSequenceNumberManager_BuildTransaction(t *testing.T) {
+	var onChain atomic.Uint64
+	onChain.Store(5)
+	client, calls := newSequenceNumberServerClient(t, &onChain)
+	manager := client.NewSequenceNumberManager(AccountOne)
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	payload := TransactionPayload{Payload: transfer}
+
+	rawTxn, err := client.BuildTransaction(AccountOne, payload, manager, GasUnitPrice(100))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), rawTxn.SequenceNumber)
+	multiAgentTxn, err := client.BuildTransactionMultiAgent(AccountOne, payload, manager, GasUnitPrice(100), FeePayer(&AccountZero))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), multiAgentTxn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData).RawTxn.SequenceNumber)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// An explicit sequence number takes priority
+	rawTxn, err = client.BuildTransaction(AccountOne, payload, manager, GasUnitPrice(100), SequenceNumber(100))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), rawTxn.SequenceNumber)
+	next, err := manager.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), next)
+
+	// The manager must be for the sender
+	_, err = client.BuildTransaction(AccountTwo, payload, manager, GasUnitPrice(100))
+	assert.Error(t, err)
+
+	// A failed build resets the manager, as the number won't be used
+	_, err = client.BuildTransaction(AccountOne, payload, manager)
+	assert.Error(t, err)
+	rawTxn, err = client.BuildTransaction(AccountOne, payload, manager, GasUnitPrice(100))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), rawTxn.SequenceNumber)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -44,6 +44,17 @@ func (txn *SignedTransaction) Verify() error {
 	return errors.New("signature is invalid")
 }
 
+// DeserializeSignedTransaction deserializes BCS bytes of a [SignedTransaction] e.g. from [NodeClient.SubmitTransactionBCS]
+// or a mempool dump.  All bytes must be consumed.
+func DeserializeSignedTransaction(bytes []byte) (*SignedTransaction, error) {
+	signedTxn := &SignedTransaction{}
+	err := bcs.Deserialize(signedTxn, bytes)
+	if err != nil {
+		return nil, err
+	}
+	return signedTxn, nil
+}
+
 // TransactionPrefix is a cached hash prefix for taking transaction hashes
 var TransactionPrefix *[]byte
 
@@ -74,7 +85,16 @@ func (txn *SignedTransaction) MarshalBCS(ser *bcs.Serializer) {
 	txn.Transaction.MarshalBCS(ser)
 	txn.Authenticator.MarshalBCS(ser)
 }
+
+// UnmarshalBCS deserializes the [SignedTransaction].  If the Transaction or Authenticator are nil, a [RawTransaction]
+// and [TransactionAuthenticator] are used, which is always the case for a transaction submitted on-chain.
 func (txn *SignedTransaction) UnmarshalBCS(des *bcs.Deserializer) {
+	if txn.Transaction == nil {
+		txn.Transaction = &RawTransaction{}
+	}
+	if txn.Authenticator == nil {
+		txn.Authenticator = &TransactionAuthenticator{}
+	}
 	txn.Transaction.UnmarshalBCS(des)
 	txn.Authenticator.UnmarshalBCS(des)
 }
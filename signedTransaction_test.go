@@ -0,0 +1,273 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRawTransactionForDeserialize creates a raw transaction with the payload, with fixed values for the other fields
+func testRawTransactionForDeserialize(sender AccountAddress, payload TransactionPayloadImpl) *RawTransaction {
+	return &RawTransaction{
+		Sender:                     sender,
+		SequenceNumber:             7,
+		Payload:                    TransactionPayload{Payload: payload},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1700000000,
+		ChainId:                    4,
+	}
+}
+
+// assertSignedTransactionRoundTrip serializes the signed transaction, deserializes it, and checks it's the same
+func assertSignedTransactionRoundTrip(t *testing.T, signedTxn *SignedTransaction) *SignedTransaction {
+	txnBytes, err := bcs.Serialize(signedTxn)
+	assert.NoError(t, err)
+	decoded, err := DeserializeSignedTransaction(txnBytes)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	assert.Equal(t, signedTxn.Authenticator.Variant, decoded.Authenticator.Variant)
+	reserialized, err := bcs.Serialize(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, txnBytes, reserialized)
+
+	// The hash is over the same bytes
+	expectedHash, err := signedTxn.Hash()
+	assert.NoError(t, err)
+	hash, err := decoded.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+	return decoded
+}
+
+func TestDeserializeSignedTransaction_SingleSigner(t *testing.T) {
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	ed25519Account, err := NewEd25519Account()
+	assert.NoError(t, err)
+	singleSenderAccount, err := NewEd25519SingleSenderAccount()
+	assert.NoError(t, err)
+	secp256k1Account, err := NewSecp256k1Account()
+	assert.NoError(t, err)
+	multiEd25519Signer, err := NewMultiEd25519Signer(3, 2)
+	assert.NoError(t, err)
+	multiKeySigner, err := NewMultiKeyTestSigner(3, 2)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		signer   TransactionSigner
+		variant  TransactionAuthenticatorVariant
+		expected crypto.AccountAuthenticatorType
+	}{
+		"Ed25519":      {ed25519Account, TransactionAuthenticatorEd25519, crypto.AccountAuthenticatorEd25519},
+		"SingleKey":    {singleSenderAccount, TransactionAuthenticatorSingleSender, crypto.AccountAuthenticatorSingleSender},
+		"Secp256k1":    {secp256k1Account, TransactionAuthenticatorSingleSender, crypto.AccountAuthenticatorSingleSender},
+		"MultiEd25519": {multiEd25519Signer, TransactionAuthenticatorMultiEd25519, crypto.AccountAuthenticatorMultiEd25519},
+		"MultiKey":     {multiKeySigner, TransactionAuthenticatorSingleSender, crypto.AccountAuthenticatorMultiKey},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rawTxn := testRawTransactionForDeserialize(test.signer.AccountAddress(), transfer)
+			signedTxn, err := rawTxn.SignedTransaction(test.signer)
+			assert.NoError(t, err)
+			assert.Equal(t, test.variant, signedTxn.Authenticator.Variant)
+
+			decoded := assertSignedTransactionRoundTrip(t, signedTxn)
+			if decoded == nil {
+				return
+			}
+			assert.Equal(t, rawTxn, decoded.Transaction)
+			sender := decoded.Authenticator.Auth
+			switch auth := sender.(type) {
+			case *Ed25519TransactionAuthenticator:
+				assert.Equal(t, test.expected, auth.Sender.Variant)
+			case *MultiEd25519TransactionAuthenticator:
+				assert.Equal(t, test.expected, auth.Sender.Variant)
+			case *SingleSenderTransactionAuthenticator:
+				assert.Equal(t, test.expected, auth.Sender.Variant)
+			default:
+				t.Errorf("unexpected authenticator %T", sender)
+			}
+
+			// MultiEd25519 verification doesn't use the bitmap yet, so it can't verify fewer signatures than keys
+			if name != "MultiEd25519" {
+				assert.NoError(t, decoded.Verify())
+			}
+		})
+	}
+}
+
+func TestDeserializeSignedTransaction_MultiAgent(t *testing.T) {
+	sender := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	secondary, err := NewSecp256k1Account()
+	assert.NoError(t, err)
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	rawTxn := NewMultiAgentTransaction(testRawTransactionForDeserialize(sender.Address, transfer), secondary.Address)
+	signedTxn, err := rawTxn.MultiAgentSignedTransaction(sender, secondary)
+	assert.NoError(t, err)
+
+	decoded := assertSignedTransactionRoundTrip(t, signedTxn)
+	auth, ok := decoded.Authenticator.Auth.(*MultiAgentTransactionAuthenticator)
+	assert.True(t, ok)
+	assert.Equal(t, []AccountAddress{secondary.Address}, auth.SecondarySignerAddresses)
+	assert.Equal(t, crypto.AccountAuthenticatorSingleSender, auth.SecondarySigners[0].Variant)
+
+	// The signatures are over the multi-agent message
+	message, err := rawTxn.SigningMessage()
+	assert.NoError(t, err)
+	assert.True(t, decoded.Authenticator.Verify(message))
+}
+
+func TestDeserializeSignedTransaction_FeePayer(t *testing.T) {
+	sender := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	feePayer := testEd25519Account(t, "0x2222222222222222222222222222222222222222222222222222222222222222")
+	secondary, err := NewEd25519SingleSenderAccount()
+	assert.NoError(t, err)
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	rawTxn := NewFeePayerTransaction(testRawTransactionForDeserialize(sender.Address, transfer), feePayer.Address, secondary.Address)
+	signedTxn, err := rawTxn.FeePayerSignedTransaction(sender, feePayer, secondary)
+	assert.NoError(t, err)
+
+	decoded := assertSignedTransactionRoundTrip(t, signedTxn)
+	auth, ok := decoded.Authenticator.Auth.(*FeePayerTransactionAuthenticator)
+	assert.True(t, ok)
+	assert.Equal(t, feePayer.Address, *auth.FeePayer)
+	assert.Equal(t, []AccountAddress{secondary.Address}, auth.SecondarySignerAddresses)
+
+	message, err := rawTxn.SigningMessage()
+	assert.NoError(t, err)
+	assert.True(t, decoded.Authenticator.Verify(message))
+}
+
+func TestDeserializeSignedTransaction_Payloads(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+
+	payloads := map[string]TransactionPayloadImpl{
+		"EntryFunction": transfer,
+		"Script": &Script{
+			Code:     []byte{0xa1, 0x1c, 0xeb, 0x0b},
+			ArgTypes: []TypeTag{AptosCoinTypeTag},
+			Args: []ScriptArgument{
+				{Variant: ScriptArgumentU64, Value: uint64(100)},
+				{Variant: ScriptArgumentAddress, Value: AccountTwo},
+				{Variant: ScriptArgumentBool, Value: true},
+				{Variant: ScriptArgumentU8Vector, Value: []byte{1, 2, 3}},
+			},
+		},
+		"Multisig": &Multisig{
+			MultisigAddress: AccountThree,
+			Payload: &MultisigTransactionPayload{
+				Variant: MultisigTransactionPayloadVariantEntryFunction,
+				Payload: transfer,
+			},
+		},
+		"MultisigWithoutPayload": &Multisig{MultisigAddress: AccountThree},
+	}
+	for name, payload := range payloads {
+		t.Run(name, func(t *testing.T) {
+			rawTxn := testRawTransactionForDeserialize(sender.Address, payload)
+			signedTxn, err := rawTxn.SignedTransaction(sender)
+			assert.NoError(t, err)
+			decoded := assertSignedTransactionRoundTrip(t, signedTxn)
+			assert.Equal(t, payload.PayloadType(), decoded.Transaction.(*RawTransaction).Payload.Payload.PayloadType())
+			assert.NoError(t, decoded.Verify())
+		})
+	}
+}
+
+func TestDeserializeSignedTransaction_Errors(t *testing.T) {
+	sender, err := NewEd25519Account()
+	assert.NoError(t, err)
+	signedTxn := buildSignedTransferForTest(t, sender)
+	txnBytes, err := bcs.Serialize(signedTxn)
+	assert.NoError(t, err)
+
+	// Truncated
+	_, err = DeserializeSignedTransaction(txnBytes[:len(txnBytes)-1])
+	assert.Error(t, err)
+
+	// Trailing bytes
+	_, err = DeserializeSignedTransaction(append(txnBytes, 0))
+	assert.Error(t, err)
+
+	// Unknown authenticator variant, right after the raw transaction
+	rawTxnBytes, err := bcs.Serialize(signedTxn.Transaction)
+	assert.NoError(t, err)
+	badBytes := append(rawTxnBytes, 9)
+	_, err = DeserializeSignedTransaction(badBytes)
+	assert.Error(t, err)
+}
+
+func TestDeserializeSignedTransaction_Keyless(t *testing.T) {
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	ephemeralKey, err := crypto.GenerateEd25519PrivateKey()
+	assert.NoError(t, err)
+
+	pubKey := &crypto.AnyPublicKey{
+		Variant: crypto.AnyPublicKeyVariantKeyless,
+		PubKey:  &crypto.KeylessPublicKey{Iss: "https://accounts.google.com", IdCommitment: make([]byte, 32)},
+	}
+	sender := AccountAddress(pubKey.AuthKey()[:])
+	rawTxn := testRawTransactionForDeserialize(sender, transfer)
+	message, err := rawTxn.SigningMessage()
+	assert.NoError(t, err)
+	ephemeralSig, err := ephemeralKey.SignMessage(message)
+	assert.NoError(t, err)
+
+	signedTxn := &SignedTransaction{
+		Transaction: rawTxn,
+		Authenticator: &TransactionAuthenticator{
+			Variant: TransactionAuthenticatorSingleSender,
+			Auth: &SingleSenderTransactionAuthenticator{Sender: &crypto.AccountAuthenticator{
+				Variant: crypto.AccountAuthenticatorSingleSender,
+				Auth: &crypto.SingleKeyAuthenticator{
+					PubKey: pubKey,
+					Sig: &crypto.AnySignature{
+						Variant: crypto.AnySignatureVariantKeyless,
+						Signature: &crypto.KeylessSignature{
+							Certificate: crypto.EphemeralCertificate{
+								Variant: crypto.EphemeralCertificateVariantZeroKnowledge,
+								Certificate: &crypto.ZeroKnowledgeSig{
+									Proof:          crypto.ZkProof{Variant: crypto.ZkProofVariantGroth16, Proof: &crypto.Groth16Proof{}},
+									ExpHorizonSecs: 10000000,
+								},
+							},
+							JwtHeader:          `{"alg":"RS256","kid":"test-kid"}`,
+							ExpiryDateSecs:     1700000000,
+							EphemeralPublicKey: crypto.EphemeralPublicKey{Variant: crypto.EphemeralPublicKeyVariantEd25519, PubKey: ephemeralKey.PubKey()},
+							EphemeralSignature: crypto.EphemeralSignature{Variant: crypto.EphemeralSignatureVariantEd25519, Signature: ephemeralSig},
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	decoded := assertSignedTransactionRoundTrip(t, signedTxn)
+	if decoded == nil {
+		return
+	}
+	auth := decoded.Authenticator.Auth.(*SingleSenderTransactionAuthenticator).Sender.Auth.(*crypto.SingleKeyAuthenticator)
+	keylessSig, ok := auth.Sig.Signature.(*crypto.KeylessSignature)
+	assert.True(t, ok)
+	assert.Equal(t, ephemeralKey.PubKey(), keylessSig.EphemeralPublicKey.PubKey)
+	header, err := keylessSig.ParseJwtHeader()
+	assert.NoError(t, err)
+	assert.Equal(t, "test-kid", header.Kid)
+	assert.Equal(t, sender, decoded.Transaction.(*RawTransaction).Sender)
+
+	// Keyless signatures can't be verified offline
+	assert.Error(t, decoded.Verify())
+}
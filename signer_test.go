@@ -7,24 +7,37 @@ import (
 
 /* This is a collection of test signers, that don't make sense in the real world, but are used for testing */
 
+// MultiEd25519TestSigner is a k-of-n [crypto.MultiEd25519PrivateKey] holding all the keys, with random keys
 type MultiEd25519TestSigner struct {
-	Keys               []*crypto.Ed25519PrivateKey
-	SignaturesRequired uint8
+	*crypto.MultiEd25519PrivateKey
+	Keys []*crypto.Ed25519PrivateKey
 }
 
 func NewMultiEd25519Signer(numKeys uint8, signaturesRequired uint8) (*MultiEd25519TestSigner, error) {
 	keys := make([]*crypto.Ed25519PrivateKey, numKeys)
+	pubKeys := make([]*crypto.Ed25519PublicKey, numKeys)
+	privateKeys := make(map[uint8]*crypto.Ed25519PrivateKey, numKeys)
 	for i := range keys {
 		key, err := crypto.GenerateEd25519PrivateKey(nil)
 		if err != nil {
 			return nil, err
 		}
 		keys[i] = key
+		pubKeys[i] = key.PubKey().(*crypto.Ed25519PublicKey)
+		privateKeys[uint8(i)] = key
+	}
+	publicKey, err := crypto.NewMultiEd25519PublicKey(pubKeys, signaturesRequired)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := crypto.NewMultiEd25519PrivateKey(publicKey, privateKeys)
+	if err != nil {
+		return nil, err
 	}
 
 	return &MultiEd25519TestSigner{
-		Keys:               keys,
-		SignaturesRequired: signaturesRequired,
+		MultiEd25519PrivateKey: signer,
+		Keys:                   keys,
 	}, nil
 }
 
@@ -34,54 +47,6 @@ func (s *MultiEd25519TestSigner) AccountAddress() AccountAddress {
 	return address
 }
 
-func (s *MultiEd25519TestSigner) Sign(msg []byte) (authenticator *crypto.AccountAuthenticator, err error) {
-	signature, err := s.SignMessage(msg)
-	if err != nil {
-		return nil, err
-	}
-
-	return &crypto.AccountAuthenticator{
-		Variant: crypto.AccountAuthenticatorMultiEd25519,
-		Auth: &crypto.MultiEd25519Authenticator{
-			PubKey: s.PubKey().(*crypto.MultiEd25519PublicKey),
-			Sig:    signature.(*crypto.MultiEd25519Signature),
-		},
-	}, nil
-}
-
-func (s *MultiEd25519TestSigner) SignMessage(msg []byte) (crypto.Signature, error) {
-	signatures := make([]*crypto.Ed25519Signature, s.SignaturesRequired)
-	for i := 0; i < int(s.SignaturesRequired); i++ {
-		sig, err := s.Keys[i].SignMessage(msg)
-		if err != nil {
-			return nil, err
-		}
-		signatures[i] = sig.(*crypto.Ed25519Signature)
-	}
-
-	return &crypto.MultiEd25519Signature{
-		Signatures: signatures,
-		Bitmap:     [4]byte{},
-	}, nil
-}
-
-func (s *MultiEd25519TestSigner) AuthKey() *crypto.AuthenticationKey {
-	return s.PubKey().AuthKey()
-}
-
-func (s *MultiEd25519TestSigner) PubKey() crypto.PublicKey {
-	pubKeys := make([]*crypto.Ed25519PublicKey, len(s.Keys))
-	for i, key := range s.Keys {
-		pubKeys[i] = key.PubKey().(*crypto.Ed25519PublicKey)
-	}
-
-	key := &crypto.MultiEd25519PublicKey{
-		PubKeys:            pubKeys,
-		SignaturesRequired: s.SignaturesRequired,
-	}
-	return key
-}
-
 // This is an example for testing, a real signer would be signing and collecting the signatures in a different way
 type MultiKeyTestSigner struct {
 	Signers            []crypto.Signer
@@ -0,0 +1,107 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// tableItemRequest is the JSON body of a table item request
+type tableItemRequest struct {
+	KeyType   string `json:"key_type"`
+	ValueType string `json:"value_type"`
+	Key       any    `json:"key"`
+}
+
+// rawTableItemRequest is the JSON body of a raw table item request
+type rawTableItemRequest struct {
+	Key string `json:"key"`
+}
+
+// GetTableItem fetches the value of a table item by its key, and decodes the JSON value into out.  The key and value
+// types are the Move types of the table e.g. for a Table<address, u64>, "address" and "u64".
+//
+// The key is encoded to the node's JSON form the same as view function arguments, see [NodeClient.ViewJson].  Struct
+// keys can be given as a map[string]any of their fields, which are encoded the same way, or as a Go struct which is
+// encoded with [json.Marshal].
+//
+//	var balance api.U64
+//	err := client.GetTableItem(handle, "address", "u64", AccountOne, &balance)
+//
+// Optionally, a ledgerVersion can be given to get the table item at a specific ledger version
+func (rc *NodeClient) GetTableItem(handle string, keyType string, valueType string, key any, out any, ledgerVersion ...uint64) error {
+	encodedKey, err := encodeTableKey(key)
+	if err != nil {
+		return fmt.Errorf("table item key: %w", err)
+	}
+	body, err := json.Marshal(tableItemRequest{
+		KeyType:   keyType,
+		ValueType: valueType,
+		Key:       encodedKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	au := rc.baseUrl.JoinPath("tables", handle, "item")
+	withLedgerVersion(au, ledgerVersion)
+	value, err := Post[json.RawMessage](rc, au.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("get table item api err: %w", err)
+	}
+	err = json.Unmarshal(value, out)
+	if err != nil {
+		return fmt.Errorf("failed to decode table item as %T: %w", out, err)
+	}
+	return nil
+}
+
+// GetTableItemRaw fetches the BCS bytes of the value of a table item by the BCS bytes of its key.  This doesn't need
+// the key and value types, and can be decoded with [bcs.Deserialize].
+//
+//	keyBytes, err := bcs.Serialize(&AccountOne)
+//	valueBytes, err := client.GetTableItemRaw(handle, keyBytes)
+//
+// Optionally, a ledgerVersion can be given to get the table item at a specific ledger version
+func (rc *NodeClient) GetTableItemRaw(handle string, key []byte, ledgerVersion ...uint64) ([]byte, error) {
+	body, err := json.Marshal(rawTableItemRequest{Key: BytesToHex(key)})
+	if err != nil {
+		return nil, err
+	}
+
+	au := rc.baseUrl.JoinPath("tables", handle, "raw_item")
+	withLedgerVersion(au, ledgerVersion)
+	value, err := rc.postBCS(rc.context(), au.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("get raw table item api err: %w", err)
+	}
+	return value, nil
+}
+
+// encodeTableKey encodes a Go value into the JSON form of a table key.  Maps are encoded field by field, as struct
+// keys, and Go structs are left for [json.Marshal].
+func encodeTableKey(key any) (any, error) {
+	reflected := reflect.ValueOf(key)
+	switch reflected.Kind() {
+	case reflect.Map:
+		if reflected.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("bad type %T, struct keys must have string field names", key)
+		}
+		out := make(map[string]any, reflected.Len())
+		iter := reflected.MapRange()
+		for iter.Next() {
+			field, err := encodeTableKey(iter.Value().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", iter.Key().String(), err)
+			}
+			out[iter.Key().String()] = field
+		}
+		return out, nil
+	}
+	encoded, err := encodeViewArgument(key)
+	if err != nil && reflected.Kind() == reflect.Struct {
+		return key, nil
+	}
+	return encoded, err
+}
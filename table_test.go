@@ -0,0 +1,136 @@
+package aptos
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTableHandle = "0x1b854694ae746cdbd8d44186ca4929b2b337df21d1c74633be19b2710552fdca"
+
+// newTableServerClient creates a client against a mock server, which checks the table item request body and responds
+// with the value
+func newTableServerClient(t *testing.T, path string, expectedBody string, accept string, value string) *Client {
+	return newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/tables/"+testTableHandle+"/"+path, r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, accept, r.Header.Get("Accept"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, expectedBody, string(body))
+		_, _ = w.Write([]byte(value))
+	})
+}
+
+func TestGetTableItem_StringKey(t *testing.T) {
+	client := newTableServerClient(t, "item",
+		`{"key_type":"0x1::string::String","value_type":"u64","key":"hello"}`,
+		"application/json",
+		`"12345"`,
+	)
+	var value api.U64
+	err := client.GetTableItem(testTableHandle, "0x1::string::String", "u64", "hello", &value)
+	assert.NoError(t, err)
+	assert.Equal(t, api.U64(12345), value)
+}
+
+func TestGetTableItem_StructKey(t *testing.T) {
+	type tokenData struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Supply      string `json:"supply"`
+	}
+	expectedBody := `{
+		"key_type": "0x3::token::TokenDataId",
+		"value_type": "0x3::token::TokenData",
+		"key": {"creator": "0x3", "collection": "Aptos", "name": "Token #1", "version": "2"}
+	}`
+	value := `{"name": "Token #1", "description": "The first token", "supply": "1"}`
+
+	// Struct keys can be maps, with the fields encoded like view arguments
+	client := newTableServerClient(t, "item", expectedBody, "application/json", value)
+	out := tokenData{}
+	err := client.GetTableItem(testTableHandle, "0x3::token::TokenDataId", "0x3::token::TokenData", map[string]any{
+		"creator":    AccountThree,
+		"collection": "Aptos",
+		"name":       "Token #1",
+		"version":    uint64(2),
+	}, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, tokenData{Name: "Token #1", Description: "The first token", Supply: "1"}, out)
+
+	// Or Go structs, encoded with their JSON tags
+	type tokenDataId struct {
+		Creator    string `json:"creator"`
+		Collection string `json:"collection"`
+		Name       string `json:"name"`
+		Version    string `json:"version"`
+	}
+	result := map[string]any{}
+	err = client.GetTableItem(testTableHandle, "0x3::token::TokenDataId", "0x3::token::TokenData", tokenDataId{
+		Creator:    "0x3",
+		Collection: "Aptos",
+		Name:       "Token #1",
+		Version:    "2",
+	}, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, "The first token", result["description"])
+}
+
+func TestGetTableItem_Errors(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Table Item not found by Table handle(0x1)","error_code":"table_item_not_found","vm_error_code":null}`))
+	})
+	var value api.U64
+	err := client.GetTableItem(testTableHandle, "address", "u64", AccountOne, &value)
+	assert.True(t, IsNotFound(err))
+
+	err = client.GetTableItem(testTableHandle, "address", "u64", map[string]any{"bad": make(chan int)}, &value)
+	assert.ErrorContains(t, err, "table item key: field bad")
+	err = client.GetTableItem(testTableHandle, "u64", "u64", -1, &value)
+	assert.ErrorContains(t, err, "table item key: negative value -1")
+	err = client.GetTableItem(testTableHandle, "address", "u64", (*AccountAddress)(nil), &value)
+	assert.ErrorContains(t, err, "table item key: nil *types.AccountAddress")
+
+	client = newTableServerClient(t, "item", `{"key_type":"address","value_type":"u64","key":"0x1"}`, "application/json", `{"not":"a number"}`)
+	err = client.GetTableItem(testTableHandle, "address", "u64", AccountOne, &value)
+	assert.ErrorContains(t, err, "failed to decode table item as *api.U64")
+}
+
+func TestGetTableItemRaw(t *testing.T) {
+	keyBytes, err := bcs.SerializeBytes([]byte("hello"))
+	assert.NoError(t, err)
+	valueBytes, err := bcs.SerializeU64(12345)
+	assert.NoError(t, err)
+	expectedBody, err := json.Marshal(map[string]string{"key": BytesToHex(keyBytes)})
+	assert.NoError(t, err)
+
+	client := newTableServerClient(t, "raw_item", string(expectedBody), "application/x-bcs", string(valueBytes))
+	raw, err := client.GetTableItemRaw(testTableHandle, keyBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, valueBytes, raw)
+
+	des := bcs.NewDeserializer(raw)
+	assert.Equal(t, uint64(12345), des.U64())
+	assert.NoError(t, des.Error())
+}
+
+func TestGetTableItem_LedgerVersion(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "42", r.URL.Query().Get("ledger_version"))
+		_, _ = w.Write([]byte(`true`))
+	})
+	var value bool
+	err := client.GetTableItem(testTableHandle, "address", "bool", AccountOne, &value, 42)
+	assert.NoError(t, err)
+	assert.True(t, value)
+	_, err = client.GetTableItemRaw(testTableHandle, AccountOne[:], 42)
+	assert.NoError(t, err)
+}
@@ -103,20 +103,17 @@ func (sf *EntryFunction) MarshalBCS(ser *bcs.Serializer) {
 	sf.Module.MarshalBCS(ser)
 	ser.WriteString(sf.Function)
 	bcs.SerializeSequence(sf.ArgTypes, ser)
-	ser.Uleb128(uint32(len(sf.Args)))
-	for _, a := range sf.Args {
-		ser.WriteBytes(a)
-	}
+	bcs.SerializeSequenceWithFunction(sf.Args, ser, func(ser *bcs.Serializer, arg []byte) {
+		ser.WriteBytes(arg)
+	})
 }
 func (sf *EntryFunction) UnmarshalBCS(des *bcs.Deserializer) {
 	sf.Module.UnmarshalBCS(des)
 	sf.Function = des.ReadString()
 	sf.ArgTypes = bcs.DeserializeSequence[TypeTag](des)
-	alen := des.Uleb128()
-	sf.Args = make([][]byte, alen)
-	for i := uint32(0); i <= alen; i++  {
-		sf.Args[i] = des.ReadBytes()
-	}
+	sf.Args = bcs.DeserializeSequenceWithFunction(des, func(des *bcs.Deserializer, arg *[]byte) {
+		*arg = des.ReadBytes()
+	})
 }
 
 //endregion
@@ -195,6 +192,7 @@ func (sf *MultisigTransactionPayload) UnmarshalBCS(des *bcs.Deserializer) {
 		des.SetError(fmt.Errorf("bad variant %d for MultisigTransactionPayload", variant))
 		return
 	}
+	sf.Variant = variant
 	des.Struct(sf.Payload)
 }
 
@@ -0,0 +1,31 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryFunction_UnmarshalBCS(t *testing.T) {
+	// Deserializing the arguments used to read one past the end, and panic with index out of range
+	for _, args := range [][][]byte{
+		{},
+		{{0x01}},
+		{AccountTwo[:], {0x64, 0, 0, 0, 0, 0, 0, 0}},
+	} {
+		entryFunction := &EntryFunction{
+			Module:   ModuleId{Address: AccountOne, Name: "aptos_account"},
+			Function: "transfer",
+			ArgTypes: []TypeTag{},
+			Args:     args,
+		}
+		entryFunctionBytes, err := bcs.Serialize(entryFunction)
+		assert.NoError(t, err)
+
+		decoded := &EntryFunction{}
+		err = bcs.Deserialize(decoded, entryFunctionBytes)
+		assert.NoError(t, err)
+		assert.Equal(t, entryFunction, decoded)
+	}
+}
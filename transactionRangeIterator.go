@@ -0,0 +1,126 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+)
+
+// DefaultTransactionPageSize is the number of transactions requested from the node per page, which is the node's
+// default maximum.  The node may cap this lower.
+const DefaultTransactionPageSize = 100
+
+// TransactionRangeError is returned by a [TransactionRangeIterator] when a page fails to be fetched.  The range can be
+// restarted from NextVersion, or the iterator can be retried, which fetches the same page again.
+type TransactionRangeError struct {
+	NextVersion uint64 // NextVersion is the first version not yet fetched
+	Err         error  // Err is the error from fetching the page
+}
+
+// Error returns the version the page failed at, and the error
+//
+// Implements:
+//   - [error]
+func (e *TransactionRangeError) Error() string {
+	return fmt.Sprintf("failed to fetch transactions from version %d: %s", e.NextVersion, e.Err)
+}
+
+// Unwrap returns the error from fetching the page
+func (e *TransactionRangeError) Unwrap() error {
+	return e.Err
+}
+
+// TransactionRangeIterator pages through the committed transactions in a range of versions, in version order, see
+// [NodeClient.TransactionsByVersionRange]
+//
+// The iterator is not safe for concurrent use.
+//
+//	iter := client.TransactionsByVersionRange(1000, 2000)
+//	for {
+//		txn, ok, err := iter.Next(ctx)
+//		if err != nil {
+//			return err // a *TransactionRangeError, with the version to restart from
+//		}
+//		if !ok {
+//			break // no more transactions
+//		}
+//		// handle txn
+//	}
+type TransactionRangeIterator struct {
+	*Paginator[*api.CommittedTransaction]
+
+	rc       *NodeClient
+	end      uint64
+	pageSize uint64
+
+	start       uint64 // start is the version of the next page to fetch
+	lastVersion uint64 // lastVersion is the version of the last transaction fetched, if fetched is set
+	fetched     bool
+}
+
+// TransactionsByVersionRange returns a [TransactionRangeIterator] over the committed transactions from the start
+// version, up to but not including the end version.  The range is fetched in pages of at most
+// [DefaultTransactionPageSize], and if the node returns fewer, the next page resumes after the last version received.
+//
+// The iterator stops early if the node has no transactions at a version yet, e.g. when the end is past the latest
+// ledger version.  On an error, a [TransactionRangeError] gives the version to restart from.
+func (rc *NodeClient) TransactionsByVersionRange(start uint64, end uint64) *TransactionRangeIterator {
+	it := &TransactionRangeIterator{
+		rc:       rc,
+		end:      end,
+		pageSize: DefaultTransactionPageSize,
+		start:    start,
+	}
+	it.Paginator = NewPaginator(it.fetchPage)
+	if start >= end {
+		it.done = true
+	}
+	return it
+}
+
+// NextVersion is the first version not yet fetched from the node, which is where the range can be restarted from
+func (it *TransactionRangeIterator) NextVersion() uint64 {
+	return it.start
+}
+
+// LastVersion is the version of the last transaction fetched from the node, false if none have been fetched
+func (it *TransactionRangeIterator) LastVersion() (uint64, bool) {
+	return it.lastVersion, it.fetched
+}
+
+// fetchPage fetches the next page of transactions in the range, implementing [PageFetcher]
+func (it *TransactionRangeIterator) fetchPage(ctx context.Context) ([]*api.CommittedTransaction, bool, error) {
+	limit := it.end - it.start
+	if limit > it.pageSize {
+		limit = it.pageSize
+	}
+	au := it.rc.baseUrl.JoinPath("transactions")
+	params := url.Values{}
+	params.Set("start", strconv.FormatUint(it.start, 10))
+	params.Set("limit", strconv.FormatUint(limit, 10))
+	au.RawQuery = params.Encode()
+
+	txns, _, err := getWithResp[[]*api.CommittedTransaction](ctx, it.rc, au.String())
+	if err != nil {
+		return nil, false, &TransactionRangeError{NextVersion: it.start, Err: fmt.Errorf("get transactions api err: %w", err)}
+	}
+	if len(txns) == 0 {
+		return nil, false, nil
+	}
+
+	// Drop anything past the end, which the node shouldn't return
+	for len(txns) > 0 && txns[len(txns)-1].Version() >= it.end {
+		txns = txns[:len(txns)-1]
+	}
+	if len(txns) == 0 {
+		it.start = it.end
+		return nil, false, nil
+	}
+	it.lastVersion = txns[len(txns)-1].Version()
+	it.fetched = true
+	it.start = it.lastVersion + 1
+	return txns, it.start < it.end, nil
+}
@@ -0,0 +1,100 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTransactionRangeServerClient creates a client against a mock server with the versions [0, ledgerEnd), which caps
+// pages at maxLimit, and fails once for a page starting at failAt
+func newTransactionRangeServerClient(t *testing.T, ledgerEnd uint64, maxLimit uint64, failAt uint64) (*Client, *[]uint64) {
+	starts := make([]uint64, 0)
+	failed := false
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/transactions", r.URL.Path)
+		start, err := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		assert.NoError(t, err)
+		limit, err := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, limit, uint64(DefaultTransactionPageSize))
+		starts = append(starts, start)
+		if start == failAt && !failed {
+			failed = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprint(w, `{"message":"service unavailable","error_code":"internal_error","vm_error_code":null}`)
+			return
+		}
+		end := min(start+min(limit, maxLimit), ledgerEnd)
+		if start >= ledgerEnd {
+			end = start
+		}
+		_, _ = fmt.Fprint(w, testStateCheckpointsJson(start, end))
+	})
+	return client, &starts
+}
+
+func TestTransactionsByVersionRange(t *testing.T) {
+	// The node caps pages lower than requested
+	client, starts := newTransactionRangeServerClient(t, 1000, 40, 1000)
+	txns, err := client.TransactionsByVersionRange(10, 250).All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, txns, 240)
+	for i, txn := range txns {
+		assert.Equal(t, uint64(10+i), txn.Version())
+	}
+	assert.Equal(t, []uint64{10, 50, 90, 130, 170, 210}, *starts)
+
+	// An empty range
+	txns, err = client.TransactionsByVersionRange(10, 10).All(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, txns)
+}
+
+func TestTransactionsByVersionRange_PastLedger(t *testing.T) {
+	client, _ := newTransactionRangeServerClient(t, 120, 100, 1000)
+	iter := client.TransactionsByVersionRange(50, 300)
+	txns, err := iter.All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, txns, 70)
+	lastVersion, ok := iter.LastVersion()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(119), lastVersion)
+	assert.Equal(t, uint64(120), iter.NextVersion())
+}
+
+func TestTransactionsByVersionRange_Error(t *testing.T) {
+	client, starts := newTransactionRangeServerClient(t, 1000, 100, 200)
+	ctx := context.Background()
+	iter := client.TransactionsByVersionRange(0, 350)
+	_, ok := iter.LastVersion()
+	assert.False(t, ok)
+
+	// It fails mid-range, with where to restart from
+	txns, err := iter.All(ctx)
+	var rangeErr *TransactionRangeError
+	assert.ErrorAs(t, err, &rangeErr)
+	assert.Equal(t, uint64(200), rangeErr.NextVersion)
+	assert.ErrorContains(t, err, "service unavailable")
+	assert.Len(t, txns, 200)
+	lastVersion, ok := iter.LastVersion()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(199), lastVersion)
+
+	// Retrying resumes from the failed page
+	rest, err := iter.All(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, rest, 150)
+	assert.Equal(t, uint64(200), rest[0].Version())
+	assert.Equal(t, uint64(349), rest[len(rest)-1].Version())
+	assert.Equal(t, []uint64{0, 100, 200, 200, 300}, *starts)
+
+	// Or restarted from the reported version with a new iterator
+	restarted, err := client.TransactionsByVersionRange(rangeErr.NextVersion, 350).All(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, rest, restarted)
+}
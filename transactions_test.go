@@ -1,8 +1,11 @@
 package aptos
 
 import (
+	"encoding/hex"
 	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/aptos-labs/aptos-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"testing"
 )
 
@@ -58,3 +61,347 @@ func TestTPMarshal(t *testing.T) {
 	// without a payload, it should fail
 	assert.Error(t, ser.Error())
 }
+
+// BCS vectors for a simple transfer of 100 octas from 0x1 to 0x2, and the same as transfer_coins<AptosCoin>.
+//
+// These are assembled by hand, field by field, from the Rust types TransactionPayload::EntryFunction and RawTransaction
+// in aptos-core's types/src/transaction, rather than taken from this SDK's serializer.  They aren't captured from the
+// Rust or TypeScript SDK output.
+const (
+	testAddressOneBcs = "0000000000000000000000000000000000000000000000000000000000000001"
+	testAddressTwoBcs = "0000000000000000000000000000000000000000000000000000000000000002"
+
+	testTransferArgsBcs = "02" + // 2 arguments
+		"20" + testAddressTwoBcs + // receiver, as 32 bytes
+		"08" + "6400000000000000" // amount 100, as 8 bytes
+
+	testTransferPayloadBcs = "02" + // TransactionPayload::EntryFunction
+		testAddressOneBcs + // module address
+		"0d" + "6170746f735f6163636f756e74" + // module name "aptos_account"
+		"08" + "7472616e73666572" + // function "transfer"
+		"00" + // no type arguments
+		testTransferArgsBcs
+	testTransferCoinsPayloadBcs = "02" + // TransactionPayload::EntryFunction
+		testAddressOneBcs + // module address
+		"0d" + "6170746f735f6163636f756e74" + // module name "aptos_account"
+		"0e" + "7472616e736665725f636f696e73" + // function "transfer_coins"
+		"01" + // 1 type argument
+		"07" + testAddressOneBcs + // TypeTag::Struct at 0x1
+		"0a" + "6170746f735f636f696e" + // module "aptos_coin"
+		"09" + "4170746f73436f696e" + // name "AptosCoin"
+		"00" + // no type parameters
+		testTransferArgsBcs
+	testTransferRawTransactionBcs = testAddressOneBcs + // sender
+		"0100000000000000" + // sequence number 1
+		testTransferPayloadBcs +
+		"e803000000000000" + // max gas amount 1000
+		"6400000000000000" + // gas unit price 100
+		"00f1536500000000" + // expiration 1700000000
+		"04" // chain id
+)
+
+func TestEntryFunction_TransferVector(t *testing.T) {
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	payload := &TransactionPayload{Payload: transfer}
+	payloadBytes, err := bcs.Serialize(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, testTransferPayloadBcs, hex.EncodeToString(payloadBytes))
+
+	// Round trip
+	decoded := &TransactionPayload{}
+	err = bcs.Deserialize(decoded, payloadBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, decoded)
+
+	// With a type argument
+	transferCoins := &TransactionPayload{Payload: &EntryFunction{
+		Module:   ModuleId{Address: AccountOne, Name: "aptos_account"},
+		Function: "transfer_coins",
+		ArgTypes: []TypeTag{AptosCoinTypeTag},
+		Args:     transfer.Args,
+	}}
+	payloadBytes, err = bcs.Serialize(transferCoins)
+	assert.NoError(t, err)
+	assert.Equal(t, testTransferCoinsPayloadBcs, hex.EncodeToString(payloadBytes))
+	decoded = &TransactionPayload{}
+	err = bcs.Deserialize(decoded, payloadBytes)
+	assert.NoError(t, err)
+	reserialized, err := bcs.Serialize(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, payloadBytes, reserialized)
+}
+
+func TestRawTransaction_TransferVector(t *testing.T) {
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     AccountOne,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: transfer},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1700000000,
+		ChainId:                    4,
+	}
+	txnBytes, err := bcs.Serialize(rawTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, testTransferRawTransactionBcs, hex.EncodeToString(txnBytes))
+
+	decoded := &RawTransaction{}
+	err = bcs.Deserialize(decoded, txnBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, rawTxn, decoded)
+}
+
+func TestSigningMessage(t *testing.T) {
+	// The domain separators are the sha3-256 of the type names, and don't depend on the order they're used in
+	assert.Equal(t, "b5e97db07fa0bd0e5598aa3643a9bc6f6693bddc1a9fec9e674a461eaa00b193", hex.EncodeToString(RawTransactionPrehash()))
+	assert.Equal(t, "5efa3c4f02f83a0f4b2d69fc95c607cc02825cc4e7be536ef0992df050d9e67c", hex.EncodeToString(RawTransactionWithDataPrehash()))
+	assert.Equal(t, "b5e97db07fa0bd0e5598aa3643a9bc6f6693bddc1a9fec9e674a461eaa00b193", hex.EncodeToString(RawTransactionPrehash()))
+
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{
+		Sender:                     AccountOne,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: transfer},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1700000000,
+		ChainId:                    4,
+	}
+	message, err := SigningMessage(rawTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, "b5e97db07fa0bd0e5598aa3643a9bc6f6693bddc1a9fec9e674a461eaa00b193"+testTransferRawTransactionBcs, hex.EncodeToString(message))
+
+	// The signature of the sender is over the message
+	sender := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	auth, err := rawTxn.Sign(sender)
+	assert.NoError(t, err)
+	assert.True(t, auth.Verify(message))
+
+	// The fee payer variant has its own domain separator, followed by the BCS of the transaction with data
+	feePayerTxn := NewFeePayerTransaction(rawTxn, AccountThree)
+	feePayerMessage, err := SigningMessageWithData(feePayerTxn)
+	assert.NoError(t, err)
+	feePayerTxnBytes, err := bcs.Serialize(feePayerTxn)
+	assert.NoError(t, err)
+	assert.Equal(t, "5efa3c4f02f83a0f4b2d69fc95c607cc02825cc4e7be536ef0992df050d9e67c"+hex.EncodeToString(feePayerTxnBytes), hex.EncodeToString(feePayerMessage))
+
+	_, err = SigningMessage(nil)
+	assert.Error(t, err)
+	_, err = SigningMessageWithData(nil)
+	assert.Error(t, err)
+}
+
+// testFeePayerAuthenticatorBcs is the fee payer authenticator for the transfer in [TestFeePayerTransaction], ed25519
+// signatures are deterministic, so this is stable
+const testFeePayerAuthenticatorBcs = "030020d04ab232742bb4ab3a1368bd4615e4e6d0224ab71a016baf8520a332c977873740d450b4815570309d0bfc0d365e9b29d67c8df37846f6a68ca608f915d0a7f25428410c9db1f3a6ba7f15cf94c5803fbd030b1a8fb5bcf2985bf0f86c514862070000a32657fd60acb0433491a33d84823c04722ae76639b272873cc27d015232904e0020a09aa5f47a6759802ff955f8dc2d2a14a5c99d23be97f864127ff9383455a4f040a650cb7e4d2b65c9614fc5e64238707c1f213b743112070be9e33e05a9fd9bdca6967b84aecf4e1c6b298956a8e18852d45685b5211c7d4cc2f366213ce5420d"
+
+// testEd25519Account creates a deterministic account from the private key hex
+func testEd25519Account(t *testing.T, privateKeyHex string) *Account {
+	key := &crypto.Ed25519PrivateKey{}
+	err := key.FromHex(privateKeyHex)
+	assert.NoError(t, err)
+	account, err := NewAccountFromSigner(key)
+	assert.NoError(t, err)
+	return account
+}
+
+func TestFeePayerTransaction(t *testing.T) {
+	sender := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	feePayer := testEd25519Account(t, "0x2222222222222222222222222222222222222222222222222222222222222222")
+
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn := NewFeePayerTransaction(&RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: transfer},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1700000000,
+		ChainId:                    4,
+	}, feePayer.Address)
+
+	signedTxn, err := rawTxn.FeePayerSignedTransaction(sender, feePayer)
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionAuthenticatorFeePayer, signedTxn.Authenticator.Variant)
+
+	// Both signatures are over the fee payer signing message
+	message, err := rawTxn.SigningMessage()
+	assert.NoError(t, err)
+	auth := signedTxn.Authenticator.Auth.(*FeePayerTransactionAuthenticator)
+	assert.True(t, auth.Sender.Verify(message))
+	assert.True(t, auth.FeePayerAuthenticator.Verify(message))
+	assert.Equal(t, feePayer.Address, *auth.FeePayer)
+
+	// FeePayer variant, sender, no secondary signers, fee payer address, fee payer
+	authBytes, err := bcs.Serialize(signedTxn.Authenticator)
+	assert.NoError(t, err)
+	assert.Equal(t, testFeePayerAuthenticatorBcs, hex.EncodeToString(authBytes))
+	senderBytes, err := bcs.Serialize(auth.Sender)
+	assert.NoError(t, err)
+	feePayerBytes, err := bcs.Serialize(auth.FeePayerAuthenticator)
+	assert.NoError(t, err)
+	expected := append([]byte{byte(TransactionAuthenticatorFeePayer)}, senderBytes...)
+	expected = append(expected, 0, 0)
+	expected = append(expected, feePayer.Address[:]...)
+	expected = append(expected, feePayerBytes...)
+	assert.Equal(t, expected, authBytes)
+
+	// Round trip the whole signed transaction
+	txnBytes, err := bcs.Serialize(signedTxn)
+	assert.NoError(t, err)
+	decoded := &SignedTransaction{Transaction: &RawTransaction{}, Authenticator: &TransactionAuthenticator{}}
+	err = bcs.Deserialize(decoded, txnBytes)
+	assert.NoError(t, err)
+	reserialized, err := bcs.Serialize(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, txnBytes, reserialized)
+}
+
+func TestFeePayerTransaction_Errors(t *testing.T) {
+	sender := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	feePayer := testEd25519Account(t, "0x2222222222222222222222222222222222222222222222222222222222222222")
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{Sender: sender.Address, Payload: TransactionPayload{Payload: transfer}, ChainId: 4}
+
+	// Missing an additional signer
+	_, err = NewFeePayerTransaction(rawTxn, feePayer.Address, AccountThree).FeePayerSignedTransaction(sender, feePayer)
+	assert.Error(t, err)
+
+	// Not a fee payer transaction
+	multiAgent := &RawTransactionWithData{
+		Variant: MultiAgentRawTransactionWithDataVariant,
+		Inner:   &MultiAgentRawTransactionWithData{RawTxn: rawTxn, SecondarySigners: []AccountAddress{}},
+	}
+	_, err = multiAgent.FeePayerSignedTransaction(sender, feePayer)
+	assert.Error(t, err)
+}
+
+func TestBuildFeePayerTransaction(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn, err := client.BuildFeePayerTransaction(AccountOne, TransactionPayload{Payload: transfer}, AccountThree,
+		SequenceNumber(1), GasUnitPrice(100), ChainIdOption(4), ExpirationSeconds(60))
+	assert.NoError(t, err)
+	assert.Equal(t, MultiAgentWithFeePayerRawTransactionWithDataVariant, rawTxn.Variant)
+	inner := rawTxn.Inner.(*MultiAgentWithFeePayerRawTransactionWithData)
+	assert.Equal(t, AccountThree, *inner.FeePayer)
+	assert.Equal(t, AccountOne, inner.RawTxn.Sender)
+
+	_, err = client.BuildFeePayerTransaction(AccountOne, TransactionPayload{Payload: transfer}, AccountThree, FeePayer(&AccountZero))
+	assert.Error(t, err)
+}
+
+func TestMultiAgentTransaction(t *testing.T) {
+	sender := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	secondary := testEd25519Account(t, "0x3333333333333333333333333333333333333333333333333333333333333333")
+
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	innerTxn := &RawTransaction{
+		Sender:                     sender.Address,
+		SequenceNumber:             1,
+		Payload:                    TransactionPayload{Payload: transfer},
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 1700000000,
+		ChainId:                    4,
+	}
+	rawTxn := NewMultiAgentTransaction(innerTxn, secondary.Address)
+
+	// MultiAgent variant, then the raw transaction and the secondary signer addresses
+	rawTxnBytes, err := bcs.Serialize(rawTxn)
+	assert.NoError(t, err)
+	innerBytes, err := bcs.Serialize(innerTxn)
+	assert.NoError(t, err)
+	expected := append([]byte{byte(MultiAgentRawTransactionWithDataVariant)}, innerBytes...)
+	expected = append(expected, 1)
+	expected = append(expected, secondary.Address[:]...)
+	assert.Equal(t, expected, rawTxnBytes)
+
+	signedTxn, err := rawTxn.MultiAgentSignedTransaction(sender, secondary)
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionAuthenticatorMultiAgent, signedTxn.Authenticator.Variant)
+	assert.Equal(t, innerTxn, signedTxn.Transaction)
+
+	// Both signatures are over the multi-agent signing message
+	message, err := rawTxn.SigningMessage()
+	assert.NoError(t, err)
+	auth := signedTxn.Authenticator.Auth.(*MultiAgentTransactionAuthenticator)
+	assert.True(t, auth.Verify(message))
+	assert.True(t, auth.Sender.Verify(message))
+	assert.True(t, auth.SecondarySigners[0].Verify(message))
+	assert.Equal(t, []AccountAddress{secondary.Address}, auth.SecondarySignerAddresses)
+
+	// MultiAgent variant, sender, secondary signer addresses, secondary signers
+	authBytes, err := bcs.Serialize(signedTxn.Authenticator)
+	assert.NoError(t, err)
+	senderBytes, err := bcs.Serialize(auth.Sender)
+	assert.NoError(t, err)
+	secondaryBytes, err := bcs.Serialize(&auth.SecondarySigners[0])
+	assert.NoError(t, err)
+	expected = append([]byte{byte(TransactionAuthenticatorMultiAgent)}, senderBytes...)
+	expected = append(expected, 1)
+	expected = append(expected, secondary.Address[:]...)
+	expected = append(expected, 1)
+	expected = append(expected, secondaryBytes...)
+	assert.Equal(t, expected, authBytes)
+
+	// Round trip the whole signed transaction
+	txnBytes, err := bcs.Serialize(signedTxn)
+	assert.NoError(t, err)
+	decoded := &SignedTransaction{Transaction: &RawTransaction{}, Authenticator: &TransactionAuthenticator{}}
+	err = bcs.Deserialize(decoded, txnBytes)
+	assert.NoError(t, err)
+	reserialized, err := bcs.Serialize(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, txnBytes, reserialized)
+}
+
+func TestMultiAgentTransaction_Errors(t *testing.T) {
+	sender := testEd25519Account(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	secondary := testEd25519Account(t, "0x3333333333333333333333333333333333333333333333333333333333333333")
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn := &RawTransaction{Sender: sender.Address, Payload: TransactionPayload{Payload: transfer}, ChainId: 4}
+
+	// Signer counts must match the secondary signer addresses
+	_, err = NewMultiAgentTransaction(rawTxn, secondary.Address, AccountThree).MultiAgentSignedTransaction(sender, secondary)
+	assert.Error(t, err)
+	_, err = NewMultiAgentTransaction(rawTxn).MultiAgentSignedTransaction(sender, secondary)
+	assert.Error(t, err)
+
+	// Not a multi-agent transaction
+	_, err = NewFeePayerTransaction(rawTxn, AccountThree, secondary.Address).MultiAgentSignedTransaction(sender, secondary)
+	assert.Error(t, err)
+}
+
+func TestBuildMultiAgentTransaction(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	transfer, err := CoinTransferPayload(nil, AccountTwo, 100)
+	assert.NoError(t, err)
+	rawTxn, err := client.BuildMultiAgentTransaction(AccountOne, []AccountAddress{AccountTwo, AccountThree}, TransactionPayload{Payload: transfer},
+		SequenceNumber(1), GasUnitPrice(100), ChainIdOption(4), ExpirationSeconds(60))
+	assert.NoError(t, err)
+	assert.Equal(t, MultiAgentRawTransactionWithDataVariant, rawTxn.Variant)
+	inner := rawTxn.Inner.(*MultiAgentRawTransactionWithData)
+	assert.Equal(t, []AccountAddress{AccountTwo, AccountThree}, inner.SecondarySigners)
+	assert.Equal(t, AccountOne, inner.RawTxn.Sender)
+
+	_, err = client.BuildMultiAgentTransaction(AccountOne, []AccountAddress{AccountTwo}, TransactionPayload{Payload: transfer}, FeePayer(&AccountZero))
+	assert.Error(t, err)
+	_, err = client.BuildMultiAgentTransaction(AccountOne, []AccountAddress{AccountTwo}, TransactionPayload{Payload: transfer}, AdditionalSigners{AccountThree})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package aptos
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxIdleConnsPerHost is the number of idle connections kept open to the node by [NewDefaultTransport].  The
+// node is a single host, so this is sized for concurrent requests, rather than the Go default of 2.
+const DefaultMaxIdleConnsPerHost = 64
+
+// DefaultMaxIdleConns is the total number of idle connections kept open by [NewDefaultTransport]
+const DefaultMaxIdleConns = 128
+
+// DefaultIdleConnTimeout is how long an idle connection is kept open by [NewDefaultTransport]
+const DefaultIdleConnTimeout = 90 * time.Second
+
+// NewDefaultTransport creates the [http.Transport] used by [NewNodeClient], with keep-alives, and a connection pool
+// sized for many concurrent requests to the node
+func NewDefaultTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          DefaultMaxIdleConns,
+		MaxIdleConnsPerHost:   DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:       DefaultIdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// HttpClientOption is an option to [NewClient] to use a custom [http.Client], see [WithHTTPClient]
+type HttpClientOption struct {
+	Client *http.Client
+}
+
+// WithHTTPClient is an option to [NewClient] to send every request with the [http.Client], the same as passing the
+// client itself.  The client's timeout and transport are used as is.
+//
+//	client, err := NewClient(MainnetConfig, WithHTTPClient(&http.Client{Timeout: 10 * time.Second}))
+func WithHTTPClient(client *http.Client) HttpClientOption {
+	return HttpClientOption{Client: client}
+}
+
+// TransportOption is an option to [NewClient] to use a custom [http.RoundTripper], see [WithTransport]
+type TransportOption struct {
+	Transport http.RoundTripper
+}
+
+// WithTransport is an option to [NewClient] to send every request through the [http.RoundTripper], e.g. for custom
+// connection pooling, proxies, or instrumenting requests.  It replaces the transport of the default client, or of the
+// client given by [WithHTTPClient], which is copied rather than changed.
+//
+//	client, err := NewClient(MainnetConfig, WithTransport(&loggingTransport{next: NewDefaultTransport()}))
+func WithTransport(transport http.RoundTripper) TransportOption {
+	return TransportOption{Transport: transport}
+}
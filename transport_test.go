@@ -0,0 +1,126 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTransport records every request, before sending it on with the default transport
+type recordingTransport struct {
+	mutex    sync.Mutex
+	requests []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mutex.Lock()
+	rt.requests = append(rt.requests, req)
+	rt.mutex.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestNodeInfoServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, testNodeInfoJson)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewDefaultTransport(t *testing.T) {
+	transport := NewDefaultTransport()
+	assert.Equal(t, DefaultMaxIdleConns, transport.MaxIdleConns)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	assert.False(t, transport.DisableKeepAlives)
+
+	nodeClient, err := NewNodeClient("http://localhost:8080/v1", 4)
+	assert.NoError(t, err)
+	assert.IsType(t, &http.Transport{}, nodeClient.client.Transport)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, nodeClient.client.Transport.(*http.Transport).MaxIdleConnsPerHost)
+}
+
+func TestWithTransport(t *testing.T) {
+	server := newTestNodeInfoServer(t)
+	transport := &recordingTransport{}
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: server.URL + "/v1"}, WithTransport(transport))
+	assert.NoError(t, err)
+	client.SetHeader("x-test", "value")
+
+	info, err := client.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), info.LedgerVersion())
+
+	assert.Len(t, transport.requests, 1)
+	assert.Equal(t, "/v1", transport.requests[0].URL.Path)
+	assert.Equal(t, "value", transport.requests[0].Header.Get("x-test"))
+
+	// The default client's settings are kept
+	assert.Equal(t, 60*time.Second, client.nodeClient.client.Timeout)
+	assert.NotNil(t, client.nodeClient.client.Jar)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	server := newTestNodeInfoServer(t)
+	transport := &recordingTransport{}
+	httpClient := &http.Client{Timeout: 5 * time.Second, Transport: transport}
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: server.URL + "/v1"}, WithHTTPClient(httpClient))
+	assert.NoError(t, err)
+	assert.Same(t, httpClient, client.nodeClient.client)
+
+	_, err = client.Info()
+	assert.NoError(t, err)
+	assert.Len(t, transport.requests, 1)
+
+	// Only one client
+	_, err = NewClient(NetworkConfig{ChainId: 4, NodeUrl: server.URL + "/v1"}, WithHTTPClient(httpClient), httpClient)
+	assert.Error(t, err)
+}
+
+func TestWithHTTPClient_WithTransport(t *testing.T) {
+	server := newTestNodeInfoServer(t)
+	transport := &recordingTransport{}
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: server.URL + "/v1"}, WithHTTPClient(httpClient), WithTransport(transport))
+	assert.NoError(t, err)
+
+	_, err = client.Info()
+	assert.NoError(t, err)
+	assert.Len(t, transport.requests, 1)
+
+	// The given client is copied, not changed
+	assert.Nil(t, httpClient.Transport)
+	assert.Equal(t, 5*time.Second, client.nodeClient.client.Timeout)
+}
+
+func TestWithTransport_ContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+	transport := &recordingTransport{}
+	client, err := NewClient(NetworkConfig{ChainId: 4, NodeUrl: server.URL + "/v1"}, WithTransport(transport))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.WithContext(ctx).Info()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// The deadline is on the request given to the transport
+	assert.Len(t, transport.requests, 1)
+	_, ok := transport.requests[0].Context().Deadline()
+	assert.True(t, ok)
+}
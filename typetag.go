@@ -2,8 +2,10 @@ package aptos
 
 import (
 	"fmt"
-	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"strconv"
 	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
 )
 
 //region TypeTag
@@ -464,11 +466,182 @@ func NewObjectTag(inner TypeTagImpl) *StructTag {
 	}
 }
 
-// AptosCoinTypeTag is the TypeTag for 0x1::aptos_coin::AptosCoin
+// AptosCoinTypeTag is the TypeTag for 0x1::aptos_coin::AptosCoin, for other common coins see [CoinTypeTagBySymbol]
 var AptosCoinTypeTag = TypeTag{&StructTag{
 	Address: AccountOne,
 	Module:  "aptos_coin",
 	Name:    "AptosCoin",
 }}
 
+// ParseTypeTag parses a Move type string into a [TypeTag], the inverse of [TypeTag.String].  It accepts the primitive
+// types, vector<T>, and structs of the form <address>::<module>::<name> with any nested type parameters.  Whitespace
+// around names and type parameters is ignored.
+//
+//	tag, err := ParseTypeTag("0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>")
+//	tag, err := ParseTypeTag("vector<0x1::option::Option<u64>>")
+func ParseTypeTag(typeStr string) (TypeTag, error) {
+	tag, err := parseTypeTag(typeStr, nil)
+	if err != nil {
+		return TypeTag{}, fmt.Errorf("failed to parse type tag %q: %w", typeStr, err)
+	}
+	return tag, nil
+}
+
+// ParseTypeTags parses each Move type string into a [TypeTag], see [ParseTypeTag].  This is useful for the type
+// arguments of an entry function, e.g. for [NewEntryFunctionFromAbi]:
+//
+//	typeArgs, err := ParseTypeTags("0x1::aptos_coin::AptosCoin")
+func ParseTypeTags(typeStrs ...string) ([]TypeTag, error) {
+	tags := make([]TypeTag, len(typeStrs))
+	for i, typeStr := range typeStrs {
+		tag, err := ParseTypeTag(typeStr)
+		if err != nil {
+			return nil, fmt.Errorf("type argument %d: %w", i+1, err)
+		}
+		tags[i] = tag
+	}
+	return tags, nil
+}
+
+// parseTypeTag parses a Move type string e.g. vector<0x1::string::String>.  Generic type parameters T0, T1, ... as
+// used in ABIs are replaced by the TypeTag at the index in generics.
+func parseTypeTag(typeStr string, generics []TypeTag) (TypeTag, error) {
+	typeStr = strings.TrimSpace(typeStr)
+	switch typeStr {
+	case "bool":
+		return NewTypeTag(&BoolTag{}), nil
+	case "u8":
+		return NewTypeTag(&U8Tag{}), nil
+	case "u16":
+		return NewTypeTag(&U16Tag{}), nil
+	case "u32":
+		return NewTypeTag(&U32Tag{}), nil
+	case "u64":
+		return NewTypeTag(&U64Tag{}), nil
+	case "u128":
+		return NewTypeTag(&U128Tag{}), nil
+	case "u256":
+		return NewTypeTag(&U256Tag{}), nil
+	case "address":
+		return NewTypeTag(&AddressTag{}), nil
+	case "signer":
+		return NewTypeTag(&SignerTag{}), nil
+	case "":
+		return TypeTag{}, fmt.Errorf("empty type")
+	}
+
+	// Generic type parameter
+	if typeStr[0] == 'T' {
+		index, err := strconv.ParseUint(typeStr[1:], 10, 16)
+		if err == nil {
+			if int(index) >= len(generics) {
+				return TypeTag{}, fmt.Errorf("generic type parameter %s has no type argument", typeStr)
+			}
+			return generics[index], nil
+		}
+	}
+
+	name := typeStr
+	var params []TypeTag
+	if start := strings.IndexRune(typeStr, '<'); start >= 0 {
+		if end := strings.LastIndexByte(typeStr, '>'); end < 0 {
+			return TypeTag{}, fmt.Errorf("type %s has unclosed type parameters", typeStr)
+		} else if end != len(typeStr)-1 {
+			return TypeTag{}, fmt.Errorf("type %s has unexpected characters after type parameters", typeStr)
+		}
+		name = strings.TrimSpace(typeStr[:start])
+		paramStrs, err := splitTypeParams(typeStr[start+1 : len(typeStr)-1])
+		if err != nil {
+			return TypeTag{}, fmt.Errorf("type %s: %w", typeStr, err)
+		}
+		params = make([]TypeTag, len(paramStrs))
+		for i, paramStr := range paramStrs {
+			params[i], err = parseTypeTag(paramStr, generics)
+			if err != nil {
+				return TypeTag{}, err
+			}
+		}
+	}
+
+	if name == "vector" {
+		if len(params) != 1 {
+			return TypeTag{}, fmt.Errorf("type %s must have exactly one type parameter", typeStr)
+		}
+		return NewTypeTag(&VectorTag{TypeParam: params[0]}), nil
+	}
+
+	parts := strings.Split(name, "::")
+	if len(parts) != 3 {
+		return TypeTag{}, fmt.Errorf("type %s is not a primitive or of the form <address>::<module>::<name>", typeStr)
+	}
+	address := AccountAddress{}
+	err := address.ParseStringRelaxed(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return TypeTag{}, fmt.Errorf("type %s has invalid address: %w", typeStr, err)
+	}
+	module := strings.TrimSpace(parts[1])
+	structName := strings.TrimSpace(parts[2])
+	if !isMoveIdentifier(module) || !isMoveIdentifier(structName) {
+		return TypeTag{}, fmt.Errorf("type %s has invalid module or struct name", typeStr)
+	}
+	if params == nil {
+		params = []TypeTag{}
+	}
+	return NewTypeTag(&StructTag{
+		Address:    address,
+		Module:     module,
+		Name:       structName,
+		TypeParams: params,
+	}), nil
+}
+
+// splitTypeParams splits type parameters on the commas that aren't in nested type parameters
+func splitTypeParams(paramsStr string) ([]string, error) {
+	params := make([]string, 0)
+	depth := 0
+	start := 0
+	for i, c := range paramsStr {
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced type parameters")
+			}
+		case ',':
+			if depth == 0 {
+				params = append(params, paramsStr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced type parameters")
+	}
+	params = append(params, paramsStr[start:])
+	for _, param := range params {
+		if strings.TrimSpace(param) == "" {
+			return nil, fmt.Errorf("empty type parameter")
+		}
+	}
+	return params, nil
+}
+
+// isMoveIdentifier tells whether the name is a valid Move identifier e.g. a module or struct name
+func isMoveIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 //endregion
@@ -88,3 +88,116 @@ func TestInvalidTypeTag(t *testing.T) {
 	err := bcs.Deserialize(tag, bytes)
 	assert.Error(t, err)
 }
+
+func TestParseTypeTag_Primitives(t *testing.T) {
+	tests := map[string]TypeTagImpl{
+		"bool":    &BoolTag{},
+		"u8":      &U8Tag{},
+		"u16":     &U16Tag{},
+		"u32":     &U32Tag{},
+		"u64":     &U64Tag{},
+		"u128":    &U128Tag{},
+		"u256":    &U256Tag{},
+		"address": &AddressTag{},
+		"signer":  &SignerTag{},
+	}
+	for typeStr, expected := range tests {
+		t.Run(typeStr, func(t *testing.T) {
+			tag, err := ParseTypeTag(typeStr)
+			assert.NoError(t, err)
+			assert.Equal(t, NewTypeTag(expected), tag)
+			assert.Equal(t, typeStr, tag.String())
+
+			// Surrounding whitespace is ignored
+			tag, err = ParseTypeTag(" \t" + typeStr + "\n")
+			assert.NoError(t, err)
+			assert.Equal(t, NewTypeTag(expected), tag)
+		})
+	}
+}
+
+func TestParseTypeTag_Structs(t *testing.T) {
+	tests := map[string]string{
+		"vector<u8>":              "vector<u8>",
+		"vector<vector<address>>": "vector<vector<address>>",
+		"0x1::string::String":     "0x1::string::String",
+		"0x00000000000000000000000000000001::string::String":                                    "0x1::string::String",
+		"0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>":                                      "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+		"0x1::coin::CoinStore < 0x1::aptos_coin::AptosCoin >":                                   "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+		"0x3::pair::Pair<u8, 0x1::string::String>":                                              "0x3::pair::Pair<u8,0x1::string::String>",
+		"vector< 0x1::option::Option<vector<u64>> >":                                            "vector<0x1::option::Option<vector<u64>>>",
+		"0x1::table::Table<address,vector<0x1::object::Object<0x1::fungible_asset::Metadata>>>": "0x1::table::Table<address,vector<0x1::object::Object<0x1::fungible_asset::Metadata>>>",
+	}
+	for typeStr, expected := range tests {
+		t.Run(typeStr, func(t *testing.T) {
+			tag, err := ParseTypeTag(typeStr)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, tag.String())
+
+			// It round trips through the string and BCS
+			reparsed, err := ParseTypeTag(tag.String())
+			assert.NoError(t, err)
+			assert.Equal(t, tag, reparsed)
+			bytes, err := bcs.Serialize(&tag)
+			assert.NoError(t, err)
+			deserialized := TypeTag{}
+			err = bcs.Deserialize(&deserialized, bytes)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, deserialized.String())
+		})
+	}
+}
+
+func TestParseTypeTag_DeeplyNested(t *testing.T) {
+	typeStr := "u8"
+	expected := NewTypeTag(&U8Tag{})
+	for i := 0; i < 32; i++ {
+		if i%2 == 0 {
+			typeStr = "vector<" + typeStr + ">"
+			expected = TypeTag{Value: &VectorTag{TypeParam: expected}}
+		} else {
+			typeStr = "0x1::option::Option<" + typeStr + ">"
+			expected = TypeTag{Value: &StructTag{Address: AccountOne, Module: "option", Name: "Option", TypeParams: []TypeTag{expected}}}
+		}
+	}
+	tag, err := ParseTypeTag(typeStr)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tag)
+	assert.Equal(t, typeStr, tag.String())
+
+	// The BCS for the type is the same as the built one
+	bytes, err := bcs.Serialize(&tag)
+	assert.NoError(t, err)
+	expectedBytes, err := bcs.Serialize(&expected)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedBytes, bytes)
+}
+
+func TestParseTypeTag_Errors(t *testing.T) {
+	tests := map[string]string{
+		"":                            "empty type",
+		"   ":                         "empty type",
+		"u9":                          "not a primitive",
+		"vector":                      "exactly one type parameter",
+		"vector<u8, u8>":              "exactly one type parameter",
+		"vector<u8":                   "unclosed type parameters",
+		"vector<u8>>":                 "unbalanced type parameters",
+		"vector<u8>x":                 "unexpected characters after type parameters",
+		"0x1::coin::CoinStore<>":      "empty type parameter",
+		"0x1::coin::CoinStore<u8,>":   "empty type parameter",
+		"0x1::coin":                   "not a primitive",
+		"0xzz::coin::Coin":            "invalid address",
+		"0x1::co in::Coin":            "invalid module or struct name",
+		"0x1::coin::1Coin":            "invalid module or struct name",
+		"T0":                          "generic type parameter T0 has no type argument",
+		"&signer":                     "not a primitive",
+		"0x1::coin::Coin<vector<u9>>": "not a primitive",
+	}
+	for typeStr, message := range tests {
+		t.Run(typeStr, func(t *testing.T) {
+			_, err := ParseTypeTag(typeStr)
+			assert.ErrorContains(t, err, message)
+			assert.ErrorContains(t, err, "failed to parse type tag")
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package aptos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// ViewJsonClient is the ability to call view functions with JSON arguments, see [ViewTyped].  It is implemented by
+// [Client] and [NodeClient].
+type ViewJsonClient interface {
+	ViewJson(function string, typeArgs []string, args []any, ledgerVersion ...uint64) (vals []any, err error)
+}
+
+// viewJsonRequest is the JSON body of a view function request
+type viewJsonRequest struct {
+	Function      string   `json:"function"`
+	TypeArguments []string `json:"type_arguments"`
+	Arguments     []any    `json:"arguments"`
+}
+
+// ViewJson calls a view function with JSON arguments, and returns the return values of the function.  Unlike
+// [NodeClient.View], the arguments don't need to be BCS encoded, they are Go values encoded to the node's JSON form:
+//
+//   - [AccountAddress] is encoded as a hex string
+//   - uint8, uint16, and uint32 are encoded as numbers
+//   - uint64, uint, non-negative int and int64, and [big.Int] are encoded as strings, for u64, u128, and u256
+//   - []byte is encoded as a hex string, for vector<u8>
+//   - bool and string are passed as is
+//   - other slices and arrays are encoded element by element, for vectors
+//   - [json.Marshaler] values, e.g. [api.U64], are passed as is
+//
+// For example:
+//
+//	vals, err := client.ViewJson("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne})
+//	balance, err := StrToUint64(vals[0].(string))
+func (rc *NodeClient) ViewJson(function string, typeArgs []string, args []any, ledgerVersion ...uint64) (vals []any, err error) {
+	if _, _, _, err = ParseModuleFunction(function); err != nil {
+		return nil, fmt.Errorf("invalid view function: %w", err)
+	}
+	if typeArgs == nil {
+		typeArgs = []string{}
+	}
+	arguments := make([]any, len(args))
+	for i, arg := range args {
+		arguments[i], err = encodeViewArgument(arg)
+		if err != nil {
+			return nil, fmt.Errorf("view function arg %d: %w", i+1, err)
+		}
+	}
+	body, err := json.Marshal(viewJsonRequest{
+		Function:      function,
+		TypeArguments: typeArgs,
+		Arguments:     arguments,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	au := rc.baseUrl.JoinPath("view")
+	withLedgerVersion(au, ledgerVersion)
+	vals, err = Post[[]any](rc, au.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("view function api err: %w", err)
+	}
+	return vals, nil
+}
+
+// ViewRequest is a single view function call for [NodeClient.ViewBatch].  If the Payload is set, it's called with BCS
+// arguments, see [NodeClient.View], otherwise the Function is called with JSON arguments, see [NodeClient.ViewJson].
+type ViewRequest struct {
+	Payload *ViewPayload // Payload is the view function with BCS arguments, or nil to use Function
+
+	Function string   // Function is the view function e.g. 0x1::coin::balance, if there's no Payload
+	TypeArgs []string // TypeArgs are the type arguments of the Function e.g. 0x1::aptos_coin::AptosCoin
+	Args     []any    // Args are the arguments of the Function, encoded as in [NodeClient.ViewJson]
+}
+
+// ViewResult is the result of a single view function call from [NodeClient.ViewBatch]
+type ViewResult struct {
+	Values []any // Values are the return values of the view function, nil if it failed
+	Err    error // Err is the error of the call, nil if it succeeded
+}
+
+// ViewBatch calls many view functions concurrently, returning a result for each in the same order as the calls.  A
+// failing call doesn't stop the others, its error is in its [ViewResult].
+//
+//	calls := make([]ViewRequest, len(addresses))
+//	for i, address := range addresses {
+//		calls[i] = ViewRequest{Function: "0x1::coin::balance", TypeArgs: []string{"0x1::aptos_coin::AptosCoin"}, Args: []any{address}}
+//	}
+//	results, err := client.ViewBatch(calls, BatchWorkers(16))
+//
+// An error is only returned for invalid options.
+//
+// Accepts options:
+//   - [BatchWorkers] the maximum number of concurrent requests, defaults to [DefaultBatchWorkers]
+//   - [AtVersion] to call all the functions at the same ledger version, defaults to the latest
+func (rc *NodeClient) ViewBatch(calls []ViewRequest, options ...any) (results []ViewResult, err error) {
+	workers := DefaultBatchWorkers
+	var ledgerVersion []uint64
+	for i, arg := range options {
+		switch value := arg.(type) {
+		case AtVersion:
+			ledgerVersion = []uint64{uint64(value)}
+		case BatchWorkers:
+			if value < 1 {
+				return nil, fmt.Errorf("ViewBatch BatchWorkers must be at least 1, got %d", value)
+			}
+			workers = int(value)
+		default:
+			return nil, fmt.Errorf("ViewBatch arg %d bad type %T", i+1, arg)
+		}
+	}
+
+	results = make([]ViewResult, len(calls))
+	runBatch(len(calls), workers, func(i int) {
+		call := &calls[i]
+		var innerErr error
+		if call.Payload != nil {
+			results[i].Values, innerErr = rc.View(call.Payload, ledgerVersion...)
+		} else {
+			results[i].Values, innerErr = rc.ViewJson(call.Function, call.TypeArgs, call.Args, ledgerVersion...)
+		}
+		if innerErr != nil {
+			results[i] = ViewResult{Err: fmt.Errorf("view call %d: %w", i+1, innerErr)}
+		}
+	})
+	return results, nil
+}
+
+// ViewTyped calls a view function with JSON arguments, see [NodeClient.ViewJson], and decodes the first return value
+// into T.  This is useful for view functions with a single return value:
+//
+//	balance, err := ViewTyped[api.U64](client, "0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne})
+func ViewTyped[T any](client ViewJsonClient, function string, typeArgs []string, args []any, ledgerVersion ...uint64) (out T, err error) {
+	vals, err := client.ViewJson(function, typeArgs, args, ledgerVersion...)
+	if err != nil {
+		return out, err
+	}
+	if len(vals) == 0 {
+		return out, fmt.Errorf("view function %s returned no values", function)
+	}
+	blob, err := json.Marshal(vals[0])
+	if err != nil {
+		return out, err
+	}
+	err = json.Unmarshal(blob, &out)
+	if err != nil {
+		return out, fmt.Errorf("failed to decode view function %s return value as %T: %w", function, out, err)
+	}
+	return out, nil
+}
+
+// encodeViewArgument encodes a Go value into the JSON form of a view function argument
+func encodeViewArgument(arg any) (any, error) {
+	// Nil pointers have no value to encode, and would panic in String() below
+	if reflected := reflect.ValueOf(arg); reflected.Kind() == reflect.Pointer && reflected.IsNil() {
+		return nil, fmt.Errorf("nil %T", arg)
+	}
+	switch value := arg.(type) {
+	case AccountAddress:
+		return value.String(), nil
+	case *AccountAddress:
+		return value.String(), nil
+	case bool, string, uint8, uint16, uint32:
+		return value, nil
+	case uint64:
+		return strconv.FormatUint(value, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(value), 10), nil
+	case int:
+		return encodeViewSignedArgument(int64(value))
+	case int64:
+		return encodeViewSignedArgument(value)
+	case *big.Int:
+		return encodeViewBigArgument(value)
+	case big.Int:
+		return encodeViewBigArgument(&value)
+	case []byte:
+		return BytesToHex(value), nil
+	case json.Marshaler:
+		return value, nil
+	}
+
+	reflected := reflect.ValueOf(arg)
+	if reflected.Kind() == reflect.Slice || reflected.Kind() == reflect.Array {
+		out := make([]any, reflected.Len())
+		for i := 0; i < reflected.Len(); i++ {
+			inner, err := encodeViewArgument(reflected.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			out[i] = inner
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("bad type %T", arg)
+}
+
+func encodeViewSignedArgument(value int64) (any, error) {
+	if value < 0 {
+		return nil, fmt.Errorf("negative value %d", value)
+	}
+	return strconv.FormatInt(value, 10), nil
+}
+
+func encodeViewBigArgument(value *big.Int) (any, error) {
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("negative value %s", value.String())
+	}
+	return value.String(), nil
+}
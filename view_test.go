@@ -0,0 +1,200 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewTyped(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/view", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "12", r.URL.Query().Get("ledger_version"))
+		var request map[string]any
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"function":       "0x1::coin::balance",
+			"type_arguments": []any{"0x1::aptos_coin::AptosCoin"},
+			"arguments":      []any{"0x1"},
+		}, request)
+		_, _ = fmt.Fprint(w, `["100000000"]`)
+	})
+
+	balance, err := ViewTyped[api.U64](client, "0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne}, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, api.U64(100000000), balance)
+
+	vals, err := client.ViewJson("0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne}, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"100000000"}, vals)
+
+	// Decoding into the wrong type fails
+	_, err = ViewTyped[bool](client, "0x1::coin::balance", []string{"0x1::aptos_coin::AptosCoin"}, []any{AccountOne}, 12)
+	assert.Error(t, err)
+}
+
+func TestViewTyped_Errors(t *testing.T) {
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(w, `{"message":"function not found","error_code":"invalid_input"}`)
+	})
+
+	_, err := ViewTyped[api.U64](client, "0x1::coin::missing", nil, nil)
+	var httpErr *HttpError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+
+	// Bad function names and arguments fail before sending
+	_, err = client.ViewJson("0x1::coin", nil, nil)
+	assert.Error(t, err)
+	_, err = client.ViewJson("0x1::coin::balance", nil, []any{-1})
+	assert.Error(t, err)
+	_, err = client.ViewJson("0x1::coin::balance", nil, []any{1.5})
+	assert.Error(t, err)
+}
+
+func TestViewBatch(t *testing.T) {
+	inFlight := &atomic.Int32{}
+	maxInFlight := &atomic.Int32{}
+	client := newMockServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		assert.Equal(t, "/v1/view", r.URL.Path)
+		assert.Equal(t, "12", r.URL.Query().Get("ledger_version"))
+		if r.Header.Get("Content-Type") == ContentTypeAptosViewFunctionBcs {
+			_, _ = fmt.Fprint(w, `["4"]`)
+			return
+		}
+		var request viewJsonRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		assert.NoError(t, err)
+		if request.Function != "0x1::coin::balance" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"message":"function not found","error_code":"invalid_input"}`)
+			return
+		}
+		// The balance is the last byte of the address
+		address := AccountAddress{}
+		err = address.ParseStringRelaxed(request.Arguments[0].(string))
+		assert.NoError(t, err)
+		_, _ = fmt.Fprintf(w, `["%d"]`, address[31])
+	})
+
+	calls := make([]ViewRequest, 0)
+	for i := 1; i <= 10; i++ {
+		calls = append(calls, ViewRequest{
+			Function: "0x1::coin::balance",
+			TypeArgs: []string{"0x1::aptos_coin::AptosCoin"},
+			Args:     []any{AccountAddress{31: byte(i)}},
+		})
+	}
+	calls[3] = ViewRequest{Function: "0x1::coin::missing"}
+	calls[6] = ViewRequest{Function: "not a function"}
+	calls[8] = ViewRequest{Payload: &ViewPayload{Module: ModuleId{Address: AccountOne, Name: "chain_id"}, Function: "get"}}
+
+	results, err := client.ViewBatch(calls, BatchWorkers(3), AtVersion(12))
+	assert.NoError(t, err)
+	assert.Len(t, results, len(calls))
+	for i, result := range results {
+		switch i {
+		case 3:
+			var httpErr *HttpError
+			assert.ErrorAs(t, result.Err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+			assert.Nil(t, result.Values)
+		case 6:
+			assert.ErrorContains(t, result.Err, "view call 7")
+			assert.Nil(t, result.Values)
+		case 8:
+			assert.NoError(t, result.Err)
+			assert.Equal(t, []any{"4"}, result.Values)
+		default:
+			// Results are in the order of the calls
+			assert.NoError(t, result.Err)
+			assert.Equal(t, []any{fmt.Sprintf("%d", i+1)}, result.Values)
+		}
+	}
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+
+	// Bad options fail before sending
+	_, err = client.ViewBatch(calls, BatchWorkers(0))
+	assert.Error(t, err)
+	_, err = client.ViewBatch(calls, "bad")
+	assert.Error(t, err)
+
+	results, err = client.ViewBatch(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestEncodeViewArgument(t *testing.T) {
+	maxU128, ok := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	assert.True(t, ok)
+
+	tests := map[string]struct {
+		arg      any
+		expected string
+	}{
+		"address":      {AccountTwo, `"0x2"`},
+		"bool":         {true, `true`},
+		"string":       {"hello", `"hello"`},
+		"u8":           {uint8(1), `1`},
+		"u32":          {uint32(70000), `70000`},
+		"u64":          {uint64(18446744073709551615), `"18446744073709551615"`},
+		"int":          {5, `"5"`},
+		"u128":         {maxU128, `"340282366920938463463374607431768211455"`},
+		"bytes":        {[]byte{0x12, 0x34}, `"0x1234"`},
+		"api.U64":      {api.U64(7), `"7"`},
+		"vector<u64>":  {[]uint64{1, 2}, `["1","2"]`},
+		"vector<addr>": {[]AccountAddress{AccountOne, AccountThree}, `["0x1","0x3"]`},
+		"nested":       {[][]uint8{{1}, {2, 3}}, `["0x01","0x0203"]`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := encodeViewArgument(test.arg)
+			assert.NoError(t, err)
+			blob, err := json.Marshal(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(blob))
+		})
+	}
+}
+
+func TestEncodeViewArgument_Errors(t *testing.T) {
+	tests := map[string]struct {
+		arg     any
+		message string
+	}{
+		"nil address":  {(*AccountAddress)(nil), "nil *types.AccountAddress"},
+		"nil big.Int":  {(*big.Int)(nil), "nil *big.Int"},
+		"nil api.U64":  {(*api.U64)(nil), "nil *api.U64"},
+		"nil element":  {[]*AccountAddress{&AccountOne, nil}, "nil *types.AccountAddress"},
+		"negative":     {-1, "negative value -1"},
+		"negative big": {big.NewInt(-1), "negative value -1"},
+		"bad type":     {struct{}{}, "bad type struct {}"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := encodeViewArgument(test.arg)
+			assert.ErrorContains(t, err, test.message)
+		})
+	}
+}